@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/goals"
+)
+
+// config is the persisted CLI configuration.
+type config struct {
+	Goals []goals.Goal `json:"goals,omitempty"`
+
+	// Groups replaces the default language groups of the langs command.
+	Groups godestats.LanguageGroups `json:"groups,omitempty"`
+
+	// Notify holds the notification sinks of the daemon and watch commands.
+	Notify []notifier `json:"notify,omitempty"`
+}
+
+// configPath returns the path of the configuration file, which defaults to
+// godestats/config.json in the user's configuration directory.
+func (a *app) configPath() (string, error) {
+	if path := a.getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "godestats", "config.json"), nil
+}
+
+// dataDir returns the directory holding the daemon's socket, history and state,
+// which is the directory of the configuration file.
+func (a *app) dataDir() (string, error) {
+	path, err := a.configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(path), nil
+}
+
+// loadConfig reads the configuration file. A missing file yields an empty configuration.
+func (a *app) loadConfig() (*config, error) {
+	path, err := a.configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// saveConfig writes the configuration file, creating its directory if needed.
+func (a *app) saveConfig(cfg *config) error {
+	path, err := a.configPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+	"github.com/Yeti47/gode-stats/pkg/history/store/bolt"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+// Defaults of the daemon command
+const (
+	DefaultDaemonInterval = time.Minute
+	DefaultRecordInterval = time.Hour
+)
+
+// statusPath is the path of the status endpoint served on the daemon's socket.
+const statusPath = "/status"
+
+// errDaemonRunning is returned when a daemon is already listening on the socket.
+var errDaemonRunning = errors.New("daemon is already running")
+
+// daemonStatus is the state the daemon reports on its status socket.
+type daemonStatus struct {
+	PID         int          `json:"pid"`
+	StartedAt   time.Time    `json:"started_at"`
+	Users       []string     `json:"users"`
+	Interval    string       `json:"interval"`
+	History     string       `json:"history,omitempty"`
+	Events      int          `json:"events"`
+	LastEvent   *watch.Event `json:"last_event,omitempty"`
+	Errors      int          `json:"errors"`
+	LastError   string       `json:"last_error,omitempty"`
+	LastErrorAt time.Time    `json:"last_error_at,omitzero"`
+	// TokenInvalid is set while the API rejects the token.
+	TokenInvalid bool `json:"token_invalid,omitempty"`
+}
+
+// daemon tracks the status of the running components.
+type daemon struct {
+	a      *app
+	tokens *watch.TokenChecker
+	mu     sync.Mutex
+	status daemonStatus
+}
+
+// event records an event of the watcher.
+func (d *daemon) event(e watch.Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.status.Events++
+	d.status.LastEvent = &e
+}
+
+// fail records and logs an error of any component. The log includes the request
+// ID of failed API calls for correlation with the server's logs.
+func (d *daemon) fail(err error) {
+	d.mu.Lock()
+	d.status.Errors++
+	d.status.LastError = err.Error()
+	d.status.LastErrorAt = time.Now()
+	d.mu.Unlock()
+
+	if id, ok := godestats.RequestID(err); ok {
+		fmt.Fprintf(d.a.stderr, "%s error: %v (request %s)\n", time.Now().Format(time.DateTime), err, id)
+		return
+	}
+	fmt.Fprintf(d.a.stderr, "%s error: %v\n", time.Now().Format(time.DateTime), err)
+}
+
+// ServeHTTP serves the status as JSON.
+func (d *daemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != statusPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	d.mu.Lock()
+	status := d.status
+	d.mu.Unlock()
+	status.TokenInvalid = d.tokens != nil && !d.tokens.Valid()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// runDaemon watches and records the users' profiles in one long-running process
+// until interrupted, serving its status on a local socket for the status command.
+func runDaemon(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	interval := fs.Duration("interval", DefaultDaemonInterval, "time between two polls of all users")
+	record := fs.Duration("record", DefaultRecordInterval, "time between two history snapshots")
+	historyPath := fs.String("history", "", "path of the history database (default next to the config, \"off\" to disable)")
+	socket := fs.String("socket", "", "path of the status socket (default next to the config)")
+	tokenCheck := fs.Duration("token-check", watch.DefaultTokenCheckInterval, "time between two checks of the API token (0 to disable)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *interval <= 0 || *record <= 0 || *tokenCheck < 0 {
+		return fmt.Errorf("%w: intervals must be positive", errUsage)
+	}
+
+	users := fs.Args()
+	if len(users) == 0 {
+		user, err := a.username(nil)
+		if err != nil {
+			return err
+		}
+		users = []string{user}
+	}
+
+	dir, err := a.dataDir()
+	if err != nil {
+		return err
+	}
+	if *socket == "" {
+		*socket = filepath.Join(dir, "daemon.sock")
+	}
+	if *historyPath == "" {
+		*historyPath = filepath.Join(dir, "history.db")
+	}
+	if *historyPath == "off" {
+		*historyPath = ""
+	}
+
+	d := &daemon{a: a, status: daemonStatus{
+		PID:       os.Getpid(),
+		StartedAt: a.now(),
+		Users:     users,
+		Interval:  interval.String(),
+		History:   *historyPath,
+	}}
+
+	listener, err := listenStatus(ctx, *socket)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: d}
+
+	c := a.client()
+	bus := watch.NewBus()
+	bus.Subscribe(watch.AllEvents, d.event)
+	if err := a.notifications(ctx, bus, d.fail); err != nil {
+		listener.Close()
+		return err
+	}
+
+	watcher := watch.NewMultiWatcher(c, users, *interval,
+		watch.WithBus(bus),
+		watch.WithState(watch.NewFileState(filepath.Join(dir, "state"))),
+		watch.WithErrorHandler(d.fail))
+
+	// Without a token there is nothing to check
+	if own, ok := c.(godestats.OwnProfileClient); ok && *tokenCheck > 0 && a.token() != "" {
+		d.tokens = watch.NewTokenChecker(own, *tokenCheck, watch.WithBus(bus), watch.WithErrorHandler(d.fail))
+	}
+
+	var recorders []*history.Recorder
+	if *historyPath != "" {
+		store, err := bolt.Open(*historyPath)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer store.Close()
+
+		for _, user := range users {
+			recorder := history.NewRecorder(c, store, user,
+				history.WithInterval(*record),
+				history.WithErrorHandler(d.fail))
+			recorder.Subscribe(bus.PublishChange)
+			recorders = append(recorders, recorder)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	run := func(fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(ctx)
+		}()
+	}
+
+	run(watcher.Run)
+	if d.tokens != nil {
+		run(d.tokens.Run)
+	}
+	for _, recorder := range recorders {
+		run(recorder.Run)
+	}
+	run(func(ctx context.Context) error {
+		go func() {
+			<-ctx.Done()
+			server.Close()
+		}()
+		return server.Serve(listener)
+	})
+
+	fmt.Fprintf(a.stderr, "watching %s, status on %s\n", strings.Join(users, ", "), *socket)
+	<-ctx.Done()
+	wg.Wait()
+	return ctx.Err()
+}
+
+// listenStatus listens on the status socket, replacing a stale socket file left
+// behind by a daemon that did not shut down cleanly.
+func listenStatus(ctx context.Context, path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := (&net.Dialer{}).DialContext(ctx, "unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w on %s", errDaemonRunning, path)
+		}
+		os.Remove(path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	return listener, nil
+}
+
+// runStatus queries a running daemon for its status.
+func runStatus(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	socket := fs.String("socket", "", "path of the status socket (default next to the config)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	if *socket == "" {
+		dir, err := a.dataDir()
+		if err != nil {
+			return err
+		}
+		*socket = filepath.Join(dir, "daemon.sock")
+	}
+
+	status, err := queryStatus(ctx, *socket)
+	if err != nil {
+		return err
+	}
+
+	now := a.now()
+	lastEvent := ""
+	if status.LastEvent != nil {
+		lastEvent = string(status.LastEvent.Kind)
+	}
+
+	return a.render(output{
+		value: status,
+		table: table{
+			header: []string{"pid", "started_at", "users", "interval", "events", "last_event", "errors", "last_error"},
+			rows: [][]string{{
+				fmt.Sprint(status.PID), timeCell(status.StartedAt), strings.Join(status.Users, " "), status.Interval,
+				fmt.Sprint(status.Events), lastEvent, fmt.Sprint(status.Errors), status.LastError,
+			}},
+		},
+		text: func(w io.Writer) {
+			fmt.Fprintf(w, "daemon running (pid %d) since %s\n", status.PID, ago(now.Sub(status.StartedAt)))
+			fmt.Fprintf(w, "watching   %s every %s\n", strings.Join(status.Users, ", "), status.Interval)
+			if status.History != "" {
+				fmt.Fprintf(w, "history    %s\n", status.History)
+			}
+			fmt.Fprintf(w, "events     %d", status.Events)
+			if e := status.LastEvent; e != nil {
+				fmt.Fprintf(w, ", last: %s of %s %s", e.Kind, e.User, ago(now.Sub(e.At)))
+			}
+			fmt.Fprintln(w)
+			if status.TokenInvalid {
+				fmt.Fprintln(w, "token      rejected by the API, no XP is recorded; run godestats login")
+			}
+			fmt.Fprintf(w, "errors     %d", status.Errors)
+			if status.LastError != "" {
+				fmt.Fprintf(w, ", last %s: %s", ago(now.Sub(status.LastErrorAt)), status.LastError)
+			}
+			fmt.Fprintln(w)
+		},
+	})
+}
+
+// queryStatus fetches the status from the daemon listening on the socket.
+func queryStatus(ctx context.Context, socket string) (*daemonStatus, error) {
+	httpClient := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://daemon"+statusPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("daemon is not running (no answer on %s): %w", socket, err)
+	}
+	defer resp.Body.Close()
+
+	var status daemonStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode daemon status: %w", err)
+	}
+	return &status, nil
+}
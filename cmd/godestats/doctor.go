@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/client"
+)
+
+// Clock skew thresholds of the doctor command. Pulses are rejected once they are
+// older than a week by the server's clock, and XP is attributed to the day of the
+// pulse, so small offsets only shift XP between days.
+const (
+	skewWarning = 5 * time.Minute
+	skewFailure = 24 * time.Hour
+)
+
+// certificateWarning is how long before expiry a server certificate is reported.
+const certificateWarning = 14 * 24 * time.Hour
+
+// doctorTimeout limits the requests of the doctor command.
+const doctorTimeout = 15 * time.Second
+
+// checkStatus is the outcome of a diagnostic check.
+type checkStatus string
+
+// Outcomes of diagnostic checks
+const (
+	checkOK   checkStatus = "ok"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+	checkSkip checkStatus = "skip"
+)
+
+// check is the result of a diagnostic check with a hint on how to fix problems.
+type check struct {
+	Name   string      `json:"name"`
+	Status checkStatus `json:"status"`
+	Detail string      `json:"detail"`
+	Hint   string      `json:"hint,omitempty"`
+}
+
+// runDoctor checks the connection to the API and the local setup and prints
+// diagnostics with hints for every problem found.
+func runDoctor(ctx context.Context, a *app, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	baseURL := strings.TrimRight(a.getenv(EnvBaseURL), "/")
+	if baseURL == "" {
+		baseURL = client.DefaultBaseURL
+	}
+	target, err := url.Parse(baseURL)
+	if err != nil || target.Host == "" {
+		return fmt.Errorf("%w: invalid %s %q", errUsage, EnvBaseURL, baseURL)
+	}
+
+	checks := []check{a.checkProxy(target)}
+
+	resp, connectivity := a.checkConnectivity(ctx, baseURL)
+	checks = append(checks, connectivity)
+	if resp != nil {
+		checks = append(checks, checkTLS(resp, a.now()), checkClock(resp, a.now()))
+	} else {
+		checks = append(checks,
+			check{Name: "tls", Status: checkSkip, Detail: "server not reachable"},
+			check{Name: "clock", Status: checkSkip, Detail: "server not reachable"})
+	}
+	checks = append(checks, a.checkToken(ctx))
+
+	failed := 0
+	rows := make([][]string, len(checks))
+	for i, c := range checks {
+		if c.Status == checkFail {
+			failed++
+		}
+		rows[i] = []string{c.Name, string(c.Status), c.Detail, c.Hint}
+	}
+
+	err = a.render(output{
+		value: checks,
+		table: table{header: []string{"check", "status", "detail", "hint"}, rows: rows},
+		text: func(w io.Writer) {
+			for _, c := range checks {
+				fmt.Fprintf(w, "[%-4s] %-12s %s\n", c.Status, c.Name, c.Detail)
+				if c.Hint != "" && (c.Status == checkWarn || c.Status == checkFail) {
+					fmt.Fprintf(w, "       %-12s → %s\n", "", c.Hint)
+				}
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+// checkProxy reports the proxy requests to the API go through, read from the
+// same environment variables the HTTP transport uses.
+func (a *app) checkProxy(target *url.URL) check {
+	c := check{Name: "proxy"}
+
+	names := []string{"HTTPS_PROXY", "https_proxy"}
+	if target.Scheme == "http" {
+		names = []string{"HTTP_PROXY", "http_proxy"}
+	}
+
+	var name, value string
+	for _, n := range names {
+		if v := a.getenv(n); v != "" {
+			name, value = n, v
+			break
+		}
+	}
+	if value == "" {
+		c.Status, c.Detail = checkOK, "no proxy configured"
+		return c
+	}
+
+	if noProxy := a.getenv("NO_PROXY") + "," + a.getenv("no_proxy"); bypassesProxy(target.Hostname(), noProxy) {
+		c.Status, c.Detail = checkOK, fmt.Sprintf("%s is set, but %s is excluded by NO_PROXY", name, target.Hostname())
+		return c
+	}
+
+	proxy, err := url.Parse(value)
+	if err != nil || proxy.Host == "" {
+		// The transport also accepts proxies without a scheme
+		proxy, err = url.Parse("http://" + value)
+	}
+	if err != nil || proxy.Host == "" {
+		c.Status, c.Detail = checkFail, fmt.Sprintf("%s is not a valid URL: %q", name, value)
+		c.Hint = fmt.Sprintf("set %s to a URL such as http://proxy.example.com:8080", name)
+		return c
+	}
+
+	c.Status, c.Detail = checkOK, fmt.Sprintf("using %s from %s", proxy.Redacted(), name)
+	return c
+}
+
+// bypassesProxy reports whether the host matches an entry of a NO_PROXY list.
+func bypassesProxy(host, noProxy string) bool {
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConnectivity requests the base URL and returns the response if the server
+// could be reached. The response body is closed.
+func (a *app) checkConnectivity(ctx context.Context, baseURL string) (*http.Response, check) {
+	c := check{Name: "connectivity"}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		c.Status, c.Detail = checkFail, err.Error()
+		return nil, c
+	}
+	req.Header.Set("User-Agent", client.UserAgent)
+
+	start := time.Now()
+	resp, err := (&http.Client{Transport: client.NewTransport()}).Do(req)
+	if err != nil {
+		c.Status, c.Detail, c.Hint = checkFail, err.Error(), connectionHint(err)
+		return nil, c
+	}
+	resp.Body.Close()
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	switch {
+	case resp.StatusCode >= 500:
+		c.Status, c.Detail = checkWarn, fmt.Sprintf("%s answered with %s after %s", baseURL, resp.Status, elapsed)
+		c.Hint = "the server has problems, try again later"
+	default:
+		c.Status, c.Detail = checkOK, fmt.Sprintf("reached %s in %s", baseURL, elapsed)
+	}
+	return resp, c
+}
+
+// connectionHint suggests a fix for an error of a failed connection.
+func connectionHint(err error) string {
+	var (
+		dnsErr     *net.DNSError
+		unknownCA  x509.UnknownAuthorityError
+		hostname   x509.HostnameError
+		invalid    x509.CertificateInvalidError
+		recordErr  tls.RecordHeaderError
+		netTimeout net.Error
+	)
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return "check the host name in " + EnvBaseURL + " and your DNS settings"
+	case errors.As(err, &unknownCA):
+		return "the certificate is not trusted; if a corporate proxy intercepts TLS, add its CA via SSL_CERT_FILE"
+	case errors.As(err, &hostname), errors.As(err, &invalid):
+		return "the server certificate is invalid; check " + EnvBaseURL + " and the system clock"
+	case errors.As(err, &recordErr):
+		return "the server does not speak TLS; use an http:// URL in " + EnvBaseURL
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netTimeout) && netTimeout.Timeout():
+		return "the server did not answer in time; check your network, firewall and proxy settings"
+	default:
+		return "check your network connection, firewall and proxy settings"
+	}
+}
+
+// checkTLS reports the TLS version and certificate expiry of the response.
+func checkTLS(resp *http.Response, now time.Time) check {
+	c := check{Name: "tls"}
+
+	if resp.TLS == nil {
+		c.Status, c.Detail = checkWarn, "connection is not encrypted"
+		c.Hint = "use an https:// URL unless the server is on a trusted network, since the API token is sent with requests"
+		return c
+	}
+
+	version := tls.VersionName(resp.TLS.Version)
+	if len(resp.TLS.PeerCertificates) == 0 {
+		c.Status, c.Detail = checkOK, version
+		return c
+	}
+
+	expires := resp.TLS.PeerCertificates[0].NotAfter
+	c.Status, c.Detail = checkOK, fmt.Sprintf("%s, certificate valid until %s", version, expires.Format(time.DateOnly))
+	if expires.Sub(now) < certificateWarning {
+		c.Status, c.Hint = checkWarn, "the server certificate expires soon; tell the instance's administrator"
+	}
+	return c
+}
+
+// checkClock compares the local clock to the Date header of the response.
+func checkClock(resp *http.Response, now time.Time) check {
+	c := check{Name: "clock"}
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		c.Status, c.Detail = checkSkip, "server sent no date"
+		return c
+	}
+
+	skew := now.Sub(serverTime)
+	direction := "ahead of"
+	if skew < 0 {
+		skew, direction = -skew, "behind"
+	}
+	skew = skew.Round(time.Second)
+
+	switch {
+	case skew <= skewWarning:
+		c.Status, c.Detail = checkOK, fmt.Sprintf("local clock within %s of the server", max(skew, time.Second))
+	case skew <= skewFailure:
+		c.Status, c.Detail = checkWarn, fmt.Sprintf("local clock is %s %s the server", skew, direction)
+		c.Hint = "enable time synchronization (NTP), otherwise XP may be attributed to the wrong day"
+	default:
+		c.Status, c.Detail = checkFail, fmt.Sprintf("local clock is %s %s the server", skew, direction)
+		c.Hint = "enable time synchronization (NTP); pulses older than 7 days by the server's clock are rejected"
+	}
+	return c
+}
+
+// checkToken verifies the API token against the authenticated profile endpoint.
+func (a *app) checkToken(ctx context.Context) check {
+	c := check{Name: "token"}
+
+	if a.token() == "" {
+		c.Status, c.Detail = checkSkip, "no token in "+EnvToken+" and not logged in"
+		return c
+	}
+
+	own, ok := a.client().(godestats.OwnProfileClient)
+	if !ok {
+		c.Status, c.Detail = checkSkip, "client cannot verify tokens"
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	profile, err := own.GetMyProfile(ctx)
+	switch {
+	case err == nil:
+		c.Status, c.Detail = checkOK, fmt.Sprintf("valid for %s", profile.User)
+	case godestats.IsUnauthorized(err):
+		c.Status, c.Detail = checkFail, "token was rejected"
+		c.Hint = "create a new machine token in your Code::Stats machine settings and run godestats login"
+	default:
+		c.Status, c.Detail = checkWarn, "could not be verified: "+godestats.UserMessage(err, a.getenv("LANG"))
+	}
+	return c
+}
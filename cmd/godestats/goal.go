@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/goals"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+)
+
+// goalBarWidth is the width of the progress bars of the goal status.
+const goalBarWidth = 20
+
+// goalLabels are the status labels of the goal periods.
+var goalLabels = map[goals.Period]string{
+	goals.Daily:  "Today    ",
+	goals.Weekly: "This week",
+}
+
+// runGoal manages daily and weekly XP goals stored in the config file.
+func runGoal(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "set":
+		return a.setGoal(args[1:])
+	case "remove":
+		return a.removeGoal(args[1:])
+	case "status":
+		return a.goalStatus(ctx, args[1:])
+	default:
+		return fmt.Errorf("%w: unknown subcommand %q", errUsage, args[0])
+	}
+}
+
+// setGoal adds or replaces the goal of a period.
+func (a *app) setGoal(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: expected a period and an XP target", errUsage)
+	}
+
+	period, err := goals.ParsePeriod(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	target, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid XP target %q", errUsage, args[1])
+	}
+
+	goal := goals.Goal{Period: period, XP: godestats.XP(target)}
+	if err := goal.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Goals = goals.Set(cfg.Goals, goal)
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+
+	return a.render(goalsOutput(cfg.Goals, fmt.Sprintf("%s goal set to %s XP", period, goal.XP)))
+}
+
+// removeGoal deletes the goal of a period.
+func (a *app) removeGoal(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected a period", errUsage)
+	}
+
+	period, err := goals.ParsePeriod(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Goals = goals.Remove(cfg.Goals, period)
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+
+	return a.render(goalsOutput(cfg.Goals, fmt.Sprintf("%s goal removed", period)))
+}
+
+// goalStatus prints the progress towards every goal in the current day and week.
+func (a *app) goalStatus(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("goal status", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	user, err := a.username(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var profile *godestats.UserProfile
+	if len(cfg.Goals) > 0 {
+		if profile, err = a.client().GetUserProfile(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	now := a.now()
+	color := a.color()
+
+	progress := make([]goalProgress, len(cfg.Goals))
+	rows := make([][]string, len(cfg.Goals))
+	for i, goal := range cfg.Goals {
+		p := goals.Track(profile, goal, now)
+		progress[i] = goalProgress{Progress: p, Fraction: p.Fraction(), Reached: p.Reached(), Remaining: p.Remaining()}
+		rows[i] = []string{
+			string(goal.Period), xpCell(goal.XP), xpCell(p.Gained), floatCell(p.Fraction()),
+			strconv.FormatBool(p.Reached()), timeCell(p.Start), timeCell(p.End),
+		}
+	}
+
+	return a.render(output{
+		value: progress,
+		table: table{header: []string{"period", "target", "gained", "fraction", "reached", "start", "end"}, rows: rows},
+		text: func(w io.Writer) {
+			if len(progress) == 0 {
+				fmt.Fprintln(w, "No goals set, add one with: godestats goal set daily 500")
+				return
+			}
+
+			fmt.Fprintf(w, "%s\n\n", profile.User)
+			for _, p := range progress {
+				fmt.Fprintf(w, "%s %s %s / %s XP (%.0f%%)", goalLabels[p.Goal.Period],
+					termchart.Gauge(p.Fraction, goalBarWidth, color),
+					p.Gained, p.Goal.XP, p.Fraction*100)
+				if p.Reached {
+					fmt.Fprint(w, " — reached")
+				} else {
+					fmt.Fprintf(w, " — %s XP to go", p.Remaining)
+				}
+				fmt.Fprintln(w)
+			}
+		},
+	})
+}
+
+// goalProgress is a goal in the structured output of the goal status command.
+type goalProgress struct {
+	goals.Progress
+	Fraction  float64      `json:"fraction"`
+	Reached   bool         `json:"reached"`
+	Remaining godestats.XP `json:"remaining"`
+}
+
+// goalsOutput describes the configured goals after a change, with a message for the text format.
+func goalsOutput(list []goals.Goal, message string) output {
+	rows := make([][]string, len(list))
+	for i, goal := range list {
+		rows[i] = []string{string(goal.Period), xpCell(goal.XP)}
+	}
+
+	if list == nil {
+		list = []goals.Goal{}
+	}
+	return output{
+		value: list,
+		table: table{header: []string{"period", "xp"}, rows: rows},
+		text:  func(w io.Writer) { fmt.Fprintln(w, message) },
+	}
+}
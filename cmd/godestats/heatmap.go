@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+)
+
+// DefaultHeatmapMonths is the number of months shown by the heatmap command.
+const DefaultHeatmapMonths = 12
+
+// runHeatmap prints a GitHub-style activity heatmap of the last months.
+func runHeatmap(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("heatmap", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	months := fs.Int("months", DefaultHeatmapMonths, "number of months to show")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *months <= 0 {
+		return fmt.Errorf("%w: months must be positive", errUsage)
+	}
+
+	user, err := a.username(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.client().GetUserProfile(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	now := a.now()
+	color := a.color()
+
+	// Cover the whole range, rounded up to full weeks
+	start := now.AddDate(0, -*months, 0)
+	weeks := (int(now.Sub(start).Hours()/24) + 6) / 7
+
+	summary := heatmapSummary{User: profile.User, Months: *months}
+	for day := range godestats.Days(godestats.DateOf(start).AddDays(1), godestats.DateOf(now)) {
+		xp := profile.XPOnDate(day)
+		if xp > 0 {
+			summary.TotalXP += xp
+			summary.ActiveDays++
+		}
+		summary.Days = append(summary.Days, godestats.DateXP{Date: day, XP: xp})
+	}
+
+	rows := make([][]string, len(summary.Days))
+	for i, day := range summary.Days {
+		rows[i] = []string{day.Date.String(), xpCell(day.XP)}
+	}
+
+	return a.render(output{
+		value: summary,
+		table: table{header: []string{"date", "xp"}, rows: rows},
+		text: func(w io.Writer) {
+			fmt.Fprintf(w, "%s — %s XP on %s in the last %d months\n\n", profile.User, summary.TotalXP, days(summary.ActiveDays), *months)
+			fmt.Fprintln(w, termchart.HeatmapMonths(weeks, now))
+			for _, line := range termchart.Heatmap(profile, weeks, now, color) {
+				fmt.Fprintln(w, line)
+			}
+			fmt.Fprintf(w, "\n%s\n", termchart.HeatmapLegend(color))
+		},
+	})
+}
+
+// heatmapSummary is the structured output of the heatmap command.
+type heatmapSummary struct {
+	User       string             `json:"user"`
+	Months     int                `json:"months"`
+	TotalXP    godestats.XP       `json:"total_xp"`
+	ActiveDays int                `json:"active_days"`
+	Days       []godestats.DateXP `json:"days"`
+}
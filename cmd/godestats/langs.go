@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// DefaultTopLanguages is the number of languages listed by the langs command.
+const DefaultTopLanguages = 10
+
+// langsBarWidth is the width of the bars of the langs command.
+const langsBarWidth = 30
+
+// runLangs prints a user's top languages with levels, XP shares and bars.
+func runLangs(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("langs", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	top := fs.Int("top", DefaultTopLanguages, "number of languages to list")
+	group := fs.Bool("group", false, "merge related languages into groups")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *top <= 0 {
+		return fmt.Errorf("%w: top must be positive", errUsage)
+	}
+
+	user, err := a.username(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.client().GetUserProfile(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if *group {
+		cfg, err := a.loadConfig()
+		if err != nil {
+			return err
+		}
+		groups := cfg.Groups
+		if groups == nil {
+			groups = godestats.DefaultLanguageGroups
+		}
+		profile = godestats.GroupLanguages(profile, groups)
+	}
+
+	ranked := godestats.LanguagesByXP(profile)
+	count := len(ranked)
+	if len(ranked) > *top {
+		ranked = ranked[:*top]
+	}
+
+	calc := xp.NewCalculator()
+	langs := make([]languageRow, len(ranked))
+	rows := make([][]string, len(ranked))
+	for i, lang := range ranked {
+		langs[i] = languageRow{
+			RankedLanguage: lang,
+			Level:          calc.GetLevel(lang.XPs),
+			Share:          profile.LanguageShare(lang.Name),
+		}
+		rows[i] = []string{strconv.Itoa(lang.Rank), lang.Name, xpCell(lang.XPs), xpCell(lang.NewXPs), strconv.Itoa(langs[i].Level), floatCell(langs[i].Share)}
+	}
+
+	return a.render(output{
+		value: langs,
+		table: table{header: []string{"rank", "language", "xp", "new_xp", "level", "share"}, rows: rows},
+		text: func(w io.Writer) {
+			fmt.Fprintf(w, "%s — %d languages\n\n", profile.User, count)
+
+			bars := make([]termchart.Bar, len(langs))
+			for i, lang := range langs {
+				bars[i] = termchart.Bar{Label: fmt.Sprintf("%2d. %s", lang.Rank, lang.Name), Value: lang.XPs}
+			}
+			for i, line := range termchart.Bars(bars, langsBarWidth, a.color()) {
+				fmt.Fprintf(w, "%s  level %d, %.1f%%\n", line, langs[i].Level, langs[i].Share*100)
+			}
+		},
+	})
+}
+
+// languageRow is a language in the structured output of the langs command.
+type languageRow struct {
+	godestats.RankedLanguage
+	Level int     `json:"level"`
+	Share float64 `json:"share"`
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/client"
+	"github.com/Yeti47/gode-stats/pkg/secrets"
+	"github.com/Yeti47/gode-stats/pkg/secrets/keyring"
+)
+
+// tokenFile is the name of the fallback token file next to the config file.
+const tokenFile = "token"
+
+// tokens returns the provider of the API token: the environment, a token file
+// given in the environment, or else the OS keyring and the fallback token file, in
+// that order. A token file given in the environment is reloaded when it changes,
+// so long-running commands pick up rotated tokens. Without a configured token the
+// provider returns "", making clients anonymous.
+func (a *app) tokens() godestats.TokenProvider {
+	env := secrets.Func(func(context.Context) (string, error) {
+		if token := a.getenv(EnvToken); token != "" {
+			return token, nil
+		}
+		return "", secrets.ErrNoToken
+	})
+	if path := a.getenv(EnvTokenFile); path != "" {
+		return secrets.Chain(env, secrets.File(path))
+	}
+
+	sources := []godestats.TokenProvider{env, keyring.Provider(keyring.DefaultName)}
+	if path, err := a.tokenPath(); err == nil {
+		sources = append(sources, secrets.File(path))
+	}
+	chain := secrets.Chain(sources...)
+	return secrets.Func(func(ctx context.Context) (string, error) {
+		token, err := chain.Token(ctx)
+		if errors.Is(err, secrets.ErrNoToken) {
+			return "", nil
+		}
+		return token, err
+	})
+}
+
+// token returns the API token, or "" if no token is configured.
+func (a *app) token() string {
+	token, _ := a.tokens().Token(context.Background())
+	return token
+}
+
+// tokenPath returns the path of the fallback token file.
+func (a *app) tokenPath() (string, error) {
+	dir, err := a.dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, tokenFile), nil
+}
+
+// storeToken saves the token in the OS keyring, falling back to a file readable only
+// by the user if no keyring is available. It returns where the token was stored.
+func (a *app) storeToken(token string) (string, error) {
+	if err := keyring.StoreToken(keyring.DefaultName, token); err == nil {
+		// Remove a fallback file from an earlier login, which would be stale now
+		if path, err := a.tokenPath(); err == nil {
+			os.Remove(path)
+		}
+		return "the OS keyring", nil
+	}
+
+	path, err := a.tokenPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write token file: %w", err)
+	}
+	return path + " (no OS keyring available)", nil
+}
+
+// runLogin prompts for a machine token, verifies it and stores it, so that it
+// doesn't have to be passed in the environment or end up in the shell history.
+func runLogin(ctx context.Context, a *app, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	fmt.Fprint(a.stderr, "Machine API token: ")
+	token, err := a.readSecret()
+	fmt.Fprintln(a.stderr)
+	if err != nil {
+		return fmt.Errorf("failed to read token: %w", err)
+	}
+	if token == "" {
+		return fmt.Errorf("%w: no token given", errUsage)
+	}
+
+	baseURL := a.getenv(EnvBaseURL)
+	if baseURL == "" {
+		baseURL = client.DefaultBaseURL
+	}
+	c := client.NewWithBaseURL(token, strings.TrimRight(baseURL, "/"))
+
+	own, ok := c.(godestats.OwnProfileClient)
+	if !ok {
+		return errors.New("client cannot verify tokens")
+	}
+	profile, err := own.GetMyProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	location, err := a.storeToken(token)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Logged in as %s, token stored in %s\n", profile.User, location)
+	return nil
+}
+
+// runLogout removes the stored token from the keyring and the fallback file.
+func runLogout(ctx context.Context, a *app, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	removed := keyring.DeleteToken(keyring.DefaultName) == nil
+	if path, err := a.tokenPath(); err == nil && os.Remove(path) == nil {
+		removed = true
+	}
+
+	if !removed {
+		fmt.Fprintln(a.stdout, "No stored token found")
+		return nil
+	}
+	fmt.Fprintln(a.stdout, "Stored token removed")
+	return nil
+}
+
+// readSecret reads a line from stdin without echoing it if stdin is a terminal.
+func (a *app) readSecret() (string, error) {
+	if f, ok := a.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		secret, err := term.ReadPassword(int(f.Fd()))
+		return strings.TrimSpace(string(secret)), err
+	}
+
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
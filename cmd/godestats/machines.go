@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/analytics"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+)
+
+// DefaultInactiveDays is the number of days without activity after which the
+// machines command highlights a machine as inactive.
+const DefaultInactiveDays = 14
+
+// machinesBarWidth is the width of the bars of the machines command.
+const machinesBarWidth = 20
+
+// ANSI escape sequences highlighting inactive machines
+const (
+	ansiWarning = "\x1b[33m"
+	ansiReset   = "\x1b[0m"
+)
+
+// runMachines lists the token owner's machines with their levels and last activity.
+func runMachines(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("machines", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	inactive := fs.Int("inactive", DefaultInactiveDays, "days without activity after which a machine is inactive")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+	if *inactive <= 0 {
+		return fmt.Errorf("%w: inactive must be positive", errUsage)
+	}
+
+	own, ok := a.client().(godestats.OwnProfileClient)
+	if !ok {
+		return errors.New("client cannot fetch the token owner's profile")
+	}
+	profile, err := own.GetMyProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := a.now()
+	color := a.color()
+	stats := analytics.MachineStats(profile, nil)
+
+	machines := make([]machineRow, len(stats))
+	rows := make([][]string, len(stats))
+	for i, stat := range stats {
+		machine := profile.Machines[stat.Name]
+		machines[i] = machineRow{
+			MachineStat: stat,
+			LastActive:  machine.LastActive,
+			Inactive:    machine.IsInactive(*inactive, now),
+		}
+		rows[i] = []string{
+			stat.Name, xpCell(stat.Progress.XP), xpCell(stat.RecentXP), strconv.Itoa(stat.Progress.Level),
+			floatCell(stat.Share), timeCell(machine.LastActive),
+			strconv.FormatBool(machines[i].Inactive), strconv.FormatBool(stat.Idle),
+		}
+	}
+
+	return a.render(output{
+		value: machines,
+		table: table{header: []string{"machine", "xp", "new_xp", "level", "share", "last_active", "inactive", "idle"}, rows: rows},
+		text: func(w io.Writer) {
+			fmt.Fprintf(w, "%s — %d machines\n\n", profile.User, len(machines))
+
+			bars := make([]termchart.Bar, len(machines))
+			for i, machine := range machines {
+				bars[i] = termchart.Bar{Label: machine.Name, Value: machine.Progress.XP}
+			}
+
+			for i, line := range termchart.Bars(bars, machinesBarWidth, color) {
+				machine := machines[i]
+
+				status := "last seen unknown"
+				if !machine.LastActive.IsZero() {
+					status = "last seen " + ago(now.Sub(machine.LastActive))
+				}
+
+				var warning string
+				switch {
+				case machine.Inactive:
+					warning = "inactive"
+				case machine.Idle:
+					warning = "no recent XP"
+				}
+				if warning != "" {
+					status += " — " + warning
+					if color {
+						status = ansiWarning + status + ansiReset
+					}
+				}
+
+				fmt.Fprintf(w, "%s  level %d, %.1f%%  %s\n", line, machine.Progress.Level, machine.Share*100, status)
+			}
+		},
+	})
+}
+
+// machineRow is a machine in the structured output of the machines command.
+type machineRow struct {
+	analytics.MachineStat
+	LastActive time.Time `json:"last_active,omitzero"`
+	Inactive   bool      `json:"inactive"`
+}
+
+// ago formats a duration in the past in its largest sensible unit.
+func ago(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%d min ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d h ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%s ago", days(int(d.Hours()/24)))
+	}
+}
@@ -0,0 +1,179 @@
+// Command godestats is a command line client for Code::Stats.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/client"
+)
+
+// Environment variables read by the CLI
+const (
+	EnvToken         = "CODESTATS_API_TOKEN"
+	EnvTokenFile     = "CODESTATS_API_TOKEN_FILE"
+	EnvUsername      = "CODESTATS_USERNAME"
+	EnvBaseURL       = "CODESTATS_BASE_URL"
+	EnvClientCert    = "CODESTATS_CLIENT_CERT"
+	EnvClientKey     = "CODESTATS_CLIENT_KEY"
+	EnvSigningKey    = "CODESTATS_SIGNING_KEY"
+	EnvSigningHeader = "CODESTATS_SIGNING_HEADER"
+	EnvNoColor       = "NO_COLOR"
+	EnvConfig        = "GODESTATS_CONFIG"
+)
+
+// errUsage is returned by commands that were invoked incorrectly.
+var errUsage = errors.New("invalid usage")
+
+// command is a CLI subcommand.
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+// commands holds all subcommands by name.
+var commands = map[string]command{
+	"daemon":   {"daemon [-interval 1m] [-record 1h] [-history path|off] [-socket path] [username...]", runDaemon},
+	"doctor":   {"doctor", runDoctor},
+	"goal":     {"goal set <daily|weekly> <xp> | goal remove <daily|weekly> | goal status [username]", runGoal},
+	"heatmap":  {"heatmap [-months 12] [username]", runHeatmap},
+	"login":    {"login", runLogin},
+	"logout":   {"logout", runLogout},
+	"langs":    {"langs [-top 10] [-group] [username]", runLangs},
+	"machines": {"machines [-inactive 14]", runMachines},
+	"notify":   {"notify add [-events kinds] <discord|slack|webhook> <url> | notify remove <n> | notify list | notify test", runNotify},
+	"profile":  {"profile [username]", runProfile},
+	"status":   {"status [-socket path]", runStatus},
+	"streak":   {"streak [-weeks 4] [username]", runStreak},
+	"watch":    {"watch [-interval 1m] [username]", runWatch},
+}
+
+// app holds the environment commands run in.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+	now    func() time.Time
+
+	// format is the output format selected with the global flags
+	format outputFormat
+	// rendered is set once a result was rendered
+	rendered bool
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv, now: time.Now}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// run executes the command line and returns the exit code.
+func (a *app) run(ctx context.Context, args []string) int {
+	args, format, err := parseOutputFlags(args)
+	if err != nil {
+		fmt.Fprintln(a.stderr, err)
+		return 2
+	}
+	a.format = format
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		a.usage()
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "unknown command %q\n\n", args[0])
+		a.usage()
+		return 2
+	}
+
+	err = cmd.run(ctx, a, args[1:])
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "%v\nusage: godestats %s\n", err, cmd.usage)
+		return 2
+	case godestats.MessageKeyFor(err) == godestats.MsgUnknown:
+		// Errors unrelated to the API, such as config errors, are most helpful as they are
+		fmt.Fprintf(a.stderr, "error: %v\n", err)
+		return 1
+	default:
+		fmt.Fprintf(a.stderr, "error: %s\n", godestats.UserMessage(err, a.getenv("LANG")))
+		return 1
+	}
+}
+
+// usage prints the list of commands.
+func (a *app) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(a.stderr, "usage: godestats [--json|--yaml|--csv|--table] <command> [arguments]")
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+// client creates an API client from the environment, authenticating with the
+// token of the sources returned by tokens.
+func (a *app) client() godestats.CodeStatsClient {
+	baseURL := a.getenv(EnvBaseURL)
+	if baseURL == "" {
+		baseURL = client.DefaultBaseURL
+	}
+	opts := []client.Option{client.WithTokenProvider(a.tokens())}
+	if cert := a.getenv(EnvClientCert); cert != "" {
+		opts = append(opts, client.WithClientCertificate(cert, a.getenv(EnvClientKey)))
+	}
+	if key := a.getenv(EnvSigningKey); key != "" {
+		opts = append(opts, client.WithRequestSigning(key, a.getenv(EnvSigningHeader)))
+	}
+	return client.NewWithBaseURL("", strings.TrimRight(baseURL, "/"), opts...)
+}
+
+// username returns the username given as the only positional argument,
+// falling back to the environment.
+func (a *app) username(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		if user := a.getenv(EnvUsername); user != "" {
+			return user, nil
+		}
+		return "", fmt.Errorf("%w: no username given and %s is not set", errUsage, EnvUsername)
+	case 1:
+		return args[0], nil
+	default:
+		return "", fmt.Errorf("%w: too many arguments", errUsage)
+	}
+}
+
+// color reports whether output should use ANSI colors.
+func (a *app) color() bool {
+	if a.getenv(EnvNoColor) != "" {
+		return false
+	}
+	f, ok := a.stdout.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,785 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yeti47/gode-stats/internal/keyringtest"
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+const testProfileJSON = `{
+	"user": "alice",
+	"total_xp": 12345,
+	"new_xp": 42,
+	"machines": {},
+	"languages": {"Go": {"xps": 10000, "new_xps": 42}, "Rust": {"xps": 2345, "new_xps": 0}},
+	"dates": {"2023-06-14": 300, "2023-06-15": 42}
+}`
+
+const testOwnProfileJSON = `{
+	"user": "alice",
+	"total_xp": 12345,
+	"new_xp": 42,
+	"machines": {
+		"laptop": {"xps": 10000, "new_xps": 42, "last_active": "2023-06-15T09:00:00Z"},
+		"desktop": {"xps": 2345, "new_xps": 0, "last_active": "2023-05-01T12:00:00Z"}
+	},
+	"languages": {},
+	"dates": {}
+}`
+
+// newTestApp creates an app talking to a fake API server.
+func newTestApp(t *testing.T, env map[string]string) (*app, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/users/alice":
+			w.Write([]byte(testProfileJSON))
+		case r.URL.Path == "/api/my/profile" && r.Header.Get("X-API-Token") == "test-token":
+			w.Write([]byte(testOwnProfileJSON))
+		case r.URL.Path == "/api/my/profile":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	vars := map[string]string{
+		EnvBaseURL: server.URL,
+		EnvConfig:  filepath.Join(t.TempDir(), "config.json"),
+	}
+	for k, v := range env {
+		vars[k] = v
+	}
+
+	// Never touch the real keyring of the machine running the tests
+	keyringtest.Init()
+
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		stdin:  strings.NewReader(""),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string { return vars[key] },
+		now:    func() time.Time { return time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC) },
+	}
+	return a, &stdout, &stderr
+}
+
+func TestRun_Profile(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+
+	if code := a.run(context.Background(), []string{"profile", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{"alice — level 2", "12,345 XP", "+42 recently", "Last 30 days", "Go   ", "Rust "} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Error("Expected no ANSI colors when not writing to a terminal")
+	}
+}
+
+func TestRun_ProfileFromEnvironment(t *testing.T) {
+	a, stdout, _ := newTestApp(t, map[string]string{EnvUsername: "alice"})
+
+	if code := a.run(context.Background(), []string{"profile"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "alice") {
+		t.Error("Expected profile of the user from the environment")
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"no command", nil, 2, "usage: godestats"},
+		{"unknown command", []string{"bogus"}, 2, `unknown command "bogus"`},
+		{"missing username", []string{"profile"}, 2, "usage: godestats profile"},
+		{"too many arguments", []string{"profile", "a", "b"}, 2, "too many arguments"},
+		{"unknown user", []string{"profile", "bob"}, 1, "doesn't exist"},
+		{"invalid weeks", []string{"streak", "-weeks", "0", "alice"}, 2, "weeks must be positive"},
+		{"missing goal subcommand", []string{"goal"}, 2, "missing subcommand"},
+		{"invalid goal period", []string{"goal", "set", "monthly", "500"}, 2, "invalid goal period"},
+		{"invalid goal target", []string{"goal", "set", "daily", "-5"}, 2, "goal target must be positive"},
+		{"invalid months", []string{"heatmap", "-months", "0", "alice"}, 2, "months must be positive"},
+		{"invalid top", []string{"langs", "-top", "0", "alice"}, 2, "top must be positive"},
+		{"machines without token", []string{"machines"}, 1, "API token is missing or invalid"},
+		{"invalid interval", []string{"watch", "-interval", "0s", "alice"}, 2, "interval must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, stderr := newTestApp(t, nil)
+
+			if code := a.run(context.Background(), tt.args); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("Expected stderr to contain '%s', got '%s'", tt.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_Streak(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+
+	if code := a.run(context.Background(), []string{"streak", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{
+		"Current streak  2 days",
+		"Longest streak  2 days, ended Jun 15, 2023",
+		"one more day sets a new record",
+		"Mo Tu We Th Fr Sa Su",
+		" ·  · 14 15",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestRun_Goal(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+	ctx := context.Background()
+
+	if code := a.run(ctx, []string{"goal", "status", "alice"}); code != 0 || !strings.Contains(stdout.String(), "No goals set") {
+		t.Fatalf("Expected hint without goals, got exit code %d and:\n%s", code, stdout.String())
+	}
+
+	for _, args := range [][]string{
+		{"goal", "set", "daily", "100"},
+		{"goal", "set", "week", "500"},
+		{"goal", "set", "weekly", "1000"},
+	} {
+		if code := a.run(ctx, args); code != 0 {
+			t.Fatalf("Expected exit code 0 for %v, got %d", args, code)
+		}
+	}
+
+	stdout.Reset()
+	if code := a.run(ctx, []string{"goal", "status", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	// Thursday the 15th: 42 XP today and 342 XP this week
+	out := stdout.String()
+	for _, expected := range []string{"42 / 100 XP (42%) — 58 XP to go", "342 / 1,000 XP (34%)"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+
+	if code := a.run(ctx, []string{"goal", "remove", "daily"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	stdout.Reset()
+	a.run(ctx, []string{"goal", "status", "alice"})
+	if strings.Contains(stdout.String(), "Today") || !strings.Contains(stdout.String(), "This week") {
+		t.Errorf("Expected only the weekly goal, got:\n%s", stdout.String())
+	}
+}
+
+func TestRun_Heatmap(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+
+	if code := a.run(context.Background(), []string{"heatmap", "--months", "3", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{"alice — 342 XP on 2 days in the last 3 months", "Mar", "Jun", "Mon ", "Less ·░▒▓█ More"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestRun_Langs(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+	ctx := context.Background()
+
+	if code := a.run(ctx, []string{"langs", "-top", "1", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{"alice — 2 languages", " 1. Go ", "10k  level 2, 81.0%"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "Rust") {
+		t.Errorf("Expected only the top language, got:\n%s", out)
+	}
+
+	path, _ := a.configPath()
+	if err := os.WriteFile(path, []byte(`{"groups": {"Systems": ["Go", "Rust"]}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	stdout.Reset()
+	if code := a.run(ctx, []string{"langs", "-group", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	if out := stdout.String(); !strings.Contains(out, " 1. Systems ") || !strings.Contains(out, "level 2, 100.0%") {
+		t.Errorf("Expected languages grouped by the config, got:\n%s", out)
+	}
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	a, _, stderr := newTestApp(t, nil)
+
+	path, _ := a.configPath()
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if code := a.run(context.Background(), []string{"goal", "status", "alice"}); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "failed to parse config") {
+		t.Errorf("Expected the config error to be shown as is, got '%s'", stderr.String())
+	}
+}
+
+func TestRun_Machines(t *testing.T) {
+	a, stdout, _ := newTestApp(t, map[string]string{EnvToken: "test-token"})
+
+	if code := a.run(context.Background(), []string{"machines"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{
+		"alice — 2 machines",
+		"level 2, 81.0%  last seen 3 h ago\n",
+		"level 1, 19.0%  last seen 45 days ago — inactive",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestRun_MachinesWithTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(path, []byte("test-token\n"), 0o600)
+	a, stdout, _ := newTestApp(t, map[string]string{EnvTokenFile: path})
+
+	if code := a.run(context.Background(), []string{"machines"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "alice — 2 machines") {
+		t.Errorf("Expected the machines of the token owner, got:\n%s", stdout.String())
+	}
+	if a.token() != "test-token" {
+		t.Errorf("Expected the token from the file, got %q", a.token())
+	}
+}
+
+func TestRun_Doctor(t *testing.T) {
+	a, stdout, _ := newTestApp(t, map[string]string{EnvToken: "test-token"})
+	a.now = time.Now
+
+	if code := a.run(context.Background(), []string{"doctor"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d:\n%s", code, stdout.String())
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{
+		"[ok  ] proxy        no proxy configured",
+		"[ok  ] connectivity reached http://",
+		"[warn] tls          connection is not encrypted",
+		"[ok  ] clock        local clock within",
+		"[ok  ] token        valid for alice",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestRun_DoctorProblems(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected []string
+	}{
+		{
+			name: "clock and token",
+			env:  map[string]string{EnvToken: "wrong-token", "HTTP_PROXY": "proxy.example.com:8080", "NO_PROXY": "127.0.0.1"},
+			expected: []string{
+				"is excluded by NO_PROXY",
+				"[fail] clock        local clock is",
+				"pulses older than 7 days",
+				"[fail] token        token was rejected",
+			},
+		},
+		{
+			name: "unreachable",
+			env:  map[string]string{EnvBaseURL: closed.URL},
+			expected: []string{
+				"[fail] connectivity",
+				"check your network connection",
+				"[skip] tls          server not reachable",
+				"[skip] token        no token in " + EnvToken + " and not logged in",
+			},
+		},
+		{
+			name: "untrusted certificate",
+			env:  map[string]string{EnvBaseURL: tlsServer.URL},
+			expected: []string{
+				"[fail] connectivity",
+				"add its CA via SSL_CERT_FILE",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, stdout, stderr := newTestApp(t, tt.env)
+
+			if code := a.run(context.Background(), []string{"doctor"}); code != 1 {
+				t.Errorf("Expected exit code 1, got %d", code)
+			}
+			if !strings.Contains(stderr.String(), "checks failed") {
+				t.Errorf("Expected failed checks to be reported, got '%s'", stderr.String())
+			}
+
+			out := stdout.String()
+			for _, expected := range tt.expected {
+				if !strings.Contains(out, expected) {
+					t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+				}
+			}
+		})
+	}
+}
+
+func TestParseOutputFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		rest     []string
+		format   outputFormat
+		hasError bool
+	}{
+		{"default", []string{"langs", "alice"}, []string{"langs", "alice"}, formatText, false},
+		{"before command", []string{"--json", "langs", "alice"}, []string{"langs", "alice"}, formatJSON, false},
+		{"after command", []string{"langs", "-top", "3", "-csv"}, []string{"langs", "-top", "3"}, formatCSV, false},
+		{"repeated", []string{"--yaml", "langs", "--yaml"}, []string{"langs"}, formatYAML, false},
+		{"after terminator", []string{"langs", "--", "--table"}, []string{"langs", "--", "--table"}, formatText, false},
+		{"username", []string{"langs", "table"}, []string{"langs", "table"}, formatText, false},
+		{"conflicting", []string{"--json", "langs", "--csv"}, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, format, err := parseOutputFlags(tt.args)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("Expected error %v, got %v", tt.hasError, err)
+			}
+			if strings.Join(rest, " ") != strings.Join(tt.rest, " ") || format != tt.format {
+				t.Errorf("Expected %v as %s, got %v as %s", tt.rest, tt.format, rest, format)
+			}
+		})
+	}
+}
+
+func TestRun_OutputFormats(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{"--json", []string{`"name": "Go"`, `"xps": 10000`, `"level": 2`}},
+		{"--yaml", []string{"- rank: 1\n  name: Go\n  xps: 10000\n  new_xps: 42\n  level: 2"}},
+		{"--csv", []string{"rank,language,xp,new_xp,level,share\n1,Go,10000,42,2,0.8100\n2,Rust,2345,0,1,0.1900\n"}},
+		{"--table", []string{"RANK  LANGUAGE  XP     NEW_XP  LEVEL  SHARE\n1     Go        10000  42      2      0.8100"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			a, stdout, _ := newTestApp(t, nil)
+
+			if code := a.run(context.Background(), []string{tt.format, "langs", "alice"}); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d", code)
+			}
+
+			out := stdout.String()
+			for _, expected := range tt.expected {
+				if !strings.Contains(out, expected) {
+					t.Errorf("Expected output to contain '%s', got:\n%s", expected, out)
+				}
+			}
+			if strings.Contains(out, "█") {
+				t.Errorf("Expected no charts in structured output, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestRun_OutputFormatsForAllCommands(t *testing.T) {
+	commands := [][]string{
+		{"profile", "alice"},
+		{"streak", "alice"},
+		{"heatmap", "alice"},
+		{"langs", "alice"},
+		{"machines"},
+		{"goal", "set", "daily", "100"},
+		{"goal", "status", "alice"},
+		{"goal", "remove", "daily"},
+		{"notify", "list"},
+	}
+
+	for _, args := range commands {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			a, stdout, stderr := newTestApp(t, map[string]string{EnvToken: "test-token"})
+
+			if code := a.run(context.Background(), append([]string{"--json"}, args...)); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+			}
+
+			var value any
+			if err := json.Unmarshal(stdout.Bytes(), &value); err != nil {
+				t.Errorf("Expected valid JSON, got %v:\n%s", err, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_WatchJSON(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if code := a.run(ctx, []string{"watch", "-interval", "10ms", "--json", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	out := stdout.String()
+	if strings.Contains(out, clearScreen) {
+		t.Error("Expected no terminal control sequences in JSON output")
+	}
+
+	dec := json.NewDecoder(strings.NewReader(out))
+	var results int
+	for dec.More() {
+		var summary profileSummary
+		if err := dec.Decode(&summary); err != nil {
+			t.Fatalf("Expected a stream of JSON results, got %v", err)
+		}
+		results++
+	}
+	if results < 2 {
+		t.Errorf("Expected at least 2 results, got %d", results)
+	}
+}
+
+func TestRun_Daemon(t *testing.T) {
+	a, _, stderr := newTestApp(t, map[string]string{EnvUsername: "alice"})
+	dir, _ := a.dataDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan int)
+	go func() {
+		done <- a.run(ctx, []string{"daemon", "-interval", "10ms"})
+	}()
+
+	// Wait for the daemon to report its status
+	var status *daemonStatus
+	deadline := time.Now().Add(2 * time.Second)
+	for status == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the daemon: %s", stderr.String())
+		}
+		status, _ = queryStatus(ctx, filepath.Join(dir, "daemon.sock"))
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(status.Users) != 1 || status.Users[0] != "alice" || status.PID != os.Getpid() {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	b, stdout, _ := newTestApp(t, map[string]string{EnvConfig: filepath.Join(dir, "config.json")})
+	if code := b.run(ctx, []string{"status"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "watching   alice every 10ms") {
+		t.Errorf("Expected status of the daemon, got:\n%s", stdout.String())
+	}
+
+	if code := b.run(ctx, []string{"daemon", "alice"}); code != 1 {
+		t.Errorf("Expected a second daemon to fail, got exit code %d", code)
+	}
+
+	cancel()
+	select {
+	case code := <-done:
+		if code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the daemon to stop")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "history.db")); err != nil {
+		t.Errorf("Expected a history database, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "daemon.sock")); !os.IsNotExist(err) {
+		t.Errorf("Expected the socket to be removed, got %v", err)
+	}
+}
+
+func TestDaemon_FailLogsRequestID(t *testing.T) {
+	a, _, stderr := newTestApp(t, nil)
+	d := &daemon{a: a}
+
+	d.fail(fmt.Errorf("watching alice: %w", &godestats.RequestError{RequestID: "req-1", Err: godestats.ErrUnauthorized}))
+	d.fail(errors.New("disk full"))
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "(request req-1)") || strings.Contains(lines[1], "request") {
+		t.Errorf("Expected the request ID only on the API error, got '%s'", stderr.String())
+	}
+	if d.status.Errors != 2 || d.status.LastError != "disk full" {
+		t.Errorf("Expected both errors to be counted, got %+v", d.status)
+	}
+}
+
+func TestRun_DaemonRejectedToken(t *testing.T) {
+	a, _, stderr := newTestApp(t, map[string]string{EnvUsername: "alice", EnvToken: "revoked-token"})
+	dir, _ := a.dataDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan int)
+	go func() {
+		done <- a.run(ctx, []string{"daemon", "-history", "off", "-token-check", "10ms"})
+	}()
+
+	// Wait for the daemon to report the rejected token
+	var status *daemonStatus
+	deadline := time.Now().Add(2 * time.Second)
+	for status == nil || !status.TokenInvalid {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the token check: %+v, %s", status, stderr.String())
+		}
+		status, _ = queryStatus(ctx, filepath.Join(dir, "daemon.sock"))
+		time.Sleep(5 * time.Millisecond)
+	}
+	if status.LastEvent == nil || status.LastEvent.Kind != watch.EventTokenInvalid {
+		t.Errorf("Expected a token_invalid event, got %+v", status.LastEvent)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestRun_StatusWithoutDaemon(t *testing.T) {
+	a, _, stderr := newTestApp(t, nil)
+
+	if code := a.run(context.Background(), []string{"status"}); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "daemon is not running") {
+		t.Errorf("Expected hint that the daemon is not running, got '%s'", stderr.String())
+	}
+}
+
+func TestRun_Login(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyring  error
+		location string
+	}{
+		{"keyring", nil, "the OS keyring"},
+		{"file fallback", errors.New("no keyring"), "no OS keyring available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, stdout, stderr := newTestApp(t, nil)
+			if tt.keyring != nil {
+				keyringtest.InitWithError(tt.keyring)
+			}
+			ctx := context.Background()
+
+			a.stdin = strings.NewReader("test-token\n")
+			if code := a.run(ctx, []string{"login"}); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+			}
+			if out := stdout.String(); !strings.Contains(out, "Logged in as alice") || !strings.Contains(out, tt.location) {
+				t.Errorf("Expected login in %s, got '%s'", tt.location, out)
+			}
+			if strings.Contains(stdout.String()+stderr.String(), "test-token") {
+				t.Error("Expected the token not to be printed")
+			}
+
+			// Authenticated commands use the stored token
+			if code := a.run(ctx, []string{"machines"}); code != 0 {
+				t.Errorf("Expected the stored token to be used, got exit code %d", code)
+			}
+
+			stdout.Reset()
+			if code := a.run(ctx, []string{"logout"}); code != 0 || !strings.Contains(stdout.String(), "Stored token removed") {
+				t.Errorf("Expected the token to be removed, got exit code %d and '%s'", code, stdout.String())
+			}
+			if token := a.token(); token != "" {
+				t.Errorf("Expected no token after logout, got %q", token)
+			}
+		})
+	}
+}
+
+func TestRun_LoginInvalidToken(t *testing.T) {
+	a, _, stderr := newTestApp(t, nil)
+	a.stdin = strings.NewReader("wrong-token\n")
+
+	if code := a.run(context.Background(), []string{"login"}); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "API token is missing or invalid") {
+		t.Errorf("Expected invalid token error, got '%s'", stderr.String())
+	}
+	if token := a.token(); token != "" {
+		t.Errorf("Expected the invalid token not to be stored, got %q", token)
+	}
+}
+
+func TestRun_Notify(t *testing.T) {
+	received := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		received <- body["text"]
+	}))
+	defer server.Close()
+
+	a, stdout, stderr := newTestApp(t, map[string]string{EnvUsername: "alice"})
+	ctx := context.Background()
+
+	if code := a.run(ctx, []string{"notify", "add", "-events", "level_up,goal_reached", "slack", server.URL}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	cfg, _ := a.loadConfig()
+	if len(cfg.Notify) != 1 || cfg.Notify[0].Kind != "slack" || len(cfg.Notify[0].Events) != 2 {
+		t.Errorf("Expected the notifier in the config, got %+v", cfg.Notify)
+	}
+
+	stdout.Reset()
+	if code := a.run(ctx, []string{"notify", "test"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "[ok]   slack") {
+		t.Errorf("Expected a successful test, got '%s'", stdout.String())
+	}
+	if text := <-received; text != "Test notification for alice from godestats" {
+		t.Errorf("Expected the test message, got %q", text)
+	}
+
+	stdout.Reset()
+	a.run(ctx, []string{"notify", "list"})
+	if !strings.Contains(stdout.String(), "1. slack") || !strings.Contains(stdout.String(), "level_up goal_reached") {
+		t.Errorf("Expected the notifier in the list, got '%s'", stdout.String())
+	}
+
+	if code := a.run(ctx, []string{"notify", "remove", "1"}); code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+	if code := a.run(ctx, []string{"notify", "test"}); code != 1 {
+		t.Errorf("Expected exit code 1 without notifiers, got %d", code)
+	}
+}
+
+func TestRun_NotifyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"unknown kind", []string{"notify", "add", "teams", "https://example.com"}, 2},
+		{"invalid URL", []string{"notify", "add", "discord", "example.com"}, 2},
+		{"unknown event", []string{"notify", "add", "-events", "pulse", "discord", "https://example.com"}, 2},
+		{"unknown notifier", []string{"notify", "remove", "1"}, 2},
+		{"missing subcommand", []string{"notify"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, _ := newTestApp(t, nil)
+			if code := a.run(context.Background(), tt.args); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+		})
+	}
+}
+
+func TestRun_NotifyFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	a, stdout, stderr := newTestApp(t, nil)
+	a.run(context.Background(), []string{"notify", "add", "discord", server.URL})
+
+	stdout.Reset()
+	if code := a.run(context.Background(), []string{"notify", "test"}); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "[fail] discord") || !strings.Contains(stderr.String(), "1 of 1 notifications failed") {
+		t.Errorf("Expected the failure to be reported, got '%s' and '%s'", stdout.String(), stderr.String())
+	}
+}
+
+func TestRun_Watch(t *testing.T) {
+	a, stdout, _ := newTestApp(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if code := a.run(ctx, []string{"watch", "-interval", "10ms", "alice"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+
+	if refreshes := strings.Count(stdout.String(), clearScreen); refreshes < 2 {
+		t.Errorf("Expected at least 2 refreshes, got %d", refreshes)
+	}
+}
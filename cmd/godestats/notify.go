@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Yeti47/gode-stats/pkg/notify"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+// notifier is a notification sink in the config file. Without events, it receives
+// every kind of event except XP gains, which the daemon reports on every poll.
+type notifier struct {
+	Kind   notify.Kind       `json:"kind"`
+	URL    string            `json:"url"`
+	Events []watch.EventKind `json:"events,omitempty"`
+}
+
+// events returns the kinds of events the notifier receives.
+func (n notifier) events() []watch.EventKind {
+	if len(n.Events) > 0 {
+		return n.Events
+	}
+	return slices.DeleteFunc(slices.Clone(watch.EventKinds), func(kind watch.EventKind) bool {
+		return kind == watch.EventXPGained
+	})
+}
+
+// runNotify manages the notification sinks used by the daemon and watch commands.
+func runNotify(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "add":
+		return a.addNotifier(args[1:])
+	case "remove":
+		return a.removeNotifier(args[1:])
+	case "list":
+		return a.listNotifiers(args[1:])
+	case "test":
+		return a.testNotifiers(ctx, args[1:])
+	default:
+		return fmt.Errorf("%w: unknown subcommand %q", errUsage, args[0])
+	}
+}
+
+// addNotifier adds a sink, or replaces the sink with the same URL.
+func (a *app) addNotifier(args []string) error {
+	fs := flag.NewFlagSet("notify add", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	events := fs.String("events", "", "comma-separated kinds of events to send (default all but xp_gained)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: expected a kind and a URL", errUsage)
+	}
+
+	kind, err := notify.ParseKind(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if err := notify.ValidateURL(fs.Arg(1)); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	n := notifier{Kind: kind, URL: fs.Arg(1)}
+	if *events != "" {
+		for _, name := range strings.Split(*events, ",") {
+			kind := watch.EventKind(strings.TrimSpace(name))
+			if !slices.Contains(watch.EventKinds, kind) {
+				return fmt.Errorf("%w: unknown event %q", errUsage, kind)
+			}
+			n.Events = append(n.Events, kind)
+		}
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Notify = slices.DeleteFunc(cfg.Notify, func(existing notifier) bool {
+		return existing.URL == n.URL
+	})
+	cfg.Notify = append(cfg.Notify, n)
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+
+	return a.render(notifiersOutput(cfg.Notify, fmt.Sprintf("%s notifications added, check them with: godestats notify test", kind)))
+}
+
+// removeNotifier deletes a sink by its number in the list.
+func (a *app) removeNotifier(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected the number of the notifier", errUsage)
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	i, err := strconv.Atoi(args[0])
+	if err != nil || i < 1 || i > len(cfg.Notify) {
+		return fmt.Errorf("%w: no notifier %q, see godestats notify list", errUsage, args[0])
+	}
+	removed := cfg.Notify[i-1]
+	cfg.Notify = slices.Delete(cfg.Notify, i-1, i)
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+
+	return a.render(notifiersOutput(cfg.Notify, fmt.Sprintf("%s notifications removed", removed.Kind)))
+}
+
+// listNotifiers prints the configured sinks.
+func (a *app) listNotifiers(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	out := notifiersOutput(cfg.Notify, "")
+	out.text = func(w io.Writer) {
+		if len(cfg.Notify) == 0 {
+			fmt.Fprintln(w, "No notifications configured, add one with: godestats notify add discord <webhook URL>")
+			return
+		}
+		for i, n := range cfg.Notify {
+			fmt.Fprintf(w, "%d. %-8s %s\n   %s\n", i+1, n.Kind, n.URL, joinKinds(n.events()))
+		}
+	}
+	return a.render(out)
+}
+
+// notifyResult is the outcome of a test notification.
+type notifyResult struct {
+	Kind  notify.Kind `json:"kind"`
+	URL   string      `json:"url"`
+	Error string      `json:"error,omitempty"`
+}
+
+// testNotifiers sends a test notification to every configured sink.
+func (a *app) testNotifiers(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Notify) == 0 {
+		return errors.New("no notifications configured, add one with: godestats notify add discord <webhook URL>")
+	}
+
+	user := a.getenv(EnvUsername)
+	if user == "" {
+		user = "you"
+	}
+
+	var failed int
+	results := make([]notifyResult, len(cfg.Notify))
+	rows := make([][]string, len(cfg.Notify))
+	for i, n := range cfg.Notify {
+		results[i] = notifyResult{Kind: n.Kind, URL: n.URL}
+		sink, err := notify.New(n.Kind, n.URL)
+		if err == nil {
+			err = notify.Test(ctx, sink, user)
+		}
+		if err != nil {
+			failed++
+			results[i].Error = err.Error()
+		}
+		rows[i] = []string{string(n.Kind), n.URL, results[i].Error}
+	}
+
+	err = a.render(output{
+		value: results,
+		table: table{header: []string{"kind", "url", "error"}, rows: rows},
+		text: func(w io.Writer) {
+			for _, r := range results {
+				status := "[ok]  "
+				if r.Error != "" {
+					status = "[fail]"
+				}
+				fmt.Fprintf(w, "%s %-8s %s\n", status, r.Kind, r.URL)
+				if r.Error != "" {
+					fmt.Fprintf(w, "       %s\n", r.Error)
+				}
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notifications failed", failed, len(results))
+	}
+	return nil
+}
+
+// notifications subscribes the configured sinks to the bus, each for its kinds of
+// events. Failed notifications are passed to onError.
+func (a *app) notifications(ctx context.Context, bus *watch.Bus, onError func(error)) error {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, n := range cfg.Notify {
+		sink, err := notify.New(n.Kind, n.URL)
+		if err != nil {
+			return fmt.Errorf("invalid notification in config: %w", err)
+		}
+		bus.Subscribe(watch.AllEvents, notify.Handler(ctx, sink, onError), watch.OnlyKinds(n.events()...))
+	}
+	return nil
+}
+
+// notifiersOutput describes the configured sinks after a change, with a message for the text format.
+func notifiersOutput(list []notifier, message string) output {
+	rows := make([][]string, len(list))
+	for i, n := range list {
+		rows[i] = []string{strconv.Itoa(i + 1), string(n.Kind), n.URL, joinKinds(n.events())}
+	}
+
+	if list == nil {
+		list = []notifier{}
+	}
+	return output{
+		value: list,
+		table: table{header: []string{"number", "kind", "url", "events"}, rows: rows},
+		text:  func(w io.Writer) { fmt.Fprintln(w, message) },
+	}
+}
+
+// joinKinds joins event kinds with spaces.
+func joinKinds(kinds []watch.EventKind) string {
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// outputFormat selects how commands print their results.
+type outputFormat string
+
+// Output formats. The text format is the default, human-oriented view with charts.
+const (
+	formatText  outputFormat = "text"
+	formatJSON  outputFormat = "json"
+	formatYAML  outputFormat = "yaml"
+	formatCSV   outputFormat = "csv"
+	formatTable outputFormat = "table"
+)
+
+// outputFlags maps the global output flags to their formats.
+var outputFlags = map[string]outputFormat{
+	"json":  formatJSON,
+	"yaml":  formatYAML,
+	"csv":   formatCSV,
+	"table": formatTable,
+}
+
+// parseOutputFlags removes the global output flags from anywhere in the arguments
+// and returns the remaining arguments with the selected format. Arguments after
+// "--" are left alone.
+func parseOutputFlags(args []string) ([]string, outputFormat, error) {
+	format := formatText
+	rest := make([]string, 0, len(args))
+
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		f, ok := outputFlags[strings.TrimLeft(arg, "-")]
+		if !ok || !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+		if format != formatText && format != f {
+			return nil, "", fmt.Errorf("%w: only one of --json, --yaml, --csv and --table may be given", errUsage)
+		}
+		format = f
+	}
+
+	return rest, format, nil
+}
+
+// table is tabular output for the CSV and table formats.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// output describes a command's result in every format: value is encoded as JSON or
+// YAML, table is printed as CSV or an aligned table, and text prints the default view.
+type output struct {
+	value any
+	table table
+	text  func(w io.Writer)
+}
+
+// render prints the output in the app's format. Commands that render repeatedly,
+// such as watch, print the header of tabular formats only once.
+func (a *app) render(out output) error {
+	switch a.format {
+	case formatJSON:
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out.value)
+	case formatYAML:
+		return a.renderYAML(out.value)
+	case formatCSV:
+		w := csv.NewWriter(a.stdout)
+		if !a.rendered {
+			w.Write(out.table.header)
+		}
+		w.WriteAll(out.table.rows)
+		a.rendered = true
+		return w.Error()
+	case formatTable:
+		w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		if !a.rendered {
+			fmt.Fprintln(w, strings.ToUpper(strings.Join(out.table.header, "\t")))
+		}
+		for _, row := range out.table.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		a.rendered = true
+		return w.Flush()
+	default:
+		out.text(a.stdout)
+		return nil
+	}
+}
+
+// renderYAML prints the value as a YAML document. The value is converted through
+// JSON first, so that field names and their order match the JSON output.
+func (a *app) renderYAML(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	// JSON is valid YAML in flow style, which is reset to the block style
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	resetStyle(&node)
+
+	if a.rendered {
+		// Separate repeated results into a stream of documents
+		fmt.Fprintln(a.stdout, "---")
+	}
+	a.rendered = true
+
+	enc := yaml.NewEncoder(a.stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return enc.Close()
+}
+
+// resetStyle clears the styles of the node and its children, so that the
+// encoder picks the default block style and quotes strings only if needed.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// xpCell formats XP for tabular output, without thousands separators.
+func xpCell(x godestats.XP) string {
+	return strconv.FormatInt(x.Int64(), 10)
+}
+
+// floatCell formats a fraction or other float for tabular output.
+func floatCell(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
+
+// timeCell formats a time for tabular output, leaving zero times empty.
+func timeCell(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// Layout of the profile view
+const (
+	profileLanguages = 8
+	profileBarWidth  = 30
+	sparklineDays    = 30
+	heatmapWeeks     = 12
+)
+
+// runProfile prints a user's profile with charts.
+func runProfile(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	user, err := a.username(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.client().GetUserProfile(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return a.render(a.profileOutput(profile))
+}
+
+// profileSummary is the structured output of the profile and watch commands.
+type profileSummary struct {
+	User          string                     `json:"user"`
+	Level         int                        `json:"level"`
+	LevelProgress float64                    `json:"level_progress"`
+	TotalXP       godestats.XP               `json:"total_xp"`
+	NewXP         godestats.XP               `json:"new_xp"`
+	Languages     []godestats.RankedLanguage `json:"languages"`
+	Dates         map[string]godestats.XP    `json:"dates"`
+}
+
+// profileOutput describes the profile view in every output format.
+func (a *app) profileOutput(profile *godestats.UserProfile) output {
+	calc := xp.NewCalculator()
+	summary := profileSummary{
+		User:          profile.User,
+		Level:         calc.GetLevel(profile.TotalXP),
+		LevelProgress: calc.GetLevelPercentage(profile.TotalXP),
+		TotalXP:       profile.TotalXP,
+		NewXP:         profile.NewXP,
+		Languages:     godestats.LanguagesByXP(profile),
+		Dates:         profile.Dates,
+	}
+
+	return output{
+		value: summary,
+		table: table{
+			header: []string{"user", "level", "level_progress", "total_xp", "new_xp", "languages"},
+			rows: [][]string{{
+				summary.User,
+				strconv.Itoa(summary.Level),
+				floatCell(summary.LevelProgress),
+				xpCell(summary.TotalXP),
+				xpCell(summary.NewXP),
+				strconv.Itoa(len(summary.Languages)),
+			}},
+		},
+		text: func(w io.Writer) { a.printProfile(w, profile) },
+	}
+}
+
+// printProfile writes the profile view: level, XP, recent activity and top languages.
+func (a *app) printProfile(w io.Writer, profile *godestats.UserProfile) {
+	calc := xp.NewCalculator()
+	now := a.now()
+	color := a.color()
+
+	level := calc.GetLevel(profile.TotalXP)
+	fmt.Fprintf(w, "%s — level %d (%.0f%%), %s XP", profile.User, level, calc.GetLevelPercentage(profile.TotalXP)*100, profile.TotalXP)
+	if profile.NewXP > 0 {
+		fmt.Fprintf(w, ", +%s recently", profile.NewXP)
+	}
+	fmt.Fprintln(w)
+
+	daily := make([]godestats.XP, sparklineDays)
+	for i := range daily {
+		daily[i] = profile.XPOn(now.AddDate(0, 0, i-sparklineDays+1))
+	}
+	fmt.Fprintf(w, "\nLast %d days  %s\n\n", sparklineDays, termchart.Sparkline(daily))
+
+	for _, line := range termchart.Heatmap(profile, heatmapWeeks, now, color) {
+		fmt.Fprintln(w, line)
+	}
+
+	ranked := godestats.LanguagesByXP(profile)
+	if len(ranked) > profileLanguages {
+		ranked = ranked[:profileLanguages]
+	}
+	if len(ranked) > 0 {
+		bars := make([]termchart.Bar, len(ranked))
+		for i, lang := range ranked {
+			bars[i] = termchart.Bar{Label: lang.Name, Value: lang.XPs}
+		}
+		fmt.Fprintln(w)
+		for _, line := range termchart.Bars(bars, profileBarWidth, color) {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+)
+
+// DefaultStreakWeeks is the number of weeks shown in the streak calendar.
+const DefaultStreakWeeks = 4
+
+// runStreak prints a user's current and longest streaks with a calendar of the last weeks.
+func runStreak(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("streak", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	weeks := fs.Int("weeks", DefaultStreakWeeks, "number of weeks in the calendar")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *weeks <= 0 {
+		return fmt.Errorf("%w: weeks must be positive", errUsage)
+	}
+
+	user, err := a.username(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.client().GetUserProfile(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	now := a.now()
+	streak := profile.Streaks(now)
+
+	// Days of coding needed to beat the longest streak
+	toRecord := streak.Longest - streak.Current + 1
+	summary := streakSummary{User: profile.User, Streak: streak, DaysToRecord: toRecord}
+
+	return a.render(output{
+		value: summary,
+		table: table{
+			header: []string{"user", "current", "longest", "longest_end", "days_to_record"},
+			rows: [][]string{{
+				summary.User,
+				strconv.Itoa(streak.Current),
+				strconv.Itoa(streak.Longest),
+				timeCell(streak.LongestEnd),
+				strconv.Itoa(toRecord),
+			}},
+		},
+		text: func(w io.Writer) {
+			fmt.Fprintf(w, "%s\n\n", profile.User)
+			fmt.Fprintf(w, "Current streak  %s\n", days(streak.Current))
+			fmt.Fprintf(w, "Longest streak  %s", days(streak.Longest))
+			if !streak.LongestEnd.IsZero() {
+				fmt.Fprintf(w, ", ended %s", streak.LongestEnd.Format("Jan 2, 2006"))
+			}
+			fmt.Fprintln(w)
+
+			switch {
+			case streak.Longest == 0:
+				fmt.Fprintln(w, "No streak yet — code today to start one")
+			case streak.Current == streak.Longest:
+				fmt.Fprintln(w, "You are on your longest streak, one more day sets a new record")
+			default:
+				fmt.Fprintf(w, "Personal record in %s\n", days(toRecord))
+			}
+
+			fmt.Fprintln(w)
+			for _, line := range termchart.Calendar(profile, *weeks, now, a.color()) {
+				fmt.Fprintln(w, line)
+			}
+		},
+	})
+}
+
+// streakSummary is the structured output of the streak command.
+type streakSummary struct {
+	User string `json:"user"`
+	godestats.Streak
+	DaysToRecord int `json:"days_to_record"`
+}
+
+// days formats a number of days.
+func days(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+	"github.com/Yeti47/gode-stats/pkg/termchart"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// DefaultWatchInterval is how often the watch command refreshes the profile.
+const DefaultWatchInterval = time.Minute
+
+// watchHistory is the number of XP gains shown in the watch sparkline.
+const watchHistory = 60
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// runWatch refreshes a user's profile view periodically until interrupted,
+// with a braille chart of the XP gained between refreshes.
+func runWatch(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	interval := fs.Duration("interval", DefaultWatchInterval, "refresh interval")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("%w: interval must be positive", errUsage)
+	}
+
+	user, err := a.username(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	// Changes between refreshes are sent to the configured notifications
+	bus := watch.NewBus()
+	err = a.notifications(ctx, bus, func(err error) {
+		fmt.Fprintf(a.stderr, "error: %v\n", err)
+	})
+	if err != nil {
+		return err
+	}
+
+	c := a.client()
+	calc := xp.NewCalculator()
+	var (
+		gains []godestats.XP
+		last  *godestats.UserProfile
+	)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		profile, err := c.GetUserProfile(ctx, user)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Fprintf(a.stderr, "error: %s\n", godestats.UserMessage(err, a.getenv("LANG")))
+		} else {
+			if last != nil {
+				gains = append(gains, max(profile.TotalXP-last.TotalXP, 0))
+				if len(gains) > watchHistory {
+					gains = gains[len(gains)-watchHistory:]
+				}
+
+				now := a.now()
+				changes := history.Diff(history.Snapshot{User: user, TakenAt: now, Profile: last},
+					history.Snapshot{User: user, TakenAt: now, Profile: profile}, calc)
+				for _, change := range changes {
+					bus.PublishChange(change)
+				}
+			}
+			last = profile
+
+			// Structured formats print one result per refresh for scripts to consume
+			out := a.profileOutput(profile)
+			out.text = func(w io.Writer) {
+				fmt.Fprint(w, clearScreen)
+				a.printProfile(w, profile)
+				fmt.Fprintf(w, "\nXP per %s, refreshed %s\n", *interval, a.now().Format(time.TimeOnly))
+				for _, line := range termchart.Braille(gains, 2) {
+					fmt.Fprintln(w, line)
+				}
+			}
+			if err := a.render(out); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Package keyringtest replaces the OS credential store used by package keyring
+// in tests, so that they never touch the keyring of the machine running them.
+package keyringtest
+
+import gokeyring "github.com/zalando/go-keyring"
+
+// Init replaces the OS credential store with an in-memory store.
+func Init() {
+	gokeyring.MockInit()
+}
+
+// InitWithError replaces the OS credential store with one failing every
+// operation with err.
+func InitWithError(err error) {
+	gokeyring.MockInitWithError(err)
+}
@@ -0,0 +1,158 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// AnomalyKind identifies the type of an activity anomaly.
+type AnomalyKind string
+
+// Supported anomaly kinds
+const (
+	// AnomalySpike is a day with far more XP than usual.
+	AnomalySpike AnomalyKind = "spike"
+	// AnomalyGap is a run of days without XP that is unlikely given how often the user codes.
+	AnomalyGap AnomalyKind = "gap"
+)
+
+const (
+	// SpikeThreshold is the modified z-score above which a day counts as a spike.
+	SpikeThreshold = 3.5
+	// GapProbability is the probability below which a run of inactive days counts as a gap.
+	GapProbability = 0.01
+	// minActiveDays is the number of active days needed to judge what is typical.
+	minActiveDays = 7
+)
+
+// Anomaly is a day or run of days with unusual activity.
+type Anomaly struct {
+	Kind AnomalyKind `json:"kind"`
+	// Start is the first day of the anomaly; End is the first day after it.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// XP is the XP gained during the anomaly.
+	XP godestats.XP `json:"xp"`
+	// Score is the modified z-score of a spike, or the improbability of a gap
+	// expressed as -log10 of its probability. Higher scores are more unusual.
+	Score float64 `json:"score"`
+}
+
+// Anomalies flags days in a profile's Dates map whose XP lies far outside the
+// user's typical distribution, and runs of inactive days that are unlikely given
+// how often the user is active, which often point to a broken editor plugin.
+//
+// Spikes are detected with the median absolute deviation of active days, which is
+// robust against the spikes themselves. A run of n inactive days is a gap if a user
+// active on a fraction r of all days would go that long with probability (1-r)^n
+// below GapProbability. Nothing is flagged with fewer than seven active days.
+// Keys that cannot be parsed are skipped and reported in the returned error.
+// Anomalies are returned in chronological order.
+func Anomalies(dates map[string]godestats.XP) ([]Anomaly, error) {
+	series, err := (&godestats.UserProfile{Dates: dates}).DailySeries()
+	series = godestats.FillDateGaps(series)
+
+	var active []float64
+	for _, day := range series {
+		if day.XP > 0 {
+			active = append(active, float64(day.XP))
+		}
+	}
+	if len(active) < minActiveDays {
+		return nil, err
+	}
+
+	anomalies := spikes(series, active)
+	anomalies = append(anomalies, gaps(series, float64(len(active))/float64(len(series)))...)
+
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return anomalies[i].Start.Before(anomalies[j].Start)
+	})
+	return anomalies, err
+}
+
+// spikes returns the days of the series whose modified z-score exceeds SpikeThreshold.
+func spikes(series []godestats.DateXP, active []float64) []Anomaly {
+	median := quantile(active, 0.5)
+
+	deviations := make([]float64, len(active))
+	for i, v := range active {
+		deviations[i] = math.Abs(v - median)
+	}
+	// 1.4826 scales the MAD to the standard deviation of a normal distribution
+	scale := 1.4826 * quantile(deviations, 0.5)
+	if scale == 0 {
+		// More than half of the active days have the same XP; fall back to the mean deviation
+		var sum float64
+		for _, d := range deviations {
+			sum += d
+		}
+		scale = 1.2533 * sum / float64(len(deviations))
+	}
+	if scale == 0 {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	for _, day := range series {
+		score := (float64(day.XP) - median) / scale
+		if score > SpikeThreshold {
+			anomalies = append(anomalies, Anomaly{
+				Kind:  AnomalySpike,
+				Start: day.Date.Time(time.UTC),
+				End:   day.Date.AddDays(1).Time(time.UTC),
+				XP:    day.XP,
+				Score: score,
+			})
+		}
+	}
+	return anomalies
+}
+
+// gaps returns the runs of inactive days of the series that are improbable for a
+// user active on the given fraction of days.
+func gaps(series []godestats.DateXP, activeRatio float64) []Anomaly {
+	if activeRatio >= 1 {
+		return nil
+	}
+	perDay := -math.Log10(1 - activeRatio)
+	threshold := -math.Log10(GapProbability)
+
+	var anomalies []Anomaly
+	for i := 0; i < len(series); {
+		if series[i].XP > 0 {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(series) && series[i].XP <= 0 {
+			i++
+		}
+
+		if score := perDay * float64(i-start); score > threshold {
+			anomalies = append(anomalies, Anomaly{
+				Kind:  AnomalyGap,
+				Start: series[start].Date.Time(time.UTC),
+				End:   series[i-1].Date.AddDays(1).Time(time.UTC),
+				Score: score,
+			})
+		}
+	}
+	return anomalies
+}
+
+// quantile returns the q-quantile of the values by linear interpolation.
+// The values are not modified.
+func quantile(values []float64, q float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
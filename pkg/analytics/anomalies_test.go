@@ -0,0 +1,68 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestAnomalies(t *testing.T) {
+	dates := map[string]godestats.XP{}
+	start := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC) // a Monday
+	for i := 0; i < 56; i++ {
+		day := start.AddDate(0, 0, i)
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			continue
+		}
+		dates[day.Format(godestats.DateFormat)] = godestats.XP(400 + (i%3)*100)
+	}
+	dates["2023-05-10"] = 5000
+	// The plugin stopped reporting for a week and a half
+	for day := time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC); day.Before(time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)); day = day.AddDate(0, 0, 1) {
+		delete(dates, day.Format(godestats.DateFormat))
+	}
+
+	anomalies, err := Anomalies(dates)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(anomalies) != 2 {
+		t.Fatalf("Expected 2 anomalies, got %+v", anomalies)
+	}
+
+	spike := anomalies[0]
+	if spike.Kind != AnomalySpike || spike.Start.Format(godestats.DateFormat) != "2023-05-10" || spike.XP != 5000 {
+		t.Errorf("Unexpected spike: %+v", spike)
+	}
+
+	// The gap includes the preceding weekend
+	gap := anomalies[1]
+	if gap.Kind != AnomalyGap || gap.Start.Format(godestats.DateFormat) != "2023-06-03" || gap.End.Format(godestats.DateFormat) != "2023-06-15" {
+		t.Errorf("Unexpected gap: %+v", gap)
+	}
+}
+
+func TestAnomalies_WeekendsAreNormal(t *testing.T) {
+	dates := map[string]godestats.XP{}
+	start := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 28; i++ {
+		day := start.AddDate(0, 0, i)
+		if wd := day.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			dates[day.Format(godestats.DateFormat)] = 500
+		}
+	}
+
+	anomalies, err := Anomalies(dates)
+	if err != nil || len(anomalies) != 0 {
+		t.Errorf("Expected no anomalies, got %+v (err=%v)", anomalies, err)
+	}
+}
+
+func TestAnomalies_TooLittleData(t *testing.T) {
+	anomalies, err := Anomalies(map[string]godestats.XP{"2023-05-01": 100, "2023-05-20": 10000})
+	if err != nil || anomalies != nil {
+		t.Errorf("Expected no anomalies, got %+v (err=%v)", anomalies, err)
+	}
+}
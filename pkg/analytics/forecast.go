@@ -0,0 +1,143 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// ErrNotEnoughHistory is returned when there is too little history for a forecast.
+var ErrNotEnoughHistory = errors.New("not enough history for a forecast")
+
+// SmoothingFactor is the weight of the most recent day in the exponential smoothing
+// of daily XP gains. Higher values follow recent changes in pace more quickly.
+const SmoothingFactor = 0.3
+
+// confidenceZ is the z-score of the 95% confidence bands.
+const confidenceZ = 1.96
+
+// ForecastPoint is the projected total XP at the end of a future day, together with
+// a 95% confidence band.
+type ForecastPoint struct {
+	Date    time.Time    `json:"date"`
+	TotalXP godestats.XP `json:"total_xp"`
+	Lower   godestats.XP `json:"lower"`
+	Upper   godestats.XP `json:"upper"`
+}
+
+// Prediction is a forecast of a user's total XP.
+type Prediction struct {
+	// Start is the last day of the history the forecast is based on, at midnight UTC.
+	Start time.Time `json:"start"`
+	// TotalXP is the total XP at the end of Start.
+	TotalXP godestats.XP `json:"total_xp"`
+	// DailyRate is the smoothed XP gained per day that the forecast assumes.
+	DailyRate float64 `json:"daily_rate"`
+	// Points holds one projection per day after Start.
+	Points []ForecastPoint `json:"points"`
+}
+
+// Reaches returns the first day on which the total XP is expected to reach target
+// at the forecast pace, which may lie beyond the forecast horizon. The boolean
+// result is false if the target will never be reached because the pace is zero.
+func (p *Prediction) Reaches(target godestats.XP) (time.Time, bool) {
+	if target <= p.TotalXP {
+		return p.Start, true
+	}
+	if p.DailyRate <= 0 {
+		return time.Time{}, false
+	}
+	days := math.Ceil(float64(target-p.TotalXP) / p.DailyRate)
+	return p.Start.AddDate(0, 0, int(days)), true
+}
+
+// Forecast projects a user's total XP for the given horizon from their snapshots,
+// ordered by the time they were taken. The daily gains are derived from the last
+// snapshot of each day (UTC) and smoothed exponentially; the confidence bands widen
+// with the square root of the distance, assuming independent daily errors.
+// At least two days of history are required.
+func Forecast(snapshots []history.Snapshot, horizon time.Duration) (*Prediction, error) {
+	days, totals := dailyTotals(snapshots)
+	if len(totals) < 2 {
+		return nil, ErrNotEnoughHistory
+	}
+
+	// Smooth the daily gains and collect the one-step-ahead errors
+	level := float64(totals[1] - totals[0])
+	var sumSquares float64
+	for i := 2; i < len(totals); i++ {
+		gained := float64(totals[i] - totals[i-1])
+		residual := gained - level
+		sumSquares += residual * residual
+		level += SmoothingFactor * residual
+	}
+	level = math.Max(level, 0)
+
+	var stddev float64
+	if n := len(totals) - 2; n > 0 {
+		stddev = math.Sqrt(sumSquares / float64(n))
+	}
+
+	last := totals[len(totals)-1]
+	prediction := &Prediction{
+		Start:     days[len(days)-1],
+		TotalXP:   last,
+		DailyRate: level,
+	}
+
+	steps := int(math.Ceil(horizon.Hours() / 24))
+	for h := 1; h <= steps; h++ {
+		expected := float64(last) + level*float64(h)
+		margin := confidenceZ * stddev * math.Sqrt(float64(h))
+		prediction.Points = append(prediction.Points, ForecastPoint{
+			Date:    prediction.Start.AddDate(0, 0, h),
+			TotalXP: godestats.XP(math.Round(expected)),
+			// Total XP never decreases
+			Lower: godestats.XP(math.Round(math.Max(expected-margin, float64(last)))),
+			Upper: godestats.XP(math.Round(expected + margin)),
+		})
+	}
+
+	return prediction, nil
+}
+
+// dailyTotals returns the total XP at the end of every day (UTC) from the first to
+// the last snapshot. Days without snapshots keep the previous day's total.
+func dailyTotals(snapshots []history.Snapshot) ([]time.Time, []godestats.XP) {
+	byDay := make(map[time.Time]godestats.XP)
+	var first, last time.Time
+	for _, snapshot := range snapshots {
+		if snapshot.Profile == nil {
+			continue
+		}
+		at := snapshot.TakenAt.UTC()
+		day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+		byDay[day] = snapshot.Profile.TotalXP
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	if len(byDay) == 0 {
+		return nil, nil
+	}
+
+	var (
+		days   []time.Time
+		totals []godestats.XP
+		total  godestats.XP
+	)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if xp, ok := byDay[day]; ok {
+			total = xp
+		}
+		days = append(days, day)
+		totals = append(totals, total)
+	}
+	return days, totals
+}
@@ -0,0 +1,95 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func TestForecast_SteadyPace(t *testing.T) {
+	start := time.Date(2023, 6, 1, 18, 0, 0, 0, time.UTC)
+	var snapshots []history.Snapshot
+	for i := 0; i < 10; i++ {
+		snapshots = append(snapshots, snapshotAt(start.AddDate(0, 0, i), godestats.XP(1000+100*i)))
+	}
+
+	prediction, err := Forecast(snapshots, 3*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if prediction.DailyRate != 100 || prediction.TotalXP != 1900 {
+		t.Errorf("Expected 100 XP per day from 1900 XP, got %f from %d", prediction.DailyRate, prediction.TotalXP)
+	}
+	if len(prediction.Points) != 3 {
+		t.Fatalf("Expected 3 points, got %d", len(prediction.Points))
+	}
+
+	point := prediction.Points[2]
+	if point.TotalXP != 2200 || point.Lower != 2200 || point.Upper != 2200 {
+		t.Errorf("Expected exact projection of 2200 XP, got %+v", point)
+	}
+	if !point.Date.Equal(time.Date(2023, 6, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected projection date: %v", point.Date)
+	}
+
+	reached, ok := prediction.Reaches(2450)
+	if !ok || !reached.Equal(time.Date(2023, 6, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected target to be reached on 2023-06-16, got %v (ok=%v)", reached, ok)
+	}
+}
+
+func TestForecast_ConfidenceBands(t *testing.T) {
+	start := time.Date(2023, 6, 1, 18, 0, 0, 0, time.UTC)
+	total := godestats.XP(1000)
+	var snapshots []history.Snapshot
+	for i := 0; i < 14; i++ {
+		if i%2 == 0 {
+			total += 200
+		}
+		snapshots = append(snapshots, snapshotAt(start.AddDate(0, 0, i), total))
+	}
+
+	prediction, err := Forecast(snapshots, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if math.Abs(prediction.DailyRate-100) > 50 {
+		t.Errorf("Expected a daily rate around 100, got %f", prediction.DailyRate)
+	}
+
+	first, last := prediction.Points[0], prediction.Points[6]
+	if !(first.Lower <= first.TotalXP && first.TotalXP <= first.Upper) {
+		t.Errorf("Expected projection within its band, got %+v", first)
+	}
+	if last.Upper-last.Lower <= first.Upper-first.Lower {
+		t.Errorf("Expected bands to widen, got %+v and %+v", first, last)
+	}
+	if first.Lower < prediction.TotalXP {
+		t.Errorf("Expected lower bound not below the current total, got %+v", first)
+	}
+}
+
+func TestForecast_NotEnoughHistory(t *testing.T) {
+	day := time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC)
+	snapshots := []history.Snapshot{snapshotAt(day, 100), snapshotAt(day.Add(time.Hour), 200)}
+
+	if _, err := Forecast(snapshots, 24*time.Hour); !errors.Is(err, ErrNotEnoughHistory) {
+		t.Errorf("Expected ErrNotEnoughHistory, got %v", err)
+	}
+}
+
+func TestPrediction_Reaches_NoActivity(t *testing.T) {
+	prediction := &Prediction{TotalXP: 100}
+	if _, ok := prediction.Reaches(200); ok {
+		t.Error("Expected target to be unreachable without activity")
+	}
+	if _, ok := prediction.Reaches(50); !ok {
+		t.Error("Expected reached target to be reported")
+	}
+}
@@ -0,0 +1,89 @@
+package analytics
+
+import (
+	"sort"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Improvement is a language's XP growth between two snapshots of a profile.
+type Improvement struct {
+	Name   string       `json:"name"`
+	OldXP  godestats.XP `json:"old_xp"`
+	NewXP  godestats.XP `json:"new_xp"`
+	Gained godestats.XP `json:"gained"`
+	// Growth is the gain relative to the old XP, e.g. 0.5 for 50%.
+	// It is 0 for languages that had no XP in the old snapshot.
+	Growth float64 `json:"growth"`
+}
+
+// Improvements ranks the languages that gained XP between two snapshots.
+type Improvements struct {
+	// ByXP is ordered by absolute gain.
+	ByXP []Improvement `json:"by_xp"`
+	// ByGrowth is ordered by relative growth and omits languages that are new in
+	// the newer snapshot, whose growth is undefined.
+	ByGrowth []Improvement `json:"by_growth"`
+}
+
+// MostImproved returns up to n languages with the most XP growth between the older
+// and newer snapshots of a profile, ranked both by absolute and by relative growth.
+// Ties are ordered by name. A non-positive n returns all languages that gained XP.
+func MostImproved(older, newer *godestats.UserProfile, n int) Improvements {
+	if newer == nil {
+		return Improvements{}
+	}
+
+	var all []Improvement
+	for name, info := range newer.Languages {
+		var oldXP godestats.XP
+		if older != nil {
+			oldXP = older.Languages[name].XPs
+		}
+		if info.XPs <= oldXP {
+			continue
+		}
+
+		improvement := Improvement{
+			Name:   name,
+			OldXP:  oldXP,
+			NewXP:  info.XPs,
+			Gained: info.XPs.Sub(oldXP),
+		}
+		if oldXP > 0 {
+			improvement.Growth = float64(improvement.Gained) / float64(oldXP)
+		}
+		all = append(all, improvement)
+	}
+
+	byXP := append([]Improvement(nil), all...)
+	sort.Slice(byXP, func(i, j int) bool {
+		if byXP[i].Gained != byXP[j].Gained {
+			return byXP[i].Gained > byXP[j].Gained
+		}
+		return byXP[i].Name < byXP[j].Name
+	})
+
+	var byGrowth []Improvement
+	for _, improvement := range all {
+		if improvement.OldXP > 0 {
+			byGrowth = append(byGrowth, improvement)
+		}
+	}
+	sort.Slice(byGrowth, func(i, j int) bool {
+		if byGrowth[i].Growth != byGrowth[j].Growth {
+			return byGrowth[i].Growth > byGrowth[j].Growth
+		}
+		return byGrowth[i].Name < byGrowth[j].Name
+	})
+
+	return Improvements{ByXP: top(byXP, n), ByGrowth: top(byGrowth, n)}
+}
+
+// top returns the first n improvements, or all of them if n is not positive.
+func top(improvements []Improvement, n int) []Improvement {
+	if n > 0 && len(improvements) > n {
+		return improvements[:n]
+	}
+	return improvements
+}
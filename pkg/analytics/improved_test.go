@@ -0,0 +1,62 @@
+package analytics
+
+import (
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestMostImproved(t *testing.T) {
+	older := &godestats.UserProfile{Languages: map[string]godestats.LanguageInfo{
+		"Go":     {XPs: 10000},
+		"Rust":   {XPs: 500},
+		"Python": {XPs: 2000},
+		"SQL":    {XPs: 300},
+	}}
+	newer := &godestats.UserProfile{Languages: map[string]godestats.LanguageInfo{
+		"Go":     {XPs: 12000}, // +2000, 20%
+		"Rust":   {XPs: 1500},  // +1000, 200%
+		"Python": {XPs: 2000},  // unchanged
+		"SQL":    {XPs: 600},   // +300, 100%
+		"Zig":    {XPs: 1000},  // new
+	}}
+
+	improvements := MostImproved(older, newer, 3)
+
+	byXP := []string{"Go", "Rust", "Zig"}
+	if len(improvements.ByXP) != len(byXP) {
+		t.Fatalf("Expected %v by XP, got %+v", byXP, improvements.ByXP)
+	}
+	for i, name := range byXP {
+		if improvements.ByXP[i].Name != name {
+			t.Errorf("Expected %s at position %d by XP, got %s", name, i+1, improvements.ByXP[i].Name)
+		}
+	}
+
+	byGrowth := []string{"Rust", "SQL", "Go"}
+	if len(improvements.ByGrowth) != len(byGrowth) {
+		t.Fatalf("Expected %v by growth, got %+v", byGrowth, improvements.ByGrowth)
+	}
+	for i, name := range byGrowth {
+		if improvements.ByGrowth[i].Name != name {
+			t.Errorf("Expected %s at position %d by growth, got %s", name, i+1, improvements.ByGrowth[i].Name)
+		}
+	}
+
+	rust := improvements.ByGrowth[0]
+	if rust.Gained != 1000 || rust.Growth != 2 || rust.OldXP != 500 || rust.NewXP != 1500 {
+		t.Errorf("Unexpected Rust improvement: %+v", rust)
+	}
+}
+
+func TestMostImproved_All(t *testing.T) {
+	newer := &godestats.UserProfile{Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 100}, "Rust": {XPs: 100}}}
+
+	improvements := MostImproved(nil, newer, 0)
+	if len(improvements.ByXP) != 2 || improvements.ByXP[0].Name != "Go" {
+		t.Errorf("Expected all languages ordered by name on ties, got %+v", improvements.ByXP)
+	}
+	if len(improvements.ByGrowth) != 0 {
+		t.Errorf("Expected no growth ranking without an old snapshot, got %+v", improvements.ByGrowth)
+	}
+}
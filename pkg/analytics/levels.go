@@ -0,0 +1,54 @@
+// Package analytics derives higher-level statistics from Code::Stats profiles and
+// their history, such as per-language levels, trends and comparisons between users.
+package analytics
+
+import (
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// Progress describes a level and the progress towards the next one for an amount of XP.
+type Progress struct {
+	XP    godestats.XP `json:"xp"`
+	Level int          `json:"level"`
+	// Percentage is the progress within the current level, between 0.0 and 1.0.
+	Percentage float64 `json:"percentage"`
+	// NextLevelXP is the total XP at which the next level is reached.
+	NextLevelXP godestats.XP `json:"next_level_xp"`
+}
+
+// Remaining returns the XP still needed to reach the next level.
+func (p Progress) Remaining() godestats.XP {
+	return p.NextLevelXP.Sub(p.XP)
+}
+
+// ProgressFor computes the progress for the given XP. A nil calculator uses the
+// standard Code::Stats formula.
+func ProgressFor(amount godestats.XP, calc godestats.XpCalculator) Progress {
+	if calc == nil {
+		calc = xp.NewCalculator()
+	}
+	return Progress{
+		XP:          amount,
+		Level:       calc.GetLevel(amount),
+		Percentage:  calc.GetLevelPercentage(amount),
+		NextLevelXP: calc.GetXpForNextLevel(amount),
+	}
+}
+
+// LanguageLevels returns the level and progress of each language of the profile,
+// keyed by language name. A nil calculator uses the standard Code::Stats formula.
+func LanguageLevels(profile *godestats.UserProfile, calc godestats.XpCalculator) map[string]Progress {
+	if profile == nil {
+		return nil
+	}
+	if calc == nil {
+		calc = xp.NewCalculator()
+	}
+
+	levels := make(map[string]Progress, len(profile.Languages))
+	for name, info := range profile.Languages {
+		levels[name] = ProgressFor(info.XPs, calc)
+	}
+	return levels
+}
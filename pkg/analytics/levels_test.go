@@ -0,0 +1,56 @@
+package analytics
+
+import (
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestLanguageLevels(t *testing.T) {
+	profile := &godestats.UserProfile{
+		Languages: map[string]godestats.LanguageInfo{
+			"Go":   {XPs: 12345},
+			"Rust": {XPs: 100},
+			"Text": {XPs: 0},
+		},
+	}
+
+	levels := LanguageLevels(profile, nil)
+	if len(levels) != 3 {
+		t.Fatalf("Expected 3 languages, got %d", len(levels))
+	}
+
+	tests := []struct {
+		language string
+		level    int
+		next     godestats.XP
+	}{
+		{"Go", 2, 14400},
+		{"Rust", 0, 1600},
+		{"Text", 0, 1600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			p := levels[tt.language]
+			if p.Level != tt.level {
+				t.Errorf("Expected level %d, got %d", tt.level, p.Level)
+			}
+			if p.NextLevelXP != tt.next {
+				t.Errorf("Expected next level at %d XP, got %d", tt.next, p.NextLevelXP)
+			}
+			if p.Remaining() != tt.next-profile.Languages[tt.language].XPs {
+				t.Errorf("Expected %d remaining XP, got %d", tt.next-profile.Languages[tt.language].XPs, p.Remaining())
+			}
+			if p.Percentage < 0 || p.Percentage > 1 {
+				t.Errorf("Expected percentage between 0 and 1, got %f", p.Percentage)
+			}
+		})
+	}
+}
+
+func TestLanguageLevels_NilProfile(t *testing.T) {
+	if levels := LanguageLevels(nil, nil); levels != nil {
+		t.Errorf("Expected nil, got %v", levels)
+	}
+}
@@ -0,0 +1,59 @@
+package analytics
+
+import (
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// MachineStat describes a machine's level and its contribution to the profile's XP.
+type MachineStat struct {
+	Name     string       `json:"name"`
+	Progress Progress     `json:"progress"`
+	RecentXP godestats.XP `json:"recent_xp"`
+	// Share is the machine's fraction of the total XP of all machines, between 0.0 and 1.0.
+	Share float64 `json:"share"`
+	// RecentShare is the machine's fraction of the recent XP of all machines, between 0.0 and 1.0.
+	RecentShare float64 `json:"recent_share"`
+	// Idle is true if the machine gained no recent XP while other machines did,
+	// which often means its editor plugin stopped reporting.
+	Idle bool `json:"idle"`
+}
+
+// MachineStats returns statistics for each machine of the profile, sorted by total XP
+// in descending order and then by name. A nil calculator uses the standard Code::Stats formula.
+func MachineStats(profile *godestats.UserProfile, calc godestats.XpCalculator) []MachineStat {
+	if profile == nil {
+		return nil
+	}
+	if calc == nil {
+		calc = xp.NewCalculator()
+	}
+
+	var total, recent godestats.XP
+	for _, info := range profile.Machines {
+		total = total.Add(info.XPs)
+		recent = recent.Add(info.NewXPs)
+	}
+
+	ranked := godestats.MachinesByXP(profile)
+	stats := make([]MachineStat, len(ranked))
+	for i, machine := range ranked {
+		stats[i] = MachineStat{
+			Name:        machine.Name,
+			Progress:    ProgressFor(machine.XPs, calc),
+			RecentXP:    machine.NewXPs,
+			Share:       fraction(machine.XPs, total),
+			RecentShare: fraction(machine.NewXPs, recent),
+			Idle:        machine.NewXPs == 0 && recent > 0,
+		}
+	}
+	return stats
+}
+
+// fraction returns part divided by whole, or 0 if whole is not positive.
+func fraction(part, whole godestats.XP) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestMachineStats(t *testing.T) {
+	profile := &godestats.UserProfile{
+		Machines: map[string]godestats.MachineInfo{
+			"desktop": {XPs: 6000, NewXPs: 300},
+			"laptop":  {XPs: 3000, NewXPs: 100},
+			"old-vm":  {XPs: 1000, NewXPs: 0},
+		},
+	}
+
+	stats := MachineStats(profile, nil)
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 machines, got %d", len(stats))
+	}
+
+	tests := []struct {
+		name        string
+		share       float64
+		recentShare float64
+		idle        bool
+	}{
+		{"desktop", 0.6, 0.75, false},
+		{"laptop", 0.3, 0.25, false},
+		{"old-vm", 0.1, 0, true},
+	}
+
+	for i, tt := range tests {
+		stat := stats[i]
+		if stat.Name != tt.name {
+			t.Errorf("Expected machine %d to be %s, got %s", i, tt.name, stat.Name)
+		}
+		if math.Abs(stat.Share-tt.share) > 1e-9 {
+			t.Errorf("Expected %s share %f, got %f", tt.name, tt.share, stat.Share)
+		}
+		if math.Abs(stat.RecentShare-tt.recentShare) > 1e-9 {
+			t.Errorf("Expected %s recent share %f, got %f", tt.name, tt.recentShare, stat.RecentShare)
+		}
+		if stat.Idle != tt.idle {
+			t.Errorf("Expected %s idle = %v", tt.name, tt.idle)
+		}
+	}
+
+	if stats[0].Progress.Level != 1 {
+		t.Errorf("Expected desktop at level 1, got %d", stats[0].Progress.Level)
+	}
+}
+
+func TestMachineStats_NoRecentActivity(t *testing.T) {
+	profile := &godestats.UserProfile{
+		Machines: map[string]godestats.MachineInfo{"laptop": {XPs: 100}},
+	}
+
+	stats := MachineStats(profile, nil)
+	if len(stats) != 1 || stats[0].Idle || stats[0].RecentShare != 0 {
+		t.Errorf("Expected a single non-idle machine without recent share, got %+v", stats)
+	}
+}
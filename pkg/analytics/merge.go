@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"strings"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// MergeProfiles combines the profiles of several accounts of the same person into one.
+// XP totals and the XP of languages, machines and dates with the same name are summed,
+// and the user names are joined with "+". Sums that would overflow are clamped rather
+// than wrapping around. Nil profiles are ignored; if no profile is given, nil is returned.
+//
+// Languages are matched by exact name; apply godestats.Normalize to the result to
+// also merge aliases such as "golang" and "Go" reported by different plugins.
+// The recent period of the most recently fetched profile is kept, and unknown
+// JSON fields are dropped.
+func MergeProfiles(profiles ...*godestats.UserProfile) *godestats.UserProfile {
+	var (
+		merged *godestats.UserProfile
+		users  []string
+	)
+
+	for _, p := range profiles {
+		if p == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &godestats.UserProfile{}
+		}
+
+		users = append(users, p.User)
+		merged.TotalXP = merged.TotalXP.AddSaturating(p.TotalXP)
+		merged.NewXP = merged.NewXP.AddSaturating(p.NewXP)
+
+		for name, info := range p.Languages {
+			if merged.Languages == nil {
+				merged.Languages = make(map[string]godestats.LanguageInfo)
+			}
+			existing := merged.Languages[name]
+			existing.XPs = existing.XPs.AddSaturating(info.XPs)
+			existing.NewXPs = existing.NewXPs.AddSaturating(info.NewXPs)
+			merged.Languages[name] = existing
+		}
+
+		for name, info := range p.Machines {
+			if merged.Machines == nil {
+				merged.Machines = make(map[string]godestats.MachineInfo)
+			}
+			existing, ok := merged.Machines[name]
+			if !ok {
+				merged.Machines[name] = info
+				continue
+			}
+			existing.XPs = existing.XPs.AddSaturating(info.XPs)
+			existing.NewXPs = existing.NewXPs.AddSaturating(info.NewXPs)
+			if info.LastActive.After(existing.LastActive) {
+				existing.LastActive = info.LastActive
+			}
+			// A machine reporting to several accounts uses a different token for each
+			if existing.TokenID != info.TokenID {
+				existing.TokenID = ""
+			}
+			merged.Machines[name] = existing
+		}
+
+		for date, amount := range p.Dates {
+			if merged.Dates == nil {
+				merged.Dates = make(map[string]godestats.XP)
+			}
+			merged.Dates[date] = merged.Dates[date].AddSaturating(amount)
+		}
+
+		if merged.Recent.IsZero() || p.Recent.End().After(merged.Recent.End()) {
+			merged.Recent = p.Recent
+		}
+	}
+
+	if merged != nil {
+		merged.User = strings.Join(users, "+")
+	}
+	return merged
+}
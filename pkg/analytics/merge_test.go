@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestMergeProfiles(t *testing.T) {
+	earlier := time.Date(2023, 6, 15, 10, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	work := &godestats.UserProfile{
+		User:      "alice-work",
+		TotalXP:   1000,
+		NewXP:     100,
+		Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 800, NewXPs: 100}, "SQL": {XPs: 200}},
+		Machines:  map[string]godestats.MachineInfo{"laptop": {XPs: 1000, NewXPs: 100, LastActive: later, TokenID: "a"}},
+		Dates:     map[string]godestats.XP{"2023-06-14": 400, "2023-06-15": 600},
+		Recent:    godestats.NewRecentPeriod(earlier),
+	}
+	personal := &godestats.UserProfile{
+		User:      "alice",
+		TotalXP:   500,
+		NewXP:     50,
+		Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 300, NewXPs: 50}, "Rust": {XPs: 200}},
+		Machines: map[string]godestats.MachineInfo{
+			"laptop":  {XPs: 200, NewXPs: 50, LastActive: earlier, TokenID: "b"},
+			"desktop": {XPs: 300},
+		},
+		Dates:  map[string]godestats.XP{"2023-06-15": 500},
+		Recent: godestats.NewRecentPeriod(later),
+	}
+
+	merged := MergeProfiles(work, nil, personal)
+
+	if merged.User != "alice-work+alice" {
+		t.Errorf("Expected joined user names, got %s", merged.User)
+	}
+	if merged.TotalXP != 1500 || merged.NewXP != 150 {
+		t.Errorf("Expected totals 1500/150, got %d/%d", merged.TotalXP, merged.NewXP)
+	}
+
+	if got := merged.Languages["Go"]; got.XPs != 1100 || got.NewXPs != 150 {
+		t.Errorf("Expected Go 1100/150, got %+v", got)
+	}
+	if len(merged.Languages) != 3 {
+		t.Errorf("Expected 3 languages, got %v", merged.Languages)
+	}
+
+	laptop := merged.Machines["laptop"]
+	if laptop.XPs != 1200 || laptop.NewXPs != 150 || !laptop.LastActive.Equal(later) || laptop.TokenID != "" {
+		t.Errorf("Unexpected merged laptop: %+v", laptop)
+	}
+	if merged.Machines["desktop"].XPs != 300 {
+		t.Errorf("Expected desktop to be kept, got %+v", merged.Machines["desktop"])
+	}
+
+	if merged.Dates["2023-06-15"] != 1100 || merged.Dates["2023-06-14"] != 400 {
+		t.Errorf("Unexpected merged dates: %v", merged.Dates)
+	}
+	if !merged.Recent.End().Equal(later) {
+		t.Errorf("Expected most recent period ending at %v, got %v", later, merged.Recent.End())
+	}
+
+	// Inputs must not be modified
+	if work.Languages["Go"].XPs != 800 || work.Machines["laptop"].XPs != 1000 {
+		t.Error("Expected input profiles to be unchanged")
+	}
+}
+
+func TestMergeProfiles_Overflow(t *testing.T) {
+	a := &godestats.UserProfile{User: "a", TotalXP: math.MaxInt64 - 10, Dates: map[string]godestats.XP{"2023-06-15": math.MaxInt64}}
+	b := &godestats.UserProfile{User: "b", TotalXP: 100, Dates: map[string]godestats.XP{"2023-06-15": 1}}
+
+	merged := MergeProfiles(a, b)
+	if merged.TotalXP != math.MaxInt64 || merged.Dates["2023-06-15"] != math.MaxInt64 {
+		t.Errorf("Expected sums to be clamped, got %d and %v", merged.TotalXP, merged.Dates)
+	}
+}
+
+func TestMergeProfiles_Empty(t *testing.T) {
+	if merged := MergeProfiles(); merged != nil {
+		t.Errorf("Expected nil, got %+v", merged)
+	}
+	if merged := MergeProfiles(nil); merged != nil {
+		t.Errorf("Expected nil, got %+v", merged)
+	}
+}
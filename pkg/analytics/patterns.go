@@ -0,0 +1,150 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Period is a calendar period together with the XP gained in it.
+type Period struct {
+	// Start is the first day of the period; End is the first day after it.
+	Start time.Time    `json:"start"`
+	End   time.Time    `json:"end"`
+	XP    godestats.XP `json:"xp"`
+}
+
+// Pattern summarizes when a user tends to code, based on their daily XP.
+// All averages include days without activity between the first and last active day.
+type Pattern struct {
+	// ByWeekday holds the average XP per day, indexed by time.Weekday.
+	ByWeekday [7]float64 `json:"by_weekday"`
+
+	// WeekdayAverage and WeekendAverage are the average XP on Monday to Friday
+	// and on Saturday and Sunday.
+	WeekdayAverage float64 `json:"weekday_average"`
+	WeekendAverage float64 `json:"weekend_average"`
+
+	// WeekendShare is the fraction of all XP gained on weekends, between 0.0 and 1.0.
+	WeekendShare float64 `json:"weekend_share"`
+
+	// BestDay is the day with the most XP.
+	BestDay Period `json:"best_day"`
+
+	// BestWeek, WorstWeek, BestMonth and WorstMonth are the most and least productive
+	// weeks (starting on Monday) and calendar months. Only periods entirely within the
+	// recorded range are considered; they are zero if there is no such period.
+	BestWeek   Period `json:"best_week"`
+	WorstWeek  Period `json:"worst_week"`
+	BestMonth  Period `json:"best_month"`
+	WorstMonth Period `json:"worst_month"`
+}
+
+// Patterns analyzes the daily XP of a profile's Dates map, whose keys are interpreted
+// as dates in the given location (UTC if nil). Keys that cannot be parsed are skipped
+// and reported in the returned error, as by UserProfile.DailySeries.
+func Patterns(dates map[string]godestats.XP, tz *time.Location) (*Pattern, error) {
+	series, err := (&godestats.UserProfile{Dates: dates}).DailySeries()
+	series = godestats.FillDateGaps(series)
+
+	pattern := &Pattern{}
+	if len(series) == 0 {
+		return pattern, err
+	}
+
+	var (
+		weekdayXP   [7]godestats.XP
+		weekdayDays [7]int
+		total       godestats.XP
+		weeks       = make(periods)
+		months      = make(periods)
+	)
+
+	for _, day := range series {
+		date := day.Date.Time(tz)
+		wd := day.Date.Weekday()
+		weekdayXP[wd] += day.XP
+		weekdayDays[wd]++
+		total += day.XP
+
+		if day.XP > pattern.BestDay.XP || pattern.BestDay.Start.IsZero() {
+			pattern.BestDay = Period{Start: date, End: date.AddDate(0, 0, 1), XP: day.XP}
+		}
+
+		weekStart := date.AddDate(0, 0, -((int(wd) + 6) % 7))
+		weeks.add(weekStart, weekStart.AddDate(0, 0, 7), day.XP)
+
+		monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		months.add(monthStart, monthStart.AddDate(0, 1, 0), day.XP)
+	}
+
+	var weekdays, weekends godestats.XP
+	var weekdayCount, weekendCount int
+	for wd := range weekdayXP {
+		if weekdayDays[wd] > 0 {
+			pattern.ByWeekday[wd] = float64(weekdayXP[wd]) / float64(weekdayDays[wd])
+		}
+		if time.Weekday(wd) == time.Saturday || time.Weekday(wd) == time.Sunday {
+			weekends += weekdayXP[wd]
+			weekendCount += weekdayDays[wd]
+		} else {
+			weekdays += weekdayXP[wd]
+			weekdayCount += weekdayDays[wd]
+		}
+	}
+
+	if weekdayCount > 0 {
+		pattern.WeekdayAverage = float64(weekdays) / float64(weekdayCount)
+	}
+	if weekendCount > 0 {
+		pattern.WeekendAverage = float64(weekends) / float64(weekendCount)
+	}
+	pattern.WeekendShare = fraction(weekends, total)
+
+	first, last := series[0].Date.Time(tz), series[len(series)-1].Date.Time(tz)
+	pattern.BestWeek, pattern.WorstWeek = weeks.extremes(first, last)
+	pattern.BestMonth, pattern.WorstMonth = months.extremes(first, last)
+
+	return pattern, err
+}
+
+// periods accumulates the XP of calendar periods keyed by their start.
+type periods map[time.Time]*Period
+
+// add adds XP to the period [start, end), creating it if needed.
+func (p periods) add(start, end time.Time, amount godestats.XP) {
+	period, ok := p[start]
+	if !ok {
+		period = &Period{Start: start, End: end}
+		p[start] = period
+	}
+	period.XP += amount
+}
+
+// extremes returns the periods with the most and least XP among those entirely
+// within the days [first, last]. Ties go to the earlier period.
+func (p periods) extremes(first, last time.Time) (best, worst Period) {
+	var complete []Period
+	for _, period := range p {
+		if !period.Start.Before(first) && !period.End.After(last.AddDate(0, 0, 1)) {
+			complete = append(complete, *period)
+		}
+	}
+	if len(complete) == 0 {
+		return Period{}, Period{}
+	}
+
+	sort.Slice(complete, func(i, j int) bool { return complete[i].Start.Before(complete[j].Start) })
+
+	best, worst = complete[0], complete[0]
+	for _, period := range complete[1:] {
+		if period.XP > best.XP {
+			best = period
+		}
+		if period.XP < worst.XP {
+			worst = period
+		}
+	}
+	return best, worst
+}
@@ -0,0 +1,72 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestPatterns(t *testing.T) {
+	// June 2023 starts on a Thursday; May 29 is a Monday
+	dates := map[string]godestats.XP{}
+	for day := time.Date(2023, 5, 29, 0, 0, 0, 0, time.UTC); day.Before(time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)); day = day.AddDate(0, 0, 1) {
+		amount := godestats.XP(100)
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			amount = 0
+		}
+		dates[day.Format(godestats.DateFormat)] = amount
+	}
+	dates["2023-06-14"] = 1000 // Wednesday
+	dates["2023-06-17"] = 300  // Saturday
+	dates["2023-06-26"] = 0    // Monday
+
+	pattern, err := Patterns(dates, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Five Wednesdays, one of them with 1000 XP
+	if math.Abs(pattern.ByWeekday[time.Wednesday]-280) > 1e-9 {
+		t.Errorf("Expected 280 average XP on Wednesdays, got %f", pattern.ByWeekday[time.Wednesday])
+	}
+	if math.Abs(pattern.WeekendAverage-30) > 1e-9 {
+		t.Errorf("Expected 30 average XP on weekends, got %f", pattern.WeekendAverage)
+	}
+	if pattern.WeekendShare <= 0 || pattern.WeekendShare >= 0.1 {
+		t.Errorf("Expected a small weekend share, got %f", pattern.WeekendShare)
+	}
+
+	if pattern.BestDay.Start.Format(godestats.DateFormat) != "2023-06-14" || pattern.BestDay.XP != 1000 {
+		t.Errorf("Unexpected best day: %+v", pattern.BestDay)
+	}
+	if pattern.BestWeek.Start.Format(godestats.DateFormat) != "2023-06-12" || pattern.BestWeek.XP != 1700 {
+		t.Errorf("Unexpected best week: %+v", pattern.BestWeek)
+	}
+	if pattern.WorstWeek.Start.Format(godestats.DateFormat) != "2023-06-26" || pattern.WorstWeek.XP != 400 {
+		t.Errorf("Unexpected worst week: %+v", pattern.WorstWeek)
+	}
+
+	// May and July are incomplete, so June is the only candidate month
+	if pattern.BestMonth.Start.Format(godestats.DateFormat) != "2023-06-01" || pattern.BestMonth != pattern.WorstMonth {
+		t.Errorf("Expected June as best and worst month, got %+v and %+v", pattern.BestMonth, pattern.WorstMonth)
+	}
+}
+
+func TestPatterns_InvalidKeys(t *testing.T) {
+	pattern, err := Patterns(map[string]godestats.XP{"2023-06-14": 100, "yesterday": 50}, nil)
+	if err == nil {
+		t.Error("Expected error for invalid date key")
+	}
+	if pattern.BestDay.XP != 100 {
+		t.Errorf("Expected valid days to be analyzed, got %+v", pattern.BestDay)
+	}
+}
+
+func TestPatterns_Empty(t *testing.T) {
+	pattern, err := Patterns(nil, nil)
+	if err != nil || pattern.BestDay.XP != 0 {
+		t.Errorf("Expected empty pattern, got %+v (err=%v)", pattern, err)
+	}
+}
@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"errors"
+	"strings"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// ErrNotInCohort is returned when the ranked user is not part of the cohort.
+var ErrNotInCohort = errors.New("user is not part of the cohort")
+
+// Standing is a user's position within a cohort for one XP value.
+type Standing struct {
+	XP godestats.XP `json:"xp"`
+	// Rank is the 1-based position by XP; users with equal XP share a rank.
+	Rank int `json:"rank"`
+	// Of is the size of the cohort.
+	Of int `json:"of"`
+	// Percentile is the percentage of the cohort with at most the user's XP, between 0 and 100.
+	Percentile float64 `json:"percentile"`
+}
+
+// Ranking is a user's standing within a cohort by total XP and per language.
+type Ranking struct {
+	User      string              `json:"user"`
+	Total     Standing            `json:"total"`
+	Languages map[string]Standing `json:"languages"`
+}
+
+// Rank computes the standing of the target user within the cohort, which must
+// include the target's profile. Users are matched by name, ignoring case. For each
+// of the target's languages, cohort members without that language count as 0 XP.
+// Nil profiles in the cohort are ignored.
+func Rank(target string, cohort []*godestats.UserProfile) (*Ranking, error) {
+	var (
+		self     *godestats.UserProfile
+		profiles = make([]*godestats.UserProfile, 0, len(cohort))
+	)
+	for _, p := range cohort {
+		if p == nil {
+			continue
+		}
+		if self == nil && strings.EqualFold(p.User, target) {
+			self = p
+		}
+		profiles = append(profiles, p)
+	}
+	if self == nil {
+		return nil, ErrNotInCohort
+	}
+
+	totals := make([]godestats.XP, len(profiles))
+	for i, p := range profiles {
+		totals[i] = p.TotalXP
+	}
+
+	ranking := &Ranking{
+		User:      self.User,
+		Total:     standing(self.TotalXP, totals),
+		Languages: make(map[string]Standing, len(self.Languages)),
+	}
+
+	values := make([]godestats.XP, len(profiles))
+	for name, info := range self.Languages {
+		for i, p := range profiles {
+			values[i] = p.Languages[name].XPs
+		}
+		ranking.Languages[name] = standing(info.XPs, values)
+	}
+
+	return ranking, nil
+}
+
+// standing returns the position of value among all values, which include it.
+func standing(value godestats.XP, values []godestats.XP) Standing {
+	s := Standing{XP: value, Rank: 1, Of: len(values)}
+
+	var atMost int
+	for _, v := range values {
+		if v > value {
+			s.Rank++
+		} else {
+			atMost++
+		}
+	}
+
+	if len(values) > 0 {
+		s.Percentile = float64(atMost) / float64(len(values)) * 100
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package analytics
+
+import (
+	"errors"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestRank(t *testing.T) {
+	cohort := []*godestats.UserProfile{
+		{User: "alice", TotalXP: 5000, Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 3000}, "Rust": {XPs: 2000}}},
+		{User: "bob", TotalXP: 8000, Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 8000}}},
+		{User: "carol", TotalXP: 5000, Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 1000}, "Rust": {XPs: 4000}}},
+		nil,
+		{User: "dave", TotalXP: 1000},
+	}
+
+	ranking, err := Rank("Alice", cohort)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		got        Standing
+		rank       int
+		percentile float64
+	}{
+		{"total", ranking.Total, 2, 75},
+		{"Go", ranking.Languages["Go"], 2, 75},
+		{"Rust", ranking.Languages["Rust"], 2, 75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Rank != tt.rank || tt.got.Of != 4 || tt.got.Percentile != tt.percentile {
+				t.Errorf("Expected rank %d of 4 at percentile %.0f, got %+v", tt.rank, tt.percentile, tt.got)
+			}
+		})
+	}
+
+	if ranking.User != "alice" || len(ranking.Languages) != 2 {
+		t.Errorf("Unexpected ranking: %+v", ranking)
+	}
+
+	// carol ties with alice on total XP
+	carol, _ := Rank("carol", cohort)
+	if carol.Total.Rank != 2 {
+		t.Errorf("Expected tied rank 2, got %d", carol.Total.Rank)
+	}
+	if carol.Languages["Rust"].Rank != 1 || carol.Languages["Rust"].Percentile != 100 {
+		t.Errorf("Expected carol to lead Rust, got %+v", carol.Languages["Rust"])
+	}
+}
+
+func TestRank_NotInCohort(t *testing.T) {
+	_, err := Rank("eve", []*godestats.UserProfile{{User: "alice"}})
+	if !errors.Is(err, ErrNotInCohort) {
+		t.Errorf("Expected ErrNotInCohort, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// Rate describes how fast XP was gained within a time window.
+type Rate struct {
+	// Start and End delimit the window the rate was measured in.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Gained is the total XP gained within the window.
+	Gained godestats.XP `json:"gained"`
+
+	// ActiveHours and ActiveDays count the distinct clock hours and calendar days
+	// (UTC) in which XP was gained.
+	ActiveHours int `json:"active_hours"`
+	ActiveDays  int `json:"active_days"`
+}
+
+// PerActiveHour returns the average XP gained per active hour, or 0 without activity.
+func (r Rate) PerActiveHour() float64 {
+	if r.ActiveHours == 0 {
+		return 0
+	}
+	return float64(r.Gained) / float64(r.ActiveHours)
+}
+
+// PerActiveDay returns the average XP gained per active day, or 0 without activity.
+func (r Rate) PerActiveDay() float64 {
+	if r.ActiveDays == 0 {
+		return 0
+	}
+	return float64(r.Gained) / float64(r.ActiveDays)
+}
+
+// PerDay returns the average XP gained per calendar day of the window, including inactive days.
+func (r Rate) PerDay() float64 {
+	days := r.End.Sub(r.Start).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return float64(r.Gained) / days
+}
+
+// gain is an amount of XP gained at a point in time.
+type gain struct {
+	at time.Time
+	xp godestats.XP
+}
+
+// Velocity estimates the XP rate from a user's snapshots, ordered by the time
+// they were taken, within the window ending at the last snapshot. The XP gained
+// between two snapshots is attributed to the later one, so the active hours are
+// only as precise as the interval at which snapshots were recorded.
+func Velocity(snapshots []history.Snapshot, window time.Duration) Rate {
+	var (
+		gains    []gain
+		previous *godestats.UserProfile
+	)
+	for _, snapshot := range snapshots {
+		if snapshot.Profile == nil {
+			continue
+		}
+		if previous != nil && snapshot.Profile.TotalXP > previous.TotalXP {
+			gains = append(gains, gain{at: snapshot.TakenAt, xp: snapshot.Profile.TotalXP.Sub(previous.TotalXP)})
+		}
+		previous = snapshot.Profile
+	}
+
+	var end time.Time
+	if n := len(snapshots); n > 0 {
+		end = snapshots[n-1].TakenAt
+	}
+	return rate(gains, end, window)
+}
+
+// PulseVelocity estimates the XP rate from sent pulses within the window ending at
+// the most recent pulse. Pulses may be given in any order.
+func PulseVelocity(pulses []godestats.Pulse, window time.Duration) Rate {
+	var (
+		gains []gain
+		end   time.Time
+	)
+	for _, pulse := range pulses {
+		var total godestats.XP
+		for _, x := range pulse.XPs {
+			total = total.Add(x.XP)
+		}
+		if total > 0 {
+			gains = append(gains, gain{at: pulse.CodedAt, xp: total})
+		}
+		if pulse.CodedAt.After(end) {
+			end = pulse.CodedAt
+		}
+	}
+	return rate(gains, end, window)
+}
+
+// rate sums up the gains within (end-window, end].
+func rate(gains []gain, end time.Time, window time.Duration) Rate {
+	r := Rate{Start: end.Add(-window), End: end}
+
+	sort.Slice(gains, func(i, j int) bool { return gains[i].at.Before(gains[j].at) })
+
+	hours := make(map[time.Time]bool)
+	days := make(map[time.Time]bool)
+	for _, g := range gains {
+		if !g.at.After(r.Start) || g.at.After(end) {
+			continue
+		}
+		r.Gained = r.Gained.Add(g.xp)
+
+		at := g.at.UTC()
+		hours[at.Truncate(time.Hour)] = true
+		days[time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)] = true
+	}
+
+	r.ActiveHours = len(hours)
+	r.ActiveDays = len(days)
+	return r
+}
@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func snapshotAt(at time.Time, total godestats.XP) history.Snapshot {
+	return history.Snapshot{User: "alice", TakenAt: at, Profile: &godestats.UserProfile{TotalXP: total}}
+}
+
+func TestVelocity(t *testing.T) {
+	base := time.Date(2023, 6, 10, 9, 0, 0, 0, time.UTC)
+	snapshots := []history.Snapshot{
+		snapshotAt(base, 1000),
+		snapshotAt(base.Add(30*time.Minute), 1200), // +200, June 10 09:xx
+		snapshotAt(base.Add(45*time.Minute), 1300), // +100, June 10 09:xx
+		snapshotAt(base.Add(2*time.Hour), 1300),    // no change
+		snapshotAt(base.Add(26*time.Hour), 1600),   // +300, June 11 11:xx
+		snapshotAt(base.Add(6*24*time.Hour), 1700), // +100, June 16 09:xx
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		gained godestats.XP
+		hours  int
+		days   int
+	}{
+		{"week", 7 * 24 * time.Hour, 700, 3, 3},
+		{"last day", 24 * time.Hour, 100, 1, 1},
+		{"partial", 6*24*time.Hour - time.Hour, 400, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Velocity(snapshots, tt.window)
+			if r.Gained != tt.gained || r.ActiveHours != tt.hours || r.ActiveDays != tt.days {
+				t.Errorf("Expected %d XP in %d hours on %d days, got %d XP in %d hours on %d days",
+					tt.gained, tt.hours, tt.days, r.Gained, r.ActiveHours, r.ActiveDays)
+			}
+		})
+	}
+
+	r := Velocity(snapshots, 7*24*time.Hour)
+	if math.Abs(r.PerActiveHour()-700.0/3) > 1e-9 {
+		t.Errorf("Expected %f XP per active hour, got %f", 700.0/3, r.PerActiveHour())
+	}
+	if r.PerDay() != 100 {
+		t.Errorf("Expected 100 XP per day, got %f", r.PerDay())
+	}
+}
+
+func TestPulseVelocity(t *testing.T) {
+	base := time.Date(2023, 6, 10, 9, 0, 0, 0, time.UTC)
+	pulses := []godestats.Pulse{
+		{CodedAt: base.Add(time.Hour), XPs: []godestats.LanguageXP{{Language: "Go", XP: 20}, {Language: "SQL", XP: 5}}},
+		{CodedAt: base, XPs: []godestats.LanguageXP{{Language: "Go", XP: 10}}},
+		{CodedAt: base.Add(-48 * time.Hour), XPs: []godestats.LanguageXP{{Language: "Go", XP: 100}}},
+	}
+
+	r := PulseVelocity(pulses, 24*time.Hour)
+	if r.Gained != 35 || r.ActiveHours != 2 || r.ActiveDays != 1 {
+		t.Errorf("Expected 35 XP in 2 hours on 1 day, got %+v", r)
+	}
+	if r.PerActiveDay() != 35 {
+		t.Errorf("Expected 35 XP per active day, got %f", r.PerActiveDay())
+	}
+}
+
+func TestVelocity_Empty(t *testing.T) {
+	r := Velocity(nil, time.Hour)
+	if r.Gained != 0 || r.PerActiveHour() != 0 || r.PerActiveDay() != 0 {
+		t.Errorf("Expected empty rate, got %+v", r)
+	}
+}
@@ -0,0 +1,223 @@
+// Package audit keeps an append-only log of submitted pulses as JSON lines, with
+// the outcome and response status of every attempt, so that XP lost during
+// incidents can be reconstructed and submitted again.
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Outcome is the result of a pulse submission.
+type Outcome string
+
+// Outcomes of pulse submissions
+const (
+	// OutcomeSent is recorded for pulses accepted by the API.
+	OutcomeSent Outcome = "sent"
+
+	// OutcomeRejected is recorded for pulses the API responded to with an error.
+	OutcomeRejected Outcome = "rejected"
+
+	// OutcomeFailed is recorded for pulses that got no response, e.g. due to
+	// network errors, or were not sent at all.
+	OutcomeFailed Outcome = "failed"
+)
+
+// Entry is a single pulse submission in the log.
+type Entry struct {
+	AttemptedAt time.Time       `json:"attempted_at"`
+	CompletedAt time.Time       `json:"completed_at"`
+	Pulse       godestats.Pulse `json:"pulse"`
+	Outcome     Outcome         `json:"outcome"`
+	// Status is the HTTP status of the response, or 0 if there was none.
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// RequestID is the request ID of the submission, to correlate it with the
+	// logs of the server.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// NewEntry creates the entry of a submission from the error returned by
+// SendPulse. The status is derived from the error, since clients don't expose
+// the response.
+func NewEntry(pulse godestats.Pulse, attemptedAt, completedAt time.Time, err error) Entry {
+	entry := Entry{AttemptedAt: attemptedAt, CompletedAt: completedAt, Pulse: pulse, Outcome: OutcomeSent, Status: http.StatusCreated}
+	if err == nil {
+		return entry
+	}
+
+	entry.Outcome, entry.Status, entry.Error = OutcomeRejected, 0, err.Error()
+	var apiErr *godestats.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		entry.Status = apiErr.StatusCode
+	case errors.Is(err, godestats.ErrUnauthorized):
+		entry.Status = http.StatusUnauthorized
+	case errors.Is(err, godestats.ErrRateLimited):
+		entry.Status = http.StatusTooManyRequests
+	default:
+		entry.Outcome = OutcomeFailed
+	}
+	return entry
+}
+
+// Log appends entries to a writer, one JSON object per line. It is safe for
+// concurrent use.
+type Log struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// New creates a log writing to w.
+func New(w io.Writer) *Log {
+	return &Log{w: w}
+}
+
+// Open opens the log file at path for appending, creating it readable only by
+// the user if it doesn't exist.
+func Open(path string) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return New(f), nil
+}
+
+// Record appends the entry to the log. Each entry is written with a single
+// write, so that entries of several processes appending to the same file don't
+// interleave.
+func (l *Log) Record(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(data); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (l *Log) Close() error {
+	if closer, ok := l.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Read decodes the entries of a log. A truncated last line, as left by a crash
+// while writing, is ignored.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(data) > 0 && data[len(data)-1] == '\n' {
+			var entry Entry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return nil, fmt.Errorf("invalid audit entry in line %d: %w", line, err)
+			}
+			entries = append(entries, entry)
+		}
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+	}
+}
+
+// ReadFile decodes the entries of the log file at path.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Unsent returns the pulses of the entries that were never sent successfully, in
+// the order of their first attempt. Pulses are identified by their content, so
+// retries of a pulse count as one.
+func Unsent(entries []Entry) []godestats.Pulse {
+	var (
+		order []string
+		sent  = make(map[string]bool)
+		byKey = make(map[string]godestats.Pulse)
+	)
+	for _, entry := range entries {
+		data, _ := json.Marshal(entry.Pulse)
+		key := string(data)
+		if _, seen := byKey[key]; !seen {
+			byKey[key] = entry.Pulse
+			order = append(order, key)
+		}
+		if entry.Outcome == OutcomeSent {
+			sent[key] = true
+		}
+	}
+
+	var pulses []godestats.Pulse
+	for _, key := range order {
+		if !sent[key] {
+			pulses = append(pulses, byKey[key])
+		}
+	}
+	return pulses
+}
+
+// Client is a CodeStatsClient recording every pulse it sends in a log.
+type Client struct {
+	inner godestats.CodeStatsClient
+	log   *Log
+	now   func() time.Time
+}
+
+// NewClient wraps a client so that all pulses sent through it are recorded.
+func NewClient(inner godestats.CodeStatsClient, log *Log) *Client {
+	return &Client{inner: inner, log: log, now: time.Now}
+}
+
+// GetUserProfile fetches the profile through the wrapped client.
+func (c *Client) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return c.inner.GetUserProfile(ctx, username, opts...)
+}
+
+// SendPulse submits the pulse through the wrapped client and records the attempt
+// with its request ID, which is generated unless the call has one (see
+// godestats.WithRequestID). Failures to record are ignored, since returning them
+// would make callers resend pulses that were accepted.
+func (c *Client) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	id := godestats.ResolveCallOptions(ctx, opts...).RequestID
+	if id == "" {
+		id = uuid.NewString()
+		opts = append(slices.Clip(opts), godestats.WithRequestID(id))
+	}
+
+	attemptedAt := c.now()
+	err := c.inner.SendPulse(ctx, pulse, opts...)
+	entry := NewEntry(pulse, attemptedAt, c.now(), err)
+	entry.RequestID = id
+	c.log.Record(entry)
+	return err
+}
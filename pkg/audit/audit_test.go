@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// fakeClient fails pulses with the queued errors.
+type fakeClient struct {
+	errs []error
+	ids  []string
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return &godestats.UserProfile{User: username}, nil
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	f.ids = append(f.ids, godestats.ResolveCallOptions(ctx, opts...).RequestID)
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func pulse(xp godestats.XP) godestats.Pulse {
+	codedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	return godestats.Pulse{CodedAt: codedAt, XPs: []godestats.LanguageXP{{Language: "Go", XP: xp}}}
+}
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		err     error
+		outcome Outcome
+		status  int
+	}{
+		{nil, OutcomeSent, 201},
+		{godestats.NewAPIError(500, "boom", ""), OutcomeRejected, 500},
+		{fmt.Errorf("sending: %w", godestats.ErrUnauthorized), OutcomeRejected, 401},
+		{&godestats.RateLimitError{RetryAfter: time.Minute}, OutcomeRejected, 429},
+		{godestats.NewNetworkError("POST request", "", errors.New("timeout")), OutcomeFailed, 0},
+		{godestats.ErrPulseTimestampTooOld, OutcomeFailed, 0},
+	}
+
+	for _, tt := range tests {
+		entry := NewEntry(pulse(1), time.Now(), time.Now(), tt.err)
+		if entry.Outcome != tt.outcome || entry.Status != tt.status {
+			t.Errorf("%v: expected %s %d, got %s %d", tt.err, tt.outcome, tt.status, entry.Outcome, entry.Status)
+		}
+		if (tt.err == nil) != (entry.Error == "") {
+			t.Errorf("%v: expected the error message to be recorded, got %q", tt.err, entry.Error)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pulses.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	inner := &fakeClient{errs: []error{
+		godestats.NewNetworkError("POST request", "", errors.New("timeout")),
+		nil,
+		godestats.NewAPIError(503, "maintenance", ""),
+		nil,
+	}}
+	c := NewClient(inner, log)
+	now := time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)
+	c.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	// The first pulse is retried successfully, the second one is lost
+	ctx := context.Background()
+	for _, p := range []godestats.Pulse{pulse(1), pulse(1), pulse(2)} {
+		c.SendPulse(ctx, p)
+	}
+	log.Close()
+
+	entries, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Outcome != OutcomeFailed || entries[1].Outcome != OutcomeSent || entries[2].Status != 503 {
+		t.Errorf("Expected failed, sent and 503, got %+v", entries)
+	}
+	if !entries[0].CompletedAt.After(entries[0].AttemptedAt) {
+		t.Errorf("Expected timestamps of the attempt, got %v and %v", entries[0].AttemptedAt, entries[0].CompletedAt)
+	}
+	for i, entry := range entries {
+		if entry.RequestID == "" || entry.RequestID != inner.ids[i] {
+			t.Errorf("Expected entry %d to record the request ID %q passed to the client, got %q", i, inner.ids[i], entry.RequestID)
+		}
+	}
+	if entries[0].RequestID == entries[1].RequestID {
+		t.Errorf("Expected a request ID per submission, got %q twice", entries[0].RequestID)
+	}
+
+	unsent := Unsent(entries)
+	if len(unsent) != 1 || unsent[0].XPs[0].XP != 2 || !unsent[0].CodedAt.Equal(pulse(2).CodedAt) {
+		t.Errorf("Expected the second pulse to be unsent, got %+v", unsent)
+	}
+
+	// The log is appended to when opened again
+	log, err = Open(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	NewClient(inner, log).SendPulse(ctx, pulse(2), godestats.WithRequestID("resend-2"))
+	log.Close()
+	entries, _ = ReadFile(path)
+	if len(entries) != 4 || len(Unsent(entries)) != 0 {
+		t.Fatalf("Expected 4 entries and no unsent pulses, got %d entries", len(entries))
+	}
+	if entries[3].RequestID != "resend-2" {
+		t.Errorf("Expected the caller's request ID, got %q", entries[3].RequestID)
+	}
+}
+
+func TestRead(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf)
+	log.Record(NewEntry(pulse(1), time.Now(), time.Now(), nil))
+	valid := buf.String()
+
+	// A crash can leave a truncated last line
+	entries, err := Read(strings.NewReader(valid + `{"attempted_at":"2026-`))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected the truncated line to be ignored, got %d entries (%v)", len(entries), err)
+	}
+
+	if _, err := Read(strings.NewReader("garbage\n" + valid)); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected an error for line 1, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Package badge renders Code::Stats profile data as shields-style SVG badges.
+package badge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+// ErrUnknownKind is returned when a badge is requested for an unsupported kind.
+var ErrUnknownKind = errors.New("unknown badge kind")
+
+// Kind identifies which profile value a badge displays.
+type Kind string
+
+// Supported badge kinds
+const (
+	KindLevel       Kind = "level"
+	KindXP          Kind = "xp"
+	KindRecentXP    Kind = "recent-xp"
+	KindTopLanguage Kind = "top-language"
+	KindLanguages   Kind = "languages"
+)
+
+// Kinds lists all supported badge kinds.
+var Kinds = []Kind{KindLevel, KindXP, KindRecentXP, KindTopLanguage, KindLanguages}
+
+// Fields returns the profile sections needed to render a badge of this kind.
+func (k Kind) Fields() godestats.ProfileFields {
+	switch k {
+	case KindTopLanguage, KindLanguages:
+		return godestats.FieldLanguages
+	default:
+		return 0
+	}
+}
+
+// Badge colors
+const (
+	LabelColor = "#555"
+	ValueColor = "#4c9ee9"
+	ErrorColor = "#e05d44"
+)
+
+// Badge holds the text and colors of a single badge.
+type Badge struct {
+	Label string
+	Value string
+	Color string
+}
+
+// ForProfile builds the badge of the given kind for a profile. Top language
+// badges take the color of the language from the registry, if it is known.
+func ForProfile(profile *godestats.UserProfile, kind Kind, calc godestats.XpCalculator) (Badge, error) {
+	switch kind {
+	case KindLevel:
+		level := calc.GetLevel(profile.TotalXP)
+		return Badge{Label: "level", Value: strconv.Itoa(level), Color: ValueColor}, nil
+	case KindXP:
+		return Badge{Label: "XP", Value: profile.TotalXP.Short(), Color: ValueColor}, nil
+	case KindRecentXP:
+		return Badge{Label: "recent XP", Value: "+" + profile.NewXP.Short(), Color: ValueColor}, nil
+	case KindTopLanguage:
+		value, color := "none", ValueColor
+		if ranked := godestats.LanguagesByXP(profile); len(ranked) > 0 {
+			value = ranked[0].Name
+			if c := languages.Color(value); c != "" {
+				color = c
+			}
+		}
+		return Badge{Label: "top language", Value: value, Color: color}, nil
+	case KindLanguages:
+		return Badge{Label: "languages", Value: strconv.Itoa(profile.TotalLanguages()), Color: ValueColor}, nil
+	default:
+		return Badge{}, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+}
+
+// SVG renders the badge as a flat, shields-style SVG image.
+func (b Badge) SVG() []byte {
+	color := b.Color
+	if color == "" {
+		color = ValueColor
+	}
+
+	labelWidth := textWidth(b.Label)
+	valueWidth := textWidth(b.Value)
+	width := labelWidth + valueWidth
+	label := html.EscapeString(b.Label)
+	value := html.EscapeString(b.Value)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, value)
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, label, value)
+	fmt.Fprintf(&buf, `<rect width="%d" height="20" fill="%s"/>`, labelWidth, LabelColor)
+	fmt.Fprintf(&buf, `<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, valueWidth, html.EscapeString(color))
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	fmt.Fprintf(&buf, `<text x="%d" y="14">%s</text>`, labelWidth/2, label)
+	fmt.Fprintf(&buf, `<text x="%d" y="14">%s</text>`, labelWidth+valueWidth/2, value)
+	buf.WriteString(`</g></svg>`)
+
+	return buf.Bytes()
+}
+
+// textWidth estimates the rendered width of a text segment including padding.
+// Verdana at 11px averages roughly 7 pixels per character.
+func textWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
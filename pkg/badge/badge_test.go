@@ -0,0 +1,64 @@
+package badge
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+func TestForProfile(t *testing.T) {
+	profile := &godestats.UserProfile{
+		User:    "alice",
+		TotalXP: 12345,
+		NewXP:   150,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go":   {XPs: 10000},
+			"Rust": {XPs: 2345},
+		},
+	}
+	calc := xp.NewCalculator()
+
+	tests := []struct {
+		kind     Kind
+		expected string
+	}{
+		{KindLevel, "2"},
+		{KindXP, "12.3k"},
+		{KindRecentXP, "+150"},
+		{KindTopLanguage, "Go"},
+		{KindLanguages, "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			b, err := ForProfile(profile, tt.kind, calc)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if b.Value != tt.expected {
+				t.Errorf("Expected value '%s', got '%s'", tt.expected, b.Value)
+			}
+		})
+	}
+
+	if _, err := ForProfile(profile, "bogus", calc); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("Expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func TestBadge_SVG(t *testing.T) {
+	svg := string(Badge{Label: "top language", Value: "C<++>"}.SVG())
+
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") {
+		t.Errorf("Expected an SVG document, got '%s'", svg)
+	}
+	if !strings.Contains(svg, "C&lt;++&gt;") {
+		t.Error("Expected value to be escaped")
+	}
+	if !strings.Contains(svg, ValueColor) {
+		t.Error("Expected default value color")
+	}
+}
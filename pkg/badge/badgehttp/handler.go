@@ -0,0 +1,142 @@
+// Package badgehttp serves Code::Stats badges over HTTP.
+package badgehttp
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/badge"
+	"github.com/Yeti47/gode-stats/pkg/cache"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// DefaultTTL is how long a rendered badge is cached and may be cached by clients.
+const DefaultTTL = 5 * time.Minute
+
+// ContentType is the content type of served badges.
+const ContentType = "image/svg+xml; charset=utf-8"
+
+// Option configures the badge handler.
+type Option func(*handler)
+
+// WithTTL sets how long rendered badges are cached.
+func WithTTL(ttl time.Duration) Option {
+	return func(h *handler) {
+		h.ttl = ttl
+	}
+}
+
+// WithCalculator sets the calculator used for level badges.
+func WithCalculator(calc godestats.XpCalculator) Option {
+	return func(h *handler) {
+		h.calc = calc
+	}
+}
+
+// handler serves badges rendered from profiles fetched through the client.
+type handler struct {
+	client godestats.ProfileClient
+	cache  cache.Cache
+	ttl    time.Duration
+	calc   godestats.XpCalculator
+	mux    *http.ServeMux
+}
+
+// Handler returns an http.Handler that serves SVG badges at /badge/{user}/{type},
+// where type is one of the badge kinds. Rendered badges are stored in the cache;
+// a nil cache disables caching. Errors are rendered as badges as well,
+// so embedded images never appear broken.
+func Handler(client godestats.ProfileClient, c cache.Cache, opts ...Option) http.Handler {
+	h := &handler{
+		client: client,
+		cache:  c,
+		ttl:    DefaultTTL,
+		calc:   xp.NewCalculator(),
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("GET /badge/{user}/{type}", h.serveBadge)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// serveBadge renders or looks up the requested badge.
+func (h *handler) serveBadge(w http.ResponseWriter, r *http.Request) {
+	user := r.PathValue("user")
+	kind := badge.Kind(r.PathValue("type"))
+	key := "badge:" + user + ":" + string(kind)
+
+	if h.cache != nil {
+		if svg, ok, err := h.cache.Get(r.Context(), key); err == nil && ok {
+			h.write(w, http.StatusOK, svg)
+			return
+		}
+	}
+
+	profile, err := godestats.GetUserProfileFields(r.Context(), h.client, user, kind.Fields())
+	if err != nil {
+		status := statusFor(err)
+		message := "unavailable"
+		if status == http.StatusNotFound {
+			message = "user not found"
+		}
+		h.writeError(w, status, message)
+		return
+	}
+	// Profiles the client fetched for the token owner contain private data
+	profile = profile.Public()
+
+	b, err := badge.ForProfile(profile, kind, h.calc)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "unknown badge")
+		return
+	}
+
+	svg := b.SVG()
+	if h.cache != nil {
+		// A failing cache only costs performance, so the badge is served regardless.
+		_ = h.cache.Set(r.Context(), key, svg, h.ttl)
+	}
+
+	h.write(w, http.StatusOK, svg)
+}
+
+// write sends an SVG response with caching headers.
+func (h *handler) write(w http.ResponseWriter, status int, svg []byte) {
+	w.Header().Set("Content-Type", ContentType)
+	if status == http.StatusOK {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(h.ttl.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	w.WriteHeader(status)
+	w.Write(svg)
+}
+
+// writeError sends an error badge.
+func (h *handler) writeError(w http.ResponseWriter, status int, message string) {
+	b := badge.Badge{Label: "Code::Stats", Value: message, Color: badge.ErrorColor}
+	h.write(w, status, b.SVG())
+}
+
+// statusFor maps a client error to the HTTP status of the error badge.
+func statusFor(err error) int {
+	switch {
+	case godestats.IsUserNotFound(err):
+		return http.StatusNotFound
+	case godestats.IsRateLimited(err):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
@@ -0,0 +1,89 @@
+package badgehttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/cache"
+)
+
+// fakeClient serves fixed profiles and counts profile requests.
+type fakeClient struct {
+	profiles map[string]*godestats.UserProfile
+	calls    int
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	f.calls++
+	if profile, ok := f.profiles[username]; ok {
+		return profile, nil
+	}
+	return nil, godestats.ErrUserNotFound
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	client := &fakeClient{profiles: map[string]*godestats.UserProfile{
+		"alice": {User: "alice", TotalXP: 12345},
+	}}
+	handler := Handler(client, cache.NewMemory())
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains string
+	}{
+		{"xp badge", "/badge/alice/xp", http.StatusOK, "12.3k"},
+		{"unknown user", "/badge/bob/xp", http.StatusNotFound, "user not found"},
+		{"unknown kind", "/badge/alice/bogus", http.StatusNotFound, "unknown badge"},
+		{"unknown path", "/other", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("Expected body to contain '%s', got '%s'", tt.contains, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_Caching(t *testing.T) {
+	client := &fakeClient{profiles: map[string]*godestats.UserProfile{
+		"alice": {User: "alice", TotalXP: 100},
+	}}
+	handler := Handler(client, cache.NewMemory())
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/badge/alice/level", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+			t.Errorf("Expected content type '%s', got '%s'", ContentType, ct)
+		}
+		if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=300" {
+			t.Errorf("Expected cache control 'public, max-age=300', got '%s'", cc)
+		}
+	}
+
+	if client.calls != 1 {
+		t.Errorf("Expected 1 profile request, got %d", client.calls)
+	}
+}
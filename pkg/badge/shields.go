@@ -0,0 +1,47 @@
+package badge
+
+import (
+	"encoding/json"
+	"strings"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// ShieldsEndpoint is the response format of shields.io endpoint badges, see
+// https://shields.io/badges/endpoint-badge.
+type ShieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color,omitempty"`
+	LabelColor    string `json:"labelColor,omitempty"`
+	IsError       bool   `json:"isError,omitempty"`
+}
+
+// ShieldsEndpoint converts the badge into the shields.io endpoint format.
+func (b Badge) ShieldsEndpoint() ShieldsEndpoint {
+	color := b.Color
+	if color == "" {
+		color = ValueColor
+	}
+	return ShieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         b.Label,
+		Message:       b.Value,
+		Color:         strings.TrimPrefix(color, "#"),
+		LabelColor:    strings.TrimPrefix(LabelColor, "#"),
+		IsError:       color == ErrorColor,
+	}
+}
+
+// ShieldsEndpointJSON renders the badge of the given kind for a profile as JSON
+// for a shields.io endpoint badge, so shields.io renders it instead of SVG. Levels
+// use the standard Code::Stats formula.
+func ShieldsEndpointJSON(profile *godestats.UserProfile, kind Kind) ([]byte, error) {
+	b, err := ForProfile(profile, kind, xp.NewCalculator())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(b.ShieldsEndpoint())
+}
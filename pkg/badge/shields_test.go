@@ -0,0 +1,47 @@
+package badge
+
+import (
+	"errors"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestShieldsEndpointJSON(t *testing.T) {
+	profile := &godestats.UserProfile{
+		User:    "alice",
+		TotalXP: 7000,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go": {XPs: 5000},
+		},
+	}
+
+	tests := []struct {
+		kind     Kind
+		expected string
+	}{
+		{KindLevel, `{"schemaVersion":1,"label":"level","message":"2","color":"4c9ee9","labelColor":"555"}`},
+		{KindTopLanguage, `{"schemaVersion":1,"label":"top language","message":"Go","color":"00add8","labelColor":"555"}`},
+	}
+
+	for _, tt := range tests {
+		data, err := ShieldsEndpointJSON(profile, tt.kind)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(data) != tt.expected {
+			t.Errorf("Expected %s for %s, got %s", tt.expected, tt.kind, data)
+		}
+	}
+
+	if _, err := ShieldsEndpointJSON(profile, "streak"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("Expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func TestBadge_ShieldsEndpoint(t *testing.T) {
+	e := Badge{Label: "Code::Stats", Value: "user not found", Color: ErrorColor}.ShieldsEndpoint()
+	if !e.IsError || e.Color != "e05d44" || e.Message != "user not found" {
+		t.Errorf("Expected an error badge, got %+v", e)
+	}
+}
@@ -0,0 +1,146 @@
+// Package bulk runs operations for many users concurrently with a bounded
+// number of workers, rate limiting and progress reporting.
+package bulk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// DefaultConcurrency is the number of workers used when Options.Concurrency is not set.
+const DefaultConcurrency = 4
+
+// Result is the outcome of an operation for a single user.
+type Result[T any] struct {
+	Username string
+	Value    T
+	Err      error
+}
+
+// Progress reports a finished operation.
+type Progress struct {
+	Username string
+	Err      error
+	Done     int
+	Total    int
+}
+
+// Options configures a bulk run. Zero values select the defaults.
+type Options struct {
+	// Concurrency is the maximum number of operations running at once.
+	Concurrency int
+	// Interval is the minimum time between the start of two operations,
+	// e.g. time.Second / 5 for at most five API requests per second. Zero disables rate limiting.
+	Interval time.Duration
+	// OnProgress is called after every finished operation. Calls are serialized.
+	OnProgress func(Progress)
+}
+
+// Run executes op for every username and returns the results in input order.
+// Operations that have not started when ctx is cancelled fail with the context's error.
+func Run[T any](ctx context.Context, usernames []string, op func(ctx context.Context, username string) (T, error), opts Options) []Result[T] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]Result[T], len(usernames))
+	jobs := make(chan int)
+	limit := newLimiter(opts.Interval)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		finished int
+	)
+
+	for w := 0; w < min(concurrency, len(usernames)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				username := usernames[i]
+				result := Result[T]{Username: username}
+
+				if err := limit.wait(ctx); err != nil {
+					result.Err = err
+				} else {
+					result.Value, result.Err = op(ctx, username)
+				}
+				results[i] = result
+
+				mu.Lock()
+				finished++
+				if opts.OnProgress != nil {
+					opts.OnProgress(Progress{Username: username, Err: result.Err, Done: finished, Total: len(usernames)})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range usernames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// FetchProfiles fetches the profiles of all users.
+func FetchProfiles(ctx context.Context, client godestats.ProfileClient, usernames []string, opts Options) []Result[*godestats.UserProfile] {
+	fetch := func(ctx context.Context, username string) (*godestats.UserProfile, error) {
+		return client.GetUserProfile(ctx, username)
+	}
+	return Run(ctx, usernames, fetch, opts)
+}
+
+// limiter spaces out operation starts by a fixed interval.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newLimiter creates a limiter; an interval of zero never waits.
+func newLimiter(interval time.Duration) *limiter {
+	return &limiter{interval: interval}
+}
+
+// wait blocks until the next operation may start or ctx is done.
+func (l *limiter) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if l.interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
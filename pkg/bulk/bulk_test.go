@@ -0,0 +1,116 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestRun(t *testing.T) {
+	users := []string{"a", "b", "c", "d", "e", "f"}
+
+	var running, peak atomic.Int32
+	op := func(ctx context.Context, username string) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if username == "c" {
+			return 0, errors.New("failed")
+		}
+		return len(username) + int(username[0]), nil
+	}
+
+	var progress []Progress
+	results := Run(context.Background(), users, op, Options{
+		Concurrency: 2,
+		OnProgress:  func(p Progress) { progress = append(progress, p) },
+	})
+
+	if len(results) != len(users) {
+		t.Fatalf("Expected %d results, got %d", len(users), len(results))
+	}
+	for i, r := range results {
+		if r.Username != users[i] {
+			t.Errorf("Expected result %d for '%s', got '%s'", i, users[i], r.Username)
+		}
+		if (r.Err != nil) != (r.Username == "c") {
+			t.Errorf("Unexpected error for '%s': %v", r.Username, r.Err)
+		}
+	}
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("Expected at most 2 concurrent operations, got %d", p)
+	}
+	if len(progress) != len(users) || progress[len(progress)-1].Done != len(users) || progress[0].Total != len(users) {
+		t.Errorf("Unexpected progress reports: %+v", progress)
+	}
+}
+
+func TestRun_RateLimit(t *testing.T) {
+	users := []string{"a", "b", "c", "d"}
+	start := time.Now()
+
+	Run(context.Background(), users, func(ctx context.Context, username string) (struct{}, error) {
+		return struct{}{}, nil
+	}, Options{Concurrency: 4, Interval: 20 * time.Millisecond})
+
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("Expected operations to be spaced out over at least 60ms, took %v", elapsed)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	results := Run(ctx, []string{"a", "b"}, func(ctx context.Context, username string) (int, error) {
+		calls++
+		return 1, nil
+	}, Options{Concurrency: 1})
+
+	if calls != 0 {
+		t.Errorf("Expected no operations after cancellation, got %d", calls)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("Expected context.Canceled for '%s', got %v", r.Username, r.Err)
+		}
+	}
+}
+
+// fakeClient returns a profile for every user except "missing".
+type fakeClient struct{}
+
+func (fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	if username == "missing" {
+		return nil, godestats.ErrUserNotFound
+	}
+	return &godestats.UserProfile{User: username}, nil
+}
+
+func (fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestFetchProfiles(t *testing.T) {
+	results := FetchProfiles(context.Background(), fakeClient{}, []string{"alice", "missing"}, Options{})
+
+	if results[0].Err != nil || results[0].Value.User != "alice" {
+		t.Errorf("Expected alice's profile, got %+v", results[0])
+	}
+	if !godestats.IsUserNotFound(results[1].Err) {
+		t.Errorf("Expected user not found, got %v", results[1].Err)
+	}
+}
@@ -0,0 +1,150 @@
+// Package cache provides caches for rendered badges, profiles and other
+// derived data, so that services built on this library don't hit the API on every request.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache defines the interface for storing byte values with an expiry.
+// Implementations must be safe for concurrent use. Besides Memory, the redis
+// subpackage provides a cache that can be shared by several service instances.
+type Cache interface {
+	// Get returns the value stored for key. The boolean result is false if
+	// there is no value or it has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores a value for key that expires after ttl. A ttl of zero or less
+	// stores the value without expiry.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the value stored for key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// entry is a value held by the memory cache.
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Memory is an in-memory Cache. Expired entries are removed on access and swept
+// periodically on writes, and the number of entries is bounded (see WithMaxEntries).
+type Memory struct {
+	mu         sync.Mutex
+	entries    map[string]entry
+	now        func() time.Time
+	maxEntries int
+	nextSweep  time.Time
+}
+
+// DefaultMaxEntries is the default number of entries a Memory cache holds.
+const DefaultMaxEntries = 10000
+
+// sweepInterval is how often writes remove the expired entries of a Memory cache.
+const sweepInterval = time.Minute
+
+// MemoryOption configures optional behavior of a Memory cache.
+type MemoryOption func(*Memory)
+
+// WithMaxEntries sets the number of entries the cache holds. When it is full,
+// storing a new key evicts the entry closest to expiry. Zero or less means no bound.
+func WithMaxEntries(n int) MemoryOption {
+	return func(m *Memory) {
+		m.maxEntries = n
+	}
+}
+
+// NewMemory creates a new empty in-memory cache holding up to DefaultMaxEntries entries.
+func NewMemory(opts ...MemoryOption) *Memory {
+	m := &Memory{
+		entries:    make(map[string]entry),
+		now:        time.Now,
+		maxEntries: DefaultMaxEntries,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Get returns a copy of the value stored for key.
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !e.expiresAt.IsZero() && !m.now().Before(e.expiresAt) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+
+	return append([]byte(nil), e.value...), true, nil
+}
+
+// Set stores a copy of the value for key.
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	e := entry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.expiresAt = m.now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if !now.Before(m.nextSweep) {
+		m.sweep(now)
+	}
+	if _, ok := m.entries[key]; !ok && m.maxEntries > 0 && len(m.entries) >= m.maxEntries {
+		m.evict(now)
+	}
+
+	m.entries[key] = e
+	return nil
+}
+
+// sweep removes the expired entries. The caller must hold m.mu.
+func (m *Memory) sweep(now time.Time) {
+	for key, e := range m.entries {
+		if !e.expiresAt.IsZero() && !now.Before(e.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+	m.nextSweep = now.Add(sweepInterval)
+}
+
+// evict makes room for a new entry by removing the expired entries, or the
+// entry closest to expiry if none has expired. The caller must hold m.mu.
+func (m *Memory) evict(now time.Time) {
+	m.sweep(now)
+	if len(m.entries) < m.maxEntries {
+		return
+	}
+
+	var victim string
+	var soonest time.Time
+	found := false
+	for key, e := range m.entries {
+		// Entries without expiry are evicted last
+		if !found || (!e.expiresAt.IsZero() && (soonest.IsZero() || e.expiresAt.Before(soonest))) {
+			victim, soonest, found = key, e.expiresAt, true
+		}
+	}
+	delete(m.entries, victim)
+}
+
+// Delete removes the value stored for key.
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
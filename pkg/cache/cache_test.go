@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory_GetSet(t *testing.T) {
+	c := NewMemory()
+	ctx := context.Background()
+
+	if _, ok, _ := c.Get(ctx, "missing"); ok {
+		t.Error("Expected no value for missing key")
+	}
+
+	value := []byte("hello")
+	c.Set(ctx, "key", value, 0)
+	value[0] = 'j'
+
+	got, ok, err := c.Get(ctx, "key")
+	if err != nil || !ok || string(got) != "hello" {
+		t.Errorf("Expected 'hello', got '%s' (ok=%v, err=%v)", got, ok, err)
+	}
+}
+
+func TestMemory_Expiry(t *testing.T) {
+	c := NewMemory()
+	ctx := context.Background()
+
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "key", []byte("value"), time.Minute)
+
+	now = now.Add(59 * time.Second)
+	if _, ok, _ := c.Get(ctx, "key"); !ok {
+		t.Error("Expected value before expiry")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok, _ := c.Get(ctx, "key"); ok {
+		t.Error("Expected value to be expired")
+	}
+}
+
+func TestMemory_Delete(t *testing.T) {
+	c := NewMemory()
+	ctx := context.Background()
+
+	c.Set(ctx, "key", []byte("value"), 0)
+	if err := c.Delete(ctx, "key"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "key"); ok {
+		t.Error("Expected value to be deleted")
+	}
+
+	if err := c.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Expected no error deleting a missing key, got %v", err)
+	}
+}
+
+func TestMemory_Sweep(t *testing.T) {
+	c := NewMemory()
+	ctx := context.Background()
+
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		c.Set(ctx, key, []byte("value"), time.Second)
+	}
+	c.Set(ctx, "kept", []byte("value"), 0)
+
+	// Expired entries that are never read are removed by a later write
+	now = now.Add(sweepInterval)
+	c.Set(ctx, "new", []byte("value"), time.Second)
+	if n := len(c.entries); n != 2 {
+		t.Errorf("Expected 2 entries after sweeping, got %d", n)
+	}
+}
+
+func TestMemory_MaxEntries(t *testing.T) {
+	c := NewMemory(WithMaxEntries(3))
+	ctx := context.Background()
+
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "forever", []byte("value"), 0)
+	c.Set(ctx, "late", []byte("value"), time.Hour)
+	c.Set(ctx, "soon", []byte("value"), time.Minute)
+
+	// Replacing a key doesn't evict
+	c.Set(ctx, "late", []byte("updated"), time.Hour)
+	if n := len(c.entries); n != 3 {
+		t.Fatalf("Expected 3 entries, got %d", n)
+	}
+
+	// A new key evicts the entry closest to expiry
+	c.Set(ctx, "new", []byte("value"), time.Hour)
+	if _, ok, _ := c.Get(ctx, "soon"); ok {
+		t.Error("Expected the entry closest to expiry to be evicted")
+	}
+	for _, key := range []string{"forever", "late", "new"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Errorf("Expected %q to be kept", key)
+		}
+	}
+
+	if c := NewMemory(WithMaxEntries(0)); c.maxEntries != 0 {
+		t.Errorf("Expected no bound, got %d", c.maxEntries)
+	}
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// DefaultProfileTTL is how long the caching client keeps fetched profiles.
+const DefaultProfileTTL = time.Minute
+
+// cachedProfile is the cached representation of a fetched profile.
+// The profile is encoded with godestats.MarshalProfile, so that unknown fields
+// survive caching.
+type cachedProfile struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Profile   json.RawMessage `json:"profile"`
+}
+
+// Client is a CodeStatsClient decorator that caches profiles in a Cache.
+// Pulses are always passed through to the wrapped client.
+type Client struct {
+	inner godestats.ProfileClient
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewClient wraps a client so that fetched profiles are cached for ttl.
+// A ttl of zero or less uses DefaultProfileTTL. Read-only clients such as
+// anonymous ones can be wrapped, but then fail to send pulses.
+func NewClient(inner godestats.ProfileClient, c Cache, ttl time.Duration) *Client {
+	if ttl <= 0 {
+		ttl = DefaultProfileTTL
+	}
+	return &Client{inner: inner, cache: c, ttl: ttl}
+}
+
+// GetUserProfile returns the cached profile for the user, fetching it from the
+// wrapped client if it is missing or expired. Cache failures fall back to the wrapped client.
+// Errors are not cached. Calls with godestats.WithNoCache always fetch the profile
+// and cache the result. The call options are passed on to the wrapped client.
+func (c *Client) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	key := profileKey(username)
+
+	if !godestats.ResolveCallOptions(ctx, opts...).NoCache {
+		if profile, ok := c.cached(ctx, key); ok {
+			return profile, nil
+		}
+	}
+
+	profile, err := c.inner.GetUserProfile(ctx, username, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchedAt := profile.Recent.End()
+	if profile.Recent.IsZero() {
+		fetchedAt = time.Now()
+	}
+
+	if encoded, err := godestats.MarshalProfile(profile); err == nil {
+		if data, err := json.Marshal(cachedProfile{FetchedAt: fetchedAt, Profile: encoded}); err == nil {
+			_ = c.cache.Set(ctx, key, data, c.ttl)
+		}
+	}
+
+	return profile, nil
+}
+
+// cached returns the profile cached under the key, if any.
+func (c *Client) cached(ctx context.Context, key string) (*godestats.UserProfile, bool) {
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil || !ok {
+		return nil, false
+	}
+
+	var cached cachedProfile
+	if json.Unmarshal(data, &cached) != nil || len(cached.Profile) == 0 || string(cached.Profile) == "null" {
+		return nil, false
+	}
+	profile, err := godestats.UnmarshalProfile(cached.Profile, true)
+	if err != nil {
+		return nil, false
+	}
+	profile.Recent = godestats.NewRecentPeriod(cached.FetchedAt)
+	return profile, true
+}
+
+// Invalidate removes the user's cached profile, so that the next request fetches it again.
+func (c *Client) Invalidate(ctx context.Context, username string) error {
+	return c.cache.Delete(ctx, profileKey(username))
+}
+
+// SendPulse submits the pulse through the wrapped client. It returns
+// ErrUnauthorized if the wrapped client is read-only.
+func (c *Client) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	sender, ok := c.inner.(godestats.CodeStatsClient)
+	if !ok {
+		return godestats.ErrUnauthorized
+	}
+	return sender.SendPulse(ctx, pulse, opts...)
+}
+
+// profileKey returns the cache key of a user's profile.
+func profileKey(username string) string {
+	return "profile:" + username
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// countingClient returns a fixed profile and counts profile requests.
+type countingClient struct {
+	profile *godestats.UserProfile
+	err     error
+	calls   int
+}
+
+func (c *countingClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.profile.Clone(), nil
+}
+
+func (c *countingClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestClient_CachesProfiles(t *testing.T) {
+	fetchedAt := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	inner := &countingClient{profile: &godestats.UserProfile{
+		User:      "alice",
+		TotalXP:   500,
+		Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 500}},
+		Recent:    godestats.NewRecentPeriod(fetchedAt),
+		Raw:       map[string]json.RawMessage{"country": json.RawMessage(`"DE"`)},
+	}}
+	c := NewClient(inner, NewMemory(), time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		profile, err := c.GetUserProfile(ctx, "alice")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !profile.Equal(inner.profile) {
+			t.Errorf("Expected cached profile to equal original")
+		}
+		if string(profile.Raw["country"]) != `"DE"` {
+			t.Errorf("Expected unknown fields to survive caching, got %v", profile.Raw)
+		}
+		if !profile.Recent.End().Equal(fetchedAt) {
+			t.Errorf("Expected recent period to end at %v, got %v", fetchedAt, profile.Recent.End())
+		}
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("Expected 1 request to the wrapped client, got %d", inner.calls)
+	}
+}
+
+func TestClient_DoesNotCacheErrors(t *testing.T) {
+	inner := &countingClient{err: godestats.ErrUserNotFound}
+	c := NewClient(inner, NewMemory(), 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetUserProfile(context.Background(), "bob"); !errors.Is(err, godestats.ErrUserNotFound) {
+			t.Errorf("Expected ErrUserNotFound, got %v", err)
+		}
+	}
+
+	if inner.calls != 2 {
+		t.Errorf("Expected 2 requests to the wrapped client, got %d", inner.calls)
+	}
+}
+
+func TestClient_Invalidate(t *testing.T) {
+	inner := &countingClient{profile: &godestats.UserProfile{User: "alice", TotalXP: 500}}
+	c := NewClient(inner, NewMemory(), time.Minute)
+	ctx := context.Background()
+
+	c.GetUserProfile(ctx, "alice")
+	if err := c.Invalidate(ctx, "alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	c.GetUserProfile(ctx, "alice")
+
+	if inner.calls != 2 {
+		t.Errorf("Expected 2 requests to the wrapped client, got %d", inner.calls)
+	}
+}
+
+// readOnlyClient is a client without SendPulse, like anonymous clients.
+type readOnlyClient struct{}
+
+func (readOnlyClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return &godestats.UserProfile{User: username}, nil
+}
+
+func TestClient_ReadOnly(t *testing.T) {
+	c := NewClient(readOnlyClient{}, NewMemory(), time.Minute)
+
+	if profile, err := c.GetUserProfile(context.Background(), "alice"); err != nil || profile.User != "alice" {
+		t.Errorf("Expected alice's profile, got %v (%v)", profile, err)
+	}
+	if err := c.SendPulse(context.Background(), godestats.Pulse{CodedAt: time.Now()}); !errors.Is(err, godestats.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestClient_NoCache(t *testing.T) {
+	inner := &countingClient{profile: &godestats.UserProfile{User: "alice", TotalXP: 500}}
+	c := NewClient(inner, NewMemory(), time.Minute)
+	ctx := context.Background()
+
+	c.GetUserProfile(ctx, "alice")
+	inner.profile.TotalXP = 600
+
+	profile, err := c.GetUserProfile(ctx, "alice", godestats.WithNoCache())
+	if err != nil || profile.TotalXP != 600 {
+		t.Fatalf("Expected a fresh profile, got %v (%v)", profile, err)
+	}
+
+	// The fresh profile replaced the cached one
+	if profile, _ := c.GetUserProfile(ctx, "alice"); profile.TotalXP != 600 || inner.calls != 2 {
+		t.Errorf("Expected the fresh profile from the cache after 2 requests, got %d XP after %d", profile.TotalXP, inner.calls)
+	}
+}
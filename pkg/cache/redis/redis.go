@@ -0,0 +1,72 @@
+// Package redis provides a cache.Cache backed by Redis, so that several
+// instances of a badge or proxy service can share cached responses.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix is prepended to all keys to separate them from other data in the database.
+const DefaultPrefix = "godestats:"
+
+// Cache is a cache.Cache storing values in Redis. Expiry is handled by Redis itself.
+type Cache struct {
+	client goredis.UniversalClient
+	prefix string
+}
+
+// Option configures optional behavior of a Cache.
+type Option func(*Cache)
+
+// WithPrefix sets the prefix prepended to all keys. Defaults to DefaultPrefix.
+func WithPrefix(prefix string) Option {
+	return func(c *Cache) {
+		c.prefix = prefix
+	}
+}
+
+// New creates a cache using the given Redis client, which may be a single node,
+// cluster or sentinel client. The caller remains responsible for closing the client.
+func New(client goredis.UniversalClient, opts ...Option) *Cache {
+	c := &Cache{client: client, prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the value stored for key.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read from redis: %w", err)
+	}
+	return value, true, nil
+}
+
+// Set stores the value for key with the given expiry.
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to write to redis: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the value stored for key.
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, c.prefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to delete from redis: %w", err)
+	}
+	return nil
+}
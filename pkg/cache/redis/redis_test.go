@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/Yeti47/gode-stats/pkg/cache"
+)
+
+func newTestCache(t *testing.T, opts ...Option) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return New(client, opts...), server
+}
+
+func TestCache_GetSetDelete(t *testing.T) {
+	c, server := newTestCache(t)
+	var _ cache.Cache = c
+	ctx := context.Background()
+
+	if _, ok, err := c.Get(ctx, "missing"); ok || err != nil {
+		t.Errorf("Expected no value for missing key, got ok=%v, err=%v", ok, err)
+	}
+
+	if err := c.Set(ctx, "key", []byte("value"), 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !server.Exists(DefaultPrefix + "key") {
+		t.Errorf("Expected key to be stored with prefix %q", DefaultPrefix)
+	}
+
+	got, ok, err := c.Get(ctx, "key")
+	if err != nil || !ok || string(got) != "value" {
+		t.Errorf("Expected 'value', got '%s' (ok=%v, err=%v)", got, ok, err)
+	}
+
+	if err := c.Delete(ctx, "key"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "key"); ok {
+		t.Error("Expected value to be deleted")
+	}
+}
+
+func TestCache_Expiry(t *testing.T) {
+	c, server := newTestCache(t, WithPrefix("test:"))
+	ctx := context.Background()
+
+	c.Set(ctx, "key", []byte("value"), time.Minute)
+	if ttl := server.TTL("test:key"); ttl != time.Minute {
+		t.Errorf("Expected TTL of 1m, got %v", ttl)
+	}
+
+	server.FastForward(time.Minute)
+	if _, ok, _ := c.Get(ctx, "key"); ok {
+		t.Error("Expected value to be expired")
+	}
+}
+
+func TestCache_ServerDown(t *testing.T) {
+	c, server := newTestCache(t)
+	server.Close()
+
+	if _, _, err := c.Get(context.Background(), "key"); err == nil {
+		t.Error("Expected error when the server is unavailable")
+	}
+}
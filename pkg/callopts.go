@@ -0,0 +1,100 @@
+package godestats
+
+import (
+	"context"
+	"time"
+)
+
+// CallOptions are settings of a single call, layered over the defaults of the
+// client, so that one shared client can serve both latency-sensitive and batch
+// callers. They are passed as trailing arguments of the call:
+//
+//	profile, err := c.GetUserProfile(ctx, "alice", godestats.WithCallTimeout(5*time.Second), godestats.WithNoCache())
+//
+// Decorating clients pass them on to the clients they wrap.
+type CallOptions struct {
+	// Timeout limits the call, or is 0 to use the client's timeout. It can only
+	// shorten the client's timeout.
+	Timeout time.Duration
+
+	// NoCache makes caching clients fetch fresh data. The fetched data is still cached.
+	NoCache bool
+
+	// IdempotencyKey identifies a pulse across submissions, or is "" to let the
+	// client generate one per call. Callers that resend a pulse themselves pass the
+	// key of the first attempt, so that the server can drop duplicates.
+	IdempotencyKey string
+
+	// RequestID identifies the call in the request header, observed responses and
+	// errors, or is "" to let the client generate one. Callers pass their own to
+	// correlate the call with their logs and traces.
+	RequestID string
+}
+
+// CallOption sets a per-call option.
+type CallOption func(*CallOptions)
+
+// WithCallTimeout limits the call to the timeout.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *CallOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithNoCache bypasses caches for the call.
+func WithNoCache() CallOption {
+	return func(o *CallOptions) {
+		o.NoCache = true
+	}
+}
+
+// WithIdempotencyKey sets the idempotency key of the pulse sent in the call.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *CallOptions) {
+		o.IdempotencyKey = key
+	}
+}
+
+// WithRequestID sets the request ID of the call.
+func WithRequestID(id string) CallOption {
+	return func(o *CallOptions) {
+		o.RequestID = id
+	}
+}
+
+// callOptionsKey is the context key of the call options.
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context carrying default options for the calls made
+// with it, on top of defaults already in ctx. Options passed to a call take
+// precedence over them. Prefer passing options to the call; the context only
+// helps where code between the caller and the client can't forward them.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, ResolveCallOptions(ctx, opts...))
+}
+
+// WithoutCallOptions returns a context without the defaults of WithCallOptions,
+// e.g. for work shared by several calls that must not inherit the options of one.
+func WithoutCallOptions(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(callOptionsKey{}).(CallOptions); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, callOptionsKey{}, CallOptions{})
+}
+
+// CallOptionsFrom returns the default call options in the context, or the zero
+// options if there are none.
+func CallOptionsFrom(ctx context.Context) CallOptions {
+	options, _ := ctx.Value(callOptionsKey{}).(CallOptions)
+	return options
+}
+
+// ResolveCallOptions returns the options of a call: the defaults in the context
+// with the options passed to the call applied on top.
+func ResolveCallOptions(ctx context.Context, opts ...CallOption) CallOptions {
+	options := CallOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
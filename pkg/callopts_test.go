@@ -0,0 +1,48 @@
+package godestats
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithCallOptions(t *testing.T) {
+	ctx := context.Background()
+	if options := CallOptionsFrom(ctx); options != (CallOptions{}) {
+		t.Errorf("Expected zero options, got %+v", options)
+	}
+
+	ctx = WithCallOptions(ctx, WithCallTimeout(5*time.Second))
+	layered := WithCallOptions(ctx, WithNoCache())
+
+	if options := CallOptionsFrom(layered); options.Timeout != 5*time.Second || !options.NoCache {
+		t.Errorf("Expected the timeout and no caching, got %+v", options)
+	}
+	if options := CallOptionsFrom(ctx); options.NoCache {
+		t.Errorf("Expected the parent context to be unchanged, got %+v", options)
+	}
+
+	keyed := WithCallOptions(layered, WithIdempotencyKey("pulse-1"), WithRequestID("req-1"))
+	if options := CallOptionsFrom(keyed); options.IdempotencyKey != "pulse-1" || options.RequestID != "req-1" || !options.NoCache {
+		t.Errorf("Expected the idempotency key and request ID on top of the other options, got %+v", options)
+	}
+}
+
+func TestResolveCallOptions(t *testing.T) {
+	if options := ResolveCallOptions(context.Background(), WithNoCache(), WithRequestID("req-1")); !options.NoCache || options.RequestID != "req-1" {
+		t.Errorf("Expected the passed options, got %+v", options)
+	}
+
+	ctx := WithCallOptions(context.Background(), WithCallTimeout(5*time.Second), WithRequestID("default"))
+	options := ResolveCallOptions(ctx, WithRequestID("req-2"))
+	if options.Timeout != 5*time.Second || options.RequestID != "req-2" {
+		t.Errorf("Expected the passed options on top of the context's, got %+v", options)
+	}
+
+	if options := CallOptionsFrom(WithoutCallOptions(ctx)); options != (CallOptions{}) {
+		t.Errorf("Expected no options after WithoutCallOptions, got %+v", options)
+	}
+	if plain := context.Background(); WithoutCallOptions(plain) != plain {
+		t.Error("Expected contexts without options to be returned unchanged")
+	}
+}
@@ -0,0 +1,157 @@
+// Package card renders profile cards as SVG images for embedding in README files.
+package card
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// ErrUnknownTheme is returned when a card is requested with an unsupported theme.
+var ErrUnknownTheme = errors.New("unknown card theme")
+
+// DefaultLanguages is the number of languages shown on a card.
+const DefaultLanguages = 5
+
+// Card dimensions
+const (
+	Width  = 495
+	Height = 195
+)
+
+// Theme defines the colors of a card.
+type Theme struct {
+	Background string
+	Border     string
+	Title      string
+	Text       string
+	Accent     string
+	Track      string
+}
+
+// Themes holds the built-in themes by name.
+var Themes = map[string]Theme{
+	"default": {Background: "#fffefe", Border: "#e4e2e2", Title: "#2f80ed", Text: "#434d58", Accent: "#4c9ee9", Track: "#ebedf0"},
+	"dark":    {Background: "#151515", Border: "#303030", Title: "#ffffff", Text: "#9f9f9f", Accent: "#79ff97", Track: "#303030"},
+	"radical": {Background: "#141321", Border: "#2a2545", Title: "#fe428e", Text: "#a9fef7", Accent: "#f8d847", Track: "#2a2545"},
+	"nord":    {Background: "#2e3440", Border: "#3b4252", Title: "#88c0d0", Text: "#d8dee9", Accent: "#81a1c1", Track: "#3b4252"},
+}
+
+// ThemeNames returns the sorted names of the built-in themes.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns the built-in theme with the given name.
+// An empty name selects the default theme.
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = "default"
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("%w: %q", ErrUnknownTheme, name)
+	}
+	return theme, nil
+}
+
+// Options configures a card. Zero values select the defaults.
+type Options struct {
+	// Theme defines the card colors. Defaults to the "default" theme.
+	Theme Theme
+	// Languages is the number of languages listed.
+	Languages int
+	// Calculator is used for levels. Defaults to the Code::Stats formula.
+	Calculator godestats.XpCalculator
+	// Now is the reference time for the streak. Defaults to the current time.
+	Now time.Time
+}
+
+// withDefaults returns a copy of the options with zero values replaced by defaults.
+func (o Options) withDefaults() Options {
+	if o.Theme == (Theme{}) {
+		o.Theme = Themes["default"]
+	}
+	if o.Languages <= 0 {
+		o.Languages = DefaultLanguages
+	}
+	if o.Calculator == nil {
+		o.Calculator = xp.NewCalculator()
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
+
+// Render renders the profile card as an SVG image.
+func Render(profile *godestats.UserProfile, opts Options) []byte {
+	opts = opts.withDefaults()
+	theme := opts.Theme
+	calc := opts.Calculator
+
+	level := calc.GetLevel(profile.TotalXP)
+	progress := calc.GetLevelPercentage(profile.TotalXP)
+	streak := profile.Streaks(opts.Now)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="Code::Stats card of %s">`,
+		Width, Height, Width, Height, esc(profile.User))
+	fmt.Fprintf(&buf, `<style>text{font-family:"Segoe UI",Ubuntu,Sans-Serif}.title{font-size:18px;font-weight:600;fill:%s}.stat{font-size:14px;fill:%s}.lang{font-size:12px;fill:%s}</style>`,
+		esc(theme.Title), esc(theme.Text), esc(theme.Text))
+	fmt.Fprintf(&buf, `<rect x="0.5" y="0.5" rx="4.5" width="%d" height="%d" fill="%s" stroke="%s"/>`, Width-1, Height-1, esc(theme.Background), esc(theme.Border))
+	fmt.Fprintf(&buf, `<text x="25" y="35" class="title">%s's Code::Stats</text>`, esc(profile.User))
+
+	// Stats column
+	stats := []struct{ label, value string }{
+		{"Level", fmt.Sprintf("%d (%.0f%%)", level, progress*100)},
+		{"Total XP", profile.TotalXP.String()},
+		{"Recent XP", "+" + profile.NewXP.String()},
+		{"Streak", fmt.Sprintf("%d days (best %d)", streak.Current, streak.Longest)},
+	}
+	for i, stat := range stats {
+		fmt.Fprintf(&buf, `<text x="25" y="%d" class="stat"><tspan font-weight="600">%s:</tspan> %s</text>`, 70+i*28, stat.label, esc(stat.value))
+	}
+	fmt.Fprintf(&buf, `<rect x="25" y="170" width="200" height="6" rx="3" fill="%s"/>`, esc(theme.Track))
+	fmt.Fprintf(&buf, `<rect x="25" y="170" width="%.1f" height="6" rx="3" fill="%s"/>`, 200*progress, esc(theme.Accent))
+
+	// Language column
+	ranked := godestats.LanguagesByXP(profile)
+	if len(ranked) > opts.Languages {
+		ranked = ranked[:opts.Languages]
+	}
+	step := 0
+	if len(ranked) > 0 {
+		step = 130 / len(ranked)
+	}
+	for i, lang := range ranked {
+		y := 60 + i*step
+		width := 0.0
+		if ranked[0].XPs > 0 {
+			width = 200 * float64(lang.XPs) / float64(ranked[0].XPs)
+		}
+		fmt.Fprintf(&buf, `<text x="270" y="%d" class="lang">%s</text>`, y, esc(lang.Name))
+		fmt.Fprintf(&buf, `<text x="470" y="%d" class="lang" text-anchor="end">%s</text>`, y, lang.XPs.Short())
+		fmt.Fprintf(&buf, `<rect x="270" y="%d" width="200" height="6" rx="3" fill="%s"/>`, y+6, esc(theme.Track))
+		fmt.Fprintf(&buf, `<rect x="270" y="%d" width="%.1f" height="6" rx="3" fill="%s"/>`, y+6, width, esc(theme.Accent))
+	}
+
+	buf.WriteString(`</svg>`)
+	return buf.Bytes()
+}
+
+// esc escapes text for use in SVG content and attributes.
+func esc(s string) string {
+	return html.EscapeString(s)
+}
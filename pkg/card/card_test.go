@@ -0,0 +1,61 @@
+package card
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestRender(t *testing.T) {
+	profile := &godestats.UserProfile{
+		User:    "<alice>",
+		TotalXP: 12345,
+		NewXP:   67,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go": {XPs: 6000}, "Rust": {XPs: 3000}, "C": {XPs: 1500},
+			"Python": {XPs: 1000}, "Java": {XPs: 500}, "Perl": {XPs: 345},
+		},
+		Dates: map[string]godestats.XP{"2023-06-14": 10, "2023-06-15": 20},
+	}
+
+	svg := string(Render(profile, Options{
+		Theme: Themes["dark"],
+		Now:   time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC),
+	}))
+
+	for _, expected := range []string{
+		"&lt;alice&gt;",
+		"12,345",
+		"+67",
+		"2 days (best 2)",
+		"Go",
+		Themes["dark"].Background,
+	} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("Expected card to contain '%s'", expected)
+		}
+	}
+
+	if strings.Contains(svg, "Perl") {
+		t.Error("Expected only the top 5 languages")
+	}
+}
+
+func TestLookupTheme(t *testing.T) {
+	theme, err := LookupTheme("")
+	if err != nil || theme != Themes["default"] {
+		t.Errorf("Expected default theme, got %+v (err=%v)", theme, err)
+	}
+
+	if _, err := LookupTheme("missing"); !errors.Is(err, ErrUnknownTheme) {
+		t.Errorf("Expected ErrUnknownTheme, got %v", err)
+	}
+
+	names := ThemeNames()
+	if len(names) != len(Themes) || names[0] != "dark" {
+		t.Errorf("Expected sorted theme names, got %v", names)
+	}
+}
@@ -0,0 +1,119 @@
+// Package cardhttp serves Code::Stats profile cards over HTTP.
+package cardhttp
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/badge"
+	"github.com/Yeti47/gode-stats/pkg/cache"
+	"github.com/Yeti47/gode-stats/pkg/card"
+)
+
+// DefaultTTL is how long a rendered card is cached and may be cached by clients.
+const DefaultTTL = 30 * time.Minute
+
+// ContentType is the content type of served cards.
+const ContentType = "image/svg+xml; charset=utf-8"
+
+// Option configures the card handler.
+type Option func(*handler)
+
+// WithTTL sets how long rendered cards are cached.
+func WithTTL(ttl time.Duration) Option {
+	return func(h *handler) {
+		h.ttl = ttl
+	}
+}
+
+// handler serves cards rendered from profiles fetched through the client.
+type handler struct {
+	client godestats.ProfileClient
+	cache  cache.Cache
+	ttl    time.Duration
+	mux    *http.ServeMux
+}
+
+// Handler returns an http.Handler that serves SVG cards at /card/{user}.
+// The theme query parameter selects a built-in theme. Rendered cards are stored
+// in the cache; a nil cache disables caching.
+func Handler(client godestats.ProfileClient, c cache.Cache, opts ...Option) http.Handler {
+	h := &handler{
+		client: client,
+		cache:  c,
+		ttl:    DefaultTTL,
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("GET /card/{user}", h.serveCard)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// serveCard renders or looks up the requested card.
+func (h *handler) serveCard(w http.ResponseWriter, r *http.Request) {
+	user := r.PathValue("user")
+	themeName := r.URL.Query().Get("theme")
+
+	theme, err := card.LookupTheme(themeName)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "unknown theme")
+		return
+	}
+
+	key := "card:" + user + ":" + themeName
+	if h.cache != nil {
+		if svg, ok, err := h.cache.Get(r.Context(), key); err == nil && ok {
+			h.write(w, http.StatusOK, svg)
+			return
+		}
+	}
+
+	profile, err := h.client.GetUserProfile(r.Context(), user)
+	if err != nil {
+		if godestats.IsUserNotFound(err) {
+			h.writeError(w, http.StatusNotFound, "user not found")
+		} else {
+			h.writeError(w, http.StatusBadGateway, "unavailable")
+		}
+		return
+	}
+	// Profiles the client fetched for the token owner contain private data
+	profile = profile.Public()
+
+	svg := card.Render(profile, card.Options{Theme: theme})
+	if h.cache != nil {
+		// A failing cache only costs performance, so the card is served regardless.
+		_ = h.cache.Set(r.Context(), key, svg, h.ttl)
+	}
+
+	h.write(w, http.StatusOK, svg)
+}
+
+// write sends an SVG response with caching headers.
+func (h *handler) write(w http.ResponseWriter, status int, svg []byte) {
+	w.Header().Set("Content-Type", ContentType)
+	if status == http.StatusOK {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(h.ttl.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	w.WriteHeader(status)
+	w.Write(svg)
+}
+
+// writeError sends an error badge in place of the card.
+func (h *handler) writeError(w http.ResponseWriter, status int, message string) {
+	b := badge.Badge{Label: "Code::Stats", Value: message, Color: badge.ErrorColor}
+	h.write(w, status, b.SVG())
+}
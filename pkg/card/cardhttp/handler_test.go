@@ -0,0 +1,72 @@
+package cardhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/cache"
+	"github.com/Yeti47/gode-stats/pkg/card"
+)
+
+// fakeClient serves fixed profiles and counts profile requests.
+type fakeClient struct {
+	profiles map[string]*godestats.UserProfile
+	calls    int
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	f.calls++
+	if profile, ok := f.profiles[username]; ok {
+		return profile, nil
+	}
+	return nil, godestats.ErrUserNotFound
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	client := &fakeClient{profiles: map[string]*godestats.UserProfile{
+		"alice": {User: "alice", TotalXP: 12345},
+	}}
+	handler := Handler(client, cache.NewMemory())
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains string
+	}{
+		{"default theme", "/card/alice", http.StatusOK, card.Themes["default"].Background},
+		{"dark theme", "/card/alice?theme=dark", http.StatusOK, card.Themes["dark"].Background},
+		{"cached", "/card/alice?theme=dark", http.StatusOK, card.Themes["dark"].Background},
+		{"unknown theme", "/card/alice?theme=bogus", http.StatusBadRequest, "unknown theme"},
+		{"unknown user", "/card/bob", http.StatusNotFound, "user not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+				t.Errorf("Expected content type '%s', got '%s'", ContentType, ct)
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("Expected body to contain '%s'", tt.contains)
+			}
+		})
+	}
+
+	if client.calls != 3 {
+		t.Errorf("Expected 3 profile requests, got %d", client.calls)
+	}
+}
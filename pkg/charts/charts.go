@@ -0,0 +1,324 @@
+// Package charts renders XP-over-time charts as PNG and SVG images without external plotting libraries.
+package charts
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+// ErrNoData is returned when a chart is requested without any data points.
+var ErrNoData = errors.New("no data to chart")
+
+// Default chart dimensions
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 400
+)
+
+// DefaultPalette holds the colors assigned to stacked series in order.
+var DefaultPalette = []color.RGBA{
+	{0x4c, 0x9e, 0xe9, 0xff},
+	{0xf5, 0x8a, 0x4b, 0xff},
+	{0x5c, 0xc2, 0x6e, 0xff},
+	{0xd9, 0x5c, 0x8a, 0xff},
+	{0x9b, 0x7b, 0xd4, 0xff},
+	{0xe8, 0xc5, 0x47, 0xff},
+	{0x47, 0xb8, 0xb8, 0xff},
+	{0x9a, 0x9a, 0x9a, 0xff},
+}
+
+// LanguagePalette returns a palette with the registry color of each series'
+// language (see package languages), so that languages keep their colors across
+// charts. Series of unknown languages take the colors of DefaultPalette.
+func LanguagePalette(series []Series) []color.RGBA {
+	palette := make([]color.RGBA, len(series))
+	for i, s := range series {
+		palette[i] = DefaultPalette[i%len(DefaultPalette)]
+		if c, ok := parseHexColor(languages.Color(s.Name)); ok {
+			palette[i] = c
+		}
+	}
+	return palette
+}
+
+// parseHexColor parses a color in the form #rrggbb.
+func parseHexColor(s string) (color.RGBA, bool) {
+	var c color.RGBA
+	if len(s) != 7 || s[0] != '#' {
+		return c, false
+	}
+	if _, err := fmt.Sscanf(s[1:], "%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		return c, false
+	}
+	c.A = 0xff
+	return c, true
+}
+
+// Series is a named sequence of XP values over time.
+type Series struct {
+	Name   string
+	Points []godestats.DateXP
+}
+
+// Options configures a chart. Zero values select the defaults.
+type Options struct {
+	Width      int
+	Height     int
+	Title      string
+	Background color.RGBA
+	Foreground color.RGBA
+	Palette    []color.RGBA
+}
+
+// withDefaults returns a copy of the options with zero values replaced by defaults.
+func (o Options) withDefaults() Options {
+	if o.Width <= 0 {
+		o.Width = DefaultWidth
+	}
+	if o.Height <= 0 {
+		o.Height = DefaultHeight
+	}
+	if o.Background == (color.RGBA{}) {
+		o.Background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	}
+	if o.Foreground == (color.RGBA{}) {
+		o.Foreground = color.RGBA{0x43, 0x4d, 0x58, 0xff}
+	}
+	if len(o.Palette) == 0 {
+		o.Palette = DefaultPalette
+	}
+	return o
+}
+
+// XPOverTimePNG renders the points as a filled line chart and encodes it as PNG.
+func XPOverTimePNG(w io.Writer, points []godestats.DateXP, opts Options) error {
+	if len(points) == 0 {
+		return ErrNoData
+	}
+	return encode(w, render([]Series{{Points: points}}, opts.withDefaults(), false))
+}
+
+// StackedAreaPNG renders the series as a stacked area chart with a legend and encodes it as PNG.
+// Series are aligned by date; missing dates count as zero XP. Without a palette in
+// the options, series are colored by language (see LanguagePalette).
+func StackedAreaPNG(w io.Writer, series []Series, opts Options) error {
+	if len(series) == 0 {
+		return ErrNoData
+	}
+	if len(opts.Palette) == 0 {
+		opts.Palette = LanguagePalette(series)
+	}
+	return encode(w, render(series, opts.withDefaults(), true))
+}
+
+// Cumulative converts daily gains into a running total that ends at total,
+// e.g. to plot total XP over the range of a profile's date series.
+func Cumulative(daily []godestats.DateXP, total godestats.XP) []godestats.DateXP {
+	result := make([]godestats.DateXP, len(daily))
+	running := total
+	for i := len(daily) - 1; i >= 0; i-- {
+		result[i] = godestats.DateXP{Date: daily[i].Date, XP: running}
+		running -= daily[i].XP
+	}
+	return result
+}
+
+// encode writes the image as PNG.
+func encode(w io.Writer, img image.Image) error {
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return nil
+}
+
+// Layout margins in pixels
+const (
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 30
+	marginBottom = 30
+	legendWidth  = 120
+	yTicks       = 4
+)
+
+// render draws the series. If stacked is false, only the first series is drawn as a line.
+func render(series []Series, opts Options, stacked bool) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, opts.Width, opts.Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{opts.Background}, image.Point{}, draw.Src)
+
+	dates, values := align(series)
+
+	right := opts.Width - marginRight
+	if stacked {
+		right -= legendWidth
+	}
+	plot := image.Rect(marginLeft, marginTop, right, opts.Height-marginBottom)
+
+	// Stack the values and find the maximum
+	tops := make([][]float64, len(values))
+	var peak float64
+	for i := range values {
+		tops[i] = make([]float64, len(dates))
+		for j := range dates {
+			tops[i][j] = float64(values[i][j])
+			if i > 0 {
+				tops[i][j] += tops[i-1][j]
+			}
+			peak = math.Max(peak, tops[i][j])
+		}
+	}
+	if peak <= 0 {
+		peak = 1
+	}
+
+	grid := blend(opts.Foreground, opts.Background, 0.15)
+	for tick := 0; tick <= yTicks; tick++ {
+		y := plot.Max.Y - tick*plot.Dy()/yTicks
+		hline(img, plot.Min.X, plot.Max.X, y, grid)
+		label := godestats.XP(peak * float64(tick) / yTicks).Short()
+		text(img, plot.Min.X-8-len(label)*7, y+4, label, opts.Foreground)
+	}
+
+	// Fill every pixel column by interpolating between the data points
+	yFor := func(v float64) int {
+		return plot.Max.Y - int(math.Round(v/peak*float64(plot.Dy())))
+	}
+	prevLine := -1
+	for x := plot.Min.X; x < plot.Max.X; x++ {
+		pos := 0.0
+		if len(dates) > 1 {
+			pos = float64(x-plot.Min.X) / float64(plot.Dx()-1) * float64(len(dates)-1)
+		}
+
+		bottom := plot.Max.Y
+		for i := range tops {
+			c := opts.Palette[i%len(opts.Palette)]
+			top := yFor(interpolate(tops[i], pos))
+			fill := c
+			if !stacked {
+				fill = blend(c, opts.Background, 0.35)
+			}
+			vline(img, x, top, bottom, fill)
+			bottom = top
+
+			if !stacked && i == 0 {
+				if prevLine < 0 {
+					prevLine = top
+				}
+				vline(img, x, min(prevLine, top)-1, max(prevLine, top)+1, c)
+				prevLine = top
+			}
+		}
+	}
+
+	// Axes
+	hline(img, plot.Min.X, plot.Max.X, plot.Max.Y, opts.Foreground)
+	vline(img, plot.Min.X, plot.Min.Y, plot.Max.Y, opts.Foreground)
+
+	first := dates[0].String()
+	last := dates[len(dates)-1].String()
+	text(img, plot.Min.X, plot.Max.Y+18, first, opts.Foreground)
+	if len(dates) > 1 {
+		text(img, plot.Max.X-len(last)*7, plot.Max.Y+18, last, opts.Foreground)
+	}
+
+	if opts.Title != "" {
+		text(img, marginLeft, marginTop-12, opts.Title, opts.Foreground)
+	}
+
+	if stacked {
+		for i := len(series) - 1; i >= 0; i-- {
+			y := marginTop + (len(series)-1-i)*18
+			c := opts.Palette[i%len(opts.Palette)]
+			draw.Draw(img, image.Rect(plot.Max.X+16, y, plot.Max.X+26, y+10), &image.Uniform{c}, image.Point{}, draw.Src)
+			text(img, plot.Max.X+32, y+10, series[i].Name, opts.Foreground)
+		}
+	}
+
+	return img
+}
+
+// align merges the dates of all series and returns the values of each series per date.
+func align(series []Series) ([]godestats.Date, [][]godestats.XP) {
+	index := make(map[godestats.Date]int)
+	var dates []godestats.Date
+	for _, s := range series {
+		for _, p := range s.Points {
+			if _, ok := index[p.Date]; !ok {
+				index[p.Date] = 0
+				dates = append(dates, p.Date)
+			}
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	for i, d := range dates {
+		index[d] = i
+	}
+
+	values := make([][]godestats.XP, len(series))
+	for i, s := range series {
+		values[i] = make([]godestats.XP, len(dates))
+		for _, p := range s.Points {
+			values[i][index[p.Date]] += p.XP
+		}
+	}
+
+	return dates, values
+}
+
+// interpolate returns the linearly interpolated value at a fractional index.
+func interpolate(values []float64, pos float64) float64 {
+	i := int(pos)
+	if i >= len(values)-1 {
+		return values[len(values)-1]
+	}
+	frac := pos - float64(i)
+	return values[i]*(1-frac) + values[i+1]*frac
+}
+
+// blend mixes a color with the background, with alpha being the weight of c.
+func blend(c, bg color.RGBA, alpha float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a)*alpha + float64(b)*(1-alpha)))
+	}
+	return color.RGBA{mix(c.R, bg.R), mix(c.G, bg.G), mix(c.B, bg.B), 0xff}
+}
+
+// hline draws a horizontal line from x1 to x2 (exclusive).
+func hline(img *image.RGBA, x1, x2, y int, c color.RGBA) {
+	for x := x1; x < x2; x++ {
+		img.SetRGBA(x, y, c)
+	}
+}
+
+// vline draws a vertical line from y1 to y2 (exclusive).
+func vline(img *image.RGBA, x, y1, y2 int, c color.RGBA) {
+	for y := y1; y < y2; y++ {
+		img.SetRGBA(x, y, c)
+	}
+}
+
+// text draws a string with its baseline at y.
+func text(img *image.RGBA, x, y int, s string, c color.RGBA) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{c},
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
@@ -0,0 +1,126 @@
+package charts
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"slices"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func day(d int) godestats.Date {
+	return godestats.NewDate(2023, 6, d)
+}
+
+func TestXPOverTimePNG(t *testing.T) {
+	points := []godestats.DateXP{{Date: day(1), XP: 100}, {Date: day(2), XP: 250}, {Date: day(3), XP: 400}}
+
+	var buf bytes.Buffer
+	if err := XPOverTimePNG(&buf, points, Options{Width: 320, Height: 200, Title: "XP"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 320 || b.Dy() != 200 {
+		t.Errorf("Expected 320x200 image, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	// The area below the last point is filled, the area above the peak is background.
+	plotted := img.At(320-marginRight-2, 200-marginBottom-2)
+	if plotted == (Options{}).withDefaults().Background {
+		t.Error("Expected filled area below the line")
+	}
+
+	if err := XPOverTimePNG(&buf, nil, Options{}); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+func TestStackedAreaPNG(t *testing.T) {
+	series := []Series{
+		{Name: "Go", Points: []godestats.DateXP{{Date: day(1), XP: 100}, {Date: day(2), XP: 200}}},
+		{Name: "Rust", Points: []godestats.DateXP{{Date: day(2), XP: 50}}},
+	}
+
+	var buf bytes.Buffer
+	if err := StackedAreaPNG(&buf, series, Options{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != DefaultWidth || b.Dy() != DefaultHeight {
+		t.Errorf("Expected default size, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	if err := StackedAreaPNG(&buf, nil, Options{}); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+func TestLanguagePalette(t *testing.T) {
+	palette := LanguagePalette([]Series{{Name: "Go"}, {Name: "My DSL"}, {Name: "rust"}})
+
+	expected := []color.RGBA{{0x00, 0xad, 0xd8, 0xff}, DefaultPalette[1], {0xde, 0xa5, 0x84, 0xff}}
+	if !slices.Equal(palette, expected) {
+		t.Errorf("Expected %v, got %v", expected, palette)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	series := []Series{
+		{Points: []godestats.DateXP{{Date: day(3), XP: 30}, {Date: day(1), XP: 10}}},
+		{Points: []godestats.DateXP{{Date: day(2), XP: 5}}},
+	}
+
+	dates, values := align(series)
+	if len(dates) != 3 || dates[0] != day(1) || dates[2] != day(3) {
+		t.Fatalf("Expected 3 sorted dates, got %v", dates)
+	}
+
+	expected := [][]godestats.XP{{10, 0, 30}, {0, 5, 0}}
+	for i := range expected {
+		for j := range expected[i] {
+			if values[i][j] != expected[i][j] {
+				t.Errorf("Expected values[%d][%d] = %d, got %d", i, j, expected[i][j], values[i][j])
+			}
+		}
+	}
+}
+
+func TestCumulative(t *testing.T) {
+	daily := []godestats.DateXP{{Date: day(1), XP: 10}, {Date: day(2), XP: 20}, {Date: day(3), XP: 30}}
+	result := Cumulative(daily, 1000)
+
+	for i, expected := range []godestats.XP{950, 970, 1000} {
+		if result[i].XP != expected {
+			t.Errorf("Expected entry %d to be %d, got %d", i, expected, result[i].XP)
+		}
+	}
+}
+
+func TestFromHistory(t *testing.T) {
+	s := &history.Series{Points: []history.Point{
+		{Time: day(1).Time(time.UTC), TotalXP: 100, Languages: map[string]godestats.XP{"Go": 100}},
+		{Time: day(2).Time(time.UTC), TotalXP: 150, Languages: map[string]godestats.XP{"Go": 150}},
+		{Time: day(2).Time(time.UTC).Add(12 * time.Hour), TotalXP: 180, Languages: map[string]godestats.XP{"Go": 150, "C": 30}},
+	}}
+
+	total, languages := FromHistory(s)
+	if len(total) != 2 || total[1].Date != day(2) || total[1].XP != 180 {
+		t.Errorf("Expected one point per day with the last totals, got %v", total)
+	}
+	if len(languages) != 2 || languages[0].Name != "C" || len(languages[1].Points) != 2 {
+		t.Errorf("Unexpected language series: %v", languages)
+	}
+}
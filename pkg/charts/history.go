@@ -0,0 +1,81 @@
+package charts
+
+import (
+	"sort"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// FromHistory converts a queried history series into the total XP over time
+// and one series of total XP per language, ordered by language name.
+// Points are taken per day of their time; of several points on the same day,
+// the last one wins, since it holds the totals at the end of that day.
+func FromHistory(s *history.Series) ([]godestats.DateXP, []Series) {
+	total := make([]godestats.DateXP, 0, len(s.Points))
+	byLanguage := make(map[string]*Series)
+
+	for _, p := range s.Points {
+		date := godestats.DateOf(p.Time)
+		total = appendDay(total, godestats.DateXP{Date: date, XP: p.TotalXP})
+		for name, xp := range p.Languages {
+			series, ok := byLanguage[name]
+			if !ok {
+				series = &Series{Name: name}
+				byLanguage[name] = series
+			}
+			series.Points = appendDay(series.Points, godestats.DateXP{Date: date, XP: xp})
+		}
+	}
+
+	languages := make([]Series, 0, len(byLanguage))
+	for _, series := range byLanguage {
+		languages = append(languages, *series)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i].Name < languages[j].Name
+	})
+
+	return total, languages
+}
+
+// FromDayLanguages converts per-day language gains into one series of daily XP
+// per language, ordered by language name. Entries without a date are skipped.
+func FromDayLanguages(days []history.DayLanguageXP) []Series {
+	byLanguage := make(map[string]*Series)
+
+	for _, d := range days {
+		if d.Date.IsZero() {
+			continue
+		}
+		series, ok := byLanguage[d.Language]
+		if !ok {
+			series = &Series{Name: d.Language}
+			byLanguage[d.Language] = series
+		}
+		series.Points = append(series.Points, godestats.DateXP{Date: d.Date, XP: d.XP})
+	}
+
+	languages := make([]Series, 0, len(byLanguage))
+	for _, series := range byLanguage {
+		sort.Slice(series.Points, func(i, j int) bool {
+			return series.Points[i].Date.Before(series.Points[j].Date)
+		})
+		languages = append(languages, *series)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i].Name < languages[j].Name
+	})
+
+	return languages
+}
+
+// appendDay appends a point to a series ordered by date, replacing the last point
+// if it is on the same day.
+func appendDay(points []godestats.DateXP, point godestats.DateXP) []godestats.DateXP {
+	if n := len(points); n > 0 && points[n-1].Date == point.Date {
+		points[n-1] = point
+		return points
+	}
+	return append(points, point)
+}
@@ -0,0 +1,163 @@
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Style selects how LanguageTrendSVG draws the series.
+type Style int
+
+// Trend chart styles
+const (
+	// StyleLine draws one line per series.
+	StyleLine Style = iota
+	// StyleStream draws the series as a streamgraph stacked around a centered baseline.
+	StyleStream
+)
+
+// LanguageTrendSVG renders the series as an SVG line or stream chart with a legend.
+// Series are aligned by date; missing dates count as zero XP. Without a palette in
+// the options, series are colored by language (see LanguagePalette).
+func LanguageTrendSVG(w io.Writer, series []Series, style Style, opts Options) error {
+	if len(series) == 0 {
+		return ErrNoData
+	}
+	if len(opts.Palette) == 0 {
+		opts.Palette = LanguagePalette(series)
+	}
+	opts = opts.withDefaults()
+
+	dates, values := align(series)
+	plot := struct{ minX, minY, maxX, maxY float64 }{
+		marginLeft, marginTop, float64(opts.Width - marginRight - legendWidth), float64(opts.Height - marginBottom),
+	}
+
+	xFor := func(i int) float64 {
+		if len(dates) == 1 {
+			return plot.minX
+		}
+		return plot.minX + float64(i)/float64(len(dates)-1)*(plot.maxX-plot.minX)
+	}
+
+	var buf bytes.Buffer
+	fg, bg := hexColor(opts.Foreground), hexColor(opts.Background)
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`,
+		opts.Width, opts.Height, opts.Width, opts.Height)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`, bg)
+	if opts.Title != "" {
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" fill="%s" font-weight="bold">%s</text>`, marginLeft, marginTop-12, fg, html.EscapeString(opts.Title))
+	}
+
+	switch style {
+	case StyleStream:
+		writeStream(&buf, values, xFor, plot.minY, plot.maxY, opts)
+	default:
+		writeLines(&buf, values, xFor, plot.minX, plot.maxX, plot.minY, plot.maxY, opts)
+	}
+
+	fmt.Fprintf(&buf, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s"/>`, plot.minX, plot.maxY, plot.maxX, plot.maxY, fg)
+	fmt.Fprintf(&buf, `<text x="%g" y="%g" fill="%s">%s</text>`, plot.minX, plot.maxY+18, fg, dates[0])
+	if len(dates) > 1 {
+		fmt.Fprintf(&buf, `<text x="%g" y="%g" fill="%s" text-anchor="end">%s</text>`, plot.maxX, plot.maxY+18, fg, dates[len(dates)-1])
+	}
+
+	for i, s := range series {
+		y := marginTop + i*18
+		fmt.Fprintf(&buf, `<rect x="%g" y="%d" width="10" height="10" fill="%s"/>`, plot.maxX+16, y, hexColor(opts.Palette[i%len(opts.Palette)]))
+		fmt.Fprintf(&buf, `<text x="%g" y="%d" fill="%s">%s</text>`, plot.maxX+32, y+10, fg, html.EscapeString(s.Name))
+	}
+
+	buf.WriteString(`</svg>`)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+	return nil
+}
+
+// writeLines draws one polyline per series with horizontal grid lines.
+func writeLines(buf *bytes.Buffer, values [][]godestats.XP, xFor func(int) float64, minX, maxX, minY, maxY float64, opts Options) {
+	var peak godestats.XP
+	for _, v := range values {
+		for _, xp := range v {
+			peak = max(peak, xp)
+		}
+	}
+	if peak <= 0 {
+		peak = 1
+	}
+
+	grid := hexColor(blend(opts.Foreground, opts.Background, 0.15))
+	for tick := 0; tick <= yTicks; tick++ {
+		y := maxY - float64(tick)/yTicks*(maxY-minY)
+		fmt.Fprintf(buf, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s"/>`, minX, y, maxX, y, grid)
+		fmt.Fprintf(buf, `<text x="%g" y="%g" fill="%s" text-anchor="end">%s</text>`,
+			minX-8, y+4, hexColor(opts.Foreground), godestats.XP(float64(peak)*float64(tick)/yTicks).Short())
+	}
+
+	for i, v := range values {
+		points := make([]string, len(v))
+		for j, xp := range v {
+			y := maxY - float64(xp)/float64(peak)*(maxY-minY)
+			points[j] = fmt.Sprintf("%.1f,%.1f", xFor(j), y)
+		}
+		fmt.Fprintf(buf, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2" stroke-linejoin="round"/>`,
+			strings.Join(points, " "), hexColor(opts.Palette[i%len(opts.Palette)]))
+	}
+}
+
+// writeStream draws the series as stacked polygons around a centered baseline.
+func writeStream(buf *bytes.Buffer, values [][]godestats.XP, xFor func(int) float64, minY, maxY float64, opts Options) {
+	n := len(values[0])
+	totals := make([]float64, n)
+	var peak float64
+	for _, v := range values {
+		for j, xp := range v {
+			totals[j] += float64(xp)
+			peak = math.Max(peak, totals[j])
+		}
+	}
+	if peak <= 0 {
+		peak = 1
+	}
+
+	scale := (maxY - minY) / peak
+	center := (minY + maxY) / 2
+
+	// Each column starts at the bottom of the centered stack
+	bottoms := make([]float64, n)
+	for j := range bottoms {
+		bottoms[j] = center + totals[j]*scale/2
+	}
+
+	for i, v := range values {
+		tops := make([]float64, n)
+		for j, xp := range v {
+			tops[j] = bottoms[j] - float64(xp)*scale
+		}
+
+		points := make([]string, 0, 2*n)
+		for j := 0; j < n; j++ {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", xFor(j), tops[j]))
+		}
+		for j := n - 1; j >= 0; j-- {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", xFor(j), bottoms[j]))
+		}
+		fmt.Fprintf(buf, `<polygon points="%s" fill="%s"/>`, strings.Join(points, " "), hexColor(opts.Palette[i%len(opts.Palette)]))
+
+		bottoms = tops
+	}
+}
+
+// hexColor formats a color as #rrggbb.
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,70 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func TestLanguageTrendSVG(t *testing.T) {
+	series := FromDayLanguages([]history.DayLanguageXP{
+		{Date: godestats.NewDate(2023, 6, 1), Language: "Go", XP: 100},
+		{Date: godestats.NewDate(2023, 6, 2), Language: "Go", XP: 300},
+		{Date: godestats.NewDate(2023, 6, 2), Language: "C<++>", XP: 50},
+		{Language: "Go", XP: 999},
+	})
+
+	tests := []struct {
+		name     string
+		style    Style
+		contains string
+	}{
+		{"line", StyleLine, "<polyline"},
+		{"stream", StyleStream, "<polygon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := LanguageTrendSVG(&buf, series, tt.style, Options{Width: 600, Height: 300, Title: "Trend"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			svg := buf.String()
+			for _, expected := range []string{tt.contains, `width="600"`, "C&lt;++&gt;", "2023-06-02", "Trend"} {
+				if !strings.Contains(svg, expected) {
+					t.Errorf("Expected SVG to contain '%s'", expected)
+				}
+			}
+
+			if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+				t.Errorf("Expected well-formed SVG: %v", err)
+			}
+		})
+	}
+
+	if err := LanguageTrendSVG(&bytes.Buffer{}, nil, StyleLine, Options{}); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+func TestFromDayLanguages(t *testing.T) {
+	series := FromDayLanguages([]history.DayLanguageXP{
+		{Date: godestats.NewDate(2023, 6, 2), Language: "Go", XP: 20},
+		{Date: godestats.NewDate(2023, 6, 1), Language: "Go", XP: 10},
+		{Date: godestats.NewDate(2023, 6, 1), Language: "C", XP: 5},
+	})
+
+	if len(series) != 2 || series[0].Name != "C" || series[1].Name != "Go" {
+		t.Fatalf("Expected C and Go series, got %v", series)
+	}
+	if points := series[1].Points; len(points) != 2 || points[0].XP != 10 || points[1].XP != godestats.XP(20) {
+		t.Errorf("Expected sorted Go points, got %v", points)
+	}
+}
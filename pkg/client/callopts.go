@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// RequestIDHeader is the request header carrying the request ID of the call.
+const RequestIDHeader = "X-Request-ID"
+
+// callKey is the context key of the resolved options of a call.
+type callKey struct{}
+
+// callContext resolves the options of a call, i.e. the options passed to it on
+// top of the defaults in the context (see godestats.ResolveCallOptions), assigns
+// the call a request ID unless the caller supplied one and applies the timeout.
+// The client's timeout still applies on top.
+func callContext(ctx context.Context, opts []godestats.CallOption) (context.Context, context.CancelFunc) {
+	options := godestats.ResolveCallOptions(ctx, opts...)
+	if options.RequestID == "" {
+		options.RequestID = uuid.NewString()
+	}
+	ctx = context.WithValue(ctx, callKey{}, options)
+	if options.Timeout > 0 {
+		return context.WithTimeout(ctx, options.Timeout)
+	}
+	return ctx, func() {}
+}
+
+// callOptions returns the options of the call made with the context.
+func callOptions(ctx context.Context) godestats.CallOptions {
+	options, _ := ctx.Value(callKey{}).(godestats.CallOptions)
+	return options
+}
+
+// requestID returns the request ID of the call made with the context, or a new
+// one for requests outside of calls, such as capability probes.
+func requestID(ctx context.Context) string {
+	if id := callOptions(ctx).RequestID; id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// callError annotates the error of a call with its request ID and redacts the
+// API token from it.
+func (c *Client) callError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if id := callOptions(ctx).RequestID; id != "" {
+		err = &godestats.RequestError{RequestID: id, Err: err}
+	}
+	return c.redact(err)
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestClient_CallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte(`{"user": "alice", "total_xp": 1, "new_xp": 0, "machines": {}, "languages": {}, "dates": {}}`))
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL)
+
+	start := time.Now()
+	_, err := c.GetUserProfile(context.Background(), "alice", godestats.WithCallTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the call to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the call to end after the call timeout, took %s", elapsed)
+	}
+}
+
+func TestClient_RequestID(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var observed []string
+	c := NewWithBaseURL("test-token", server.URL, WithRetries(1), WithPulseRetries(1), WithResponseObserver(func(info ResponseInfo) {
+		observed = append(observed, info.RequestID)
+	})).(*Client)
+	c.retryDelay = time.Millisecond
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}
+
+	// Generated IDs are shared by the retries of a call and returned in its error
+	err := c.SendPulse(context.Background(), pulse)
+	id, ok := godestats.RequestID(err)
+	if !ok || len(id) != 36 {
+		t.Fatalf("Expected a UUID request ID in the error, got %q (%v)", id, err)
+	}
+	if len(ids) != 2 || ids[0] != id || ids[1] != id {
+		t.Errorf("Expected both attempts to send %s, got %q", id, ids)
+	}
+	if len(observed) != 2 || observed[0] != id {
+		t.Errorf("Expected observed responses to carry %s, got %q", id, observed)
+	}
+	if !godestats.IsTemporary(err) {
+		t.Errorf("Expected the error to stay classifiable, got %v", err)
+	}
+
+	// Every call gets a new ID unless the caller supplies one
+	c.GetUserProfile(context.Background(), "alice")
+	if ids[2] == id {
+		t.Errorf("Expected a new request ID per call, got %s again", id)
+	}
+
+	_, err = c.GetUserProfile(context.Background(), "alice", godestats.WithRequestID("trace-42"))
+	if got, _ := godestats.RequestID(err); got != "trace-42" || ids[len(ids)-1] != "trace-42" {
+		t.Errorf("Expected the caller's request ID, got %q in the error and %q in the header", got, ids[len(ids)-1])
+	}
+
+	// Options passed to the call take precedence over defaults in the context
+	ctx := godestats.WithCallOptions(context.Background(), godestats.WithRequestID("default"))
+	c.GetUserProfile(ctx, "alice")
+	if got := ids[len(ids)-1]; got != "default" {
+		t.Errorf("Expected the request ID of the context, got %q", got)
+	}
+	c.GetUserProfile(ctx, "alice", godestats.WithRequestID("trace-43"))
+	if got := ids[len(ids)-1]; got != "trace-43" {
+		t.Errorf("Expected the request ID passed to the call, got %q", got)
+	}
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// VersionHeader is the response header in which instances may announce their version.
+const VersionHeader = "X-Code-Stats-Version"
+
+// Paths probed to detect optional features
+const (
+	graphQLPath     = "/profile-graph"
+	liveUpdatesPath = "/live_update_socket/websocket?vsn=1.0.0"
+)
+
+// graphQLProbe is the smallest valid GraphQL query.
+const graphQLProbe = `{"query":"{__typename}"}`
+
+// WithCapabilities sets the features of the instance instead of detecting them,
+// e.g. for a self-hosted instance known to lack some endpoints.
+func WithCapabilities(caps godestats.Capabilities) Option {
+	return func(c *Client) {
+		c.caps = &caps
+	}
+}
+
+// Capabilities returns the features supported by the instance. They are detected
+// by probing the optional endpoints on the first call and cached afterwards; failed
+// detections are not cached. Version-dependent features are not probed if the
+// instance announces a version older than their minimum, e.g. MinGraphQLVersion.
+// Once known, the client no longer falls back to the authenticated profile
+// endpoint if the instance lacks it, and GetMyProfile returns ErrUnsupported.
+//
+// Concurrent calls share one detection, which runs without holding the lock, so
+// that requests and ServerVersion don't wait for the probes.
+func (c *Client) Capabilities(ctx context.Context) (godestats.Capabilities, error) {
+	c.capsMu.Lock()
+	if c.caps != nil {
+		defer c.capsMu.Unlock()
+		return *c.caps, nil
+	}
+	call := c.capsProbe
+	if call == nil {
+		call = &capsCall{done: make(chan struct{})}
+		c.capsProbe = call
+		go c.detect(godestats.WithoutCallOptions(context.WithoutCancel(ctx)), call)
+	}
+	c.capsMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return godestats.Capabilities{}, ctx.Err()
+	case <-call.done:
+	}
+	return call.caps, call.err
+}
+
+// capsCall is an in-flight detection of the capabilities shared by all waiting callers.
+type capsCall struct {
+	done chan struct{}
+	caps godestats.Capabilities
+	err  error
+}
+
+// detect probes the optional endpoints, publishes the capabilities if the
+// detection succeeded and releases all waiting callers.
+func (c *Client) detect(ctx context.Context, call *capsCall) {
+	call.caps, call.err = c.probeCapabilities(ctx)
+
+	c.capsMu.Lock()
+	if call.err == nil {
+		c.caps = &call.caps
+	}
+	c.capsProbe = nil
+	c.capsMu.Unlock()
+
+	close(call.done)
+}
+
+// probeCapabilities detects the features of the instance.
+func (c *Client) probeCapabilities(ctx context.Context) (godestats.Capabilities, error) {
+	var caps godestats.Capabilities
+	probes := []struct {
+		method, path, body string
+		minVersion         string
+		supported          *bool
+	}{
+		{http.MethodGet, c.apiPrefix + "/my/profile", "", "", &caps.MyProfile},
+		{http.MethodPost, graphQLPath, graphQLProbe, MinGraphQLVersion, &caps.GraphQL},
+		{http.MethodGet, liveUpdatesPath, "", MinLiveUpdatesVersion, &caps.LiveUpdates},
+	}
+
+	for _, p := range probes {
+		// Skip features that the announced version predates
+		if versionBefore(c.announcedVersion(), p.minVersion) {
+			continue
+		}
+		supported, err := c.probe(ctx, p.method, p.path, p.body)
+		if err != nil {
+			return godestats.Capabilities{}, c.redact(err)
+		}
+		*p.supported = supported
+	}
+	caps.Version = c.announcedVersion()
+	return caps, nil
+}
+
+// supports reports whether the instance has the feature, assuming it does as long
+// as the capabilities have not been detected.
+func (c *Client) supports(feature func(godestats.Capabilities) bool) bool {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	return c.caps == nil || feature(*c.caps)
+}
+
+// probe requests an endpoint and reports whether it exists. Any status other than
+// not found or not implemented counts as existing, since probes are neither
+// authenticated nor proper requests for the endpoint.
+func (c *Client) probe(ctx context.Context, method, path, body string) (bool, error) {
+	endpoint := c.baseURL + path
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, strings.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if strings.HasPrefix(path, c.apiPrefix+"/my/") {
+		if token, err := c.token(ctx); err == nil && token != "" {
+			req.Header.Set(AuthHeader, token)
+		}
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return false, godestats.NewNetworkError(method+" request", endpoint, err)
+	}
+	defer closeBody(resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return false, rateLimitError(resp)
+	case resp.StatusCode == http.StatusNotImplemented:
+		return false, nil
+	case resp.StatusCode >= 500:
+		return false, c.apiError(resp, endpoint)
+	}
+
+	supported := resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusMethodNotAllowed
+	return supported, nil
+}
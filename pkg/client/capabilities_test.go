@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestClient_Capabilities(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set(VersionHeader, "2.1.0")
+		switch r.URL.Path {
+		case "/api/my/profile":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/profile-graph":
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected GraphQL probe to POST, got %s", r.Method)
+			}
+			w.Write([]byte(`{"data": {"__typename": "RootQueryType"}}`))
+		case "/live_update_socket/websocket":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL).(*Client)
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := godestats.Capabilities{Version: "2.1.0", MyProfile: true, GraphQL: true, LiveUpdates: true}
+	if caps != expected {
+		t.Errorf("Expected %+v, got %+v", expected, caps)
+	}
+
+	// Capabilities are detected only once
+	probes := requests.Load()
+	c.Capabilities(context.Background())
+	if requests.Load() != probes {
+		t.Errorf("Expected cached capabilities, got %d more requests", requests.Load()-probes)
+	}
+
+	var _ godestats.CapabilitiesClient = c
+}
+
+func TestClient_CapabilitiesOldInstance(t *testing.T) {
+	var ownProfileRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/my/profile" {
+			ownProfileRequests.Add(1)
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("test-token", server.URL).(*Client)
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if caps != (godestats.Capabilities{}) {
+		t.Errorf("Expected no optional features, got %+v", caps)
+	}
+
+	if _, err := c.GetMyProfile(context.Background()); !errors.Is(err, godestats.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+
+	// The private profile fallback is skipped once the endpoint is known to be missing
+	if _, err := c.GetUserProfile(context.Background(), "owner"); !godestats.IsUserNotFound(err) {
+		t.Errorf("Expected user not found error, got %v", err)
+	}
+	if n := ownProfileRequests.Load(); n != 1 {
+		t.Errorf("Expected only the probe to request the own profile, got %d requests", n)
+	}
+}
+
+func TestClient_CapabilitiesErrors(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL).(*Client)
+	if _, err := c.Capabilities(context.Background()); !godestats.IsTemporary(err) {
+		t.Fatalf("Expected a temporary error, got %v", err)
+	}
+
+	// Failed detections are retried on the next call
+	failing.Store(false)
+	caps, err := c.Capabilities(context.Background())
+	if err != nil || caps.GraphQL {
+		t.Errorf("Expected detection to succeed without GraphQL, got %+v (err=%v)", caps, err)
+	}
+}
+
+func TestClient_CapabilitiesConcurrent(t *testing.T) {
+	var probes atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		<-release
+		w.Header().Set(VersionHeader, "2.1.0")
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL).(*Client)
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Capabilities(context.Background()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	for probes.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The lock is not held while probing
+	done := make(chan struct{})
+	go func() {
+		c.ServerVersion()
+		c.supports(func(godestats.Capabilities) bool { return true })
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected ServerVersion not to wait for the probes")
+	}
+
+	// A caller that gives up stops waiting without affecting the detection
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Capabilities(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if n := probes.Load(); n != 3 {
+		t.Errorf("Expected one detection with 3 probes, got %d requests", n)
+	}
+	if version := c.ServerVersion(); version != "2.1.0" {
+		t.Errorf("Expected version 2.1.0, got %q", version)
+	}
+}
+
+func TestWithCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	preset := godestats.Capabilities{Version: "1.0.0", MyProfile: true}
+	c := NewWithBaseURL("", server.URL, WithCapabilities(preset)).(*Client)
+
+	caps, err := c.Capabilities(context.Background())
+	if err != nil || caps != preset {
+		t.Errorf("Expected preset capabilities %+v, got %+v (err=%v)", preset, caps, err)
+	}
+}
@@ -3,20 +3,25 @@ package client
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/secrets"
+	"github.com/Yeti47/gode-stats/pkg/signing"
 )
 
 const (
 	// DefaultBaseURL is the default base URL for the Code::Stats API.
 	DefaultBaseURL = "https://codestats.net"
-	// APIPrefix is the prefix for all API endpoints.
+	// APIPrefix is the default prefix for all API endpoints, see WithAPIPrefix.
 	APIPrefix = "/api"
 	// AuthHeader is the HTTP header used for API token authentication.
 	AuthHeader = "X-API-Token"
@@ -26,58 +31,157 @@ const (
 
 // Client implements the CodeStatsClient interface for interacting with the Code::Stats API.
 type Client struct {
-	baseURL    string
-	apiToken   string
-	httpClient *http.Client
+	baseURL         string
+	apiPrefix       string
+	apiToken        string
+	tokens          godestats.TokenProvider
+	httpClient      *http.Client
+	preserveUnknown bool
+	certFile        string
+	keyFile         string
+	signing         *signing.Transport
+	retries         int
+	pulseRetries    int
+	encoder         Encoder
+	retryDelay      time.Duration
+	observe         func(ResponseInfo)
+
+	tokenMu   sync.Mutex
+	lastToken string
+
+	capsMu    sync.Mutex
+	caps      *godestats.Capabilities
+	capsProbe *capsCall
+
+	versionMu     sync.Mutex
+	serverVersion string
 }
 
 // New creates a new Code::Stats API client with the provided API token.
-func New(apiToken string) godestats.CodeStatsClient {
-	return NewWithBaseURL(apiToken, DefaultBaseURL)
-}
-
-// NewAnonymous creates a new anonymous Code::Stats API client for read-only operations.
-// This client can only retrieve public user profiles and cannot send pulses.
-func NewAnonymous() godestats.CodeStatsClient {
-	return NewWithBaseURL("", DefaultBaseURL)
+func New(apiToken string, opts ...Option) godestats.CodeStatsClient {
+	return NewWithBaseURL(apiToken, DefaultBaseURL, opts...)
 }
 
 // NewWithBaseURL creates a new Code::Stats API client with a custom base URL.
 // This is useful for testing against custom instances or local development servers.
-func NewWithBaseURL(apiToken, baseURL string) godestats.CodeStatsClient {
-	return &Client{
-		baseURL:  baseURL,
-		apiToken: apiToken,
+func NewWithBaseURL(apiToken, baseURL string, opts ...Option) godestats.CodeStatsClient {
+	c := &Client{
+		baseURL:      baseURL,
+		apiPrefix:    APIPrefix,
+		apiToken:     apiToken,
+		retries:      DefaultRetries,
+		pulseRetries: DefaultPulseRetries,
+		retryDelay:   DefaultRetryDelay,
+		encoder:      DefaultEncoder,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   DefaultTimeout,
+			Transport: defaultTransport,
 		},
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.certFile != "" {
+		c.httpClient = withClientCertificate(c.httpClient, c.certFile, c.keyFile)
+	}
+	if c.signing != nil {
+		c.httpClient = withRequestSigning(c.httpClient, *c.signing)
+	}
+
+	return c
 }
 
 // GetUserProfile retrieves the public profile information for the specified user.
-func (c *Client) GetUserProfile(ctx context.Context, username string) (*godestats.UserProfile, error) {
+// If the profile is private and the client has an API token, the authenticated
+// profile endpoint is tried instead, so token owners can access their own private data.
+func (c *Client) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	ctx, cancel := callContext(ctx, opts)
+	defer cancel()
+
+	profile, err := c.getProfile(ctx, username, godestats.AllFields)
+	return profile, c.callError(ctx, err)
+}
+
+// GetUserProfilePartial retrieves a profile containing only the selected sections.
+// The API always returns the full profile, but unselected sections are skipped while
+// decoding, which saves allocations when e.g. only the level is needed.
+// Unknown fields are not preserved in partial profiles.
+func (c *Client) GetUserProfilePartial(ctx context.Context, username string, fields godestats.ProfileFields, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	ctx, cancel := callContext(ctx, opts)
+	defer cancel()
+
+	profile, err := c.getProfile(ctx, username, fields)
+	return profile, c.callError(ctx, err)
+}
+
+// GetMyProfile retrieves the profile of the owner of the API token from the
+// authenticated endpoint, including the machines' last activity and token IDs.
+func (c *Client) GetMyProfile(ctx context.Context, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	ctx, cancel := callContext(ctx, opts)
+	defer cancel()
+
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, godestats.ErrUnauthorized
+	}
+	if !c.supports(func(caps godestats.Capabilities) bool { return caps.MyProfile }) {
+		return nil, fmt.Errorf("%w: authenticated profile endpoint", godestats.ErrUnsupported)
+	}
+	profile, err := c.fetchProfile(ctx, fmt.Sprintf("%s%s/my/profile", c.baseURL, c.apiPrefix), token, godestats.AllFields)
+	return profile, c.callError(ctx, err)
+}
+
+// getProfile implements GetUserProfile and GetUserProfilePartial.
+func (c *Client) getProfile(ctx context.Context, username string, fields godestats.ProfileFields) (*godestats.UserProfile, error) {
 	if username == "" {
 		return nil, godestats.ErrEmptyUsername
 	}
 
 	// Construct the API URL
-	endpoint := fmt.Sprintf("%s%s/users/%s", c.baseURL, APIPrefix, url.PathEscape(username))
+	endpoint := fmt.Sprintf("%s%s/users/%s", c.baseURL, c.apiPrefix, url.PathEscape(username))
 
+	profile, err := c.fetchProfile(ctx, endpoint, "", fields)
+	if err == nil || !errors.Is(err, godestats.ErrUserNotFound) {
+		return profile, err
+	}
+	token, tokenErr := c.token(ctx)
+	if tokenErr != nil || token == "" || !c.supports(func(caps godestats.Capabilities) bool { return caps.MyProfile }) {
+		return nil, err
+	}
+
+	// Fall back to the authenticated endpoint for the token owner's private profile
+	ownProfile, ownErr := c.fetchProfile(ctx, fmt.Sprintf("%s%s/my/profile", c.baseURL, c.apiPrefix), token, fields)
+	if ownErr != nil || !strings.EqualFold(ownProfile.User, username) {
+		return nil, err
+	}
+
+	return ownProfile, nil
+}
+
+// fetchProfile retrieves and decodes the selected sections of a profile from the
+// given endpoint, sending the API token unless it is empty.
+func (c *Client) fetchProfile(ctx context.Context, endpoint, token string, fields godestats.ProfileFields) (*godestats.UserProfile, error) {
 	// Create the request
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", UserAgent)
 	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set(AuthHeader, token)
+	}
 
 	// Execute the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, godestats.NewNetworkError("GET request", endpoint, err)
 	}
-	defer resp.Body.Close()
+	defer closeBody(resp.Body)
 
 	// Handle HTTP errors
 	if resp.StatusCode == http.StatusNotFound {
@@ -89,96 +193,224 @@ func (c *Client) GetUserProfile(ctx context.Context, username string) (*godestat
 	}
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return nil, godestats.ErrRateLimited
+		return nil, rateLimitError(resp)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-
-		// Try to parse error message from JSON
-		var errorResp struct {
-			Error string `json:"error"`
-		}
-		message := string(body)
-		if json.Unmarshal(body, &errorResp) == nil && errorResp.Error != "" {
-			message = errorResp.Error
-		}
-
-		return nil, godestats.NewAPIError(resp.StatusCode, message, endpoint)
+		return nil, c.apiError(resp, endpoint)
 	}
 
 	// Parse the response
-	var profile godestats.UserProfile
-	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, godestats.NewNetworkError("reading response", endpoint, err)
+	}
+
+	var profile *godestats.UserProfile
+	if fields == godestats.AllFields {
+		profile, err = godestats.UnmarshalProfile(body, c.preserveUnknown)
+	} else {
+		profile, err = godestats.UnmarshalProfileFields(body, fields)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("%w: %v", godestats.ErrInvalidResponse, err)
 	}
+	profile.Recent = godestats.NewRecentPeriod(time.Now())
 
-	return &profile, nil
+	return profile, nil
 }
 
 // SendPulse submits a pulse (collection of XPs for different languages) to the API.
-func (c *Client) SendPulse(ctx context.Context, pulse godestats.Pulse) error {
-	if c.apiToken == "" {
-		return godestats.ErrUnauthorized
+// Pulses carry an idempotency key and are only retried after temporary failures
+// if enabled with WithPulseRetries.
+func (c *Client) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	_, err := c.SendPulseResult(ctx, pulse, opts...)
+	return err
+}
+
+// SendPulseResult submits a pulse like SendPulse and returns the response of
+// the server. Responses that can't be decoded yield an empty result, since the
+// pulse was accepted nonetheless.
+func (c *Client) SendPulseResult(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) (*godestats.PulseResult, error) {
+	ctx, cancel := callContext(ctx, opts)
+	defer cancel()
+
+	result, err := c.sendPulse(ctx, pulse)
+	return result, c.callError(ctx, err)
+}
+
+// sendPulse implements SendPulseResult.
+func (c *Client) sendPulse(ctx context.Context, pulse godestats.Pulse) (*godestats.PulseResult, error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, godestats.ErrUnauthorized
 	}
 
 	// Validate pulse timestamp (must not be older than a week)
 	weekAgo := time.Now().AddDate(0, 0, -7)
 	if pulse.CodedAt.Before(weekAgo) {
-		return godestats.ErrPulseTimestampTooOld
+		return nil, godestats.ErrPulseTimestampTooOld
 	}
 
-	// Construct the API URL
-	endpoint := fmt.Sprintf("%s%s/my/pulses", c.baseURL, APIPrefix)
-
-	// Serialize the pulse to JSON
-	pulseData, err := json.Marshal(pulse)
+	key, err := idempotencyKey(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to serialize pulse: %w", err)
+		return nil, err
 	}
 
+	var result *godestats.PulseResult
+	err = c.retry(ctx, 1+c.pulseRetries, func() (err error) {
+		result, err = c.sendPulseOnce(ctx, pulse, token, key)
+		return err
+	})
+	return result, err
+}
+
+// sendPulseOnce makes a single attempt to send a pulse.
+func (c *Client) sendPulseOnce(ctx context.Context, pulse godestats.Pulse, token, key string) (*godestats.PulseResult, error) {
+	// Construct the API URL
+	endpoint := fmt.Sprintf("%s%s/my/pulses", c.baseURL, c.apiPrefix)
+
+	// Serialize the pulse to JSON in a pooled buffer. The request gets its own copy
+	// of the bytes, so the transport can replay the body for redirects and HTTP/2
+	// retries through req.GetBody after the buffer went back to the pool.
+	buf := getBuffer()
+	if err := c.encoder.Encode(buf, pulse); err != nil {
+		putBuffer(buf)
+		return nil, fmt.Errorf("failed to serialize pulse: %w", err)
+	}
+	body := bytes.Clone(buf.Bytes())
+	putBuffer(buf)
+
 	// Create the request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(pulseData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", UserAgent)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set(AuthHeader, c.apiToken)
+	req.Header.Set(AuthHeader, token)
+	req.Header.Set(IdempotencyKeyHeader, key)
 
 	// Execute the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
-		return godestats.NewNetworkError("POST request", endpoint, err)
+		return nil, godestats.NewNetworkError("POST request", endpoint, err)
 	}
-	defer resp.Body.Close()
+	defer closeBody(resp.Body)
 
 	// Handle HTTP errors
 	if resp.StatusCode == http.StatusCreated {
-		return nil // Success
+		return pulseResult(resp.Body), nil // Success
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return godestats.ErrUnauthorized
+		return nil, godestats.ErrUnauthorized
 	}
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return godestats.ErrRateLimited
+		return nil, rateLimitError(resp)
 	}
 
-	// Read error response
-	body, _ := io.ReadAll(resp.Body)
+	return nil, c.apiError(resp, endpoint)
+}
+
+// pulseResult decodes the response to an accepted pulse, returning an empty
+// result if it is too large or can't be decoded.
+func pulseResult(body io.Reader) *godestats.PulseResult {
+	buf := getBuffer()
+	defer putBuffer(buf)
 
-	// Try to parse error message from JSON
-	var errorResp struct {
-		Error string `json:"error"`
+	if _, err := buf.ReadFrom(io.LimitReader(body, maxPulseResult+1)); err != nil || buf.Len() > maxPulseResult {
+		return &godestats.PulseResult{}
+	}
+	result, err := godestats.ParsePulseResult(buf.Bytes())
+	if err != nil {
+		return &godestats.PulseResult{}
 	}
-	message := string(body)
-	if json.Unmarshal(body, &errorResp) == nil && errorResp.Error != "" {
-		message = errorResp.Error
+	return result
+}
+
+// send executes a request with the headers common to all requests, including the
+// request ID of the call, records the version announced by the instance in the
+// response and reports the response to the observer, if any.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set(APIVersionHeader, APIVersion)
+	req.Header.Set(RequestIDHeader, requestID(req.Context()))
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.observeResponse(req, start, resp, err)
+	if err != nil {
+		return nil, err
+	}
+	c.setServerVersion(resp.Header.Get(VersionHeader))
+	return resp, nil
+}
+
+// token returns the API token to authenticate with, or "" for anonymous clients.
+// Providers without a token fail with godestats.ErrUnauthorized.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.tokens == nil {
+		return c.apiToken, nil
+	}
+
+	token, err := c.tokens.Token(ctx)
+	if errors.Is(err, secrets.ErrNoToken) {
+		return "", fmt.Errorf("%w: %w", godestats.ErrUnauthorized, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get API token: %w", err)
+	}
+	c.tokenMu.Lock()
+	c.lastToken = token
+	c.tokenMu.Unlock()
+	return token, nil
+}
+
+// secrets returns the tokens that must not appear in errors.
+func (c *Client) secrets() []string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return []string{c.apiToken, c.lastToken}
+}
+
+// redact removes the API token from the message of an error, in case the server
+// or a proxy echoed it back.
+func (c *Client) redact(err error) error {
+	return godestats.RedactError(err, c.secrets()...)
+}
+
+// String describes the client without revealing its API token, so that clients
+// can be logged or dumped with fmt.
+func (c *Client) String() string {
+	token := "none"
+	if c.apiToken != "" || c.tokens != nil {
+		token = godestats.Redacted
+	}
+	return fmt.Sprintf("client.Client{baseURL: %q, apiToken: %s}", godestats.RedactURL(c.baseURL), token)
+}
+
+// GoString implements fmt.GoStringer like String, so that %#v doesn't reveal the API token.
+func (c *Client) GoString() string {
+	return c.String()
+}
+
+// rateLimitError builds the error for a rate-limited response, including the delay
+// from its Retry-After header, which holds either seconds or an HTTP date.
+func rateLimitError(resp *http.Response) error {
+	err := &godestats.RateLimitError{}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if seconds, parseErr := strconv.Atoi(value); parseErr == nil && seconds > 0 {
+		err.RetryAfter = time.Duration(seconds) * time.Second
+	} else if at, parseErr := http.ParseTime(value); parseErr == nil {
+		err.RetryAfter = max(time.Until(at), 0)
 	}
 
-	return godestats.NewAPIError(resp.StatusCode, message, endpoint)
+	return err
 }
@@ -2,9 +2,13 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -69,6 +73,9 @@ func TestClient_GetUserProfile_Success(t *testing.T) {
 	if profile.NewXP != 50 {
 		t.Errorf("Expected new XP 50, got %d", profile.NewXP)
 	}
+	if profile.Recent.Duration != godestats.RecentWindow {
+		t.Errorf("Expected recent period of %v, got %v", godestats.RecentWindow, profile.Recent.Duration)
+	}
 }
 
 func TestClient_GetUserProfile_NotFound(t *testing.T) {
@@ -145,6 +152,71 @@ func TestClient_SendPulse_Success(t *testing.T) {
 	}
 }
 
+func TestClient_SendPulse_Redirect(t *testing.T) {
+	pulse := godestats.Pulse{
+		CodedAt: time.Now().Truncate(time.Second),
+		XPs:     []godestats.LanguageXP{{Language: "Go", XP: 15}},
+	}
+	expected, _ := json.Marshal(pulse)
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.URL.Path == "/api/my/pulses" {
+			http.Redirect(w, r, "/moved/my/pulses", http.StatusPermanentRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	if err := NewWithBaseURL("test-token", server.URL).SendPulse(context.Background(), pulse); err != nil {
+		t.Fatalf("Expected the redirect to be followed, got %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != string(expected) || bodies[1] != string(expected) {
+		t.Errorf("Expected the body %s to be sent twice, got %q", expected, bodies)
+	}
+}
+
+func TestClient_SendPulseResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"confirmation", `{"ok": "Great success!", "xps": [{"language": "Go", "xp": 15}]}`, "Great success!"},
+		{"empty response", ``, ""},
+		{"unexpected response", `Created`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			c := NewWithBaseURL("test-token", server.URL).(*Client)
+			pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 15}}}
+
+			result, err := c.SendPulseResult(context.Background(), pulse)
+			if err != nil {
+				t.Fatalf("Expected accepted pulses to succeed, got %v", err)
+			}
+			if result.Message != tt.expected {
+				t.Errorf("Expected message %q, got %q", tt.expected, result.Message)
+			}
+			if tt.expected != "" && (len(result.XPs) != 1 || result.XPs[0].XP != 15) {
+				t.Errorf("Expected the recorded XP, got %+v", result.XPs)
+			}
+		})
+	}
+
+	var _ godestats.PulseResultClient = &Client{}
+}
+
 func TestClient_SendPulse_NoToken(t *testing.T) {
 	client := New("")
 
@@ -204,8 +276,18 @@ func TestConstructors(t *testing.T) {
 	}
 }
 
-func TestAnonymousClient_SendPulse_ShouldFail(t *testing.T) {
-	client := NewAnonymous()
+func TestAnonymousClient_CannotSendPulse(t *testing.T) {
+	// Anonymous clients lack SendPulse at compile time
+	var anonymous any = NewAnonymous()
+	if _, ok := anonymous.(godestats.CodeStatsClient); ok {
+		t.Error("Expected the anonymous client not to implement CodeStatsClient")
+	}
+	if _, ok := anonymous.(godestats.ProfileClient); !ok {
+		t.Error("Expected the anonymous client to implement ProfileClient")
+	}
+
+	// Clients without a token still fail at runtime
+	client := New("")
 
 	pulse := godestats.Pulse{
 		CodedAt: time.Now(),
@@ -215,11 +297,163 @@ func TestAnonymousClient_SendPulse_ShouldFail(t *testing.T) {
 	}
 
 	err := client.SendPulse(context.Background(), pulse)
-	if err == nil {
-		t.Fatal("Expected error when sending pulse with anonymous client")
-	}
-
 	if !errors.Is(err, godestats.ErrUnauthorized) {
 		t.Errorf("Expected ErrUnauthorized, got: %v", err)
 	}
 }
+
+func TestClient_GetUserProfile_PrivateOwnProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/users/owner", "/api/users/stranger":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/my/profile":
+			if r.Header.Get("X-API-Token") != "test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"user": "Owner", "total_xp": 42}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithBaseURL("test-token", server.URL)
+
+	profile, err := client.GetUserProfile(context.Background(), "owner")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if profile.TotalXP != 42 {
+		t.Errorf("Expected total XP 42, got %d", profile.TotalXP)
+	}
+
+	// A private profile of another user must still be reported as not found
+	_, err = client.GetUserProfile(context.Background(), "stranger")
+	if !godestats.IsUserNotFound(err) {
+		t.Errorf("Expected user not found error, got: %v", err)
+	}
+
+	// Anonymous clients never fall back to the authenticated endpoint
+	_, err = NewWithBaseURL("", server.URL).GetUserProfile(context.Background(), "owner")
+	if !godestats.IsUserNotFound(err) {
+		t.Errorf("Expected user not found error, got: %v", err)
+	}
+}
+
+func TestClient_GetMyProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/my/profile" || r.Header.Get("X-API-Token") != "test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"user": "owner", "total_xp": 42, "machines": {"laptop": {"xps": 42, "last_active": "2023-06-15T10:00:00Z"}}}`))
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("test-token", server.URL).(*Client)
+	profile, err := c.GetMyProfile(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if profile.User != "owner" || profile.Machines["laptop"].LastActive.IsZero() {
+		t.Errorf("Expected owner profile with machine activity, got %+v", profile)
+	}
+
+	_, err = NewWithBaseURL("", server.URL).(*Client).GetMyProfile(context.Background())
+	if !godestats.IsUnauthorized(err) {
+		t.Errorf("Expected unauthorized error without token, got: %v", err)
+	}
+
+	_, err = NewWithBaseURL("wrong-token", server.URL).(*Client).GetMyProfile(context.Background())
+	if !godestats.IsUnauthorized(err) {
+		t.Errorf("Expected unauthorized error for an invalid token, got: %v", err)
+	}
+
+	var _ godestats.OwnProfileClient = c
+}
+
+func TestClient_GetUserProfilePartial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user": "testuser", "total_xp": 10, "languages": {"Go": {"xps": 10}}, "dates": {"2023-01-01": 10}}`))
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL).(*Client)
+	profile, err := c.GetUserProfilePartial(context.Background(), "testuser", godestats.FieldLanguages)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if profile.TotalXP != 10 || profile.Languages["Go"].XPs != 10 {
+		t.Errorf("Expected totals and languages, got %+v", profile)
+	}
+	if profile.Dates != nil {
+		t.Errorf("Expected dates not to be decoded, got %v", profile.Dates)
+	}
+	if profile.Recent.IsZero() {
+		t.Error("Expected recent period to be set")
+	}
+
+	var _ godestats.PartialProfileClient = c
+}
+
+func TestClient_GetUserProfile_RetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := NewWithBaseURL("", server.URL).GetUserProfile(context.Background(), "testuser")
+	if !errors.Is(err, godestats.ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+
+	if delay, ok := godestats.RetryAfter(err); !ok || delay != 2*time.Minute {
+		t.Errorf("Expected retry after 2m, got %v (ok=%v)", delay, ok)
+	}
+}
+
+func TestClient_RedactsToken(t *testing.T) {
+	const token = "s3cret-token"
+
+	// A misbehaving server echoing the token in its errors
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "bad request with token ` + r.Header.Get(AuthHeader) + `"}`))
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL(token, server.URL).(*Client)
+	ctx := context.Background()
+
+	_, profileErr := c.GetMyProfile(ctx)
+	pulseErr := c.SendPulse(ctx, godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}})
+
+	var apiErr *godestats.APIError
+	if !errors.As(pulseErr, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected an APIError, got %v", pulseErr)
+	}
+
+	outputs := map[string]string{
+		"profile error": profileErr.Error(),
+		"pulse error":   pulseErr.Error(),
+		"error %+v":     fmt.Sprintf("%+v", pulseErr),
+		"API message":   apiErr.Message,
+		"client %v":     fmt.Sprintf("%v", c),
+		"client %+v":    fmt.Sprintf("%+v", c),
+		"client %#v":    fmt.Sprintf("%#v", c),
+		"client %s":     fmt.Sprintf("%s", c),
+	}
+	for name, output := range outputs {
+		if strings.Contains(output, token) {
+			t.Errorf("%s reveals the token: %s", name, output)
+		}
+	}
+	if !strings.Contains(outputs["client %v"], godestats.Redacted) {
+		t.Errorf("Expected the client to show a redacted token, got %s", outputs["client %v"])
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// CoalescingClient is a client decorator that collapses concurrent
+// GetUserProfile calls for the same user into a single request.
+type CoalescingClient struct {
+	inner godestats.ProfileClient
+
+	mu       sync.Mutex
+	inflight map[profileKey]*profileCall
+}
+
+// profileKey identifies requests that can share a profile call. Fresh and
+// possibly cached profiles are fetched separately.
+type profileKey struct {
+	username string
+	noCache  bool
+}
+
+// profileCall is an in-flight profile request shared by all waiting callers.
+type profileCall struct {
+	done    chan struct{}
+	profile *godestats.UserProfile
+	err     error
+}
+
+// NewCoalescing wraps a client so that concurrent identical profile requests
+// share one in-flight HTTP request. Read-only clients such as NewAnonymous can be
+// wrapped as well; pulses are passed through unchanged.
+func NewCoalescing(inner godestats.ProfileClient) *CoalescingClient {
+	return &CoalescingClient{
+		inner:    inner,
+		inflight: make(map[profileKey]*profileCall),
+	}
+}
+
+// GetUserProfile returns the user's profile, joining an in-flight request for
+// the same user if there is one. Each caller receives its own copy of the profile.
+// The shared request is not cancelled when a single caller's context is;
+// that caller stops waiting and returns the context's error instead.
+//
+// The shared request doesn't inherit the call options of the caller that started
+// it, only whether it bypasses caches; each caller's timeout limits its own wait.
+func (c *CoalescingClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	options := godestats.ResolveCallOptions(ctx, opts...)
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	key := profileKey{username: username, noCache: options.NoCache}
+	c.mu.Lock()
+	call, ok := c.inflight[key]
+	if !ok {
+		call = &profileCall{done: make(chan struct{})}
+		c.inflight[key] = call
+		go c.fetch(godestats.WithoutCallOptions(context.WithoutCancel(ctx)), key, call)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+	}
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	return call.profile.Clone(), nil
+}
+
+// fetch performs the shared request and releases all waiting callers.
+func (c *CoalescingClient) fetch(ctx context.Context, key profileKey, call *profileCall) {
+	var opts []godestats.CallOption
+	if key.noCache {
+		opts = append(opts, godestats.WithNoCache())
+	}
+	call.profile, call.err = c.inner.GetUserProfile(ctx, key.username, opts...)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+
+	close(call.done)
+}
+
+// GetUserProfilePartial retrieves a profile containing only the selected sections
+// through the wrapped client. Partial requests are not coalesced.
+func (c *CoalescingClient) GetUserProfilePartial(ctx context.Context, username string, fields godestats.ProfileFields, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return godestats.GetUserProfileFields(ctx, c.inner, username, fields, opts...)
+}
+
+// SendPulse submits the pulse through the wrapped client. It returns
+// ErrUnauthorized if the wrapped client is read-only.
+func (c *CoalescingClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	sender, ok := c.inner.(godestats.CodeStatsClient)
+	if !ok {
+		return godestats.ErrUnauthorized
+	}
+	return sender.SendPulse(ctx, pulse, opts...)
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// blockingClient counts profile requests and blocks them until released.
+type blockingClient struct {
+	calls   atomic.Int32
+	release chan struct{}
+	err     error
+
+	mu      sync.Mutex
+	options []godestats.CallOptions
+}
+
+func (b *blockingClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	b.mu.Lock()
+	b.options = append(b.options, godestats.ResolveCallOptions(ctx, opts...))
+	b.mu.Unlock()
+	b.calls.Add(1)
+	<-b.release
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &godestats.UserProfile{User: username, TotalXP: 100}, nil
+}
+
+func (b *blockingClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestCoalescingClient_SharesRequests(t *testing.T) {
+	inner := &blockingClient{release: make(chan struct{})}
+	c := NewCoalescing(inner)
+
+	const callers = 10
+	profiles := make([]*godestats.UserProfile, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profile, err := c.GetUserProfile(context.Background(), "alice")
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			profiles[i] = profile
+		}()
+	}
+
+	// Wait until the request is in flight before releasing it
+	for inner.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	if n := inner.calls.Load(); n != 1 {
+		t.Errorf("Expected 1 request, got %d", n)
+	}
+
+	profiles[0].TotalXP = 0
+	if profiles[1].TotalXP != 100 {
+		t.Error("Expected every caller to receive its own copy")
+	}
+
+	// A later call starts a new request
+	c.GetUserProfile(context.Background(), "alice")
+	if n := inner.calls.Load(); n != 2 {
+		t.Errorf("Expected 2 requests, got %d", n)
+	}
+}
+
+func TestCoalescingClient_Errors(t *testing.T) {
+	inner := &blockingClient{release: make(chan struct{}), err: godestats.ErrUserNotFound}
+	close(inner.release)
+
+	if _, err := NewCoalescing(inner).GetUserProfile(context.Background(), "bob"); !errors.Is(err, godestats.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestCoalescingClient_CallerCancellation(t *testing.T) {
+	inner := &blockingClient{release: make(chan struct{})}
+	c := NewCoalescing(inner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		_, err := c.GetUserProfile(ctx, "alice")
+		result <- err
+	}()
+
+	for inner.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// Another caller joins the shared request, which was not cancelled
+	go func() {
+		_, err := c.GetUserProfile(context.Background(), "alice")
+		result <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+
+	if err := <-result; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if n := inner.calls.Load(); n != 1 {
+		t.Errorf("Expected 1 request, got %d", n)
+	}
+}
+
+func TestCoalescingClient_CallOptions(t *testing.T) {
+	inner := &blockingClient{release: make(chan struct{})}
+	c := NewCoalescing(inner)
+
+	result := make(chan error, 3)
+	go func() {
+		ctx := godestats.WithCallOptions(context.Background(), godestats.WithIdempotencyKey("key"))
+		_, err := c.GetUserProfile(ctx, "alice", godestats.WithRequestID("first"))
+		result <- err
+	}()
+	for inner.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A caller bypassing caches doesn't join the request that may be served from one
+	go func() {
+		_, err := c.GetUserProfile(context.Background(), "alice", godestats.WithNoCache())
+		result <- err
+	}()
+	for inner.calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A joining caller's timeout only limits its own wait
+	if _, err := c.GetUserProfile(context.Background(), "alice", godestats.WithCallTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	close(inner.release)
+	for range 2 {
+		if err := <-result; err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	if n := inner.calls.Load(); n != 2 {
+		t.Fatalf("Expected 2 requests, got %d", n)
+	}
+	noCache := 0
+	for _, options := range inner.options {
+		if options.RequestID != "" || options.IdempotencyKey != "" || options.Timeout != 0 {
+			t.Errorf("Expected the shared request not to inherit caller options, got %+v", options)
+		}
+		if options.NoCache {
+			noCache++
+		}
+	}
+	if noCache != 1 {
+		t.Errorf("Expected 1 request bypassing caches, got %d", noCache)
+	}
+}
+
+func TestCoalescingClient_Anonymous(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user": "alice", "total_xp": 2020, "new_xp": 0, "machines": {}, "languages": {"Go": {"xps": 2020, "new_xps": 0}}, "dates": {}}`))
+	}))
+	defer server.Close()
+
+	c := NewCoalescing(NewAnonymousWithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if profile, err := c.GetUserProfile(ctx, "alice"); err != nil || profile.TotalXP != 2020 {
+		t.Errorf("Expected alice's profile, got %+v (%v)", profile, err)
+	}
+
+	partial, err := c.GetUserProfilePartial(ctx, "alice", godestats.FieldMachines)
+	if err != nil || partial.TotalXP != 2020 || partial.Languages != nil {
+		t.Errorf("Expected only totals and machines, got %+v (%v)", partial, err)
+	}
+
+	if err := c.SendPulse(ctx, godestats.Pulse{CodedAt: time.Now()}); !errors.Is(err, godestats.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for a read-only client, got %v", err)
+	}
+
+	var _ godestats.PartialProfileClient = c
+}
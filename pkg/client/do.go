@@ -0,0 +1,193 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Retry defaults of Do
+const (
+	// DefaultRetries is how often idempotent requests are retried after temporary failures.
+	DefaultRetries = 2
+
+	// DefaultRetryDelay is the delay before the first retry, doubled for every further one.
+	DefaultRetryDelay = 500 * time.Millisecond
+
+	// MaxRetryDelay is the longest delay Do waits before a retry. Rate limited
+	// requests asking for a longer delay fail immediately.
+	MaxRetryDelay = 30 * time.Second
+
+	// DefaultPulseRetries is how often SendPulse retries pulses after temporary
+	// failures. Pulses are not retried by default, since a pulse whose response
+	// got lost may have been credited already.
+	DefaultPulseRetries = 0
+)
+
+// WithRetries sets how often Do retries idempotent requests after temporary
+// failures such as server errors, rate limiting and timeouts. Zero disables
+// retries; the default is DefaultRetries. Pulses are retried per WithPulseRetries.
+func WithRetries(retries int) Option {
+	return func(c *Client) {
+		c.retries = max(retries, 0)
+	}
+}
+
+// WithPulseRetries sets how often SendPulse retries pulses after temporary
+// failures. Retries reuse the pulse's idempotency key, so they only avoid
+// duplicate XP on instances that honour IdempotencyKeyHeader. The default is
+// DefaultPulseRetries.
+func WithPulseRetries(retries int) Option {
+	return func(c *Client) {
+		c.pulseRetries = max(retries, 0)
+	}
+}
+
+// Do calls an API endpoint that has no dedicated method, such as a new or
+// undocumented one, with the client's plumbing: the API token if the client has
+// one, the User-Agent, error classification as for the other methods and
+// retries of idempotent requests (see WithRetries).
+//
+// The path is relative to the API prefix, e.g. "/users/alice". A non-nil body is
+// sent as JSON with the client's Encoder; []byte and json.RawMessage bodies are
+// sent unchanged. The response is decoded as JSON into out unless out is nil or
+// the response is empty; a *[]byte receives the raw response. Unauthorized and rate limited responses
+// return ErrUnauthorized and a RateLimitError, other error responses an APIError.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any, opts ...godestats.CallOption) error {
+	ctx, cancel := callContext(ctx, opts)
+	defer cancel()
+
+	return c.callError(ctx, c.do(ctx, method, path, body, out))
+}
+
+// do implements Do.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var data []byte
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		data = b
+	case json.RawMessage:
+		data = b
+	default:
+		var buf bytes.Buffer
+		if err := c.encoder.Encode(&buf, body); err != nil {
+			return fmt.Errorf("failed to serialize request body: %w", err)
+		}
+		data = buf.Bytes()
+	}
+
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	attempts := 1
+	if idempotent(method) {
+		attempts += c.retries
+	}
+
+	return c.retry(ctx, attempts, func() error {
+		return c.doOnce(ctx, method, c.baseURL+c.apiPrefix+path, token, data, out)
+	})
+}
+
+// retry calls fn up to attempts times while it fails temporarily or is rate
+// limited, doubling the delay between attempts up to MaxRetryDelay. Rate limited
+// calls asking for a delay longer than MaxRetryDelay are not retried.
+func (c *Client) retry(ctx context.Context, attempts int, fn func() error) error {
+	delay := min(c.retryDelay, MaxRetryDelay)
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= attempts || !(godestats.IsTemporary(err) || godestats.IsRateLimited(err)) {
+			return err
+		}
+
+		wait := delay
+		if retryAfter, ok := godestats.RetryAfter(err); ok {
+			if retryAfter > MaxRetryDelay {
+				return err
+			}
+			wait = retryAfter
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, MaxRetryDelay)
+	}
+}
+
+// doOnce sends a single request of Do.
+func (c *Client) doOnce(ctx context.Context, method, endpoint, token string, body []byte, out any) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set(AuthHeader, token)
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return godestats.NewNetworkError(method+" request", endpoint, err)
+	}
+	defer closeBody(resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return godestats.ErrUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return rateLimitError(resp)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return c.apiError(resp, endpoint)
+	}
+
+	if out == nil {
+		return nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return godestats.NewNetworkError("reading response", endpoint, err)
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = data
+		return nil
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: %v", godestats.ErrInvalidResponse, err)
+	}
+	return nil
+}
+
+// idempotent reports whether requests with the method can be retried safely.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestClient_Do(t *testing.T) {
+	failures := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/my/echo":
+			if r.Header.Get(AuthHeader) != "token" || r.Header.Get("User-Agent") != UserAgent {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			w.Write(body)
+		case "/api/flaky":
+			if failures < 2 {
+				failures++
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+		case "/api/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "no such endpoint"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("token", server.URL).(*Client)
+	c.retryDelay = time.Millisecond
+	ctx := context.Background()
+
+	var echoed map[string]int
+	if err := c.Do(ctx, http.MethodPost, "/my/echo", map[string]int{"xp": 5}, &echoed); err != nil || echoed["xp"] != 5 {
+		t.Errorf("Expected the body to be echoed, got %v (%v)", echoed, err)
+	}
+
+	var raw []byte
+	if err := c.Do(ctx, http.MethodPost, "/my/echo", json.RawMessage(`{"raw":true}`), &raw); err != nil || string(raw) != `{"raw":true}` {
+		t.Errorf("Expected the raw body, got %s (%v)", raw, err)
+	}
+
+	var result struct{ OK bool }
+	if err := c.Do(ctx, http.MethodGet, "/flaky", nil, &result); err != nil || !result.OK {
+		t.Errorf("Expected the request to succeed after retries, got %v (%v)", result, err)
+	}
+
+	if err := c.Do(ctx, http.MethodGet, "/empty", nil, &result); err != nil {
+		t.Errorf("Expected empty responses to be accepted, got %v", err)
+	}
+
+	var apiErr *godestats.APIError
+	if err := c.Do(ctx, http.MethodGet, "/missing", nil, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 || apiErr.Message != "no such endpoint" {
+		t.Errorf("Expected a 404 APIError, got %v", err)
+	}
+
+	anonymous := NewWithBaseURL("", server.URL)
+	if err := anonymous.(*Client).Do(ctx, http.MethodGet, "/my/echo", nil, nil); !errors.Is(err, godestats.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestClient_DoRetries(t *testing.T) {
+	tests := []struct {
+		method   string
+		retries  int
+		expected int
+	}{
+		{http.MethodGet, DefaultRetries, 1 + DefaultRetries},
+		{http.MethodGet, 0, 1},
+		// Retrying could apply non-idempotent requests twice
+		{http.MethodPost, DefaultRetries, 1},
+	}
+
+	for _, tt := range tests {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+
+		c := NewWithBaseURL("", server.URL, WithRetries(tt.retries)).(*Client)
+		c.retryDelay = time.Millisecond
+		err := c.Do(context.Background(), tt.method, "/anything", nil, nil)
+		server.Close()
+
+		if !godestats.IsTemporary(err) || requests != tt.expected {
+			t.Errorf("%s with %d retries: expected %d requests and a temporary error, got %d (%v)", tt.method, tt.retries, tt.expected, requests, err)
+		}
+	}
+}
+
+func TestClient_DoRetryDelayCapped(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	// A backoff delay beyond the maximum is capped rather than giving up, so the
+	// client waits for the retry until the context ends
+	c := NewWithBaseURL("", server.URL).(*Client)
+	c.retryDelay = 2 * MaxRetryDelay
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Do(ctx, http.MethodGet, "/anything", nil, nil)
+	if !godestats.IsTemporary(err) || requests != 1 {
+		t.Errorf("Expected 1 request and a temporary error, got %d (%v)", requests, err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the retry to wait for the capped delay, returned after %v", elapsed)
+	}
+}
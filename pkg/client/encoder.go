@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Encoder writes the JSON bodies of requests, i.e. pulses and the bodies of Do.
+type Encoder interface {
+	Encode(w io.Writer, v any) error
+}
+
+// JSONEncoder is an Encoder with adjustable details of the encoding, e.g. to
+// match the bytes of requests exactly in proxies and golden tests. Other details,
+// such as the order of fields, need a custom Encoder.
+type JSONEncoder struct {
+	// EscapeHTML escapes <, > and & in strings.
+	EscapeHTML bool
+
+	// TrailingNewline ends the body with a newline.
+	TrailingNewline bool
+
+	// TimeFormat is the layout of the times of pulses, time.RFC3339Nano if empty.
+	TimeFormat string
+}
+
+// DefaultEncoder is the encoder of clients without WithEncoder, which encodes
+// like json.Marshal.
+var DefaultEncoder = JSONEncoder{EscapeHTML: true}
+
+// WithEncoder sets the encoder of request bodies.
+func WithEncoder(encoder Encoder) Option {
+	return func(c *Client) {
+		c.encoder = encoder
+	}
+}
+
+// encodedPulse is a pulse with a preformatted time.
+type encodedPulse struct {
+	CodedAt string                 `json:"coded_at"`
+	XPs     []godestats.LanguageXP `json:"xps"`
+}
+
+// Encode writes the JSON encoding of v.
+func (e JSONEncoder) Encode(w io.Writer, v any) error {
+	if e.TimeFormat != "" {
+		switch pulse := v.(type) {
+		case godestats.Pulse:
+			v = encodedPulse{CodedAt: pulse.CodedAt.Format(e.TimeFormat), XPs: pulse.XPs}
+		case *godestats.Pulse:
+			v = encodedPulse{CodedAt: pulse.CodedAt.Format(e.TimeFormat), XPs: pulse.XPs}
+		}
+	}
+
+	if e.TrailingNewline {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(e.EscapeHTML)
+		return enc.Encode(v)
+	}
+
+	// The encoder always appends a newline, so the output is buffered to drop it
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(e.EscapeHTML)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return err
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	codedAt := time.Date(2026, 10, 18, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	pulse := godestats.Pulse{CodedAt: codedAt, XPs: []godestats.LanguageXP{{Language: "C<>", XP: 5}}}
+
+	tests := []struct {
+		name     string
+		encoder  JSONEncoder
+		value    any
+		expected string
+	}{
+		{"default", DefaultEncoder, pulse, `{"coded_at":"2026-10-18T12:30:00+02:00","xps":[{"language":"C\u003c\u003e","xp":5}]}`},
+		{"trailing newline", JSONEncoder{EscapeHTML: true, TrailingNewline: true}, pulse, `{"coded_at":"2026-10-18T12:30:00+02:00","xps":[{"language":"C\u003c\u003e","xp":5}]}` + "\n"},
+		{"unescaped", JSONEncoder{}, pulse, `{"coded_at":"2026-10-18T12:30:00+02:00","xps":[{"language":"C<>","xp":5}]}`},
+		{"time format", JSONEncoder{TimeFormat: "2006-01-02T15:04:05.000-07:00"}, &pulse, `{"coded_at":"2026-10-18T12:30:00.000+02:00","xps":[{"language":"C<>","xp":5}]}`},
+		{"other values", JSONEncoder{TimeFormat: time.Kitchen}, map[string]string{"a": "<b>"}, `{"a":"<b>"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.encoder.Encode(&buf, tt.value); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWithEncoder(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	codedAt := time.Now().UTC().Truncate(time.Second)
+	pulse := godestats.Pulse{CodedAt: codedAt, XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}
+	c := NewWithBaseURL("token", server.URL, WithEncoder(JSONEncoder{TimeFormat: time.RFC3339}))
+
+	if err := c.SendPulse(context.Background(), pulse); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"coded_at":"` + codedAt.Format(time.RFC3339) + `","xps":[{"language":"Go","xp":1}]}`
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key of a
+// pulse. All attempts to send a pulse carry the same key, so that the server can
+// drop a retry of a pulse it already accepted before the connection failed.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKey returns the key for the pulse sent with the context: the key in
+// the call options if any (see godestats.WithIdempotencyKey), or a new random one.
+func idempotencyKey(ctx context.Context) (string, error) {
+	if key := callOptions(ctx).IdempotencyKey; key != "" {
+		return key, nil
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
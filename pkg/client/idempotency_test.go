@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestClient_SendPulse_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		// Fail every first attempt, as if the response to an accepted pulse got lost
+		if len(keys)%2 == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("test-token", server.URL, WithPulseRetries(1)).(*Client)
+	c.retryDelay = time.Millisecond
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 15}}}
+
+	for range 2 {
+		if err := c.SendPulse(context.Background(), pulse); err != nil {
+			t.Fatalf("Expected the pulse to be sent after a retry, got %v", err)
+		}
+	}
+
+	if len(keys) != 4 || keys[0] == "" {
+		t.Fatalf("Expected 4 attempts with idempotency keys, got %q", keys)
+	}
+	if keys[0] != keys[1] || keys[2] != keys[3] {
+		t.Errorf("Expected retries to reuse the key, got %q", keys)
+	}
+	if keys[0] == keys[2] {
+		t.Errorf("Expected a new key per pulse, got %q twice", keys[0])
+	}
+
+	if err := c.SendPulse(context.Background(), pulse, godestats.WithIdempotencyKey("pulse-1")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key := keys[len(keys)-1]; key != "pulse-1" {
+		t.Errorf("Expected the key of the call options, got %q", key)
+	}
+}
+
+func TestClient_SendPulse_NoRetries(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// Pulses are not retried by default, whatever the retries of other requests
+	for _, opts := range [][]Option{nil, {WithRetries(3)}, {WithPulseRetries(0)}} {
+		requests = 0
+		c := NewWithBaseURL("test-token", server.URL, opts...)
+		err := c.SendPulse(context.Background(), godestats.Pulse{CodedAt: time.Now()})
+		if !godestats.IsTemporary(err) || requests != 1 {
+			t.Errorf("Expected a single attempt with a temporary error, got %d (%v)", requests, err)
+		}
+	}
+}
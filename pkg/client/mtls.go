@@ -0,0 +1,50 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// WithClientCertificate authenticates TLS connections with the PEM encoded
+// certificate and private key in the files certFile and keyFile, for reverse
+// proxies in front of self-hosted instances that require mutual TLS. The files
+// are read on every TLS handshake, so renewed certificates are picked up without
+// restarting. The certificate is added to a copy of the client's *http.Transport,
+// also when combined with WithHTTPClient; other round trippers must be
+// configured by the caller.
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(c *Client) {
+		c.certFile, c.keyFile = certFile, keyFile
+	}
+}
+
+// withClientCertificate returns a copy of the HTTP client whose transport presents
+// the client certificate. HTTP clients with other round trippers are returned unchanged.
+func withClientCertificate(httpClient *http.Client, certFile, keyFile string) *http.Client {
+	var transport *http.Transport
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		// Cloning also keeps the shared default transport free of the certificate
+		transport = t.Clone()
+	default:
+		return httpClient
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		return &cert, nil
+	}
+
+	copied := *httpClient
+	copied.Transport = transport
+	return &copied
+}
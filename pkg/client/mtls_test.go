@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// writeCertificate writes a self-signed client certificate and its key as PEM files.
+func writeCertificate(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	return certFile, keyFile
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "tracker" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, "tracker")
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		succeed bool
+	}{
+		{"with certificate", []Option{WithHTTPClient(server.Client()), WithClientCertificate(certFile, keyFile)}, true},
+		{"option order", []Option{WithClientCertificate(certFile, keyFile), WithHTTPClient(server.Client())}, true},
+		{"without certificate", []Option{WithHTTPClient(server.Client())}, false},
+		{"missing files", []Option{WithHTTPClient(server.Client()), WithClientCertificate(filepath.Join(dir, "missing.crt"), keyFile)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewWithBaseURL("token", server.URL, tt.opts...)
+			err := c.SendPulse(context.Background(), pulse)
+			if tt.succeed && err != nil {
+				t.Errorf("Expected the pulse to be accepted, got %v", err)
+			}
+			if !tt.succeed && err == nil {
+				t.Error("Expected the TLS handshake to fail")
+			}
+		})
+	}
+
+	if server.Client().Transport.(*http.Transport).TLSClientConfig.GetClientCertificate != nil {
+		t.Error("Expected the passed HTTP client to be left unchanged")
+	}
+}
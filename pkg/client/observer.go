@@ -0,0 +1,98 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// ResponseInfo describes a completed request for response observers, see
+// WithResponseObserver. Retries are observed as separate requests.
+type ResponseInfo struct {
+	// Method and URL of the request. Credentials in the URL are redacted.
+	Method string
+	URL    string
+
+	// RequestID is the request ID of the call, shared by its retries.
+	RequestID string
+
+	// StatusCode and Header of the response. StatusCode is 0 and Header nil if
+	// the request failed without a response; Err is set then.
+	StatusCode int
+	Header     http.Header
+
+	// RequestBytes and ResponseBytes are the sizes of the bodies sent and read.
+	RequestBytes  int64
+	ResponseBytes int64
+
+	// Duration is the time from sending the request until the response body was
+	// closed or the request failed.
+	Duration time.Duration
+
+	// Err is the error of a request that failed without a response.
+	Err error
+}
+
+// WithResponseObserver sets a function called with the metadata of every
+// response, e.g. to throttle adaptively on rate limit headers or to track
+// latencies, without a custom transport. It is called synchronously once the
+// response body is closed, so it must not block, and must not modify the header.
+func WithResponseObserver(observe func(ResponseInfo)) Option {
+	return func(c *Client) {
+		c.observe = observe
+	}
+}
+
+// observedBody is a response body that reports how much was read when closed.
+type observedBody struct {
+	io.ReadCloser
+	n    int64
+	once sync.Once
+	done func(n int64)
+}
+
+// Read implements io.Reader and counts the bytes read.
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// Close closes the body and reports it once.
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(b.n) })
+	return err
+}
+
+// observeResponse reports the request to the observer, if any, when the response
+// body is closed or right away if the request failed.
+func (c *Client) observeResponse(req *http.Request, start time.Time, resp *http.Response, err error) {
+	if c.observe == nil {
+		return
+	}
+
+	info := ResponseInfo{
+		Method:       req.Method,
+		URL:          godestats.RedactURL(req.URL.String()),
+		RequestID:    req.Header.Get(RequestIDHeader),
+		RequestBytes: max(req.ContentLength, 0),
+	}
+	if err != nil {
+		info.Duration = time.Since(start)
+		info.Err = c.redact(err)
+		c.observe(info)
+		return
+	}
+
+	info.StatusCode = resp.StatusCode
+	info.Header = resp.Header
+	resp.Body = &observedBody{ReadCloser: resp.Body, done: func(n int64) {
+		info.ResponseBytes = n
+		info.Duration = time.Since(start)
+		c.observe(info)
+	}}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestWithResponseObserver(t *testing.T) {
+	const body = `{"user": "alice", "total_xp": 10}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+
+	var infos []ResponseInfo
+	c := NewWithBaseURL("test-token", server.URL, WithResponseObserver(func(info ResponseInfo) {
+		infos = append(infos, info)
+	}))
+
+	ctx := context.Background()
+	if _, err := c.GetUserProfile(ctx, "alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := c.SendPulse(ctx, godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 observed responses, got %d", len(infos))
+	}
+	profile, pulse := infos[0], infos[1]
+	if profile.Method != http.MethodGet || profile.URL != server.URL+"/api/users/alice" || profile.StatusCode != http.StatusOK {
+		t.Errorf("Expected the profile request, got %+v", profile)
+	}
+	if profile.ResponseBytes != int64(len(body)) || profile.RequestBytes != 0 {
+		t.Errorf("Expected %d response bytes and no request bytes, got %d and %d", len(body), profile.ResponseBytes, profile.RequestBytes)
+	}
+	if profile.Header.Get("X-RateLimit-Remaining") != "7" || profile.Duration <= 0 {
+		t.Errorf("Expected the response header and a duration, got %+v", profile)
+	}
+	if pulse.StatusCode != http.StatusCreated || pulse.RequestBytes == 0 {
+		t.Errorf("Expected the pulse with its request body, got %+v", pulse)
+	}
+
+	// Failed requests are observed with their error
+	server.Close()
+	infos = nil
+	c.GetUserProfile(ctx, "alice")
+	if len(infos) != 1 || infos[0].Err == nil || infos[0].StatusCode != 0 {
+		t.Errorf("Expected the failed request to be observed with its error, got %+v", infos)
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import "strings"
+
+// Option configures optional behavior of a Client.
+type Option func(*Client)
+
+// WithUnknownFields enables retaining JSON fields not known to this library
+// in the Raw map of decoded profiles.
+func WithUnknownFields() Option {
+	return func(c *Client) {
+		c.preserveUnknown = true
+	}
+}
+
+// WithAPIPrefix sets the path prefix of the API endpoints, APIPrefix by default,
+// for instances mounted under a subpath behind a reverse proxy, e.g.
+// "/codestats/api" for https://host/codestats/api. An empty prefix serves the
+// API from the root of the base URL.
+func WithAPIPrefix(prefix string) Option {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return func(c *Client) {
+		c.apiPrefix = prefix
+	}
+}
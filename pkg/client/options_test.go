@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithUnknownFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user": "testuser", "total_xp": 10, "country": "DE"}`))
+	}))
+	defer server.Close()
+
+	profile, err := NewWithBaseURL("", server.URL).GetUserProfile(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if profile.Raw != nil {
+		t.Errorf("Expected no raw fields by default, got %v", profile.Raw)
+	}
+
+	profile, err = NewWithBaseURL("", server.URL, WithUnknownFields()).GetUserProfile(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(profile.Raw["country"]) != `"DE"` {
+		t.Errorf("Expected raw country field, got %v", profile.Raw)
+	}
+}
+
+func TestWithAPIPrefix(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{"/codestats/api", "/codestats/api/users/alice"},
+		{"codestats/api/", "/codestats/api/users/alice"},
+		{"", "/users/alice"},
+	}
+
+	for _, tt := range tests {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Write([]byte(`{"user": "alice", "total_xp": 10}`))
+		}))
+
+		_, err := NewWithBaseURL("", server.URL, WithAPIPrefix(tt.prefix)).GetUserProfile(context.Background(), "alice")
+		server.Close()
+
+		if err != nil || path != tt.expected {
+			t.Errorf("Prefix %q: expected a request to %s, got %s (%v)", tt.prefix, tt.expected, path, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// ErrPageLoop is returned by Pages when an endpoint returns the cursor it was
+// called with as the next one, which would otherwise loop forever.
+var ErrPageLoop = errors.New("pagination did not advance")
+
+// Page is one page of a list endpoint.
+type Page[T any] struct {
+	Items []T
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+}
+
+// PageFunc fetches the page at the cursor. The first page is fetched with an
+// empty cursor.
+type PageFunc[T any] func(ctx context.Context, cursor string) (Page[T], error)
+
+// Pages iterates over the items of all pages, fetching the next page only once
+// the items of the current one were consumed. A failed fetch yields the error
+// and ends the iteration, as does cancelling the context.
+//
+//	for machine, err := range client.Pages(ctx, fetchMachines) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Pages[T any](ctx context.Context, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if page.Next == "" {
+				return
+			}
+			if page.Next == cursor {
+				yield(zero, fmt.Errorf("%w: cursor %q", ErrPageLoop, cursor))
+				return
+			}
+			cursor = page.Next
+		}
+	}
+}
+
+// Offsets adapts an offset based endpoint to a PageFunc. The endpoint is asked
+// for limit items at a time, and a page with fewer items is the last one.
+func Offsets[T any](limit int, fetch func(ctx context.Context, offset, limit int) ([]T, error)) PageFunc[T] {
+	return func(ctx context.Context, cursor string) (Page[T], error) {
+		offset := 0
+		if cursor != "" {
+			var err error
+			if offset, err = strconv.Atoi(cursor); err != nil {
+				return Page[T]{}, fmt.Errorf("invalid offset cursor %q: %w", cursor, err)
+			}
+		}
+
+		items, err := fetch(ctx, offset, limit)
+		if err != nil {
+			return Page[T]{}, err
+		}
+
+		page := Page[T]{Items: items}
+		if len(items) >= limit && len(items) > 0 {
+			page.Next = strconv.Itoa(offset + len(items))
+		}
+		return page, nil
+	}
+}
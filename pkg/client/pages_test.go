@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestPages(t *testing.T) {
+	// Three pages linked by cursors
+	pages := map[string]Page[int]{
+		"":  {Items: []int{1, 2}, Next: "b"},
+		"b": {Items: []int{3, 4}, Next: "c"},
+		"c": {Items: []int{5}},
+	}
+	fetches := 0
+	fetch := func(ctx context.Context, cursor string) (Page[int], error) {
+		fetches++
+		return pages[cursor], nil
+	}
+
+	var items []int
+	for item, err := range Pages(context.Background(), fetch) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		items = append(items, item)
+	}
+	if !slices.Equal(items, []int{1, 2, 3, 4, 5}) || fetches != 3 {
+		t.Errorf("Expected 5 items from 3 fetches, got %v from %d", items, fetches)
+	}
+
+	// Stopping early doesn't fetch further pages
+	fetches = 0
+	for item := range Pages(context.Background(), fetch) {
+		if item == 2 {
+			break
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected 1 fetch, got %d", fetches)
+	}
+}
+
+func TestPages_Errors(t *testing.T) {
+	failure := errors.New("boom")
+	tests := []struct {
+		name     string
+		fetch    PageFunc[int]
+		items    int
+		expected error
+	}{
+		{"failed fetch", func(ctx context.Context, cursor string) (Page[int], error) {
+			if cursor == "" {
+				return Page[int]{Items: []int{1}, Next: "2"}, nil
+			}
+			return Page[int]{}, failure
+		}, 1, failure},
+		{"loop", func(ctx context.Context, cursor string) (Page[int], error) {
+			return Page[int]{Items: []int{1}, Next: "same"}, nil
+		}, 2, ErrPageLoop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := 0
+			var last error
+			for _, err := range Pages(context.Background(), tt.fetch) {
+				if err != nil {
+					last = err
+					continue
+				}
+				items++
+			}
+			if items != tt.items || !errors.Is(last, tt.expected) {
+				t.Errorf("Expected %d items and %v, got %d and %v", tt.items, tt.expected, items, last)
+			}
+		})
+	}
+}
+
+func TestOffsets(t *testing.T) {
+	all := []string{"a", "b", "c", "d", "e"}
+	var offsets []string
+	fetch := Offsets(2, func(ctx context.Context, offset, limit int) ([]string, error) {
+		offsets = append(offsets, strconv.Itoa(offset))
+		return all[min(offset, len(all)):min(offset+limit, len(all))], nil
+	})
+
+	var items []string
+	for item, err := range Pages(context.Background(), fetch) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		items = append(items, item)
+	}
+	if !slices.Equal(items, all) || !slices.Equal(offsets, []string{"0", "2", "4"}) {
+		t.Errorf("Expected all items at offsets 0, 2 and 4, got %v at %v", items, offsets)
+	}
+}
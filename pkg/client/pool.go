@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// maxPooledBuffer is the capacity above which buffers are not returned to the pool,
+// so a single large payload doesn't pin memory for the lifetime of the process.
+const maxPooledBuffer = 64 << 10
+
+// maxErrorBody limits how much of an error response is read.
+const maxErrorBody = 16 << 10
+
+// maxPulseResult limits how much of the response to an accepted pulse is decoded.
+const maxPulseResult = 16 << 10
+
+// bufferPool holds reusable buffers for encoding request bodies and reading error bodies.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns a buffer to the pool unless it grew too large.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// apiError builds an APIError from an error response, using the message from
+// a JSON error body if present and the raw body otherwise. The API token is
+// redacted from the message.
+func (c *Client) apiError(resp *http.Response, endpoint string) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	buf.ReadFrom(io.LimitReader(resp.Body, maxErrorBody))
+
+	var errorResp struct {
+		Error string `json:"error"`
+	}
+	message := buf.String()
+	if json.Unmarshal(buf.Bytes(), &errorResp) == nil && errorResp.Error != "" {
+		message = errorResp.Error
+	}
+
+	return godestats.NewAPIError(resp.StatusCode, godestats.RedactSecret(message, c.secrets()...), godestats.RedactURL(endpoint))
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestPutBuffer_DropsLargeBuffers(t *testing.T) {
+	large := bytes.NewBuffer(make([]byte, 0, maxPooledBuffer+1))
+	putBuffer(large)
+
+	for i := 0; i < 10; i++ {
+		if getBuffer() == large {
+			t.Fatal("Expected oversized buffer not to be pooled")
+		}
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"JSON error", `{"error": "Invalid pulse"}`, "Invalid pulse"},
+		{"plain text", "Bad gateway", "Bad gateway"},
+		{"truncated", strings.Repeat("x", maxErrorBody+100), strings.Repeat("x", maxErrorBody)},
+		{"echoed token", `{"error": "Invalid token secret-token"}`, "Invalid token REDACTED"},
+	}
+
+	c := &Client{apiToken: "secret-token"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(tt.body))}
+
+			var apiErr *godestats.APIError
+			if !errors.As(c.apiError(resp, "/api"), &apiErr) {
+				t.Fatal("Expected APIError")
+			}
+			if apiErr.Message != tt.expected {
+				t.Errorf("Expected message of length %d, got length %d", len(tt.expected), len(apiErr.Message))
+			}
+		})
+	}
+}
+
+func BenchmarkSendPulse(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("token", server.URL)
+	pulse := godestats.Pulse{
+		CodedAt: time.Now(),
+		XPs:     []godestats.LanguageXP{{Language: "Go", XP: 42}, {Language: "Markdown", XP: 7}},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.SendPulse(context.Background(), pulse); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
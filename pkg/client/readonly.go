@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// ReadOnlyClient is an anonymous client for public profiles. It has no SendPulse
+// method, so sending pulses without a token is a compile error rather than an
+// ErrUnauthorized at runtime. It implements godestats.ProfileClient and can be
+// used with watchers, recorders and the HTTP handlers.
+type ReadOnlyClient struct {
+	c *Client
+}
+
+// NewAnonymous creates an anonymous Code::Stats API client for read-only operations.
+// This client can only retrieve public user profiles and cannot send pulses.
+func NewAnonymous(opts ...Option) *ReadOnlyClient {
+	return NewAnonymousWithBaseURL(DefaultBaseURL, opts...)
+}
+
+// NewAnonymousWithBaseURL creates an anonymous client for a custom instance.
+func NewAnonymousWithBaseURL(baseURL string, opts ...Option) *ReadOnlyClient {
+	return &ReadOnlyClient{c: NewWithBaseURL("", baseURL, opts...).(*Client)}
+}
+
+// GetUserProfile retrieves the public profile of the user.
+func (r *ReadOnlyClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return r.c.GetUserProfile(ctx, username, opts...)
+}
+
+// GetUserProfilePartial retrieves a public profile containing only the selected sections.
+func (r *ReadOnlyClient) GetUserProfilePartial(ctx context.Context, username string, fields godestats.ProfileFields, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return r.c.GetUserProfilePartial(ctx, username, fields, opts...)
+}
+
+// Capabilities detects the features of the instance, see Client.Capabilities.
+func (r *ReadOnlyClient) Capabilities(ctx context.Context) (godestats.Capabilities, error) {
+	return r.c.Capabilities(ctx)
+}
+
+// ServerVersion returns the version announced by the instance, see Client.ServerVersion.
+func (r *ReadOnlyClient) ServerVersion() string {
+	return r.c.ServerVersion()
+}
+
+// String describes the client.
+func (r *ReadOnlyClient) String() string {
+	return fmt.Sprintf("client.ReadOnlyClient{baseURL: %q}", godestats.RedactURL(r.c.baseURL))
+}
+
+// GoString implements fmt.GoStringer like String.
+func (r *ReadOnlyClient) GoString() string {
+	return r.String()
+}
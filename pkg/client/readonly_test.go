@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AuthHeader) != "" {
+			t.Errorf("Expected no token to be sent, got %s", r.Header.Get(AuthHeader))
+		}
+		w.Write([]byte(`{"user": "alice", "total_xp": 2020, "new_xp": 0, "machines": {}, "languages": {}, "dates": {}}`))
+	}))
+	defer server.Close()
+
+	c := NewAnonymousWithBaseURL(server.URL)
+
+	profile, err := c.GetUserProfile(context.Background(), "alice")
+	if err != nil || profile.User != "alice" {
+		t.Fatalf("Expected alice's profile, got %v (%v)", profile, err)
+	}
+
+	partial, err := c.GetUserProfilePartial(context.Background(), "alice", godestats.FieldMachines)
+	if err != nil || partial.TotalXP != 2020 || partial.Languages != nil {
+		t.Errorf("Expected only totals and machines, got %+v (%v)", partial, err)
+	}
+
+	if s := fmt.Sprintf("%v %#v", c, c); !strings.Contains(s, "client.ReadOnlyClient{baseURL: ") {
+		t.Errorf("Expected a description of the client, got %s", s)
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// ErrUnknownMachine is returned when a pulse is sent for a machine without a token.
+var ErrUnknownMachine = errors.New("no token for machine")
+
+// RoutingClient sends pulses with the token of the machine they were coded on.
+// Code::Stats attributes pulses to the machine a token belongs to, so a single
+// process reporting for several machines needs one token per machine.
+type RoutingClient struct {
+	clients  map[string]*Client
+	machines []string
+}
+
+// NewRouting creates a routing client from machine name to API token mappings.
+// The options apply to the clients of all machines.
+func NewRouting(tokens map[string]string, opts ...Option) *RoutingClient {
+	return NewRoutingWithBaseURL(tokens, DefaultBaseURL, opts...)
+}
+
+// NewRoutingWithBaseURL creates a routing client for a custom instance.
+func NewRoutingWithBaseURL(tokens map[string]string, baseURL string, opts ...Option) *RoutingClient {
+	r := &RoutingClient{clients: make(map[string]*Client, len(tokens))}
+	for machine, token := range tokens {
+		r.clients[machine] = NewWithBaseURL(token, baseURL, opts...).(*Client)
+		r.machines = append(r.machines, machine)
+	}
+	sort.Strings(r.machines)
+	return r
+}
+
+// Machines returns the sorted names of the machines with a token.
+func (r *RoutingClient) Machines() []string {
+	return slices.Clone(r.machines)
+}
+
+// Client returns the client of a machine.
+func (r *RoutingClient) Client(machine string) (*Client, bool) {
+	c, ok := r.clients[machine]
+	return c, ok
+}
+
+// SendPulseAs submits the pulse with the token of the machine.
+func (r *RoutingClient) SendPulseAs(ctx context.Context, machine string, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	c, ok := r.clients[machine]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownMachine, machine)
+	}
+	return c.SendPulse(ctx, pulse, opts...)
+}
+
+// SendPulse submits the pulse for the only configured machine. With several
+// machines it returns ErrUnknownMachine, since the pulse would be attributed to
+// an arbitrary one; use SendPulseAs instead.
+func (r *RoutingClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	if len(r.machines) != 1 {
+		return fmt.Errorf("%w: pulse sent without a machine to %d machines", ErrUnknownMachine, len(r.machines))
+	}
+	return r.SendPulseAs(ctx, r.machines[0], pulse, opts...)
+}
+
+// GetUserProfile retrieves the profile with the client of the first machine, so
+// that the tokens' owner can access their private profile.
+func (r *RoutingClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	if len(r.machines) == 0 {
+		return nil, fmt.Errorf("%w: no machines configured", ErrUnknownMachine)
+	}
+	return r.clients[r.machines[0]].GetUserProfile(ctx, username, opts...)
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestRoutingClient_SendPulseAs(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get(AuthHeader))
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	r := NewRoutingWithBaseURL(map[string]string{"laptop": "token-laptop", "desktop": "token-desktop"}, server.URL)
+	ctx := context.Background()
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 10}}}
+
+	if machines := r.Machines(); len(machines) != 2 || machines[0] != "desktop" {
+		t.Errorf("Expected sorted machines, got %v", machines)
+	}
+
+	for _, machine := range []string{"laptop", "desktop", "laptop"} {
+		if err := r.SendPulseAs(ctx, machine, pulse); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	expected := []string{"token-laptop", "token-desktop", "token-laptop"}
+	for i, token := range expected {
+		if tokens[i] != token {
+			t.Errorf("Pulse %d: expected %s, got %s", i+1, token, tokens[i])
+		}
+	}
+
+	if err := r.SendPulseAs(ctx, "server", pulse); !errors.Is(err, ErrUnknownMachine) {
+		t.Errorf("Expected ErrUnknownMachine, got %v", err)
+	}
+	if err := r.SendPulse(ctx, pulse); !errors.Is(err, ErrUnknownMachine) {
+		t.Errorf("Expected ErrUnknownMachine without a machine, got %v", err)
+	}
+	if len(tokens) != 3 {
+		t.Errorf("Expected no further pulses, got %d", len(tokens))
+	}
+}
+
+func TestRoutingClient_SingleMachine(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AuthHeader) != "token-laptop" {
+			t.Errorf("Expected the laptop's token, got %s", r.Header.Get(AuthHeader))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var c godestats.CodeStatsClient = NewRoutingWithBaseURL(map[string]string{"laptop": "token-laptop"}, server.URL)
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 10}}}
+	if err := c.SendPulse(context.Background(), pulse); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
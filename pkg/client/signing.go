@@ -0,0 +1,26 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/Yeti47/gode-stats/pkg/signing"
+)
+
+// WithRequestSigning signs all requests with HMAC-SHA256 using the secret, in
+// the header or signing.DefaultHeader if it is empty, so that relays in front of
+// self-hosted instances can verify them with signing.VerifyRequest or
+// signing.Middleware. The signing transport wraps the client's transport, also
+// when combined with WithHTTPClient.
+func WithRequestSigning(secret, header string) Option {
+	return func(c *Client) {
+		c.signing = &signing.Transport{Secret: secret, Header: header}
+	}
+}
+
+// withRequestSigning returns a copy of the HTTP client whose requests are signed.
+func withRequestSigning(httpClient *http.Client, t signing.Transport) *http.Client {
+	t.Base = httpClient.Transport
+	copied := *httpClient
+	copied.Transport = &t
+	return &copied
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/signing"
+)
+
+func TestWithRequestSigning(t *testing.T) {
+	relay := httptest.NewServer(signing.Middleware("relay-secret", "", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})))
+	defer relay.Close()
+
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}
+
+	c := NewWithBaseURL("token", relay.URL, WithRequestSigning("relay-secret", ""), WithHTTPClient(relay.Client()))
+	if err := c.SendPulse(context.Background(), pulse); err != nil {
+		t.Errorf("Expected the signed pulse to be accepted, got %v", err)
+	}
+
+	c = NewWithBaseURL("token", relay.URL)
+	if err := c.SendPulse(context.Background(), pulse); !errors.Is(err, godestats.ErrUnauthorized) {
+		t.Errorf("Expected unsigned pulses to be rejected, got %v", err)
+	}
+}
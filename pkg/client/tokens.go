@@ -0,0 +1,26 @@
+package client
+
+import (
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/secrets"
+)
+
+// WithTokenProvider gets the API token from the provider before every
+// authenticated request instead of using the token passed to the constructor,
+// so that tokens can come from the environment, the OS keyring or a secret
+// manager and be rotated without recreating the client. See package secrets for
+// implementations.
+func WithTokenProvider(provider godestats.TokenProvider) Option {
+	return func(c *Client) {
+		c.tokens = provider
+	}
+}
+
+// WithTokenFile reads the API token from the file at path instead of using the
+// token passed to the constructor. The file is checked for changes before every
+// authenticated request, so rotated tokens of mounted secrets (Kubernetes, Vault
+// agent) are picked up without restarting. While the file is missing or empty,
+// e.g. during an update, the last token read is used.
+func WithTokenFile(path string) Option {
+	return WithTokenProvider(secrets.File(path))
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/secrets"
+)
+
+// writeToken writes the token file with a distinct modification time.
+func writeToken(t *testing.T, path, token string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestWithTokenFile(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get(AuthHeader))
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	start := time.Now().Add(-time.Hour)
+	writeToken(t, path, "token-1", start)
+
+	c := NewWithBaseURL("ignored", server.URL, WithTokenFile(path))
+	ctx := context.Background()
+	send := func() error {
+		return c.SendPulse(ctx, godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}})
+	}
+
+	if err := send(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Rotated token
+	writeToken(t, path, "token-2", start.Add(time.Minute))
+	if err := send(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The last token is kept while the file is being replaced
+	os.Remove(path)
+	if err := send(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"token-1", "token-2", "token-2"}
+	for i, token := range expected {
+		if tokens[i] != token {
+			t.Errorf("Request %d: expected %s, got %s", i+1, token, tokens[i])
+		}
+	}
+}
+
+func TestWithTokenFile_Missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	c := NewWithBaseURL("", "http://127.0.0.1:1", WithTokenFile(path))
+
+	err := c.SendPulse(context.Background(), godestats.Pulse{CodedAt: time.Now()})
+	if !errors.Is(err, godestats.ErrUnauthorized) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected an unauthorized missing file error, got %v", err)
+	}
+
+	writeToken(t, path, "   ", time.Now())
+	if _, err := c.(*Client).GetMyProfile(context.Background()); !errors.Is(err, godestats.ErrUnauthorized) || !errors.Is(err, secrets.ErrNoToken) {
+		t.Errorf("Expected an unauthorized empty file error, got %v", err)
+	}
+
+	// Providers returning an empty token are unauthorized as well
+	empty := NewWithBaseURL("", "http://127.0.0.1:1", WithTokenProvider(secrets.Func(func(context.Context) (string, error) {
+		return "", nil
+	})))
+	if err := empty.SendPulse(context.Background(), godestats.Pulse{CodedAt: time.Now()}); !errors.Is(err, godestats.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestWithTokenProvider(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(AuthHeader))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	tokens := []string{"token-1", "token-2"}
+	calls := 0
+	provider := secrets.Func(func(ctx context.Context) (string, error) {
+		if calls == len(tokens) {
+			return "", errors.New("vault sealed with token-2")
+		}
+		calls++
+		return tokens[calls-1], nil
+	})
+
+	c := NewWithBaseURL("", server.URL, WithTokenProvider(provider))
+	ctx := context.Background()
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}
+
+	for range tokens {
+		if err := c.SendPulse(ctx, pulse); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if received[0] != "token-1" || received[1] != "token-2" {
+		t.Errorf("Expected the provider's tokens, got %v", received)
+	}
+
+	// Provider errors are returned without the tokens handed out before
+	err := c.SendPulse(ctx, pulse)
+	if err == nil || strings.Contains(err.Error(), "token-2") {
+		t.Errorf("Expected a redacted provider error, got %v", err)
+	}
+	if s := fmt.Sprint(c); !strings.Contains(s, godestats.Redacted) {
+		t.Errorf("Expected the client to report a token, got %s", s)
+	}
+}
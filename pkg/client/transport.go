@@ -0,0 +1,69 @@
+package client
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Transport defaults tuned for clients that poll the API frequently
+const (
+	// DefaultTimeout is the overall timeout of a single request.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxIdleConnsPerHost is the number of keep-alive connections kept per host.
+	DefaultMaxIdleConnsPerHost = 10
+	// DefaultIdleConnTimeout is how long idle keep-alive connections are kept open.
+	DefaultIdleConnTimeout = 90 * time.Second
+	// DefaultTLSSessionCacheSize is the number of TLS sessions cached for resumption.
+	DefaultTLSSessionCacheSize = 64
+)
+
+// maxDrainBytes limits how much of an unread response body is discarded to allow
+// connection reuse. Larger bodies are cheaper to abandon than to read.
+const maxDrainBytes = 64 << 10
+
+// defaultTransport is shared by all clients without a custom HTTP client,
+// so that they share one connection pool.
+var defaultTransport = NewTransport()
+
+// NewTransport creates an http.Transport with defaults suited to the Code::Stats API:
+// keep-alive connections are reused across polls, TLS sessions are resumed,
+// and proxies are taken from the environment.
+func NewTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:       DefaultIdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		TLSClientConfig: &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ClientSessionCache: tls.NewLRUClientSessionCache(DefaultTLSSessionCacheSize),
+		},
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests, e.g. to configure
+// a custom transport, proxy or timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// closeBody discards the rest of a response body and closes it, so the
+// underlying connection can be reused for the next request.
+func closeBody(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// newConnCountingServer starts a server that counts new connections.
+func newConnCountingServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server, &conns
+}
+
+func TestClient_ReusesConnections(t *testing.T) {
+	// Unread bodies larger than the transport's read buffer can prevent connection reuse
+	padding := strings.Repeat(" ", 16<<10)
+
+	requests := 0
+	server, conns := newConnCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ok": "Great success!"}` + padding))
+		case requests%2 == 0:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Not found"}` + padding))
+		default:
+			w.Write([]byte(`{"user": "testuser", "total_xp": 10}`))
+		}
+	})
+
+	c := NewWithBaseURL("token", server.URL)
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		c.GetUserProfile(ctx, "testuser")
+	}
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 1}}}
+	if err := c.SendPulse(ctx, pulse); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := c.SendPulse(ctx, pulse); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Errorf("Expected all requests to share 1 connection, got %d connections", n)
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	transport := NewTransport()
+
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("Expected %d idle connections per host, got %d", DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("Expected idle timeout %v, got %v", DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.ClientSessionCache == nil {
+		t.Error("Expected TLS session cache")
+	}
+	if transport.Proxy == nil {
+		t.Error("Expected proxy from environment")
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	used := false
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user": "testuser"}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewWithBaseURL("", server.URL, WithHTTPClient(httpClient)).GetUserProfile(context.Background(), "testuser"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !used {
+		t.Error("Expected the custom HTTP client to be used")
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
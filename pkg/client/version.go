@@ -0,0 +1,99 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// API version negotiation
+const (
+	// APIVersionHeader is the request header announcing the API version the client speaks.
+	APIVersionHeader = "X-Code-Stats-API-Version"
+	// APIVersion is the version of the API this library implements.
+	APIVersion = "1"
+)
+
+// Minimum instance versions of the version-dependent features. Instances announcing
+// an older version in VersionHeader are not probed for them.
+const (
+	// MinGraphQLVersion is the first version with the profile GraphQL endpoint.
+	MinGraphQLVersion = "2.1.0"
+	// MinLiveUpdatesVersion is the first version with the live update WebSocket.
+	MinLiveUpdatesVersion = "2.0.0"
+)
+
+// ServerVersion returns the version the instance announced in the most recent
+// response, or the version of the capabilities if no response announced one.
+// It is "" if the version is unknown, e.g. before the first request.
+func (c *Client) ServerVersion() string {
+	if version := c.announcedVersion(); version != "" {
+		return version
+	}
+
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+	if c.caps != nil {
+		return c.caps.Version
+	}
+	return ""
+}
+
+// announcedVersion returns the version announced in the most recent response.
+func (c *Client) announcedVersion() string {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	return c.serverVersion
+}
+
+// setServerVersion records the version announced by a response, if any.
+func (c *Client) setServerVersion(version string) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return
+	}
+	c.versionMu.Lock()
+	c.serverVersion = version
+	c.versionMu.Unlock()
+}
+
+// versionBefore reports whether version is known to be older than min. Unknown
+// or unparsable versions are not, so that features are assumed to be available.
+func versionBefore(version, min string) bool {
+	v, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	m, _ := parseVersion(min)
+	for i := range v {
+		if v[i] != m[i] {
+			return v[i] < m[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses a version such as "2.1.0" or "v2.1-rc1" into its major,
+// minor and patch numbers, ignoring pre-release and build suffixes.
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return parts, false
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) > len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
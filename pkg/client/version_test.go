@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestClient_ServerVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(APIVersionHeader); got != APIVersion {
+			t.Errorf("Expected API version %s, got %q", APIVersion, got)
+		}
+		w.Header().Set(VersionHeader, "2.3.1")
+		w.Write([]byte(`{"user": "alice", "total_xp": 10}`))
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL).(*Client)
+	if v := c.ServerVersion(); v != "" {
+		t.Errorf("Expected no version before the first request, got %s", v)
+	}
+
+	if _, err := c.GetUserProfile(context.Background(), "alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v := c.ServerVersion(); v != "2.3.1" {
+		t.Errorf("Expected version 2.3.1, got %s", v)
+	}
+
+	preset := NewWithBaseURL("", server.URL, WithCapabilities(godestats.Capabilities{Version: "1.0.0"})).(*Client)
+	if v := preset.ServerVersion(); v != "1.0.0" {
+		t.Errorf("Expected the version of the capabilities, got %s", v)
+	}
+}
+
+func TestClient_CapabilitiesVersionGating(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set(VersionHeader, "2.0.5")
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	c := NewWithBaseURL("", server.URL).(*Client)
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := godestats.Capabilities{Version: "2.0.5", MyProfile: true, LiveUpdates: true}
+	if caps != expected {
+		t.Errorf("Expected %+v, got %+v", expected, caps)
+	}
+	for _, path := range paths {
+		if path == graphQLPath {
+			t.Errorf("Expected no GraphQL probe for version 2.0.5")
+		}
+	}
+}
+
+func TestVersionBefore(t *testing.T) {
+	tests := []struct {
+		version, min string
+		expected     bool
+	}{
+		{"2.0.0", "2.1.0", true},
+		{"2.1.0", "2.1.0", false},
+		{"2.10", "2.9.0", false},
+		{"v1.9.9-rc1", "2.0.0", true},
+		{"3", "2.1.0", false},
+		{"", "2.1.0", false},
+		{"nightly", "2.1.0", false},
+		{"1.0.0", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := versionBefore(tt.version, tt.min); got != tt.expected {
+			t.Errorf("versionBefore(%q, %q): expected %v, got %v", tt.version, tt.min, tt.expected, got)
+		}
+	}
+}
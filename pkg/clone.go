@@ -0,0 +1,154 @@
+package godestats
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Clone returns a deep copy of the profile that shares no maps or slices with the original.
+func (p *UserProfile) Clone() *UserProfile {
+	if p == nil {
+		return nil
+	}
+
+	clone := *p
+
+	if p.Machines != nil {
+		clone.Machines = make(map[string]MachineInfo, len(p.Machines))
+		for name, info := range p.Machines {
+			clone.Machines[name] = info
+		}
+	}
+
+	if p.Languages != nil {
+		clone.Languages = make(map[string]LanguageInfo, len(p.Languages))
+		for name, info := range p.Languages {
+			clone.Languages[name] = info
+		}
+	}
+
+	if p.Dates != nil {
+		clone.Dates = make(map[string]XP, len(p.Dates))
+		for date, xp := range p.Dates {
+			clone.Dates[date] = xp
+		}
+	}
+
+	if p.Raw != nil {
+		clone.Raw = make(map[string]json.RawMessage, len(p.Raw))
+		for name, value := range p.Raw {
+			clone.Raw[name] = append(json.RawMessage(nil), value...)
+		}
+	}
+
+	return &clone
+}
+
+// Public returns a copy of the profile without the data that only the authenticated
+// profile endpoint returns, such as the machines' last activity and token IDs, and
+// without unknown fields. Services exposing profiles to anyone serve the copy, since
+// the client falls back to the authenticated endpoint for the token owner.
+func (p *UserProfile) Public() *UserProfile {
+	public := p.Clone()
+	if public == nil {
+		return nil
+	}
+
+	for name, info := range public.Machines {
+		info.LastActive = time.Time{}
+		info.TokenID = ""
+		public.Machines[name] = info
+	}
+	public.Raw = nil
+
+	return public
+}
+
+// Equal reports whether two profiles contain the same data.
+// Nil and empty maps are considered equal, and the recent period is ignored
+// so that snapshots fetched at different times can be compared.
+func (p *UserProfile) Equal(other *UserProfile) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	if p.User != other.User || p.TotalXP != other.TotalXP || p.NewXP != other.NewXP {
+		return false
+	}
+
+	if len(p.Machines) != len(other.Machines) {
+		return false
+	}
+	for name, info := range p.Machines {
+		otherInfo, ok := other.Machines[name]
+		if !ok || !info.Equal(otherInfo) {
+			return false
+		}
+	}
+
+	if len(p.Languages) != len(other.Languages) {
+		return false
+	}
+	for name, info := range p.Languages {
+		if otherInfo, ok := other.Languages[name]; !ok || info != otherInfo {
+			return false
+		}
+	}
+
+	if len(p.Dates) != len(other.Dates) {
+		return false
+	}
+	for date, xp := range p.Dates {
+		if otherXP, ok := other.Dates[date]; !ok || xp != otherXP {
+			return false
+		}
+	}
+
+	if len(p.Raw) != len(other.Raw) {
+		return false
+	}
+	for name, value := range p.Raw {
+		if otherValue, ok := other.Raw[name]; !ok || !bytes.Equal(value, otherValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether two machine entries contain the same data.
+func (m MachineInfo) Equal(other MachineInfo) bool {
+	return m.XPs == other.XPs &&
+		m.NewXPs == other.NewXPs &&
+		m.LastActive.Equal(other.LastActive) &&
+		m.TokenID == other.TokenID
+}
+
+// Equal reports whether two language entries contain the same data.
+func (l LanguageInfo) Equal(other LanguageInfo) bool {
+	return l == other
+}
+
+// Clone returns a deep copy of the pulse that shares no slices with the original.
+func (p Pulse) Clone() Pulse {
+	clone := p
+	if p.XPs != nil {
+		clone.XPs = append([]LanguageXP(nil), p.XPs...)
+	}
+	return clone
+}
+
+// Equal reports whether two pulses have the same timestamp and the same
+// language XPs in the same order.
+func (p Pulse) Equal(other Pulse) bool {
+	if !p.CodedAt.Equal(other.CodedAt) || len(p.XPs) != len(other.XPs) {
+		return false
+	}
+	for i := range p.XPs {
+		if p.XPs[i] != other.XPs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package godestats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func newTestProfile() *UserProfile {
+	return &UserProfile{
+		User:    "testuser",
+		TotalXP: 1000,
+		NewXP:   50,
+		Machines: map[string]MachineInfo{
+			"laptop": {XPs: 1000, NewXPs: 50, LastActive: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Languages: map[string]LanguageInfo{
+			"Go": {XPs: 1000, NewXPs: 50},
+		},
+		Dates: map[string]XP{"2023-01-01": 50},
+		Raw:   map[string]json.RawMessage{"country": json.RawMessage(`"DE"`)},
+	}
+}
+
+func TestUserProfile_Clone(t *testing.T) {
+	original := newTestProfile()
+	clone := original.Clone()
+
+	if !original.Equal(clone) {
+		t.Fatal("Expected clone to equal original")
+	}
+
+	clone.Languages["Go"] = LanguageInfo{XPs: 1}
+	clone.Dates["2023-01-02"] = 10
+	clone.Raw["country"][1] = 'X'
+
+	if original.Languages["Go"].XPs != 1000 {
+		t.Error("Modifying clone languages affected original")
+	}
+	if len(original.Dates) != 1 {
+		t.Error("Modifying clone dates affected original")
+	}
+	if string(original.Raw["country"]) != `"DE"` {
+		t.Error("Modifying clone raw fields affected original")
+	}
+
+	var nilProfile *UserProfile
+	if nilProfile.Clone() != nil {
+		t.Error("Expected nil clone of nil profile")
+	}
+}
+
+func TestUserProfile_Public(t *testing.T) {
+	profile := &UserProfile{
+		User: "alice",
+		Machines: map[string]MachineInfo{
+			"laptop": {XPs: 1000, NewXPs: 50, LastActive: time.Now(), TokenID: "token-1"},
+		},
+		Raw: map[string]json.RawMessage{"email": json.RawMessage(`"alice@example.com"`)},
+	}
+
+	public := profile.Public()
+	if machine := public.Machines["laptop"]; machine != (MachineInfo{XPs: 1000, NewXPs: 50}) {
+		t.Errorf("Expected only the machine's XP, got %+v", machine)
+	}
+	if public.Raw != nil {
+		t.Errorf("Expected no unknown fields, got %v", public.Raw)
+	}
+	if profile.Machines["laptop"].TokenID != "token-1" || profile.Raw == nil {
+		t.Error("Expected the original profile to be unchanged")
+	}
+
+	if (*UserProfile)(nil).Public() != nil {
+		t.Error("Expected nil for a nil profile")
+	}
+}
+
+func TestUserProfile_Equal(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(p *UserProfile)
+		expected bool
+	}{
+		{"identical", func(p *UserProfile) {}, true},
+		{"different total", func(p *UserProfile) { p.TotalXP++ }, false},
+		{"different language", func(p *UserProfile) { p.Languages["Go"] = LanguageInfo{XPs: 1} }, false},
+		{"extra date", func(p *UserProfile) { p.Dates["2023-01-02"] = 1 }, false},
+		{"different machine activity", func(p *UserProfile) {
+			p.Machines["laptop"] = MachineInfo{XPs: 1000, NewXPs: 50}
+		}, false},
+		{"different raw field", func(p *UserProfile) { p.Raw["country"] = json.RawMessage(`"FR"`) }, false},
+		{"same instant in other zone", func(p *UserProfile) {
+			m := p.Machines["laptop"]
+			m.LastActive = m.LastActive.In(time.FixedZone("UTC+1", 3600))
+			p.Machines["laptop"] = m
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := newTestProfile()
+			tt.modify(other)
+			if result := newTestProfile().Equal(other); result != tt.expected {
+				t.Errorf("Expected Equal() = %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if newTestProfile().Equal(nil) {
+		t.Error("Expected profile not to equal nil")
+	}
+}
+
+func TestPulse_CloneEqual(t *testing.T) {
+	pulse := Pulse{
+		CodedAt: time.Now(),
+		XPs:     []LanguageXP{{Language: "Go", XP: 10}},
+	}
+
+	clone := pulse.Clone()
+	if !pulse.Equal(clone) {
+		t.Fatal("Expected clone to equal original")
+	}
+
+	clone.XPs[0].XP = 20
+	if pulse.XPs[0].XP != 10 {
+		t.Error("Modifying clone XPs affected original")
+	}
+	if pulse.Equal(clone) {
+		t.Error("Expected modified clone not to equal original")
+	}
+}
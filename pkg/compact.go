@@ -0,0 +1,129 @@
+package godestats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrCompactOverflow is returned when a daily XP value does not fit the compact representation.
+var ErrCompactOverflow = errors.New("daily XP exceeds compact representation")
+
+// secondsPerDay is the length of a calendar day in UTC.
+const secondsPerDay = 24 * 60 * 60
+
+// CompactDates is a memory-efficient representation of a profile's Dates map,
+// storing sorted day ordinals and daily XP in parallel slices. It uses a fraction of
+// the memory of the map and contains no pointers, which reduces garbage collection
+// work when many profiles are held in memory, e.g. in a cache.
+type CompactDates struct {
+	// Days holds the days as ordinals (days since 1970-01-01) in ascending order.
+	Days []int32
+	// XPs holds the XP gained on the day at the same index.
+	XPs []int32
+}
+
+// DayOrdinal returns the number of days between 1970-01-01 and the calendar day of t,
+// taken in t's own location.
+func DayOrdinal(t time.Time) int32 {
+	return DateOf(t).Ordinal()
+}
+
+// OrdinalDate returns midnight UTC of the day with the given ordinal.
+func OrdinalDate(ordinal int32) time.Time {
+	return time.Unix(int64(ordinal)*secondsPerDay, 0).UTC()
+}
+
+// NewCompactDates converts a Dates map into its compact representation.
+// Keys that cannot be parsed and values that don't fit into an int32 are skipped
+// and reported in the returned error, so the valid part is always usable.
+func NewCompactDates(dates map[string]XP) (CompactDates, error) {
+	type entry struct {
+		day int32
+		xp  int32
+	}
+
+	entries := make([]entry, 0, len(dates))
+	var errs []error
+	for key, xp := range dates {
+		date, err := ParseDate(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid date key %q: %w", key, err))
+			continue
+		}
+		if xp > math.MaxInt32 || xp < math.MinInt32 {
+			errs = append(errs, fmt.Errorf("%w: %s on %s", ErrCompactOverflow, xp, key))
+			continue
+		}
+		entries = append(entries, entry{day: date.Ordinal(), xp: int32(xp)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].day < entries[j].day
+	})
+
+	c := CompactDates{
+		Days: make([]int32, len(entries)),
+		XPs:  make([]int32, len(entries)),
+	}
+	for i, e := range entries {
+		c.Days[i], c.XPs[i] = e.day, e.xp
+	}
+
+	return c, errors.Join(errs...)
+}
+
+// CompactDates returns the compact representation of the profile's Dates map.
+func (p *UserProfile) CompactDates() (CompactDates, error) {
+	return NewCompactDates(p.Dates)
+}
+
+// Len returns the number of days with an entry.
+func (c CompactDates) Len() int {
+	return len(c.Days)
+}
+
+// XPOn returns the XP gained on the calendar day of the given time,
+// taken in the time's own location like UserProfile.XPOn.
+func (c CompactDates) XPOn(date time.Time) XP {
+	return c.XPOnDate(DateOf(date))
+}
+
+// XPOnDate returns the XP gained on the given day.
+func (c CompactDates) XPOnDate(date Date) XP {
+	day := date.Ordinal()
+	i := sort.Search(len(c.Days), func(i int) bool { return c.Days[i] >= day })
+	if i < len(c.Days) && c.Days[i] == day {
+		return XP(c.XPs[i])
+	}
+	return 0
+}
+
+// Total returns the sum of XP over all days.
+func (c CompactDates) Total() XP {
+	var total XP
+	for _, xp := range c.XPs {
+		total += XP(xp)
+	}
+	return total
+}
+
+// Map converts the compact representation back into a Dates map.
+func (c CompactDates) Map() map[string]XP {
+	dates := make(map[string]XP, len(c.Days))
+	for i, day := range c.Days {
+		dates[DateOf(OrdinalDate(day)).String()] = XP(c.XPs[i])
+	}
+	return dates
+}
+
+// Series returns the days as a sorted date series.
+func (c CompactDates) Series() []DateXP {
+	series := make([]DateXP, len(c.Days))
+	for i, day := range c.Days {
+		series[i] = DateXP{Date: DateOf(OrdinalDate(day)), XP: XP(c.XPs[i])}
+	}
+	return series
+}
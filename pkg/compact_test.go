@@ -0,0 +1,114 @@
+package godestats
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDayOrdinal(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected int32
+	}{
+		{"epoch", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"end of day", time.Date(1970, 1, 2, 23, 59, 59, 0, time.UTC), 1},
+		{"before epoch", time.Date(1969, 12, 31, 12, 0, 0, 0, time.UTC), -1},
+		{"local day", time.Date(2023, 6, 15, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60)), 19523},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DayOrdinal(tt.time); result != tt.expected {
+				t.Errorf("Expected ordinal %d, got %d", tt.expected, result)
+			}
+		})
+	}
+
+	if date := OrdinalDate(19523); date.Format(DateFormat) != "2023-06-15" {
+		t.Errorf("Expected 2023-06-15, got %s", date.Format(DateFormat))
+	}
+}
+
+func TestCompactDates(t *testing.T) {
+	dates := map[string]XP{
+		"2023-06-15": 40,
+		"2023-01-01": 10,
+		"2023-03-10": 25,
+		"bogus":      5,
+		"2023-06-16": 1 << 40,
+	}
+
+	c, err := NewCompactDates(dates)
+	if err == nil {
+		t.Error("Expected errors for invalid entries")
+	}
+	if !errors.Is(err, ErrCompactOverflow) {
+		t.Errorf("Expected ErrCompactOverflow in %v", err)
+	}
+
+	if c.Len() != 3 {
+		t.Fatalf("Expected 3 days, got %d", c.Len())
+	}
+	for i := 1; i < c.Len(); i++ {
+		if c.Days[i-1] >= c.Days[i] {
+			t.Errorf("Expected days in ascending order, got %v", c.Days)
+		}
+	}
+
+	if xp := c.XPOn(time.Date(2023, 3, 10, 18, 0, 0, 0, time.UTC)); xp != 25 {
+		t.Errorf("Expected 25 XP on 2023-03-10, got %d", xp)
+	}
+	if xp := c.XPOn(time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC)); xp != 0 {
+		t.Errorf("Expected 0 XP on 2023-03-11, got %d", xp)
+	}
+	if total := c.Total(); total != 75 {
+		t.Errorf("Expected total 75, got %d", total)
+	}
+
+	m := c.Map()
+	if len(m) != 3 || m["2023-06-15"] != 40 {
+		t.Errorf("Unexpected map: %v", m)
+	}
+
+	series := c.Series()
+	if len(series) != 3 || series[0].XP != 10 || series[0].Date != NewDate(2023, 1, 1) {
+		t.Errorf("Unexpected series: %v", series)
+	}
+}
+
+func TestCompactDates_MatchesProfile(t *testing.T) {
+	profile := &UserProfile{Dates: map[string]XP{}}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 400; i += 3 {
+		profile.Dates[start.AddDate(0, 0, i).Format(DateFormat)] = XP(i)
+	}
+
+	c, err := profile.CompactDates()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 400; i++ {
+		day := start.AddDate(0, 0, i)
+		if c.XPOn(day) != profile.XPOn(day) {
+			t.Fatalf("Mismatch on %s: %d != %d", day.Format(DateFormat), c.XPOn(day), profile.XPOn(day))
+		}
+	}
+}
+
+func BenchmarkCompactDates_XPOn(b *testing.B) {
+	dates := make(map[string]XP, 2000)
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2000; i++ {
+		dates[start.AddDate(0, 0, i).Format(DateFormat)] = XP(i)
+	}
+	c, _ := NewCompactDates(dates)
+	day := start.AddDate(0, 0, 1234)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.XPOn(day)
+	}
+}
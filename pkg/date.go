@@ -0,0 +1,136 @@
+package godestats
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// Date is a calendar day without a time of day or location, such as the keys of
+// the Dates map. The zero value is not a valid day and reports true from IsZero.
+// Dates are comparable with == and usable as map keys.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the given day, normalizing out-of-range values like time.Date,
+// e.g. October 32 becomes November 1.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar day of t, taken in t's own location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a day in the DateFormat layout, e.g. "2023-06-15".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateFormat, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String formats the day in the DateFormat layout. The zero Date formats as "".
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Time(time.UTC).Format(DateFormat)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Time returns midnight of the day in the given location (UTC if nil).
+func (d Date) Time(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the day n days after d, or before it if n is negative.
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year, d.Month, d.Day+n)
+}
+
+// DaysSince returns the number of days from other to d, negative if d is earlier.
+func (d Date) DaysSince(other Date) int {
+	return int(d.Ordinal() - other.Ordinal())
+}
+
+// Ordinal returns the number of days between 1970-01-01 and d.
+func (d Date) Ordinal() int32 {
+	return int32(d.Time(time.UTC).Unix() / secondsPerDay)
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.Time(time.UTC).Weekday()
+}
+
+// Compare returns -1 if d is before other, +1 if it is after, and 0 if they are equal.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return cmp.Compare(d.Year, other.Year)
+	case d.Month != other.Month:
+		return cmp.Compare(int(d.Month), int(other.Month))
+	default:
+		return cmp.Compare(d.Day, other.Day)
+	}
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// MarshalText encodes the day in the DateFormat layout, which also makes Date
+// usable as a JSON string and as a JSON object key.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a day in the DateFormat layout. An empty value decodes
+// as the zero Date.
+func (d *Date) UnmarshalText(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		*d = Date{}
+		return nil
+	}
+
+	date, err := ParseDate(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid date %q: %w", data, err)
+	}
+	*d = date
+	return nil
+}
+
+// Days iterates over the days from first through last, both inclusive.
+// Nothing is yielded if last is before first.
+func Days(first, last Date) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for day := first; !day.After(last); day = day.AddDays(1) {
+			if !yield(day) {
+				return
+			}
+		}
+	}
+}
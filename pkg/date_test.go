@@ -0,0 +1,142 @@
+package godestats
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Date
+		wantErr  bool
+	}{
+		{"2023-06-15", NewDate(2023, 6, 15), false},
+		{"2024-02-29", NewDate(2024, 2, 29), false},
+		{"2023-02-29", Date{}, true},
+		{"2023-6-15", Date{}, true},
+		{"15.06.2023", Date{}, true},
+		{"", Date{}, true},
+	}
+
+	for _, tt := range tests {
+		date, err := ParseDate(tt.input)
+		if (err != nil) != tt.wantErr || date != tt.expected {
+			t.Errorf("ParseDate(%q): expected %v (error %v), got %v (%v)", tt.input, tt.expected, tt.wantErr, date, err)
+		}
+		if err == nil && date.String() != tt.input {
+			t.Errorf("Expected %q to round-trip, got %q", tt.input, date.String())
+		}
+	}
+}
+
+func TestDate_Arithmetic(t *testing.T) {
+	date := NewDate(2023, 12, 31)
+
+	if next := date.AddDays(1); next != NewDate(2024, 1, 1) {
+		t.Errorf("Expected 2024-01-01, got %s", next)
+	}
+	if prev := NewDate(2024, 3, 1).AddDays(-1); prev != NewDate(2024, 2, 29) {
+		t.Errorf("Expected 2024-02-29, got %s", prev)
+	}
+	if normalized := NewDate(2023, 10, 32); normalized != NewDate(2023, 11, 1) {
+		t.Errorf("Expected 2023-11-01, got %s", normalized)
+	}
+	if days := NewDate(2024, 1, 10).DaysSince(date); days != 10 {
+		t.Errorf("Expected 10 days, got %d", days)
+	}
+	if ordinal := NewDate(1970, 1, 2).Ordinal(); ordinal != 1 {
+		t.Errorf("Expected ordinal 1, got %d", ordinal)
+	}
+	if wd := NewDate(2023, 6, 15).Weekday(); wd != time.Thursday {
+		t.Errorf("Expected Thursday, got %s", wd)
+	}
+}
+
+func TestDate_Compare(t *testing.T) {
+	a, b := NewDate(2023, 1, 31), NewDate(2023, 2, 1)
+
+	if !a.Before(b) || a.After(b) || a.Compare(b) != -1 {
+		t.Errorf("Expected %s before %s", a, b)
+	}
+	if !b.After(a) || b.Compare(a) != 1 {
+		t.Errorf("Expected %s after %s", b, a)
+	}
+	if a.Compare(a) != 0 || a.Before(a) || a.After(a) {
+		t.Errorf("Expected %s to equal itself", a)
+	}
+}
+
+func TestDateOf(t *testing.T) {
+	tz := time.FixedZone("UTC+2", 2*60*60)
+	instant := time.Date(2023, 6, 14, 23, 30, 0, 0, time.UTC)
+
+	if date := DateOf(instant); date != NewDate(2023, 6, 14) {
+		t.Errorf("Expected 2023-06-14 in UTC, got %s", date)
+	}
+	if date := DateOf(instant.In(tz)); date != NewDate(2023, 6, 15) {
+		t.Errorf("Expected 2023-06-15 in UTC+2, got %s", date)
+	}
+
+	midnight := NewDate(2023, 6, 15).Time(tz)
+	if !midnight.Equal(time.Date(2023, 6, 15, 0, 0, 0, 0, tz)) {
+		t.Errorf("Expected midnight in UTC+2, got %v", midnight)
+	}
+	if loc := NewDate(2023, 6, 15).Time(nil).Location(); loc != time.UTC {
+		t.Errorf("Expected UTC for a nil location, got %s", loc)
+	}
+}
+
+func TestDays(t *testing.T) {
+	var days []string
+	for day := range Days(NewDate(2023, 2, 27), NewDate(2023, 3, 2)) {
+		days = append(days, day.String())
+	}
+	expected := []string{"2023-02-27", "2023-02-28", "2023-03-01", "2023-03-02"}
+	if !slices.Equal(days, expected) {
+		t.Errorf("Expected %v, got %v", expected, days)
+	}
+
+	for day := range Days(NewDate(2023, 3, 2), NewDate(2023, 3, 1)) {
+		t.Errorf("Expected no days for an empty range, got %s", day)
+	}
+
+	count := 0
+	for range Days(NewDate(2023, 1, 1), NewDate(2023, 12, 31)) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("Expected iteration to stop after 3 days, got %d", count)
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	data, err := json.Marshal(DateXP{Date: NewDate(2023, 6, 15), XP: 40})
+	if err != nil || string(data) != `{"date":"2023-06-15","xp":40}` {
+		t.Errorf("Unexpected encoding: %s (%v)", data, err)
+	}
+
+	byDate, err := json.Marshal(map[Date]XP{NewDate(2023, 6, 15): 40})
+	if err != nil || string(byDate) != `{"2023-06-15":40}` {
+		t.Errorf("Unexpected map encoding: %s (%v)", byDate, err)
+	}
+
+	var decoded DateXP
+	if err := json.Unmarshal([]byte(`{"date":"2023-06-15","xp":40}`), &decoded); err != nil || decoded.Date != NewDate(2023, 6, 15) {
+		t.Errorf("Unexpected decoding: %+v (%v)", decoded, err)
+	}
+
+	var empty Date
+	if err := json.Unmarshal([]byte(`""`), &empty); err != nil || !empty.IsZero() {
+		t.Errorf("Expected the zero Date for an empty string, got %v (%v)", empty, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"June 15"`), &decoded.Date); err == nil {
+		t.Error("Expected an error for an invalid date")
+	}
+}
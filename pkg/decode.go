@@ -0,0 +1,84 @@
+package godestats
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownProfileFields caches the JSON field names of UserProfile.
+var (
+	knownProfileFields     map[string]bool
+	knownProfileFieldsOnce sync.Once
+)
+
+// UnmarshalProfile decodes a user profile from JSON.
+// If preserveUnknown is true, fields not known to this library are retained in
+// the profile's Raw map, giving access to new API fields before they are typed.
+func UnmarshalProfile(data []byte, preserveUnknown bool) (*UserProfile, error) {
+	var profile UserProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, err
+	}
+
+	if !preserveUnknown {
+		return &profile, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := profileFieldNames()
+	for name, value := range fields {
+		if known[name] {
+			continue
+		}
+		if profile.Raw == nil {
+			profile.Raw = make(map[string]json.RawMessage)
+		}
+		profile.Raw[name] = value
+	}
+
+	return &profile, nil
+}
+
+// MarshalProfile encodes a user profile as JSON like json.Marshal, but with the
+// fields of its Raw map added, so that UnmarshalProfile with preserveUnknown
+// restores them. Caches and stores use it to keep unknown fields.
+func MarshalProfile(profile *UserProfile) ([]byte, error) {
+	data, err := json.Marshal(profile)
+	if err != nil || profile == nil || len(profile.Raw) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := profileFieldNames()
+	for name, value := range profile.Raw {
+		if !known[name] {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// profileFieldNames returns the set of JSON field names decoded into UserProfile.
+func profileFieldNames() map[string]bool {
+	knownProfileFieldsOnce.Do(func() {
+		knownProfileFields = make(map[string]bool)
+		t := reflect.TypeOf(UserProfile{})
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if name != "" && name != "-" {
+				knownProfileFields[name] = true
+			}
+		}
+	})
+	return knownProfileFields
+}
@@ -0,0 +1,88 @@
+package godestats
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const profileWithUnknownFields = `{
+	"user": "testuser",
+	"total_xp": 1000,
+	"new_xp": 50,
+	"languages": {"Go": {"xps": 1000, "new_xps": 50}},
+	"badges": ["early-bird"],
+	"country": "DE"
+}`
+
+func TestUnmarshalProfile(t *testing.T) {
+	profile, err := UnmarshalProfile([]byte(profileWithUnknownFields), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if profile.User != "testuser" || profile.TotalXP != 1000 {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+	if profile.Raw != nil {
+		t.Errorf("Expected no raw fields without preservation, got %v", profile.Raw)
+	}
+}
+
+func TestUnmarshalProfile_PreserveUnknown(t *testing.T) {
+	profile, err := UnmarshalProfile([]byte(profileWithUnknownFields), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if profile.Languages["Go"].XPs != 1000 {
+		t.Errorf("Expected typed fields to be decoded, got %+v", profile.Languages)
+	}
+
+	if len(profile.Raw) != 2 {
+		t.Fatalf("Expected 2 raw fields, got %d: %v", len(profile.Raw), profile.Raw)
+	}
+	if string(profile.Raw["country"]) != `"DE"` {
+		t.Errorf("Expected raw country '\"DE\"', got '%s'", profile.Raw["country"])
+	}
+	if _, ok := profile.Raw["user"]; ok {
+		t.Error("Expected known fields not to be retained in Raw")
+	}
+}
+
+func TestMarshalProfile(t *testing.T) {
+	profile, err := UnmarshalProfile([]byte(profileWithUnknownFields), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	profile.Raw["user"] = json.RawMessage(`"mallory"`)
+
+	data, err := MarshalProfile(profile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded, err := UnmarshalProfile(data, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	delete(profile.Raw, "user")
+	if !decoded.Equal(profile) || string(decoded.Raw["country"]) != `"DE"` || string(decoded.Raw["badges"]) != `["early-bird"]` {
+		t.Errorf("Expected the profile and its unknown fields to round-trip, got %+v", decoded)
+	}
+	if decoded.User != "testuser" {
+		t.Errorf("Expected Raw not to override known fields, got user %q", decoded.User)
+	}
+
+	// Without unknown fields, profiles encode like json.Marshal
+	plain := &UserProfile{User: "alice", TotalXP: 10}
+	expected, _ := json.Marshal(plain)
+	if data, err := MarshalProfile(plain); err != nil || string(data) != string(expected) {
+		t.Errorf("Expected %s, got %s (%v)", expected, data, err)
+	}
+}
+
+func TestUnmarshalProfile_InvalidJSON(t *testing.T) {
+	if _, err := UnmarshalProfile([]byte(`{"user": `), true); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
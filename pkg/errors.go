@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
 // Common error variables that consumers can check against
@@ -29,6 +31,13 @@ var (
 
 	// ErrRateLimited is returned when the API rate limit is exceeded
 	ErrRateLimited = errors.New("API rate limit exceeded")
+
+	// ErrUnsupported is returned when the Code::Stats instance lacks the requested feature
+	ErrUnsupported = errors.New("not supported by this Code::Stats instance")
+
+	// ErrLocationIgnored is returned by the deprecated UserProfile.DateSeries when it
+	// is passed a location other than UTC, which dates don't have
+	ErrLocationIgnored = errors.New("location ignored: dates have no time zone, use Date.Time")
 )
 
 // APIError represents an error response from the Code::Stats API
@@ -51,6 +60,26 @@ func (e *APIError) IsTemporary() bool {
 	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
 }
 
+// RateLimitError is returned when the API rate limit is exceeded. It matches
+// ErrRateLimited with errors.Is and carries the delay requested by the API, if any.
+type RateLimitError struct {
+	// RetryAfter is how long to wait before retrying, or 0 if the API did not say.
+	RetryAfter time.Duration `json:"retry_after"`
+}
+
+// Error implements the error interface for RateLimitError
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v, retry after %s", ErrRateLimited, e.RetryAfter)
+	}
+	return ErrRateLimited.Error()
+}
+
+// Unwrap returns ErrRateLimited so that errors.Is matches it
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
 // NetworkError wraps network-related errors with additional context
 type NetworkError struct {
 	Operation string `json:"operation"`
@@ -86,6 +115,23 @@ func (e *NetworkError) IsTemporary() bool {
 		strings.Contains(errMsg, "connection reset")
 }
 
+// RequestError annotates the error of a call with the ID of its request, see
+// RequestID. Its message is that of the wrapped error.
+type RequestError struct {
+	RequestID string `json:"request_id"`
+	Err       error  `json:"error"`
+}
+
+// Error implements the error interface for RequestError
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error for error unwrapping
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
 // Helper functions for creating specific errors
 
 // NewAPIError creates a new APIError with the given status code and message
@@ -97,11 +143,15 @@ func NewAPIError(statusCode int, message, endpoint string) *APIError {
 	}
 }
 
-// NewNetworkError creates a new NetworkError with context
-func NewNetworkError(operation, url string, err error) *NetworkError {
+// NewNetworkError creates a new NetworkError with context. Credentials in the URL,
+// also in a wrapped *url.Error, are redacted (see RedactURL).
+func NewNetworkError(operation, rawURL string, err error) *NetworkError {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = &url.Error{Op: urlErr.Op, URL: RedactURL(urlErr.URL), Err: urlErr.Err}
+	}
 	return &NetworkError{
 		Operation: operation,
-		URL:       url,
+		URL:       RedactURL(rawURL),
 		Err:       err,
 	}
 }
@@ -181,6 +231,26 @@ func IsRateLimited(err error) bool {
 	return false
 }
 
+// RetryAfter returns how long the API asked to wait before retrying after a
+// rate-limited request. The boolean result is false if the error does not carry a delay.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) && rateErr.RetryAfter > 0 {
+		return rateErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// RequestID returns the ID of the request that failed with the error, e.g. to
+// include it in bug reports, and false if the error carries none.
+func RequestID(err error) (string, bool) {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.RequestID != "" {
+		return reqErr.RequestID, true
+	}
+	return "", false
+}
+
 // IsNetworkError checks if an error is a network-related error
 func IsNetworkError(err error) bool {
 	if err == nil {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestAPIError(t *testing.T) {
@@ -146,6 +147,7 @@ func TestIsRateLimited(t *testing.T) {
 		{"nil error", nil, false},
 		{"ErrRateLimited", ErrRateLimited, true},
 		{"429 API error", NewAPIError(429, "Too many requests", ""), true},
+		{"RateLimitError", &RateLimitError{RetryAfter: time.Minute}, true},
 		{"500 API error", NewAPIError(500, "Server error", ""), false},
 		{"other error", errors.New("random error"), false},
 	}
@@ -160,6 +162,58 @@ func TestIsRateLimited(t *testing.T) {
 	}
 }
 
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected time.Duration
+		ok       bool
+	}{
+		{"nil error", nil, 0, false},
+		{"ErrRateLimited", ErrRateLimited, 0, false},
+		{"without delay", &RateLimitError{}, 0, false},
+		{"with delay", &RateLimitError{RetryAfter: 30 * time.Second}, 30 * time.Second, true},
+		{"wrapped", fmt.Errorf("fetch: %w", &RateLimitError{RetryAfter: time.Minute}), time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := RetryAfter(tt.err)
+			if delay != tt.expected || ok != tt.ok {
+				t.Errorf("Expected RetryAfter() = %v, %v, got %v, %v", tt.expected, tt.ok, delay, ok)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	reqErr := &RequestError{RequestID: "req-1", Err: ErrUnauthorized}
+	if reqErr.Error() != ErrUnauthorized.Error() || !errors.Is(reqErr, ErrUnauthorized) {
+		t.Errorf("Expected the request error to behave like the wrapped error, got %v", reqErr)
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+		ok       bool
+	}{
+		{"nil error", nil, "", false},
+		{"without request ID", ErrUnauthorized, "", false},
+		{"with request ID", reqErr, "req-1", true},
+		{"wrapped", fmt.Errorf("watching alice: %w", reqErr), "req-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := RequestID(tt.err)
+			if id != tt.expected || ok != tt.ok {
+				t.Errorf("Expected RequestID() = %q, %v, got %q, %v", tt.expected, tt.ok, id, ok)
+			}
+		})
+	}
+}
+
 func TestIsNetworkError(t *testing.T) {
 	tests := []struct {
 		name     string
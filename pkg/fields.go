@@ -0,0 +1,106 @@
+package godestats
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ProfileFields selects the optional sections of a profile.
+// The user name and XP totals are always included.
+type ProfileFields uint8
+
+// Optional profile sections
+const (
+	FieldMachines ProfileFields = 1 << iota
+	FieldLanguages
+	FieldDates
+
+	// AllFields selects every section.
+	AllFields = FieldMachines | FieldLanguages | FieldDates
+)
+
+// Has reports whether all sections in other are selected.
+func (f ProfileFields) Has(other ProfileFields) bool {
+	return f&other == other
+}
+
+// PartialProfileClient is implemented by clients that can fetch selected profile sections,
+// avoiding the cost of decoding large language and date maps when only totals are needed.
+type PartialProfileClient interface {
+	// GetUserProfilePartial retrieves a profile containing only the selected sections.
+	GetUserProfilePartial(ctx context.Context, username string, fields ProfileFields, opts ...CallOption) (*UserProfile, error)
+}
+
+// GetUserProfileFields retrieves a profile with the selected sections, using a partial
+// fetch if the client supports it and filtering a full profile otherwise.
+func GetUserProfileFields(ctx context.Context, client ProfileClient, username string, fields ProfileFields, opts ...CallOption) (*UserProfile, error) {
+	if partial, ok := client.(PartialProfileClient); ok {
+		return partial.GetUserProfilePartial(ctx, username, fields, opts...)
+	}
+
+	profile, err := client.GetUserProfile(ctx, username, opts...)
+	if err != nil {
+		return nil, err
+	}
+	profile.SelectFields(fields)
+	return profile, nil
+}
+
+// SelectFields removes all sections from the profile that are not selected.
+func (p *UserProfile) SelectFields(fields ProfileFields) {
+	if !fields.Has(FieldMachines) {
+		p.Machines = nil
+	}
+	if !fields.Has(FieldLanguages) {
+		p.Languages = nil
+	}
+	if !fields.Has(FieldDates) {
+		p.Dates = nil
+	}
+}
+
+// UnmarshalProfileFields decodes only the selected sections of a user profile from JSON.
+// Unselected sections are validated but not decoded, so no maps are allocated for them.
+func UnmarshalProfileFields(data []byte, fields ProfileFields) (*UserProfile, error) {
+	var profile UserProfile
+	partial := struct {
+		User      *string     `json:"user"`
+		TotalXP   *XP         `json:"total_xp"`
+		NewXP     *XP         `json:"new_xp"`
+		Machines  lazySection `json:"machines"`
+		Languages lazySection `json:"languages"`
+		Dates     lazySection `json:"dates"`
+	}{
+		User:    &profile.User,
+		TotalXP: &profile.TotalXP,
+		NewXP:   &profile.NewXP,
+	}
+
+	if fields.Has(FieldMachines) {
+		partial.Machines.target = &profile.Machines
+	}
+	if fields.Has(FieldLanguages) {
+		partial.Languages.target = &profile.Languages
+	}
+	if fields.Has(FieldDates) {
+		partial.Dates.target = &profile.Dates
+	}
+
+	if err := json.Unmarshal(data, &partial); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// lazySection decodes a JSON value into target, or skips it if target is nil.
+type lazySection struct {
+	target any
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *lazySection) UnmarshalJSON(data []byte) error {
+	if s.target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, s.target)
+}
@@ -0,0 +1,90 @@
+package godestats
+
+import (
+	"context"
+	"testing"
+)
+
+const fieldsTestJSON = `{
+	"user": "alice",
+	"total_xp": 300,
+	"new_xp": 20,
+	"machines": {"laptop": {"xps": 300, "new_xps": 20}},
+	"languages": {"Go": {"xps": 300, "new_xps": 20}},
+	"dates": {"2023-06-15": 20}
+}`
+
+func TestUnmarshalProfileFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		fields    ProfileFields
+		machines  bool
+		languages bool
+		dates     bool
+	}{
+		{"totals only", 0, false, false, false},
+		{"languages", FieldLanguages, false, true, false},
+		{"machines and dates", FieldMachines | FieldDates, true, false, true},
+		{"all", AllFields, true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := UnmarshalProfileFields([]byte(fieldsTestJSON), tt.fields)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if profile.User != "alice" || profile.TotalXP != 300 || profile.NewXP != 20 {
+				t.Errorf("Expected totals to always be decoded, got %+v", profile)
+			}
+			if (profile.Machines != nil) != tt.machines {
+				t.Errorf("Expected machines decoded = %v", tt.machines)
+			}
+			if (profile.Languages != nil) != tt.languages {
+				t.Errorf("Expected languages decoded = %v", tt.languages)
+			}
+			if (profile.Dates != nil) != tt.dates {
+				t.Errorf("Expected dates decoded = %v", tt.dates)
+			}
+		})
+	}
+
+	if _, err := UnmarshalProfileFields([]byte(`{"user": "alice", "dates": [}`), 0); err == nil {
+		t.Error("Expected error for invalid JSON in a skipped section")
+	}
+}
+
+// fullClient only supports fetching full profiles.
+type fullClient struct{}
+
+func (fullClient) GetUserProfile(ctx context.Context, username string, opts ...CallOption) (*UserProfile, error) {
+	return UnmarshalProfile([]byte(fieldsTestJSON), false)
+}
+
+func (fullClient) SendPulse(ctx context.Context, pulse Pulse, opts ...CallOption) error {
+	return nil
+}
+
+func TestGetUserProfileFields_Fallback(t *testing.T) {
+	profile, err := GetUserProfileFields(context.Background(), fullClient{}, "alice", FieldDates)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if profile.Machines != nil || profile.Languages != nil {
+		t.Error("Expected unselected sections to be removed")
+	}
+	if profile.Dates["2023-06-15"] != 20 {
+		t.Errorf("Expected dates to be kept, got %v", profile.Dates)
+	}
+}
+
+func TestProfileFields_Has(t *testing.T) {
+	if !AllFields.Has(FieldLanguages | FieldDates) {
+		t.Error("Expected AllFields to include languages and dates")
+	}
+	if FieldLanguages.Has(FieldDates) {
+		t.Error("Expected languages not to include dates")
+	}
+}
@@ -0,0 +1,134 @@
+// Package goals tracks daily and weekly XP targets against the activity recorded
+// in a profile's Dates map.
+package goals
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Period is the time span a goal's XP has to be gained in.
+type Period string
+
+// Supported goal periods. Weeks start on Monday.
+const (
+	Daily  Period = "daily"
+	Weekly Period = "weekly"
+)
+
+// Goal validation errors
+var (
+	ErrInvalidPeriod = errors.New("invalid goal period")
+	ErrInvalidTarget = errors.New("goal target must be positive")
+)
+
+// ParsePeriod parses a period name. Besides "daily" and "weekly" it accepts
+// "day", "today", "week" and "this-week", ignoring case.
+func ParsePeriod(s string) (Period, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "daily", "day", "today":
+		return Daily, nil
+	case "weekly", "week", "this-week":
+		return Weekly, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidPeriod, s)
+	}
+}
+
+// Goal is an amount of XP to gain within every period.
+type Goal struct {
+	Period Period       `json:"period"`
+	XP     godestats.XP `json:"xp"`
+}
+
+// Validate reports whether the goal has a known period and a positive target.
+func (g Goal) Validate() error {
+	if g.Period != Daily && g.Period != Weekly {
+		return fmt.Errorf("%w: %q", ErrInvalidPeriod, g.Period)
+	}
+	if g.XP <= 0 {
+		return ErrInvalidTarget
+	}
+	return nil
+}
+
+// Bounds returns the start and end of the period containing t, in t's location.
+func (p Period) Bounds(t time.Time) (start, end time.Time) {
+	start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if p == Weekly {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	}
+	return start, start.AddDate(0, 0, 1)
+}
+
+// Set returns the goals with the given goal added, replacing an existing goal of
+// the same period. Daily goals come before weekly goals.
+func Set(goals []Goal, goal Goal) []Goal {
+	result := []Goal{goal}
+	for _, g := range goals {
+		if g.Period != goal.Period {
+			result = append(result, g)
+		}
+	}
+
+	if len(result) > 1 && result[0].Period == Weekly {
+		result[0], result[1] = result[1], result[0]
+	}
+	return result
+}
+
+// Remove returns the goals without the goal of the given period.
+func Remove(goals []Goal, period Period) []Goal {
+	var result []Goal
+	for _, g := range goals {
+		if g.Period != period {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// Progress describes how much of a goal was reached in the current period.
+type Progress struct {
+	Goal   Goal         `json:"goal"`
+	Start  time.Time    `json:"start"`
+	End    time.Time    `json:"end"`
+	Gained godestats.XP `json:"gained"`
+}
+
+// Fraction returns the share of the goal's XP gained so far, which exceeds 1
+// once the goal was surpassed.
+func (p Progress) Fraction() float64 {
+	if p.Goal.XP <= 0 {
+		return 0
+	}
+	return float64(p.Gained) / float64(p.Goal.XP)
+}
+
+// Reached reports whether the goal's XP was gained.
+func (p Progress) Reached() bool {
+	return p.Gained >= p.Goal.XP
+}
+
+// Remaining returns the XP still needed to reach the goal, or 0 if it was reached.
+func (p Progress) Remaining() godestats.XP {
+	return max(p.Goal.XP-p.Gained, 0)
+}
+
+// Track returns the progress towards the goal in the period containing now,
+// with days taken in the location of now.
+func Track(profile *godestats.UserProfile, goal Goal, now time.Time) Progress {
+	start, end := goal.Period.Bounds(now)
+
+	var gained godestats.XP
+	for day := start; day.Before(end) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		gained += profile.XPOn(day)
+	}
+
+	return Progress{Goal: goal, Start: start, End: end, Gained: gained}
+}
@@ -0,0 +1,113 @@
+package goals
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Period
+		err      error
+	}{
+		{"daily", Daily, nil},
+		{"Today", Daily, nil},
+		{"week", Weekly, nil},
+		{" weekly ", Weekly, nil},
+		{"monthly", "", ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			period, err := ParsePeriod(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+			if period != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, period)
+			}
+		})
+	}
+}
+
+func TestGoal_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		goal Goal
+		err  error
+	}{
+		{"valid", Goal{Period: Daily, XP: 500}, nil},
+		{"unknown period", Goal{Period: "yearly", XP: 500}, ErrInvalidPeriod},
+		{"zero target", Goal{Period: Weekly}, ErrInvalidTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.goal.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSetAndRemove(t *testing.T) {
+	goals := Set(nil, Goal{Period: Weekly, XP: 3000})
+	goals = Set(goals, Goal{Period: Daily, XP: 500})
+	goals = Set(goals, Goal{Period: Weekly, XP: 4000})
+
+	if len(goals) != 2 || goals[0] != (Goal{Period: Daily, XP: 500}) || goals[1] != (Goal{Period: Weekly, XP: 4000}) {
+		t.Errorf("Expected daily then updated weekly goal, got %+v", goals)
+	}
+
+	goals = Remove(goals, Daily)
+	if len(goals) != 1 || goals[0].Period != Weekly {
+		t.Errorf("Expected only the weekly goal, got %+v", goals)
+	}
+}
+
+func TestTrack(t *testing.T) {
+	now := time.Date(2023, 6, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+	profile := &godestats.UserProfile{Dates: map[string]godestats.XP{
+		"2023-06-11": 1000, // Sunday of the previous week
+		"2023-06-12": 300,  // Monday
+		"2023-06-14": 200,  // Wednesday
+	}}
+
+	tests := []struct {
+		name      string
+		goal      Goal
+		gained    godestats.XP
+		start     time.Time
+		reached   bool
+		remaining godestats.XP
+	}{
+		{"daily", Goal{Period: Daily, XP: 500}, 200, time.Date(2023, 6, 14, 0, 0, 0, 0, time.UTC), false, 300},
+		{"weekly", Goal{Period: Weekly, XP: 400}, 500, time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC), true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := Track(profile, tt.goal, now)
+			if progress.Gained != tt.gained {
+				t.Errorf("Expected %d XP gained, got %d", tt.gained, progress.Gained)
+			}
+			if !progress.Start.Equal(tt.start) {
+				t.Errorf("Expected period to start %v, got %v", tt.start, progress.Start)
+			}
+			if progress.Reached() != tt.reached {
+				t.Errorf("Expected reached %v, got %v", tt.reached, progress.Reached())
+			}
+			if progress.Remaining() != tt.remaining {
+				t.Errorf("Expected %d XP remaining, got %d", tt.remaining, progress.Remaining())
+			}
+		})
+	}
+
+	if fraction := Track(profile, Goal{Period: Weekly, XP: 400}, now).Fraction(); fraction != 1.25 {
+		t.Errorf("Expected fraction 1.25, got %v", fraction)
+	}
+}
@@ -0,0 +1,72 @@
+package godestats
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+// LanguageGroups maps group names to the languages they combine, such as
+// "Web" for HTML, CSS and JavaScript.
+type LanguageGroups map[string][]string
+
+// DefaultLanguageGroups are groups of closely related languages.
+var DefaultLanguageGroups = LanguageGroups{
+	"Web":    {"HTML", "CSS", "SCSS", "Sass", "Less", "JavaScript", "TypeScript", "JSX", "TSX", "Vue", "Svelte"},
+	"Config": {"JSON", "YAML", "TOML", "XML", "INI"},
+	"Docs":   {"Markdown", "reStructuredText", "AsciiDoc", "Plain text"},
+	"Shell":  {"Shell", "Zsh", "Fish", "PowerShell"},
+}
+
+// CategoryLanguageGroups returns groups of the known languages by their category
+// in the language registry, such as "Programming" or "Markup".
+func CategoryLanguageGroups() LanguageGroups {
+	groups := make(LanguageGroups, len(languages.Categories))
+	for _, category := range languages.Categories {
+		if names := languages.InCategory(category); len(names) > 0 {
+			groups[category.Title()] = names
+		}
+	}
+	return groups
+}
+
+// Group returns the name of the group containing the language, compared
+// case-insensitively, or the language itself if it is not part of any group.
+// If several groups contain the language, the first group by name wins.
+func (g LanguageGroups) Group(language string) string {
+	names := make([]string, 0, len(g))
+	for group := range g {
+		names = append(names, group)
+	}
+	sort.Strings(names)
+
+	for _, group := range names {
+		for _, member := range g[group] {
+			if strings.EqualFold(member, language) {
+				return group
+			}
+		}
+	}
+	return language
+}
+
+// GroupLanguages returns a copy of the profile whose languages are merged into
+// their groups. Languages outside of all groups are kept as they are.
+func GroupLanguages(profile *UserProfile, groups LanguageGroups) *UserProfile {
+	if profile == nil {
+		return nil
+	}
+
+	grouped := profile.Clone()
+	grouped.Languages = make(map[string]LanguageInfo, len(profile.Languages))
+	for name, info := range profile.Languages {
+		group := groups.Group(name)
+		merged := grouped.Languages[group]
+		merged.XPs += info.XPs
+		merged.NewXPs += info.NewXPs
+		grouped.Languages[group] = merged
+	}
+
+	return grouped
+}
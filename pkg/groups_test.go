@@ -0,0 +1,84 @@
+package godestats
+
+import (
+	"testing"
+
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+func TestLanguageGroups_Group(t *testing.T) {
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{"TypeScript", "Web"},
+		{"yaml", "Config"},
+		{"Go", "Go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			if got := DefaultLanguageGroups.Group(tt.language); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGroupLanguages(t *testing.T) {
+	profile := &UserProfile{
+		TotalXP: 600,
+		Languages: map[string]LanguageInfo{
+			"HTML":       {XPs: 100, NewXPs: 10},
+			"JavaScript": {XPs: 200, NewXPs: 20},
+			"Go":         {XPs: 300},
+		},
+	}
+
+	grouped := GroupLanguages(profile, DefaultLanguageGroups)
+
+	if len(grouped.Languages) != 2 {
+		t.Fatalf("Expected 2 languages, got %v", grouped.Languages)
+	}
+	if web := grouped.Languages["Web"]; web.XPs != 300 || web.NewXPs != 30 {
+		t.Errorf("Expected Web with 300 XP and 30 new XP, got %+v", web)
+	}
+	if grouped.Languages["Go"].XPs != 300 || grouped.TotalXP != 600 {
+		t.Errorf("Expected Go and the total to be unchanged, got %+v", grouped)
+	}
+	if len(profile.Languages) != 3 {
+		t.Error("Expected the original profile to be unchanged")
+	}
+	if GroupLanguages(nil, DefaultLanguageGroups) != nil {
+		t.Error("Expected nil for a nil profile")
+	}
+}
+
+func TestCategoryLanguageGroups(t *testing.T) {
+	groups := CategoryLanguageGroups()
+
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{"Go", "Programming"},
+		{"scss", "Style"},
+		{"Markdown", "Prose"},
+		{"My DSL", "My DSL"},
+	}
+	for _, tt := range tests {
+		if got := groups.Group(tt.language); got != tt.expected {
+			t.Errorf("Group(%q): expected %s, got %s", tt.language, tt.expected, got)
+		}
+	}
+}
+
+func TestDefaultLanguageGroups_Known(t *testing.T) {
+	for group, members := range DefaultLanguageGroups {
+		for _, member := range members {
+			if _, ok := languages.Lookup(member); !ok {
+				t.Errorf("Expected %s in group %s to be in the language registry", member, group)
+			}
+		}
+	}
+}
@@ -0,0 +1,1457 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: godestats/v1/godestats.proto
+
+// Package godestats.v1 exposes Code::Stats profiles, pulses and profile analytics
+// of the gode-stats library over gRPC.
+
+package godestatsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Profile is a user's Code::Stats profile.
+type Profile struct {
+	state     protoimpl.MessageState   `protogen:"open.v1"`
+	User      string                   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	TotalXp   int64                    `protobuf:"varint,2,opt,name=total_xp,json=totalXp,proto3" json:"total_xp,omitempty"`
+	NewXp     int64                    `protobuf:"varint,3,opt,name=new_xp,json=newXp,proto3" json:"new_xp,omitempty"`
+	Level     int32                    `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Languages map[string]*LanguageInfo `protobuf:"bytes,5,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Machines  map[string]*MachineInfo  `protobuf:"bytes,6,rep,name=machines,proto3" json:"machines,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Dates maps days in YYYY-MM-DD format to the XP gained on them.
+	Dates         map[string]int64 `protobuf:"bytes,7,rep,name=dates,proto3" json:"dates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Profile) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Profile) GetTotalXp() int64 {
+	if x != nil {
+		return x.TotalXp
+	}
+	return 0
+}
+
+func (x *Profile) GetNewXp() int64 {
+	if x != nil {
+		return x.NewXp
+	}
+	return 0
+}
+
+func (x *Profile) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Profile) GetLanguages() map[string]*LanguageInfo {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Profile) GetMachines() map[string]*MachineInfo {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+func (x *Profile) GetDates() map[string]int64 {
+	if x != nil {
+		return x.Dates
+	}
+	return nil
+}
+
+// LanguageInfo is the XP of a language.
+type LanguageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Xp            int64                  `protobuf:"varint,1,opt,name=xp,proto3" json:"xp,omitempty"`
+	NewXp         int64                  `protobuf:"varint,2,opt,name=new_xp,json=newXp,proto3" json:"new_xp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LanguageInfo) Reset() {
+	*x = LanguageInfo{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageInfo) ProtoMessage() {}
+
+func (x *LanguageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageInfo.ProtoReflect.Descriptor instead.
+func (*LanguageInfo) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LanguageInfo) GetXp() int64 {
+	if x != nil {
+		return x.Xp
+	}
+	return 0
+}
+
+func (x *LanguageInfo) GetNewXp() int64 {
+	if x != nil {
+		return x.NewXp
+	}
+	return 0
+}
+
+// MachineInfo is the XP of a machine.
+type MachineInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Xp    int64                  `protobuf:"varint,1,opt,name=xp,proto3" json:"xp,omitempty"`
+	NewXp int64                  `protobuf:"varint,2,opt,name=new_xp,json=newXp,proto3" json:"new_xp,omitempty"`
+	// Last activity, only set by GetMyProfile.
+	LastActive    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_active,json=lastActive,proto3" json:"last_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MachineInfo) Reset() {
+	*x = MachineInfo{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineInfo) ProtoMessage() {}
+
+func (x *MachineInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineInfo.ProtoReflect.Descriptor instead.
+func (*MachineInfo) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MachineInfo) GetXp() int64 {
+	if x != nil {
+		return x.Xp
+	}
+	return 0
+}
+
+func (x *MachineInfo) GetNewXp() int64 {
+	if x != nil {
+		return x.NewXp
+	}
+	return 0
+}
+
+func (x *MachineInfo) GetLastActive() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActive
+	}
+	return nil
+}
+
+// LanguageXP is the XP gained in a language.
+type LanguageXP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Xp            int64                  `protobuf:"varint,2,opt,name=xp,proto3" json:"xp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LanguageXP) Reset() {
+	*x = LanguageXP{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageXP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageXP) ProtoMessage() {}
+
+func (x *LanguageXP) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageXP.ProtoReflect.Descriptor instead.
+func (*LanguageXP) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LanguageXP) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *LanguageXP) GetXp() int64 {
+	if x != nil {
+		return x.Xp
+	}
+	return 0
+}
+
+// Pulse is a collection of XP per language coded at a specific time.
+type Pulse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CodedAt       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=coded_at,json=codedAt,proto3" json:"coded_at,omitempty"`
+	Xps           []*LanguageXP          `protobuf:"bytes,2,rep,name=xps,proto3" json:"xps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pulse) Reset() {
+	*x = Pulse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pulse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pulse) ProtoMessage() {}
+
+func (x *Pulse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pulse.ProtoReflect.Descriptor instead.
+func (*Pulse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pulse) GetCodedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CodedAt
+	}
+	return nil
+}
+
+func (x *Pulse) GetXps() []*LanguageXP {
+	if x != nil {
+		return x.Xps
+	}
+	return nil
+}
+
+// Progress is a level and the progress towards the next one.
+type Progress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Xp    int64                  `protobuf:"varint,1,opt,name=xp,proto3" json:"xp,omitempty"`
+	Level int32                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	// Progress within the current level, between 0.0 and 1.0.
+	Percentage    float64 `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	NextLevelXp   int64   `protobuf:"varint,4,opt,name=next_level_xp,json=nextLevelXp,proto3" json:"next_level_xp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Progress) GetXp() int64 {
+	if x != nil {
+		return x.Xp
+	}
+	return 0
+}
+
+func (x *Progress) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Progress) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Progress) GetNextLevelXp() int64 {
+	if x != nil {
+		return x.NextLevelXp
+	}
+	return 0
+}
+
+// LanguageLevel is the progress of a language.
+type LanguageLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Progress      *Progress              `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LanguageLevel) Reset() {
+	*x = LanguageLevel{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageLevel) ProtoMessage() {}
+
+func (x *LanguageLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageLevel.ProtoReflect.Descriptor instead.
+func (*LanguageLevel) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LanguageLevel) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *LanguageLevel) GetProgress() *Progress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+// MachineStat is a machine's level and contribution to the profile's XP.
+type MachineStat struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Progress    *Progress              `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	RecentXp    int64                  `protobuf:"varint,3,opt,name=recent_xp,json=recentXp,proto3" json:"recent_xp,omitempty"`
+	Share       float64                `protobuf:"fixed64,4,opt,name=share,proto3" json:"share,omitempty"`
+	RecentShare float64                `protobuf:"fixed64,5,opt,name=recent_share,json=recentShare,proto3" json:"recent_share,omitempty"`
+	// Idle is true if the machine gained no recent XP while other machines did.
+	Idle          bool `protobuf:"varint,6,opt,name=idle,proto3" json:"idle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MachineStat) Reset() {
+	*x = MachineStat{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineStat) ProtoMessage() {}
+
+func (x *MachineStat) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineStat.ProtoReflect.Descriptor instead.
+func (*MachineStat) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MachineStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MachineStat) GetProgress() *Progress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *MachineStat) GetRecentXp() int64 {
+	if x != nil {
+		return x.RecentXp
+	}
+	return 0
+}
+
+func (x *MachineStat) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+func (x *MachineStat) GetRecentShare() float64 {
+	if x != nil {
+		return x.RecentShare
+	}
+	return 0
+}
+
+func (x *MachineStat) GetIdle() bool {
+	if x != nil {
+		return x.Idle
+	}
+	return false
+}
+
+// Period is a calendar period together with the XP gained in it.
+type Period struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Xp            int64                  `protobuf:"varint,3,opt,name=xp,proto3" json:"xp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Period) Reset() {
+	*x = Period{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Period) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Period) ProtoMessage() {}
+
+func (x *Period) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Period.ProtoReflect.Descriptor instead.
+func (*Period) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Period) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Period) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Period) GetXp() int64 {
+	if x != nil {
+		return x.Xp
+	}
+	return 0
+}
+
+// Pattern summarizes when a user tends to code.
+type Pattern struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Average XP per day, indexed from Sunday (0) to Saturday (6).
+	ByWeekday      []float64 `protobuf:"fixed64,1,rep,packed,name=by_weekday,json=byWeekday,proto3" json:"by_weekday,omitempty"`
+	WeekdayAverage float64   `protobuf:"fixed64,2,opt,name=weekday_average,json=weekdayAverage,proto3" json:"weekday_average,omitempty"`
+	WeekendAverage float64   `protobuf:"fixed64,3,opt,name=weekend_average,json=weekendAverage,proto3" json:"weekend_average,omitempty"`
+	WeekendShare   float64   `protobuf:"fixed64,4,opt,name=weekend_share,json=weekendShare,proto3" json:"weekend_share,omitempty"`
+	BestDay        *Period   `protobuf:"bytes,5,opt,name=best_day,json=bestDay,proto3" json:"best_day,omitempty"`
+	BestWeek       *Period   `protobuf:"bytes,6,opt,name=best_week,json=bestWeek,proto3" json:"best_week,omitempty"`
+	WorstWeek      *Period   `protobuf:"bytes,7,opt,name=worst_week,json=worstWeek,proto3" json:"worst_week,omitempty"`
+	BestMonth      *Period   `protobuf:"bytes,8,opt,name=best_month,json=bestMonth,proto3" json:"best_month,omitempty"`
+	WorstMonth     *Period   `protobuf:"bytes,9,opt,name=worst_month,json=worstMonth,proto3" json:"worst_month,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Pattern) Reset() {
+	*x = Pattern{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pattern) ProtoMessage() {}
+
+func (x *Pattern) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pattern.ProtoReflect.Descriptor instead.
+func (*Pattern) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Pattern) GetByWeekday() []float64 {
+	if x != nil {
+		return x.ByWeekday
+	}
+	return nil
+}
+
+func (x *Pattern) GetWeekdayAverage() float64 {
+	if x != nil {
+		return x.WeekdayAverage
+	}
+	return 0
+}
+
+func (x *Pattern) GetWeekendAverage() float64 {
+	if x != nil {
+		return x.WeekendAverage
+	}
+	return 0
+}
+
+func (x *Pattern) GetWeekendShare() float64 {
+	if x != nil {
+		return x.WeekendShare
+	}
+	return 0
+}
+
+func (x *Pattern) GetBestDay() *Period {
+	if x != nil {
+		return x.BestDay
+	}
+	return nil
+}
+
+func (x *Pattern) GetBestWeek() *Period {
+	if x != nil {
+		return x.BestWeek
+	}
+	return nil
+}
+
+func (x *Pattern) GetWorstWeek() *Period {
+	if x != nil {
+		return x.WorstWeek
+	}
+	return nil
+}
+
+func (x *Pattern) GetBestMonth() *Period {
+	if x != nil {
+		return x.BestMonth
+	}
+	return nil
+}
+
+func (x *Pattern) GetWorstMonth() *Period {
+	if x != nil {
+		return x.WorstMonth
+	}
+	return nil
+}
+
+type GetProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRequest) Reset() {
+	*x = GetProfileRequest{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRequest) ProtoMessage() {}
+
+func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetProfileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *Profile               `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileResponse) Reset() {
+	*x = GetProfileResponse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileResponse) ProtoMessage() {}
+
+func (x *GetProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileResponse.ProtoReflect.Descriptor instead.
+func (*GetProfileResponse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProfileResponse) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type GetMyProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyProfileRequest) Reset() {
+	*x = GetMyProfileRequest{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyProfileRequest) ProtoMessage() {}
+
+func (x *GetMyProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetMyProfileRequest) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{12}
+}
+
+type GetMyProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *Profile               `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyProfileResponse) Reset() {
+	*x = GetMyProfileResponse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyProfileResponse) ProtoMessage() {}
+
+func (x *GetMyProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyProfileResponse.ProtoReflect.Descriptor instead.
+func (*GetMyProfileResponse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetMyProfileResponse) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type SendPulseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pulse         *Pulse                 `protobuf:"bytes,1,opt,name=pulse,proto3" json:"pulse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPulseRequest) Reset() {
+	*x = SendPulseRequest{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPulseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPulseRequest) ProtoMessage() {}
+
+func (x *SendPulseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPulseRequest.ProtoReflect.Descriptor instead.
+func (*SendPulseRequest) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SendPulseRequest) GetPulse() *Pulse {
+	if x != nil {
+		return x.Pulse
+	}
+	return nil
+}
+
+type SendPulseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPulseResponse) Reset() {
+	*x = SendPulseResponse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPulseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPulseResponse) ProtoMessage() {}
+
+func (x *SendPulseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPulseResponse.ProtoReflect.Descriptor instead.
+func (*SendPulseResponse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{15}
+}
+
+type GetLanguageLevelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLanguageLevelsRequest) Reset() {
+	*x = GetLanguageLevelsRequest{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLanguageLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLanguageLevelsRequest) ProtoMessage() {}
+
+func (x *GetLanguageLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLanguageLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLanguageLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetLanguageLevelsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetLanguageLevelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Languages sorted by XP in descending order.
+	Languages     []*LanguageLevel `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLanguageLevelsResponse) Reset() {
+	*x = GetLanguageLevelsResponse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLanguageLevelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLanguageLevelsResponse) ProtoMessage() {}
+
+func (x *GetLanguageLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLanguageLevelsResponse.ProtoReflect.Descriptor instead.
+func (*GetLanguageLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetLanguageLevelsResponse) GetLanguages() []*LanguageLevel {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+type GetMachineStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMachineStatsRequest) Reset() {
+	*x = GetMachineStatsRequest{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineStatsRequest) ProtoMessage() {}
+
+func (x *GetMachineStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetMachineStatsRequest) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetMachineStatsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetMachineStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Machines sorted by XP in descending order.
+	Machines      []*MachineStat `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMachineStatsResponse) Reset() {
+	*x = GetMachineStatsResponse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineStatsResponse) ProtoMessage() {}
+
+func (x *GetMachineStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetMachineStatsResponse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetMachineStatsResponse) GetMachines() []*MachineStat {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+type GetPatternsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// IANA time zone the days are interpreted in, UTC if empty.
+	TimeZone      string `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPatternsRequest) Reset() {
+	*x = GetPatternsRequest{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPatternsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPatternsRequest) ProtoMessage() {}
+
+func (x *GetPatternsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPatternsRequest.ProtoReflect.Descriptor instead.
+func (*GetPatternsRequest) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPatternsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetPatternsRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type GetPatternsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pattern       *Pattern               `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPatternsResponse) Reset() {
+	*x = GetPatternsResponse{}
+	mi := &file_godestats_v1_godestats_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPatternsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPatternsResponse) ProtoMessage() {}
+
+func (x *GetPatternsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godestats_v1_godestats_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPatternsResponse.ProtoReflect.Descriptor instead.
+func (*GetPatternsResponse) Descriptor() ([]byte, []int) {
+	return file_godestats_v1_godestats_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPatternsResponse) GetPattern() *Pattern {
+	if x != nil {
+		return x.Pattern
+	}
+	return nil
+}
+
+var File_godestats_v1_godestats_proto protoreflect.FileDescriptor
+
+const file_godestats_v1_godestats_proto_rawDesc = "" +
+	"\n" +
+	"\x1cgodestats/v1/godestats.proto\x12\fgodestats.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x04\n" +
+	"\aProfile\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12\x19\n" +
+	"\btotal_xp\x18\x02 \x01(\x03R\atotalXp\x12\x15\n" +
+	"\x06new_xp\x18\x03 \x01(\x03R\x05newXp\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\x12B\n" +
+	"\tlanguages\x18\x05 \x03(\v2$.godestats.v1.Profile.LanguagesEntryR\tlanguages\x12?\n" +
+	"\bmachines\x18\x06 \x03(\v2#.godestats.v1.Profile.MachinesEntryR\bmachines\x126\n" +
+	"\x05dates\x18\a \x03(\v2 .godestats.v1.Profile.DatesEntryR\x05dates\x1aX\n" +
+	"\x0eLanguagesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.godestats.v1.LanguageInfoR\x05value:\x028\x01\x1aV\n" +
+	"\rMachinesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.godestats.v1.MachineInfoR\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"DatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"5\n" +
+	"\fLanguageInfo\x12\x0e\n" +
+	"\x02xp\x18\x01 \x01(\x03R\x02xp\x12\x15\n" +
+	"\x06new_xp\x18\x02 \x01(\x03R\x05newXp\"q\n" +
+	"\vMachineInfo\x12\x0e\n" +
+	"\x02xp\x18\x01 \x01(\x03R\x02xp\x12\x15\n" +
+	"\x06new_xp\x18\x02 \x01(\x03R\x05newXp\x12;\n" +
+	"\vlast_active\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastActive\"8\n" +
+	"\n" +
+	"LanguageXP\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x0e\n" +
+	"\x02xp\x18\x02 \x01(\x03R\x02xp\"j\n" +
+	"\x05Pulse\x125\n" +
+	"\bcoded_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\acodedAt\x12*\n" +
+	"\x03xps\x18\x02 \x03(\v2\x18.godestats.v1.LanguageXPR\x03xps\"t\n" +
+	"\bProgress\x12\x0e\n" +
+	"\x02xp\x18\x01 \x01(\x03R\x02xp\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\x12\"\n" +
+	"\rnext_level_xp\x18\x04 \x01(\x03R\vnextLevelXp\"_\n" +
+	"\rLanguageLevel\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x122\n" +
+	"\bprogress\x18\x02 \x01(\v2\x16.godestats.v1.ProgressR\bprogress\"\xbf\x01\n" +
+	"\vMachineStat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\bprogress\x18\x02 \x01(\v2\x16.godestats.v1.ProgressR\bprogress\x12\x1b\n" +
+	"\trecent_xp\x18\x03 \x01(\x03R\brecentXp\x12\x14\n" +
+	"\x05share\x18\x04 \x01(\x01R\x05share\x12!\n" +
+	"\frecent_share\x18\x05 \x01(\x01R\vrecentShare\x12\x12\n" +
+	"\x04idle\x18\x06 \x01(\bR\x04idle\"x\n" +
+	"\x06Period\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x0e\n" +
+	"\x02xp\x18\x03 \x01(\x03R\x02xp\"\xa4\x03\n" +
+	"\aPattern\x12\x1d\n" +
+	"\n" +
+	"by_weekday\x18\x01 \x03(\x01R\tbyWeekday\x12'\n" +
+	"\x0fweekday_average\x18\x02 \x01(\x01R\x0eweekdayAverage\x12'\n" +
+	"\x0fweekend_average\x18\x03 \x01(\x01R\x0eweekendAverage\x12#\n" +
+	"\rweekend_share\x18\x04 \x01(\x01R\fweekendShare\x12/\n" +
+	"\bbest_day\x18\x05 \x01(\v2\x14.godestats.v1.PeriodR\abestDay\x121\n" +
+	"\tbest_week\x18\x06 \x01(\v2\x14.godestats.v1.PeriodR\bbestWeek\x123\n" +
+	"\n" +
+	"worst_week\x18\a \x01(\v2\x14.godestats.v1.PeriodR\tworstWeek\x123\n" +
+	"\n" +
+	"best_month\x18\b \x01(\v2\x14.godestats.v1.PeriodR\tbestMonth\x125\n" +
+	"\vworst_month\x18\t \x01(\v2\x14.godestats.v1.PeriodR\n" +
+	"worstMonth\"/\n" +
+	"\x11GetProfileRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"E\n" +
+	"\x12GetProfileResponse\x12/\n" +
+	"\aprofile\x18\x01 \x01(\v2\x15.godestats.v1.ProfileR\aprofile\"\x15\n" +
+	"\x13GetMyProfileRequest\"G\n" +
+	"\x14GetMyProfileResponse\x12/\n" +
+	"\aprofile\x18\x01 \x01(\v2\x15.godestats.v1.ProfileR\aprofile\"=\n" +
+	"\x10SendPulseRequest\x12)\n" +
+	"\x05pulse\x18\x01 \x01(\v2\x13.godestats.v1.PulseR\x05pulse\"\x13\n" +
+	"\x11SendPulseResponse\"6\n" +
+	"\x18GetLanguageLevelsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"V\n" +
+	"\x19GetLanguageLevelsResponse\x129\n" +
+	"\tlanguages\x18\x01 \x03(\v2\x1b.godestats.v1.LanguageLevelR\tlanguages\"4\n" +
+	"\x16GetMachineStatsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"P\n" +
+	"\x17GetMachineStatsResponse\x125\n" +
+	"\bmachines\x18\x01 \x03(\v2\x19.godestats.v1.MachineStatR\bmachines\"M\n" +
+	"\x12GetPatternsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"F\n" +
+	"\x13GetPatternsResponse\x12/\n" +
+	"\apattern\x18\x01 \x01(\v2\x15.godestats.v1.PatternR\apattern2\xa2\x04\n" +
+	"\x10GodeStatsService\x12O\n" +
+	"\n" +
+	"GetProfile\x12\x1f.godestats.v1.GetProfileRequest\x1a .godestats.v1.GetProfileResponse\x12U\n" +
+	"\fGetMyProfile\x12!.godestats.v1.GetMyProfileRequest\x1a\".godestats.v1.GetMyProfileResponse\x12L\n" +
+	"\tSendPulse\x12\x1e.godestats.v1.SendPulseRequest\x1a\x1f.godestats.v1.SendPulseResponse\x12d\n" +
+	"\x11GetLanguageLevels\x12&.godestats.v1.GetLanguageLevelsRequest\x1a'.godestats.v1.GetLanguageLevelsResponse\x12^\n" +
+	"\x0fGetMachineStats\x12$.godestats.v1.GetMachineStatsRequest\x1a%.godestats.v1.GetMachineStatsResponse\x12R\n" +
+	"\vGetPatterns\x12 .godestats.v1.GetPatternsRequest\x1a!.godestats.v1.GetPatternsResponseBBZ@github.com/Yeti47/gode-stats/pkg/grpcapi/godestatsv1;godestatsv1b\x06proto3"
+
+var (
+	file_godestats_v1_godestats_proto_rawDescOnce sync.Once
+	file_godestats_v1_godestats_proto_rawDescData []byte
+)
+
+func file_godestats_v1_godestats_proto_rawDescGZIP() []byte {
+	file_godestats_v1_godestats_proto_rawDescOnce.Do(func() {
+		file_godestats_v1_godestats_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_godestats_v1_godestats_proto_rawDesc), len(file_godestats_v1_godestats_proto_rawDesc)))
+	})
+	return file_godestats_v1_godestats_proto_rawDescData
+}
+
+var file_godestats_v1_godestats_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_godestats_v1_godestats_proto_goTypes = []any{
+	(*Profile)(nil),                   // 0: godestats.v1.Profile
+	(*LanguageInfo)(nil),              // 1: godestats.v1.LanguageInfo
+	(*MachineInfo)(nil),               // 2: godestats.v1.MachineInfo
+	(*LanguageXP)(nil),                // 3: godestats.v1.LanguageXP
+	(*Pulse)(nil),                     // 4: godestats.v1.Pulse
+	(*Progress)(nil),                  // 5: godestats.v1.Progress
+	(*LanguageLevel)(nil),             // 6: godestats.v1.LanguageLevel
+	(*MachineStat)(nil),               // 7: godestats.v1.MachineStat
+	(*Period)(nil),                    // 8: godestats.v1.Period
+	(*Pattern)(nil),                   // 9: godestats.v1.Pattern
+	(*GetProfileRequest)(nil),         // 10: godestats.v1.GetProfileRequest
+	(*GetProfileResponse)(nil),        // 11: godestats.v1.GetProfileResponse
+	(*GetMyProfileRequest)(nil),       // 12: godestats.v1.GetMyProfileRequest
+	(*GetMyProfileResponse)(nil),      // 13: godestats.v1.GetMyProfileResponse
+	(*SendPulseRequest)(nil),          // 14: godestats.v1.SendPulseRequest
+	(*SendPulseResponse)(nil),         // 15: godestats.v1.SendPulseResponse
+	(*GetLanguageLevelsRequest)(nil),  // 16: godestats.v1.GetLanguageLevelsRequest
+	(*GetLanguageLevelsResponse)(nil), // 17: godestats.v1.GetLanguageLevelsResponse
+	(*GetMachineStatsRequest)(nil),    // 18: godestats.v1.GetMachineStatsRequest
+	(*GetMachineStatsResponse)(nil),   // 19: godestats.v1.GetMachineStatsResponse
+	(*GetPatternsRequest)(nil),        // 20: godestats.v1.GetPatternsRequest
+	(*GetPatternsResponse)(nil),       // 21: godestats.v1.GetPatternsResponse
+	nil,                               // 22: godestats.v1.Profile.LanguagesEntry
+	nil,                               // 23: godestats.v1.Profile.MachinesEntry
+	nil,                               // 24: godestats.v1.Profile.DatesEntry
+	(*timestamppb.Timestamp)(nil),     // 25: google.protobuf.Timestamp
+}
+var file_godestats_v1_godestats_proto_depIdxs = []int32{
+	22, // 0: godestats.v1.Profile.languages:type_name -> godestats.v1.Profile.LanguagesEntry
+	23, // 1: godestats.v1.Profile.machines:type_name -> godestats.v1.Profile.MachinesEntry
+	24, // 2: godestats.v1.Profile.dates:type_name -> godestats.v1.Profile.DatesEntry
+	25, // 3: godestats.v1.MachineInfo.last_active:type_name -> google.protobuf.Timestamp
+	25, // 4: godestats.v1.Pulse.coded_at:type_name -> google.protobuf.Timestamp
+	3,  // 5: godestats.v1.Pulse.xps:type_name -> godestats.v1.LanguageXP
+	5,  // 6: godestats.v1.LanguageLevel.progress:type_name -> godestats.v1.Progress
+	5,  // 7: godestats.v1.MachineStat.progress:type_name -> godestats.v1.Progress
+	25, // 8: godestats.v1.Period.start:type_name -> google.protobuf.Timestamp
+	25, // 9: godestats.v1.Period.end:type_name -> google.protobuf.Timestamp
+	8,  // 10: godestats.v1.Pattern.best_day:type_name -> godestats.v1.Period
+	8,  // 11: godestats.v1.Pattern.best_week:type_name -> godestats.v1.Period
+	8,  // 12: godestats.v1.Pattern.worst_week:type_name -> godestats.v1.Period
+	8,  // 13: godestats.v1.Pattern.best_month:type_name -> godestats.v1.Period
+	8,  // 14: godestats.v1.Pattern.worst_month:type_name -> godestats.v1.Period
+	0,  // 15: godestats.v1.GetProfileResponse.profile:type_name -> godestats.v1.Profile
+	0,  // 16: godestats.v1.GetMyProfileResponse.profile:type_name -> godestats.v1.Profile
+	4,  // 17: godestats.v1.SendPulseRequest.pulse:type_name -> godestats.v1.Pulse
+	6,  // 18: godestats.v1.GetLanguageLevelsResponse.languages:type_name -> godestats.v1.LanguageLevel
+	7,  // 19: godestats.v1.GetMachineStatsResponse.machines:type_name -> godestats.v1.MachineStat
+	9,  // 20: godestats.v1.GetPatternsResponse.pattern:type_name -> godestats.v1.Pattern
+	1,  // 21: godestats.v1.Profile.LanguagesEntry.value:type_name -> godestats.v1.LanguageInfo
+	2,  // 22: godestats.v1.Profile.MachinesEntry.value:type_name -> godestats.v1.MachineInfo
+	10, // 23: godestats.v1.GodeStatsService.GetProfile:input_type -> godestats.v1.GetProfileRequest
+	12, // 24: godestats.v1.GodeStatsService.GetMyProfile:input_type -> godestats.v1.GetMyProfileRequest
+	14, // 25: godestats.v1.GodeStatsService.SendPulse:input_type -> godestats.v1.SendPulseRequest
+	16, // 26: godestats.v1.GodeStatsService.GetLanguageLevels:input_type -> godestats.v1.GetLanguageLevelsRequest
+	18, // 27: godestats.v1.GodeStatsService.GetMachineStats:input_type -> godestats.v1.GetMachineStatsRequest
+	20, // 28: godestats.v1.GodeStatsService.GetPatterns:input_type -> godestats.v1.GetPatternsRequest
+	11, // 29: godestats.v1.GodeStatsService.GetProfile:output_type -> godestats.v1.GetProfileResponse
+	13, // 30: godestats.v1.GodeStatsService.GetMyProfile:output_type -> godestats.v1.GetMyProfileResponse
+	15, // 31: godestats.v1.GodeStatsService.SendPulse:output_type -> godestats.v1.SendPulseResponse
+	17, // 32: godestats.v1.GodeStatsService.GetLanguageLevels:output_type -> godestats.v1.GetLanguageLevelsResponse
+	19, // 33: godestats.v1.GodeStatsService.GetMachineStats:output_type -> godestats.v1.GetMachineStatsResponse
+	21, // 34: godestats.v1.GodeStatsService.GetPatterns:output_type -> godestats.v1.GetPatternsResponse
+	29, // [29:35] is the sub-list for method output_type
+	23, // [23:29] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_godestats_v1_godestats_proto_init() }
+func file_godestats_v1_godestats_proto_init() {
+	if File_godestats_v1_godestats_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_godestats_v1_godestats_proto_rawDesc), len(file_godestats_v1_godestats_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_godestats_v1_godestats_proto_goTypes,
+		DependencyIndexes: file_godestats_v1_godestats_proto_depIdxs,
+		MessageInfos:      file_godestats_v1_godestats_proto_msgTypes,
+	}.Build()
+	File_godestats_v1_godestats_proto = out.File
+	file_godestats_v1_godestats_proto_goTypes = nil
+	file_godestats_v1_godestats_proto_depIdxs = nil
+}
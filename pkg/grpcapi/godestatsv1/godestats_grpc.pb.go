@@ -0,0 +1,338 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: godestats/v1/godestats.proto
+
+// Package godestats.v1 exposes Code::Stats profiles, pulses and profile analytics
+// of the gode-stats library over gRPC.
+
+package godestatsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GodeStatsService_GetProfile_FullMethodName        = "/godestats.v1.GodeStatsService/GetProfile"
+	GodeStatsService_GetMyProfile_FullMethodName      = "/godestats.v1.GodeStatsService/GetMyProfile"
+	GodeStatsService_SendPulse_FullMethodName         = "/godestats.v1.GodeStatsService/SendPulse"
+	GodeStatsService_GetLanguageLevels_FullMethodName = "/godestats.v1.GodeStatsService/GetLanguageLevels"
+	GodeStatsService_GetMachineStats_FullMethodName   = "/godestats.v1.GodeStatsService/GetMachineStats"
+	GodeStatsService_GetPatterns_FullMethodName       = "/godestats.v1.GodeStatsService/GetPatterns"
+)
+
+// GodeStatsServiceClient is the client API for GodeStatsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GodeStatsService wraps a Code::Stats client. Errors of the API are mapped to
+// gRPC status codes: unknown or private users to NOT_FOUND, missing or invalid
+// tokens to UNAUTHENTICATED, rate limiting to RESOURCE_EXHAUSTED, invalid input
+// to INVALID_ARGUMENT and network or server problems to UNAVAILABLE.
+type GodeStatsServiceClient interface {
+	// GetProfile returns the public profile of a user.
+	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*GetProfileResponse, error)
+	// GetMyProfile returns the profile of the owner of the server's API token,
+	// including the machines' last activity.
+	GetMyProfile(ctx context.Context, in *GetMyProfileRequest, opts ...grpc.CallOption) (*GetMyProfileResponse, error)
+	// SendPulse sends a pulse with the server's API token.
+	SendPulse(ctx context.Context, in *SendPulseRequest, opts ...grpc.CallOption) (*SendPulseResponse, error)
+	// GetLanguageLevels returns the level and progress of each language of a user.
+	GetLanguageLevels(ctx context.Context, in *GetLanguageLevelsRequest, opts ...grpc.CallOption) (*GetLanguageLevelsResponse, error)
+	// GetMachineStats returns the level and share of XP of each machine of a user.
+	GetMachineStats(ctx context.Context, in *GetMachineStatsRequest, opts ...grpc.CallOption) (*GetMachineStatsResponse, error)
+	// GetPatterns summarizes when a user tends to code.
+	GetPatterns(ctx context.Context, in *GetPatternsRequest, opts ...grpc.CallOption) (*GetPatternsResponse, error)
+}
+
+type godeStatsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGodeStatsServiceClient(cc grpc.ClientConnInterface) GodeStatsServiceClient {
+	return &godeStatsServiceClient{cc}
+}
+
+func (c *godeStatsServiceClient) GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*GetProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProfileResponse)
+	err := c.cc.Invoke(ctx, GodeStatsService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *godeStatsServiceClient) GetMyProfile(ctx context.Context, in *GetMyProfileRequest, opts ...grpc.CallOption) (*GetMyProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyProfileResponse)
+	err := c.cc.Invoke(ctx, GodeStatsService_GetMyProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *godeStatsServiceClient) SendPulse(ctx context.Context, in *SendPulseRequest, opts ...grpc.CallOption) (*SendPulseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendPulseResponse)
+	err := c.cc.Invoke(ctx, GodeStatsService_SendPulse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *godeStatsServiceClient) GetLanguageLevels(ctx context.Context, in *GetLanguageLevelsRequest, opts ...grpc.CallOption) (*GetLanguageLevelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLanguageLevelsResponse)
+	err := c.cc.Invoke(ctx, GodeStatsService_GetLanguageLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *godeStatsServiceClient) GetMachineStats(ctx context.Context, in *GetMachineStatsRequest, opts ...grpc.CallOption) (*GetMachineStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMachineStatsResponse)
+	err := c.cc.Invoke(ctx, GodeStatsService_GetMachineStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *godeStatsServiceClient) GetPatterns(ctx context.Context, in *GetPatternsRequest, opts ...grpc.CallOption) (*GetPatternsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPatternsResponse)
+	err := c.cc.Invoke(ctx, GodeStatsService_GetPatterns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GodeStatsServiceServer is the server API for GodeStatsService service.
+// All implementations must embed UnimplementedGodeStatsServiceServer
+// for forward compatibility.
+//
+// GodeStatsService wraps a Code::Stats client. Errors of the API are mapped to
+// gRPC status codes: unknown or private users to NOT_FOUND, missing or invalid
+// tokens to UNAUTHENTICATED, rate limiting to RESOURCE_EXHAUSTED, invalid input
+// to INVALID_ARGUMENT and network or server problems to UNAVAILABLE.
+type GodeStatsServiceServer interface {
+	// GetProfile returns the public profile of a user.
+	GetProfile(context.Context, *GetProfileRequest) (*GetProfileResponse, error)
+	// GetMyProfile returns the profile of the owner of the server's API token,
+	// including the machines' last activity.
+	GetMyProfile(context.Context, *GetMyProfileRequest) (*GetMyProfileResponse, error)
+	// SendPulse sends a pulse with the server's API token.
+	SendPulse(context.Context, *SendPulseRequest) (*SendPulseResponse, error)
+	// GetLanguageLevels returns the level and progress of each language of a user.
+	GetLanguageLevels(context.Context, *GetLanguageLevelsRequest) (*GetLanguageLevelsResponse, error)
+	// GetMachineStats returns the level and share of XP of each machine of a user.
+	GetMachineStats(context.Context, *GetMachineStatsRequest) (*GetMachineStatsResponse, error)
+	// GetPatterns summarizes when a user tends to code.
+	GetPatterns(context.Context, *GetPatternsRequest) (*GetPatternsResponse, error)
+	mustEmbedUnimplementedGodeStatsServiceServer()
+}
+
+// UnimplementedGodeStatsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGodeStatsServiceServer struct{}
+
+func (UnimplementedGodeStatsServiceServer) GetProfile(context.Context, *GetProfileRequest) (*GetProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedGodeStatsServiceServer) GetMyProfile(context.Context, *GetMyProfileRequest) (*GetMyProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyProfile not implemented")
+}
+func (UnimplementedGodeStatsServiceServer) SendPulse(context.Context, *SendPulseRequest) (*SendPulseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendPulse not implemented")
+}
+func (UnimplementedGodeStatsServiceServer) GetLanguageLevels(context.Context, *GetLanguageLevelsRequest) (*GetLanguageLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLanguageLevels not implemented")
+}
+func (UnimplementedGodeStatsServiceServer) GetMachineStats(context.Context, *GetMachineStatsRequest) (*GetMachineStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMachineStats not implemented")
+}
+func (UnimplementedGodeStatsServiceServer) GetPatterns(context.Context, *GetPatternsRequest) (*GetPatternsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPatterns not implemented")
+}
+func (UnimplementedGodeStatsServiceServer) mustEmbedUnimplementedGodeStatsServiceServer() {}
+func (UnimplementedGodeStatsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeGodeStatsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GodeStatsServiceServer will
+// result in compilation errors.
+type UnsafeGodeStatsServiceServer interface {
+	mustEmbedUnimplementedGodeStatsServiceServer()
+}
+
+func RegisterGodeStatsServiceServer(s grpc.ServiceRegistrar, srv GodeStatsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGodeStatsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GodeStatsService_ServiceDesc, srv)
+}
+
+func _GodeStatsService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GodeStatsServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GodeStatsService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GodeStatsServiceServer).GetProfile(ctx, req.(*GetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GodeStatsService_GetMyProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GodeStatsServiceServer).GetMyProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GodeStatsService_GetMyProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GodeStatsServiceServer).GetMyProfile(ctx, req.(*GetMyProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GodeStatsService_SendPulse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendPulseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GodeStatsServiceServer).SendPulse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GodeStatsService_SendPulse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GodeStatsServiceServer).SendPulse(ctx, req.(*SendPulseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GodeStatsService_GetLanguageLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLanguageLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GodeStatsServiceServer).GetLanguageLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GodeStatsService_GetLanguageLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GodeStatsServiceServer).GetLanguageLevels(ctx, req.(*GetLanguageLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GodeStatsService_GetMachineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMachineStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GodeStatsServiceServer).GetMachineStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GodeStatsService_GetMachineStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GodeStatsServiceServer).GetMachineStats(ctx, req.(*GetMachineStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GodeStatsService_GetPatterns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPatternsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GodeStatsServiceServer).GetPatterns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GodeStatsService_GetPatterns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GodeStatsServiceServer).GetPatterns(ctx, req.(*GetPatternsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GodeStatsService_ServiceDesc is the grpc.ServiceDesc for GodeStatsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GodeStatsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "godestats.v1.GodeStatsService",
+	HandlerType: (*GodeStatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProfile",
+			Handler:    _GodeStatsService_GetProfile_Handler,
+		},
+		{
+			MethodName: "GetMyProfile",
+			Handler:    _GodeStatsService_GetMyProfile_Handler,
+		},
+		{
+			MethodName: "SendPulse",
+			Handler:    _GodeStatsService_SendPulse_Handler,
+		},
+		{
+			MethodName: "GetLanguageLevels",
+			Handler:    _GodeStatsService_GetLanguageLevels_Handler,
+		},
+		{
+			MethodName: "GetMachineStats",
+			Handler:    _GodeStatsService_GetMachineStats_Handler,
+		},
+		{
+			MethodName: "GetPatterns",
+			Handler:    _GodeStatsService_GetPatterns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "godestats/v1/godestats.proto",
+}
@@ -0,0 +1,239 @@
+// Package grpcapi serves a Code::Stats client and the profile analytics over gRPC,
+// so tools written in other languages can use the library. The service is defined
+// in proto/godestats/v1/godestats.proto; the generated Go code lives in godestatsv1.
+//
+//	s := grpc.NewServer()
+//	godestatsv1.RegisterGodeStatsServiceServer(s, grpcapi.NewServer(client.New(token)))
+package grpcapi
+
+//go:generate buf generate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/analytics"
+	"github.com/Yeti47/gode-stats/pkg/grpcapi/godestatsv1"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCalculator sets the calculator used for levels.
+func WithCalculator(calc godestats.XpCalculator) Option {
+	return func(s *Server) {
+		s.calc = calc
+	}
+}
+
+// Server implements the GodeStatsService by delegating to a Code::Stats client.
+type Server struct {
+	godestatsv1.UnimplementedGodeStatsServiceServer
+
+	client godestats.CodeStatsClient
+	calc   godestats.XpCalculator
+}
+
+// NewServer creates a service backed by the client. Pulses are sent and the own
+// profile is fetched with the client's API token.
+func NewServer(client godestats.CodeStatsClient, opts ...Option) *Server {
+	s := &Server{
+		client: client,
+		calc:   xp.NewCalculator(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// GetProfile returns the public profile of a user.
+func (s *Server) GetProfile(ctx context.Context, req *godestatsv1.GetProfileRequest) (*godestatsv1.GetProfileResponse, error) {
+	profile, err := s.client.GetUserProfile(ctx, req.GetUsername())
+	if err != nil {
+		return nil, statusFor(err)
+	}
+	return &godestatsv1.GetProfileResponse{Profile: s.profileMessage(profile)}, nil
+}
+
+// GetMyProfile returns the profile of the owner of the client's API token.
+func (s *Server) GetMyProfile(ctx context.Context, req *godestatsv1.GetMyProfileRequest) (*godestatsv1.GetMyProfileResponse, error) {
+	own, ok := s.client.(godestats.OwnProfileClient)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "the client cannot fetch the own profile")
+	}
+
+	profile, err := own.GetMyProfile(ctx)
+	if err != nil {
+		return nil, statusFor(err)
+	}
+	return &godestatsv1.GetMyProfileResponse{Profile: s.profileMessage(profile)}, nil
+}
+
+// SendPulse sends a pulse with the client's API token.
+func (s *Server) SendPulse(ctx context.Context, req *godestatsv1.SendPulseRequest) (*godestatsv1.SendPulseResponse, error) {
+	msg := req.GetPulse()
+	if msg == nil || msg.GetCodedAt() == nil || len(msg.GetXps()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "pulse needs a coded_at time and at least one language")
+	}
+
+	pulse := godestats.Pulse{CodedAt: msg.GetCodedAt().AsTime()}
+	for _, lxp := range msg.GetXps() {
+		pulse.XPs = append(pulse.XPs, godestats.LanguageXP{Language: lxp.GetLanguage(), XP: godestats.XP(lxp.GetXp())})
+	}
+
+	if err := s.client.SendPulse(ctx, pulse); err != nil {
+		return nil, statusFor(err)
+	}
+	return &godestatsv1.SendPulseResponse{}, nil
+}
+
+// GetLanguageLevels returns the progress of each language, sorted by XP.
+func (s *Server) GetLanguageLevels(ctx context.Context, req *godestatsv1.GetLanguageLevelsRequest) (*godestatsv1.GetLanguageLevelsResponse, error) {
+	profile, err := s.client.GetUserProfile(ctx, req.GetUsername())
+	if err != nil {
+		return nil, statusFor(err)
+	}
+
+	resp := &godestatsv1.GetLanguageLevelsResponse{}
+	for name, p := range analytics.LanguageLevels(profile, s.calc) {
+		resp.Languages = append(resp.Languages, &godestatsv1.LanguageLevel{Language: name, Progress: progressMessage(p)})
+	}
+	slices.SortFunc(resp.Languages, func(a, b *godestatsv1.LanguageLevel) int {
+		return cmp.Or(cmp.Compare(b.Progress.Xp, a.Progress.Xp), cmp.Compare(a.Language, b.Language))
+	})
+	return resp, nil
+}
+
+// GetMachineStats returns the statistics of each machine, sorted by XP.
+func (s *Server) GetMachineStats(ctx context.Context, req *godestatsv1.GetMachineStatsRequest) (*godestatsv1.GetMachineStatsResponse, error) {
+	profile, err := s.client.GetUserProfile(ctx, req.GetUsername())
+	if err != nil {
+		return nil, statusFor(err)
+	}
+
+	resp := &godestatsv1.GetMachineStatsResponse{}
+	for _, m := range analytics.MachineStats(profile, s.calc) {
+		resp.Machines = append(resp.Machines, &godestatsv1.MachineStat{
+			Name:        m.Name,
+			Progress:    progressMessage(m.Progress),
+			RecentXp:    int64(m.RecentXP),
+			Share:       m.Share,
+			RecentShare: m.RecentShare,
+			Idle:        m.Idle,
+		})
+	}
+	return resp, nil
+}
+
+// GetPatterns summarizes when the user tends to code.
+func (s *Server) GetPatterns(ctx context.Context, req *godestatsv1.GetPatternsRequest) (*godestatsv1.GetPatternsResponse, error) {
+	tz, err := time.LoadLocation(req.GetTimeZone())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown time zone %q", req.GetTimeZone())
+	}
+
+	profile, err := s.client.GetUserProfile(ctx, req.GetUsername())
+	if err != nil {
+		return nil, statusFor(err)
+	}
+
+	pattern, err := analytics.Patterns(profile.Dates, tz)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &godestatsv1.GetPatternsResponse{Pattern: &godestatsv1.Pattern{
+		ByWeekday:      pattern.ByWeekday[:],
+		WeekdayAverage: pattern.WeekdayAverage,
+		WeekendAverage: pattern.WeekendAverage,
+		WeekendShare:   pattern.WeekendShare,
+		BestDay:        periodMessage(pattern.BestDay),
+		BestWeek:       periodMessage(pattern.BestWeek),
+		WorstWeek:      periodMessage(pattern.WorstWeek),
+		BestMonth:      periodMessage(pattern.BestMonth),
+		WorstMonth:     periodMessage(pattern.WorstMonth),
+	}}, nil
+}
+
+// profileMessage converts a profile to its message.
+func (s *Server) profileMessage(profile *godestats.UserProfile) *godestatsv1.Profile {
+	msg := &godestatsv1.Profile{
+		User:      profile.User,
+		TotalXp:   int64(profile.TotalXP),
+		NewXp:     int64(profile.NewXP),
+		Level:     int32(s.calc.GetLevel(profile.TotalXP)),
+		Languages: make(map[string]*godestatsv1.LanguageInfo, len(profile.Languages)),
+		Machines:  make(map[string]*godestatsv1.MachineInfo, len(profile.Machines)),
+		Dates:     make(map[string]int64, len(profile.Dates)),
+	}
+
+	for name, lang := range profile.Languages {
+		msg.Languages[name] = &godestatsv1.LanguageInfo{Xp: int64(lang.XPs), NewXp: int64(lang.NewXPs)}
+	}
+	for name, machine := range profile.Machines {
+		info := &godestatsv1.MachineInfo{Xp: int64(machine.XPs), NewXp: int64(machine.NewXPs)}
+		if !machine.LastActive.IsZero() {
+			info.LastActive = timestamppb.New(machine.LastActive)
+		}
+		msg.Machines[name] = info
+	}
+	for day, amount := range profile.Dates {
+		msg.Dates[day] = int64(amount)
+	}
+
+	return msg
+}
+
+// progressMessage converts a level progress to its message.
+func progressMessage(p analytics.Progress) *godestatsv1.Progress {
+	return &godestatsv1.Progress{
+		Xp:          int64(p.XP),
+		Level:       int32(p.Level),
+		Percentage:  p.Percentage,
+		NextLevelXp: int64(p.NextLevelXP),
+	}
+}
+
+// periodMessage converts a period to its message, or nil for the zero period.
+func periodMessage(p analytics.Period) *godestatsv1.Period {
+	if p.Start.IsZero() {
+		return nil
+	}
+	return &godestatsv1.Period{Start: timestamppb.New(p.Start), End: timestamppb.New(p.End), Xp: int64(p.XP)}
+}
+
+// statusFor maps a library error to a gRPC status error.
+func statusFor(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, godestats.ErrEmptyUsername), errors.Is(err, godestats.ErrPulseTimestampTooOld):
+		code = codes.InvalidArgument
+	case godestats.IsUserNotFound(err):
+		code = codes.NotFound
+	case godestats.IsUnauthorized(err):
+		code = codes.Unauthenticated
+	case godestats.IsRateLimited(err):
+		code = codes.ResourceExhausted
+	case errors.Is(err, godestats.ErrUnsupported):
+		code = codes.Unimplemented
+	case godestats.IsTemporary(err), godestats.IsNetworkError(err):
+		code = codes.Unavailable
+	}
+	return status.Error(code, err.Error())
+}
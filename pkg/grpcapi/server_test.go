@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/grpcapi/godestatsv1"
+)
+
+// fakeClient serves alice's profile and records sent pulses.
+type fakeClient struct {
+	pulses []godestats.Pulse
+}
+
+func (c *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	if username != "alice" {
+		return nil, godestats.ErrUserNotFound
+	}
+	return &godestats.UserProfile{
+		User:    "alice",
+		TotalXP: 7000,
+		NewXP:   100,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go":   {XPs: 5000, NewXPs: 100},
+			"Rust": {XPs: 2000},
+		},
+		Machines: map[string]godestats.MachineInfo{
+			"laptop":  {XPs: 6000, NewXPs: 100},
+			"desktop": {XPs: 1000},
+		},
+		Dates: map[string]godestats.XP{"2023-06-12": 400, "2023-06-17": 100},
+	}, nil
+}
+
+func (c *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	if pulse.CodedAt.Before(time.Now().AddDate(0, 0, -7)) {
+		return godestats.ErrPulseTimestampTooOld
+	}
+	c.pulses = append(c.pulses, pulse)
+	return nil
+}
+
+// newTestClient serves the client over an in-memory connection.
+func newTestClient(t *testing.T, client godestats.CodeStatsClient) godestatsv1.GodeStatsServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	godestatsv1.RegisterGodeStatsServiceServer(server, NewServer(client))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return godestatsv1.NewGodeStatsServiceClient(conn)
+}
+
+func TestServer_GetProfile(t *testing.T) {
+	c := newTestClient(t, &fakeClient{})
+
+	resp, err := c.GetProfile(context.Background(), &godestatsv1.GetProfileRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	profile := resp.GetProfile()
+	if profile.GetUser() != "alice" || profile.GetTotalXp() != 7000 || profile.GetLevel() != 2 {
+		t.Errorf("Expected alice at level 2 with 7000 XP, got %v", profile)
+	}
+	if profile.GetLanguages()["Go"].GetNewXp() != 100 || profile.GetDates()["2023-06-12"] != 400 {
+		t.Errorf("Expected languages and dates, got %v", profile)
+	}
+
+	_, err = c.GetProfile(context.Background(), &godestatsv1.GetProfileRequest{Username: "bob"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+
+	// The fake client can't fetch the own profile
+	_, err = c.GetMyProfile(context.Background(), &godestatsv1.GetMyProfileRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented, got %v", err)
+	}
+}
+
+func TestServer_SendPulse(t *testing.T) {
+	client := &fakeClient{}
+	c := newTestClient(t, client)
+	ctx := context.Background()
+
+	pulse := &godestatsv1.Pulse{
+		CodedAt: timestamppb.Now(),
+		Xps:     []*godestatsv1.LanguageXP{{Language: "Go", Xp: 15}},
+	}
+	if _, err := c.SendPulse(ctx, &godestatsv1.SendPulseRequest{Pulse: pulse}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.pulses) != 1 || client.pulses[0].XPs[0] != (godestats.LanguageXP{Language: "Go", XP: 15}) {
+		t.Errorf("Expected the pulse to be sent, got %+v", client.pulses)
+	}
+
+	tests := []struct {
+		name  string
+		pulse *godestatsv1.Pulse
+	}{
+		{"missing pulse", nil},
+		{"missing languages", &godestatsv1.Pulse{CodedAt: timestamppb.Now()}},
+		{"too old", &godestatsv1.Pulse{
+			CodedAt: timestamppb.New(time.Now().AddDate(0, 0, -8)),
+			Xps:     []*godestatsv1.LanguageXP{{Language: "Go", Xp: 15}},
+		}},
+	}
+	for _, tt := range tests {
+		_, err := c.SendPulse(ctx, &godestatsv1.SendPulseRequest{Pulse: tt.pulse})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestServer_Analytics(t *testing.T) {
+	c := newTestClient(t, &fakeClient{})
+	ctx := context.Background()
+
+	levels, err := c.GetLanguageLevels(ctx, &godestatsv1.GetLanguageLevelsRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(levels.GetLanguages()) != 2 || levels.GetLanguages()[0].GetLanguage() != "Go" || levels.GetLanguages()[0].GetProgress().GetLevel() != 1 {
+		t.Errorf("Expected Go at level 1 first, got %v", levels.GetLanguages())
+	}
+
+	machines, err := c.GetMachineStats(ctx, &godestatsv1.GetMachineStatsRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(machines.GetMachines()) != 2 || machines.GetMachines()[1].GetName() != "desktop" || !machines.GetMachines()[1].GetIdle() {
+		t.Errorf("Expected the idle desktop second, got %v", machines.GetMachines())
+	}
+
+	patterns, err := c.GetPatterns(ctx, &godestatsv1.GetPatternsRequest{Username: "alice", TimeZone: "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pattern := patterns.GetPattern()
+	if len(pattern.GetByWeekday()) != 7 || pattern.GetBestDay().GetXp() != 400 {
+		t.Errorf("Expected a weekday pattern with the best day, got %v", pattern)
+	}
+
+	_, err = c.GetPatterns(ctx, &godestatsv1.GetPatternsRequest{Username: "alice", TimeZone: "Mars/Olympus"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown time zone, got %v", err)
+	}
+}
+
+func TestStatusFor(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected codes.Code
+	}{
+		{godestats.ErrUnauthorized, codes.Unauthenticated},
+		{&godestats.RateLimitError{RetryAfter: time.Minute}, codes.ResourceExhausted},
+		{godestats.ErrEmptyUsername, codes.InvalidArgument},
+		{godestats.ErrUnsupported, codes.Unimplemented},
+		{godestats.NewAPIError(503, "maintenance", "/api/users/alice"), codes.Unavailable},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{godestats.ErrInvalidResponse, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		if code := status.Code(statusFor(tt.err)); code != tt.expected {
+			t.Errorf("statusFor(%v): expected %s, got %s", tt.err, tt.expected, code)
+		}
+	}
+}
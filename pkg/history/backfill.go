@@ -0,0 +1,140 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// DayLanguageXP is the XP gained in a single language on a single day,
+// as provided by the Code::Stats profile GraphQL data.
+type DayLanguageXP struct {
+	Date     godestats.Date `json:"date"`
+	Language string         `json:"language"`
+	XP       godestats.XP   `json:"xp"`
+}
+
+// BackfillOptions configures how history is reconstructed from a profile.
+type BackfillOptions struct {
+	// Location is the time zone the Dates keys refer to. Defaults to UTC.
+	Location *time.Location
+
+	// DayLanguages optionally provides per-language XP per day, which allows
+	// the backfilled snapshots to include language totals.
+	DayLanguages []DayLanguageXP
+}
+
+// Backfill seeds the store with one end-of-day snapshot per day in the profile's
+// Dates map, reconstructing each day's total XP by subtracting later days from the
+// current total. Days that already have a snapshot and days that have not ended
+// when the profile was fetched are skipped. It returns the number of stored snapshots.
+func Backfill(ctx context.Context, store Store, profile *godestats.UserProfile, opts BackfillOptions) (int, error) {
+	if profile == nil {
+		return 0, errors.New("profile is required")
+	}
+
+	tz := opts.Location
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	series, err := profile.DailySeries()
+	if err != nil {
+		return 0, err
+	}
+
+	fetchedAt := time.Now()
+	if !profile.Recent.IsZero() {
+		fetchedAt = profile.Recent.End()
+	}
+
+	dayLanguages := groupDayLanguages(opts.DayLanguages)
+
+	// Walk backwards from the current totals, removing each day's XP after its snapshot
+	total := profile.TotalXP
+	languages := make(map[string]godestats.XP, len(profile.Languages))
+	for name, info := range profile.Languages {
+		languages[name] = info.XPs
+	}
+
+	stored := 0
+	for i := len(series) - 1; i >= 0; i-- {
+		day := series[i]
+		startOfDay := day.Date.Time(tz)
+		endOfDay := day.Date.AddDays(1).Time(tz).Add(-time.Nanosecond)
+
+		if !endOfDay.After(fetchedAt) {
+			exists, err := hasSnapshot(ctx, store, profile.User, startOfDay, endOfDay)
+			if err != nil {
+				return stored, err
+			}
+
+			if !exists {
+				snapshot := Snapshot{
+					User:    profile.User,
+					TakenAt: endOfDay,
+					Profile: &godestats.UserProfile{
+						User:    profile.User,
+						TotalXP: total,
+						Dates:   map[string]godestats.XP{day.Date.String(): day.XP},
+						Recent:  godestats.NewRecentPeriod(endOfDay),
+					},
+				}
+				if dayLanguages != nil {
+					snapshot.Profile.Languages = languageSnapshot(languages)
+				}
+
+				if err := store.Put(ctx, snapshot); err != nil {
+					return stored, err
+				}
+				stored++
+			}
+		}
+
+		total -= day.XP
+		for name, xp := range dayLanguages[day.Date] {
+			languages[name] -= xp
+		}
+	}
+
+	return stored, nil
+}
+
+// groupDayLanguages indexes day-language data by date.
+func groupDayLanguages(entries []DayLanguageXP) map[godestats.Date]map[string]godestats.XP {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	grouped := make(map[godestats.Date]map[string]godestats.XP)
+	for _, entry := range entries {
+		if grouped[entry.Date] == nil {
+			grouped[entry.Date] = make(map[string]godestats.XP)
+		}
+		grouped[entry.Date][entry.Language] += entry.XP
+	}
+
+	return grouped
+}
+
+// languageSnapshot converts language totals into profile language entries, omitting languages without XP.
+func languageSnapshot(languages map[string]godestats.XP) map[string]godestats.LanguageInfo {
+	infos := make(map[string]godestats.LanguageInfo, len(languages))
+	for name, xp := range languages {
+		if xp > 0 {
+			infos[name] = godestats.LanguageInfo{XPs: xp}
+		}
+	}
+	return infos
+}
+
+// hasSnapshot reports whether the store contains a snapshot of the user within [from, to].
+func hasSnapshot(ctx context.Context, store Store, user string, from, to time.Time) (bool, error) {
+	snapshots, err := store.Range(ctx, user, from, to.Add(time.Nanosecond))
+	if err != nil {
+		return false, err
+	}
+	return len(snapshots) > 0, nil
+}
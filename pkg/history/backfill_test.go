@@ -0,0 +1,92 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func newBackfillProfile(fetchedAt time.Time) *godestats.UserProfile {
+	return &godestats.UserProfile{
+		User:    "u",
+		TotalXP: 1000,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go":   {XPs: 900},
+			"Rust": {XPs: 100},
+		},
+		Dates: map[string]godestats.XP{
+			"2023-06-12": 100,
+			"2023-06-13": 200,
+			"2023-06-15": 50,
+		},
+		Recent: godestats.NewRecentPeriod(fetchedAt),
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	fetchedAt := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	stored, err := Backfill(ctx, store, newBackfillProfile(fetchedAt), BackfillOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The current day has not ended yet and is skipped
+	if stored != 2 {
+		t.Fatalf("Expected 2 backfilled snapshots, got %d", stored)
+	}
+
+	snapshots, _ := store.Range(ctx, "u", time.Time{}, fetchedAt)
+	if snapshots[0].Profile.TotalXP != 750 || snapshots[1].Profile.TotalXP != 950 {
+		t.Errorf("Unexpected reconstructed totals: %d, %d",
+			snapshots[0].Profile.TotalXP, snapshots[1].Profile.TotalXP)
+	}
+	if snapshots[0].Profile.Languages != nil {
+		t.Error("Expected no language data without day-language input")
+	}
+
+	// Running the backfill again must not duplicate snapshots
+	stored, err = Backfill(ctx, store, newBackfillProfile(fetchedAt), BackfillOptions{})
+	if err != nil || stored != 0 {
+		t.Errorf("Expected no additional snapshots, got %d (%v)", stored, err)
+	}
+}
+
+func TestBackfill_DayLanguages(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	fetchedAt := time.Date(2023, 6, 16, 1, 0, 0, 0, time.UTC)
+
+	opts := BackfillOptions{
+		DayLanguages: []DayLanguageXP{
+			{Date: godestats.NewDate(2023, 6, 12), Language: "Go", XP: 100},
+			{Date: godestats.NewDate(2023, 6, 13), Language: "Go", XP: 150},
+			{Date: godestats.NewDate(2023, 6, 13), Language: "Rust", XP: 50},
+			{Date: godestats.NewDate(2023, 6, 15), Language: "Rust", XP: 50},
+		},
+	}
+
+	stored, err := Backfill(ctx, store, newBackfillProfile(fetchedAt), opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stored != 3 {
+		t.Fatalf("Expected 3 backfilled snapshots, got %d", stored)
+	}
+
+	snapshots, _ := store.Range(ctx, "u", time.Time{}, fetchedAt)
+
+	first := snapshots[0].Profile.Languages
+	if first["Go"].XPs != 750 || first["Rust"].XPs != 0 {
+		t.Errorf("Unexpected language totals for first day: %+v", first)
+	}
+
+	last := snapshots[2].Profile.Languages
+	if last["Go"].XPs != 900 || last["Rust"].XPs != 100 {
+		t.Errorf("Unexpected language totals for last day: %+v", last)
+	}
+}
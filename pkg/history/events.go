@@ -0,0 +1,96 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// ChangeKind identifies the type of a change between two snapshots.
+type ChangeKind string
+
+// Kinds of changes detected between snapshots
+const (
+	// ChangeXPGained is emitted when the total XP changed.
+	ChangeXPGained ChangeKind = "xp_gained"
+
+	// ChangeLevelUp is emitted when the overall level increased.
+	ChangeLevelUp ChangeKind = "level_up"
+
+	// ChangeLanguageLevelUp is emitted when the level of a single language increased.
+	ChangeLanguageLevelUp ChangeKind = "language_level_up"
+
+	// ChangeNewLanguage is emitted when a language appears for the first time.
+	ChangeNewLanguage ChangeKind = "new_language"
+)
+
+// ChangeEvent describes a single change between two consecutive snapshots.
+// Language is only set for language-specific changes.
+type ChangeEvent struct {
+	Kind     ChangeKind   `json:"kind"`
+	User     string       `json:"user"`
+	At       time.Time    `json:"at"`
+	Language string       `json:"language,omitempty"`
+	OldXP    godestats.XP `json:"old_xp"`
+	NewXP    godestats.XP `json:"new_xp"`
+	OldLevel int          `json:"old_level"`
+	NewLevel int          `json:"new_level"`
+}
+
+// Delta returns the XP difference described by the event.
+func (e ChangeEvent) Delta() godestats.XP {
+	return e.NewXP - e.OldXP
+}
+
+// Diff compares two snapshots of the same user and returns the changes between them,
+// ordered as XP change, level-up, then language changes by language name.
+func Diff(prev, next Snapshot, calc godestats.XpCalculator) []ChangeEvent {
+	if prev.Profile == nil || next.Profile == nil {
+		return nil
+	}
+
+	var events []ChangeEvent
+	base := ChangeEvent{User: next.User, At: next.TakenAt}
+
+	oldXP, newXP := prev.Profile.TotalXP, next.Profile.TotalXP
+	if oldXP != newXP {
+		e := base
+		e.Kind = ChangeXPGained
+		e.OldXP, e.NewXP = oldXP, newXP
+		e.OldLevel, e.NewLevel = calc.GetLevel(oldXP), calc.GetLevel(newXP)
+		events = append(events, e)
+
+		if e.NewLevel > e.OldLevel {
+			e.Kind = ChangeLevelUp
+			events = append(events, e)
+		}
+	}
+
+	names := make([]string, 0, len(next.Profile.Languages))
+	for name := range next.Profile.Languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		newInfo := next.Profile.Languages[name]
+		oldInfo, existed := prev.Profile.Languages[name]
+
+		e := base
+		e.Language = name
+		e.OldXP, e.NewXP = oldInfo.XPs, newInfo.XPs
+		e.OldLevel, e.NewLevel = calc.GetLevel(oldInfo.XPs), calc.GetLevel(newInfo.XPs)
+
+		if !existed {
+			e.Kind = ChangeNewLanguage
+			events = append(events, e)
+		}
+		if e.NewLevel > e.OldLevel {
+			e.Kind = ChangeLanguageLevelUp
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,107 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+func TestDiff(t *testing.T) {
+	at := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	prev := Snapshot{User: "u", Profile: &godestats.UserProfile{
+		TotalXP: 1500,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go": {XPs: 1500},
+		},
+	}}
+	next := Snapshot{User: "u", TakenAt: at, Profile: &godestats.UserProfile{
+		TotalXP: 1700,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go":   {XPs: 1650},
+			"Rust": {XPs: 50},
+		},
+	}}
+
+	events := Diff(prev, next, xp.NewCalculator())
+
+	expected := []struct {
+		kind     ChangeKind
+		language string
+	}{
+		{ChangeXPGained, ""},
+		{ChangeLevelUp, ""},
+		{ChangeLanguageLevelUp, "Go"},
+		{ChangeNewLanguage, "Rust"},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(events), events)
+	}
+
+	for i, e := range expected {
+		if events[i].Kind != e.kind || events[i].Language != e.language {
+			t.Errorf("Event %d: expected %s/%s, got %s/%s", i, e.kind, e.language, events[i].Kind, events[i].Language)
+		}
+		if !events[i].At.Equal(at) || events[i].User != "u" {
+			t.Errorf("Event %d: unexpected metadata %+v", i, events[i])
+		}
+	}
+
+	if delta := events[0].Delta(); delta != 200 {
+		t.Errorf("Expected delta 200, got %d", delta)
+	}
+	if events[1].OldLevel != 0 || events[1].NewLevel != 1 {
+		t.Errorf("Unexpected level change: %+v", events[1])
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	profile := &godestats.UserProfile{TotalXP: 100}
+	events := Diff(Snapshot{Profile: profile}, Snapshot{Profile: profile}, xp.NewCalculator())
+	if len(events) != 0 {
+		t.Errorf("Expected no events, got %+v", events)
+	}
+
+	if Diff(Snapshot{}, Snapshot{Profile: profile}, xp.NewCalculator()) != nil {
+		t.Error("Expected no events without a previous profile")
+	}
+}
+
+func TestRecorder_Subscribe(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	// A snapshot from an earlier run is used as the baseline
+	store.Put(ctx, Snapshot{User: "testuser", TakenAt: now.Add(-time.Hour), Profile: &godestats.UserProfile{TotalXP: 50}})
+
+	recorder := NewRecorder(&fakeClient{}, store, "testuser")
+	recorder.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	events := make(chan ChangeEvent, 10)
+	recorder.Subscribe(func(e ChangeEvent) { events <- e })
+
+	for i := 0; i < 2; i++ {
+		if _, err := recorder.Record(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	close(events)
+
+	var deltas []godestats.XP
+	for e := range events {
+		if e.Kind == ChangeXPGained {
+			deltas = append(deltas, e.Delta())
+		}
+	}
+
+	if len(deltas) != 2 || deltas[0] != 50 || deltas[1] != 100 {
+		t.Errorf("Expected XP deltas [50 100], got %v", deltas)
+	}
+}
@@ -0,0 +1,208 @@
+// Package export dumps stored history into columnar files partitioned by month,
+// for analysis with tools such as DuckDB or Pandas.
+//
+// Files are laid out in Hive-style partitions, e.g.
+//
+//	dir/user=alice/month=2023-06/snapshots.parquet
+//
+// so that they can be queried with hive partitioning enabled.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/Yeti47/gode-stats/pkg/bulk"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// Format selects the file format of an export.
+type Format int
+
+// Supported export formats
+const (
+	CSV Format = iota
+	Parquet
+)
+
+// Extension returns the file extension used for the format.
+func (f Format) Extension() string {
+	if f == Parquet {
+		return ".parquet"
+	}
+	return ".csv"
+}
+
+// Row is a single exported record: one language of one snapshot.
+// Snapshots without language data are exported as a single row with an empty language.
+type Row struct {
+	User       string    `parquet:"user" json:"user"`
+	TakenAt    time.Time `parquet:"taken_at,timestamp(millisecond)" json:"taken_at"`
+	TotalXP    int64     `parquet:"total_xp" json:"total_xp"`
+	Language   string    `parquet:"language" json:"language"`
+	LanguageXP int64     `parquet:"language_xp" json:"language_xp"`
+}
+
+// csvHeader is the header row of exported CSV files.
+var csvHeader = []string{"user", "taken_at", "total_xp", "language", "language_xp"}
+
+// Export writes the user's snapshots taken within [from, to) into one file per
+// calendar month (UTC) below dir and returns the paths of the written files.
+// Snapshots are read from the store one month at a time to bound memory usage.
+func Export(ctx context.Context, store history.Store, user string, from, to time.Time, dir string, format Format) ([]string, error) {
+	var files []string
+
+	for month := monthStart(from); month.Before(to); month = month.AddDate(0, 1, 0) {
+		start, end := month, month.AddDate(0, 1, 0)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+
+		snapshots, err := store.Range(ctx, user, start, end)
+		if err != nil {
+			return files, err
+		}
+		if len(snapshots) == 0 {
+			continue
+		}
+
+		path := partitionPath(dir, user, month, format)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return files, fmt.Errorf("failed to create partition directory: %w", err)
+		}
+
+		if err := writeFile(path, Rows(snapshots), format); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+// ExportUsers exports the snapshots of several users concurrently, as Export does for one user.
+// It returns the written files of all users and the joined errors of users that failed.
+func ExportUsers(ctx context.Context, store history.Store, users []string, from, to time.Time, dir string, format Format, opts bulk.Options) ([]string, error) {
+	results := bulk.Run(ctx, users, func(ctx context.Context, user string) ([]string, error) {
+		return Export(ctx, store, user, from, to, dir, format)
+	}, opts)
+
+	var (
+		files []string
+		errs  []error
+	)
+	for _, r := range results {
+		files = append(files, r.Value...)
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("export of %s: %w", r.Username, r.Err))
+		}
+	}
+
+	return files, errors.Join(errs...)
+}
+
+// Rows flattens snapshots into export rows, with languages in alphabetical order.
+func Rows(snapshots []history.Snapshot) []Row {
+	var rows []Row
+
+	for _, snapshot := range snapshots {
+		if snapshot.Profile == nil {
+			continue
+		}
+
+		base := Row{
+			User:    snapshot.User,
+			TakenAt: snapshot.TakenAt.UTC(),
+			TotalXP: snapshot.Profile.TotalXP.Int64(),
+		}
+
+		if len(snapshot.Profile.Languages) == 0 {
+			rows = append(rows, base)
+			continue
+		}
+
+		names := make([]string, 0, len(snapshot.Profile.Languages))
+		for name := range snapshot.Profile.Languages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			row := base
+			row.Language = name
+			row.LanguageXP = snapshot.Profile.Languages[name].XPs.Int64()
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// writeFile writes the rows to path in the given format.
+func writeFile(path string, rows []Row, format Format) error {
+	switch format {
+	case Parquet:
+		if err := parquet.WriteFile(path, rows); err != nil {
+			return fmt.Errorf("failed to write parquet file: %w", err)
+		}
+		return nil
+	case CSV:
+		return writeCSV(path, rows)
+	default:
+		return fmt.Errorf("unsupported export format %d", format)
+	}
+}
+
+// writeCSV writes the rows to a CSV file with a header row.
+func writeCSV(path string, rows []Row) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create csv file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write(csvHeader)
+	for _, row := range rows {
+		w.Write([]string{
+			row.User,
+			row.TakenAt.Format(time.RFC3339),
+			strconv.FormatInt(row.TotalXP, 10),
+			row.Language,
+			strconv.FormatInt(row.LanguageXP, 10),
+		})
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write csv file: %w", err)
+	}
+	return file.Close()
+}
+
+// partitionPath returns the Hive-style path of the file holding a user's month.
+func partitionPath(dir, user string, month time.Time, format Format) string {
+	return filepath.Join(dir,
+		"user="+url.PathEscape(user),
+		"month="+month.Format("2006-01"),
+		"snapshots"+format.Extension())
+}
+
+// monthStart returns the first instant of t's calendar month in UTC.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
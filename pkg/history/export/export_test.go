@@ -0,0 +1,145 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/bulk"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func newTestStore(t *testing.T) *history.MemoryStore {
+	t.Helper()
+
+	store := history.NewMemoryStore()
+	for _, takenAt := range []time.Time{
+		time.Date(2023, 5, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 20, 1, 0, 0, 0, time.UTC),
+	} {
+		store.Put(context.Background(), history.Snapshot{
+			User:    "alice",
+			TakenAt: takenAt,
+			Profile: &godestats.UserProfile{
+				TotalXP: 300,
+				Languages: map[string]godestats.LanguageInfo{
+					"Rust": {XPs: 100},
+					"Go":   {XPs: 200},
+				},
+			},
+		})
+	}
+
+	return store
+}
+
+func TestExport_CSV(t *testing.T) {
+	dir := t.TempDir()
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	files, err := Export(context.Background(), newTestStore(t), "alice", from, to, dir, CSV)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		filepath.Join(dir, "user=alice", "month=2023-05", "snapshots.csv"),
+		filepath.Join(dir, "user=alice", "month=2023-06", "snapshots.csv"),
+	}
+	if len(files) != len(expected) || files[0] != expected[0] || files[1] != expected[1] {
+		t.Fatalf("Expected files %v, got %v", expected, files)
+	}
+
+	f, err := os.Open(files[1])
+	if err != nil {
+		t.Fatalf("Failed to open export: %v", err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+
+	// Header plus two languages for each of the two June snapshots
+	if len(records) != 5 {
+		t.Fatalf("Expected 5 records, got %d", len(records))
+	}
+	if records[1][3] != "Go" || records[1][4] != "200" || records[1][1] != "2023-06-01T01:00:00Z" {
+		t.Errorf("Unexpected first row: %v", records[1])
+	}
+}
+
+func TestExport_Parquet(t *testing.T) {
+	dir := t.TempDir()
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	files, err := Export(context.Background(), newTestStore(t), "alice", from, to, dir, Parquet)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %v", files)
+	}
+
+	rows, err := parquet.ReadFile[Row](files[0])
+	if err != nil {
+		t.Fatalf("Failed to read parquet export: %v", err)
+	}
+
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(rows))
+	}
+	if rows[0].User != "alice" || rows[0].Language != "Go" || rows[0].LanguageXP != 200 || rows[0].TotalXP != 300 {
+		t.Errorf("Unexpected first row: %+v", rows[0])
+	}
+	if !rows[0].TakenAt.Equal(time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected timestamp: %v", rows[0].TakenAt)
+	}
+}
+
+func TestRows_WithoutLanguages(t *testing.T) {
+	rows := Rows([]history.Snapshot{{
+		User:    "alice",
+		TakenAt: time.Now(),
+		Profile: &godestats.UserProfile{TotalXP: 42},
+	}})
+
+	if len(rows) != 1 || rows[0].Language != "" || rows[0].TotalXP != 42 {
+		t.Errorf("Unexpected rows: %+v", rows)
+	}
+}
+
+func TestExportUsers(t *testing.T) {
+	dir := t.TempDir()
+	store := newTestStore(t)
+	store.Put(context.Background(), history.Snapshot{
+		User:    "bob",
+		TakenAt: time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC),
+		Profile: &godestats.UserProfile{TotalXP: 10},
+	})
+
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	files, err := ExportUsers(context.Background(), store, []string{"alice", "bob", "carol"}, from, to, dir, CSV, bulk.Options{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files, got %v", files)
+	}
+	if files[1] != filepath.Join(dir, "user=bob", "month=2023-06", "snapshots.csv") {
+		t.Errorf("Expected bob's file second, got %s", files[1])
+	}
+}
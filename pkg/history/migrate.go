@@ -0,0 +1,55 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors reported by store schema migrations
+var (
+	// ErrSchemaTooNew is returned when a store was written by a newer version of this library.
+	ErrSchemaTooNew = errors.New("store schema is newer than supported by this library version")
+
+	// ErrMigrationRequired is returned when a store's schema is outdated and automatic migration is disabled.
+	ErrMigrationRequired = errors.New("store schema is outdated and must be migrated")
+)
+
+// Migration describes a single schema change of a store backend.
+// Versions start at 1; version 0 denotes an empty store.
+type Migration struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+}
+
+// String returns a human-readable description of the migration.
+func (m Migration) String() string {
+	return fmt.Sprintf("%d: %s", m.Version, m.Description)
+}
+
+// PendingMigrations returns the migrations that must be applied to bring a store
+// from the current schema version up to date, in the order they must be applied.
+// The migrations must be ordered by strictly increasing version.
+func PendingMigrations(current int, migrations []Migration) ([]Migration, error) {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			return nil, fmt.Errorf("migration %d is out of order", migrations[i].Version)
+		}
+	}
+
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	if current > latest {
+		return nil, fmt.Errorf("%w: version %d, latest known %d", ErrSchemaTooNew, current, latest)
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,36 @@
+package history
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Description: "create snapshots"},
+		{Version: 2, Description: "create rollups"},
+		{Version: 3, Description: "add index"},
+	}
+
+	pending, err := PendingMigrations(1, migrations)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Version != 2 || pending[1].Version != 3 {
+		t.Errorf("Unexpected pending migrations: %v", pending)
+	}
+
+	pending, err = PendingMigrations(3, migrations)
+	if err != nil || len(pending) != 0 {
+		t.Errorf("Expected no pending migrations, got %v (%v)", pending, err)
+	}
+
+	if _, err := PendingMigrations(4, migrations); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Expected ErrSchemaTooNew, got %v", err)
+	}
+
+	unordered := []Migration{{Version: 2}, {Version: 1}}
+	if _, err := PendingMigrations(0, unordered); err == nil {
+		t.Error("Expected error for unordered migrations")
+	}
+}
@@ -0,0 +1,85 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Point is a single downsampled value of a user's XP history.
+type Point struct {
+	// Time is the start of the resolution bucket the point represents.
+	Time time.Time `json:"time"`
+
+	// TotalXP is the user's total XP at the end of the bucket.
+	TotalXP godestats.XP `json:"total_xp"`
+
+	// Gained is the XP gained since the previous point; 0 for the first point.
+	Gained godestats.XP `json:"gained"`
+
+	// Languages holds the total XP per language at the end of the bucket.
+	Languages map[string]godestats.XP `json:"languages"`
+}
+
+// Series is a downsampled XP history of a single user.
+type Series struct {
+	User       string        `json:"user"`
+	Resolution time.Duration `json:"resolution"`
+	Points     []Point       `json:"points"`
+}
+
+// Query returns the user's XP history within [from, to) from the store, downsampled
+// to one point per resolution bucket. Buckets are aligned to the zero time, so
+// daily buckets start at midnight UTC. Buckets without snapshots are omitted.
+func Query(ctx context.Context, store Store, user string, from, to time.Time, resolution time.Duration) (*Series, error) {
+	if resolution <= 0 {
+		return nil, errors.New("resolution must be positive")
+	}
+
+	snapshots, err := store.Range(ctx, user, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &Series{User: user, Resolution: resolution}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Profile == nil {
+			continue
+		}
+
+		bucket := snapshot.TakenAt.Truncate(resolution)
+		point := Point{
+			Time:      bucket,
+			TotalXP:   snapshot.Profile.TotalXP,
+			Languages: make(map[string]godestats.XP, len(snapshot.Profile.Languages)),
+		}
+		for name, info := range snapshot.Profile.Languages {
+			point.Languages[name] = info.XPs
+		}
+
+		// Later snapshots within the same bucket replace earlier ones
+		if n := len(series.Points); n > 0 && series.Points[n-1].Time.Equal(bucket) {
+			series.Points[n-1] = point
+		} else {
+			series.Points = append(series.Points, point)
+		}
+	}
+
+	for i := 1; i < len(series.Points); i++ {
+		series.Points[i].Gained = series.Points[i].TotalXP - series.Points[i-1].TotalXP
+	}
+
+	return series, nil
+}
+
+// Language returns the series of a single language's total XP, one value per point.
+func (s *Series) Language(name string) []godestats.XP {
+	values := make([]godestats.XP, len(s.Points))
+	for i, point := range s.Points {
+		values[i] = point.Languages[name]
+	}
+	return values
+}
@@ -0,0 +1,66 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestQuery(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	base := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	put := func(offset time.Duration, goXP, rustXP godestats.XP) {
+		store.Put(ctx, Snapshot{
+			User:    "u",
+			TakenAt: base.Add(offset),
+			Profile: &godestats.UserProfile{
+				TotalXP: goXP + rustXP,
+				Languages: map[string]godestats.LanguageInfo{
+					"Go":   {XPs: goXP},
+					"Rust": {XPs: rustXP},
+				},
+			},
+		})
+	}
+
+	put(1*time.Hour, 100, 0)
+	put(5*time.Hour, 150, 10)
+	put(25*time.Hour, 200, 20)
+	put(49*time.Hour, 300, 20)
+
+	series, err := Query(ctx, store, "u", base, base.Add(72*time.Hour), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(series.Points) != 3 {
+		t.Fatalf("Expected 3 daily points, got %d", len(series.Points))
+	}
+
+	expectedTotals := []godestats.XP{160, 220, 320}
+	expectedGained := []godestats.XP{0, 60, 100}
+	for i, point := range series.Points {
+		if point.TotalXP != expectedTotals[i] || point.Gained != expectedGained[i] {
+			t.Errorf("Point %d: expected %d/%d, got %d/%d",
+				i, expectedTotals[i], expectedGained[i], point.TotalXP, point.Gained)
+		}
+		if !point.Time.Equal(base.Add(time.Duration(i) * 24 * time.Hour)) {
+			t.Errorf("Point %d: unexpected bucket time %v", i, point.Time)
+		}
+	}
+
+	rust := series.Language("Rust")
+	if len(rust) != 3 || rust[0] != 10 || rust[2] != 20 {
+		t.Errorf("Unexpected Rust series: %v", rust)
+	}
+}
+
+func TestQuery_InvalidResolution(t *testing.T) {
+	if _, err := Query(context.Background(), NewMemoryStore(), "u", time.Time{}, time.Now(), 0); err == nil {
+		t.Error("Expected error for zero resolution")
+	}
+}
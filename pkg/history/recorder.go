@@ -0,0 +1,236 @@
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// previousLookback is how far back the store is searched for the snapshot
+// preceding the first one recorded by a new recorder.
+const previousLookback = 7 * 24 * time.Hour
+
+// DefaultInterval is the default time between two snapshots.
+const DefaultInterval = time.Hour
+
+// Recorder periodically fetches a user's profile and stores it as a snapshot,
+// preserving trend data beyond what the API's aggregated Dates map provides.
+type Recorder struct {
+	client   godestats.ProfileClient
+	store    Store
+	username string
+	interval time.Duration
+	jitter   float64
+	onError  func(error)
+	now      func() time.Time
+
+	retention     *RetentionPolicy
+	lastCompacted time.Time
+
+	calc        godestats.XpCalculator
+	mu          sync.Mutex
+	last        *Snapshot
+	subscribers []func(ChangeEvent)
+}
+
+// RecorderOption configures optional behavior of a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithInterval sets the time between two snapshots.
+func WithInterval(interval time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithJitter randomizes the time between two snapshots by up to the given fraction
+// of the interval in either direction (see godestats.Jitter), so that recorders
+// deployed from the same configuration don't fetch profiles at the same moment.
+func WithJitter(fraction float64) RecorderOption {
+	return func(r *Recorder) {
+		r.jitter = fraction
+	}
+}
+
+// WithErrorHandler sets a function that is called for every failed snapshot
+// while the recorder is running. Errors are ignored by default.
+func WithErrorHandler(handler func(error)) RecorderOption {
+	return func(r *Recorder) {
+		r.onError = handler
+	}
+}
+
+// WithRetention enables periodic compaction of the recorded user's snapshots
+// according to the given policy. The store must implement Deleter.
+func WithRetention(policy RetentionPolicy) RecorderOption {
+	return func(r *Recorder) {
+		r.retention = &policy
+	}
+}
+
+// NewRecorder creates a new recorder that stores snapshots of the given user's profile.
+func NewRecorder(client godestats.ProfileClient, store Store, username string, opts ...RecorderOption) *Recorder {
+	r := &Recorder{
+		client:   client,
+		store:    store,
+		username: username,
+		interval: DefaultInterval,
+		onError:  func(error) {},
+		now:      time.Now,
+		calc:     xp.NewCalculator(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Subscribe registers a handler that is called with every change detected between
+// a newly recorded snapshot and the previous one. Handlers are called synchronously
+// from Record in the order of registration; to consume changes from a channel,
+// subscribe a handler that sends to it.
+func (r *Recorder) Subscribe(handler func(ChangeEvent)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, handler)
+}
+
+// Record fetches the profile once and stores it as a snapshot.
+// If the store implements RollupStore, its rollups are updated as well.
+// If updating rollups or compaction fails, the stored snapshot is returned
+// together with the error.
+func (r *Recorder) Record(ctx context.Context) (Snapshot, error) {
+	profile, err := r.client.GetUserProfile(ctx, r.username)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		User:    r.username,
+		TakenAt: r.now(),
+		Profile: profile,
+	}
+
+	previous, err := r.previous(ctx, snapshot.TakenAt)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	if err := r.store.Put(ctx, snapshot); err != nil {
+		return Snapshot{}, err
+	}
+
+	r.publish(previous, snapshot)
+
+	if rollups, ok := r.store.(RollupStore); ok {
+		if err := UpdateRollups(ctx, rollups, previous, snapshot); err != nil {
+			return snapshot, err
+		}
+	}
+
+	if err := r.compactIfDue(ctx, snapshot.TakenAt); err != nil {
+		return snapshot, err
+	}
+
+	return snapshot, nil
+}
+
+// previous returns the snapshot preceding one taken at the given time, looking it up
+// in the store if this recorder has not recorded a snapshot yet.
+func (r *Recorder) previous(ctx context.Context, takenAt time.Time) (*Snapshot, error) {
+	r.mu.Lock()
+	last := r.last
+	r.mu.Unlock()
+
+	if last != nil {
+		return last, nil
+	}
+
+	snapshots, err := r.store.Range(ctx, r.username, takenAt.Add(-previousLookback), takenAt)
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return &snapshots[len(snapshots)-1], nil
+}
+
+// publish remembers the new snapshot and notifies subscribers about its changes.
+func (r *Recorder) publish(previous *Snapshot, snapshot Snapshot) {
+	r.mu.Lock()
+	r.last = &snapshot
+	subscribers := append([]func(ChangeEvent){}, r.subscribers...)
+	r.mu.Unlock()
+
+	if previous == nil || len(subscribers) == 0 {
+		return
+	}
+
+	for _, event := range Diff(*previous, snapshot, r.calc) {
+		for _, handler := range subscribers {
+			handler(event)
+		}
+	}
+}
+
+// compactIfDue applies the retention policy if enough time has passed since the last run.
+func (r *Recorder) compactIfDue(ctx context.Context, now time.Time) error {
+	if r.retention == nil {
+		return nil
+	}
+
+	// Claim the run under the lock, so that concurrent snapshots compact only once
+	r.mu.Lock()
+	previous := r.lastCompacted
+	if !previous.IsZero() && now.Sub(previous) < r.retention.Interval {
+		r.mu.Unlock()
+		return nil
+	}
+	r.lastCompacted = now
+	r.mu.Unlock()
+
+	if _, err := Compact(ctx, r.store, r.username, *r.retention, now); err != nil {
+		// Retry with the next snapshot
+		r.mu.Lock()
+		if r.lastCompacted.Equal(now) {
+			r.lastCompacted = previous
+		}
+		r.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Run records a snapshot immediately and then once per interval, jittered if
+// configured, until the context is cancelled. Failed snapshots are reported to the
+// error handler and do not stop the recorder. Run always returns the context's error.
+func (r *Recorder) Run(ctx context.Context) error {
+	start := time.Now()
+	for {
+		if _, err := r.Record(ctx); err != nil && ctx.Err() == nil {
+			r.onError(err)
+		}
+
+		// Schedule from the previous start like a ticker, so slow snapshots don't
+		// delay the next one, but skip snapshots that are already overdue
+		start = start.Add(godestats.Jitter(r.interval, r.jitter))
+		if now := time.Now(); start.Before(now) {
+			start = now
+		}
+		timer := time.NewTimer(time.Until(start))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
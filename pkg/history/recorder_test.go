@@ -0,0 +1,143 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// fakeClient is a CodeStatsClient returning a profile with increasing XP on every call.
+type fakeClient struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &godestats.UserProfile{User: username, TotalXP: godestats.XP(f.calls * 100)}, nil
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestRecorder_Record(t *testing.T) {
+	store := NewMemoryStore()
+	recorder := NewRecorder(&fakeClient{}, store, "testuser")
+
+	snapshot, err := recorder.Record(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if snapshot.User != "testuser" || snapshot.Profile.TotalXP != 100 {
+		t.Errorf("Unexpected snapshot: %+v", snapshot)
+	}
+
+	stored, _ := store.Range(context.Background(), "testuser", snapshot.TakenAt, snapshot.TakenAt.Add(time.Second))
+	if len(stored) != 1 {
+		t.Errorf("Expected snapshot to be stored, got %d", len(stored))
+	}
+}
+
+func TestRecorder_Run(t *testing.T) {
+	store := NewMemoryStore()
+	client := &fakeClient{}
+
+	var mu sync.Mutex
+	tick := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder(client, store, "testuser", WithInterval(time.Millisecond))
+	recorder.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		tick = tick.Add(time.Minute)
+		return tick
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for {
+			snapshots, _ := store.Range(context.Background(), "testuser", time.Time{}, time.Now().AddDate(100, 0, 0))
+			if len(snapshots) >= 3 {
+				cancel()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	if err := recorder.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRecorder_Run_Jitter(t *testing.T) {
+	store := NewMemoryStore()
+	recorder := NewRecorder(&fakeClient{}, store, "testuser", WithInterval(time.Millisecond), WithJitter(0.5))
+	if recorder.jitter != 0.5 {
+		t.Fatalf("Expected jitter 0.5, got %v", recorder.jitter)
+	}
+
+	var mu sync.Mutex
+	tick := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		tick = tick.Add(time.Minute)
+		return tick
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			snapshots, _ := store.Range(context.Background(), "testuser", time.Time{}, time.Now().AddDate(100, 0, 0))
+			if len(snapshots) >= 3 {
+				cancel()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	recorder.Run(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Error("Expected jittered recorder to keep recording")
+	}
+}
+
+func TestRecorder_Run_ReportsErrors(t *testing.T) {
+	apiErr := errors.New("api down")
+	errs := make(chan error, 1)
+
+	recorder := NewRecorder(&fakeClient{err: apiErr}, NewMemoryStore(), "testuser",
+		WithInterval(time.Hour),
+		WithErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- recorder.Run(ctx) }()
+
+	if err := <-errs; !errors.Is(err, apiErr) {
+		t.Errorf("Expected api error, got %v", err)
+	}
+
+	cancel()
+	<-done
+}
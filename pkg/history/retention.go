@@ -0,0 +1,107 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrDeleteUnsupported is returned when compaction is requested for a store that cannot delete snapshots.
+var ErrDeleteUnsupported = errors.New("store does not support deleting snapshots")
+
+// Deleter is implemented by stores that can remove snapshots.
+// It is required for retention and compaction.
+type Deleter interface {
+	// Delete removes the user's snapshots taken within [from, to) and returns how many were removed.
+	Delete(ctx context.Context, user string, from, to time.Time) (int, error)
+}
+
+// RetentionTier keeps at most one snapshot per Resolution for snapshots younger than MaxAge.
+type RetentionTier struct {
+	Resolution time.Duration `json:"resolution"`
+	MaxAge     time.Duration `json:"max_age"`
+}
+
+// RetentionPolicy describes how snapshots are downsampled as they age.
+// Each tier applies to snapshots older than the previous tier's MaxAge;
+// snapshots older than the last tier's MaxAge are removed entirely.
+// Snapshots younger than the first tier's Resolution are never touched.
+type RetentionPolicy struct {
+	Tiers []RetentionTier `json:"tiers"`
+
+	// Interval is the minimum time between two compaction runs of a recorder.
+	Interval time.Duration `json:"interval"`
+}
+
+// DefaultRetentionPolicy keeps hourly snapshots for a week and daily snapshots for a year.
+var DefaultRetentionPolicy = RetentionPolicy{
+	Tiers: []RetentionTier{
+		{Resolution: time.Hour, MaxAge: 7 * 24 * time.Hour},
+		{Resolution: 24 * time.Hour, MaxAge: 365 * 24 * time.Hour},
+	},
+	Interval: 24 * time.Hour,
+}
+
+// Compact applies the retention policy to the user's snapshots in the store.
+// Within each tier's resolution bucket the latest snapshot is kept, since it holds
+// the most recent cumulative totals. It returns the number of removed snapshots.
+func Compact(ctx context.Context, store Store, user string, policy RetentionPolicy, now time.Time) (int, error) {
+	deleter, ok := store.(Deleter)
+	if !ok {
+		return 0, ErrDeleteUnsupported
+	}
+
+	if len(policy.Tiers) == 0 {
+		return 0, nil
+	}
+
+	tiers := append([]RetentionTier(nil), policy.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MaxAge < tiers[j].MaxAge })
+
+	snapshots, err := store.Range(ctx, user, time.Time{}, now.Add(-tiers[0].Resolution))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	kept := make(map[int64]time.Time)
+	var obsolete []time.Time
+
+	// Walk from newest to oldest so the latest snapshot of each bucket is seen first
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		takenAt := snapshots[i].TakenAt
+		tier, ok := tierFor(tiers, now.Sub(takenAt))
+		if !ok {
+			obsolete = append(obsolete, takenAt)
+			continue
+		}
+
+		bucket := takenAt.Truncate(tier.Resolution).UnixNano()
+		if _, exists := kept[bucket]; exists {
+			obsolete = append(obsolete, takenAt)
+			continue
+		}
+		kept[bucket] = takenAt
+	}
+
+	for _, takenAt := range obsolete {
+		n, err := deleter.Delete(ctx, user, takenAt, takenAt.Add(time.Nanosecond))
+		removed += n
+		if err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+// tierFor returns the tier responsible for snapshots of the given age.
+func tierFor(tiers []RetentionTier, age time.Duration) (RetentionTier, bool) {
+	for _, tier := range tiers {
+		if age < tier.MaxAge {
+			return tier, true
+		}
+	}
+	return RetentionTier{}, false
+}
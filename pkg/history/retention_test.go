@@ -0,0 +1,133 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestCompact(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	// Snapshots every 15 minutes for the last 3 days, plus some very old ones
+	var times []time.Time
+	for tm := now.Add(-72 * time.Hour); tm.Before(now); tm = tm.Add(15 * time.Minute) {
+		times = append(times, tm)
+	}
+	times = append(times, now.AddDate(-2, 0, 0), now.AddDate(-2, 0, 1))
+
+	for i, tm := range times {
+		store.Put(ctx, Snapshot{User: "u", TakenAt: tm, Profile: &godestats.UserProfile{TotalXP: godestats.XP(i)}})
+	}
+
+	policy := RetentionPolicy{
+		Tiers: []RetentionTier{
+			{Resolution: 24 * time.Hour, MaxAge: 365 * 24 * time.Hour},
+			{Resolution: time.Hour, MaxAge: 24 * time.Hour},
+		},
+	}
+
+	removed, err := Compact(ctx, store, "u", policy, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshots, _ := store.Range(ctx, "u", time.Time{}, now.Add(time.Hour))
+	if removed != len(times)-len(snapshots) {
+		t.Errorf("Reported %d removed snapshots, but %d are gone", removed, len(times)-len(snapshots))
+	}
+
+	// Old snapshots are gone entirely
+	if snapshots[0].TakenAt.Before(now.AddDate(-1, 0, 0)) {
+		t.Errorf("Expected snapshots beyond the last tier to be removed, got %v", snapshots[0].TakenAt)
+	}
+
+	// Within each hour of the last day at most one snapshot remains, except the newest hour
+	perHour := make(map[time.Time]int)
+	for _, s := range snapshots {
+		if now.Sub(s.TakenAt) < 24*time.Hour && now.Sub(s.TakenAt) >= time.Hour {
+			perHour[s.TakenAt.Truncate(time.Hour)]++
+		}
+	}
+	for hour, n := range perHour {
+		if n != 1 {
+			t.Errorf("Expected 1 snapshot in hour %v, got %d", hour, n)
+		}
+	}
+
+	// The latest snapshot of a bucket is the one that is kept
+	day := now.Add(-48 * time.Hour).Truncate(24 * time.Hour)
+	for _, s := range snapshots {
+		if s.TakenAt.Truncate(24*time.Hour).Equal(day) && !s.TakenAt.Equal(day.Add(24*time.Hour-15*time.Minute)) {
+			t.Errorf("Expected the last snapshot of the day to be kept, got %v", s.TakenAt)
+		}
+	}
+
+	// Snapshots younger than the finest resolution are untouched
+	recent, _ := store.Range(ctx, "u", now.Add(-time.Hour), now)
+	if len(recent) != 4 {
+		t.Errorf("Expected 4 recent snapshots to be kept, got %d", len(recent))
+	}
+}
+
+// rangeOnlyStore is a store that cannot delete snapshots.
+type rangeOnlyStore struct{ Store }
+
+func TestCompact_Unsupported(t *testing.T) {
+	store := rangeOnlyStore{NewMemoryStore()}
+	_, err := Compact(context.Background(), store, "u", DefaultRetentionPolicy, time.Now())
+	if !errors.Is(err, ErrDeleteUnsupported) {
+		t.Errorf("Expected ErrDeleteUnsupported, got %v", err)
+	}
+}
+
+func TestRecorder_WithRetention(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	store.Put(ctx, Snapshot{User: "testuser", TakenAt: now.AddDate(-2, 0, 0), Profile: &godestats.UserProfile{}})
+
+	recorder := NewRecorder(&fakeClient{}, store, "testuser", WithRetention(DefaultRetentionPolicy))
+	recorder.now = func() time.Time { return now }
+
+	if _, err := recorder.Record(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshots, _ := store.Range(ctx, "testuser", time.Time{}, now.Add(time.Hour))
+	if len(snapshots) != 1 || !snapshots[0].TakenAt.Equal(now) {
+		t.Errorf("Expected only the new snapshot to remain, got %+v", snapshots)
+	}
+}
+
+func TestRecorder_WithRetention_Concurrent(t *testing.T) {
+	store := NewMemoryStore()
+	recorder := NewRecorder(&fakeClient{}, store, "testuser", WithRetention(DefaultRetentionPolicy))
+
+	// Snapshots recorded concurrently, e.g. by Run and a manual Record, share the
+	// compaction schedule; run with -race to detect unguarded access
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := recorder.Record(context.Background()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if recorder.lastCompacted.IsZero() {
+		t.Error("Expected the retention policy to have been applied")
+	}
+}
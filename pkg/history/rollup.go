@@ -0,0 +1,110 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Granularity is the period length of a rollup.
+type Granularity string
+
+// Supported rollup granularities. Periods are aligned in UTC; weeks start on Monday.
+const (
+	Daily  Granularity = "day"
+	Weekly Granularity = "week"
+)
+
+// Granularities lists all rollup granularities maintained by the recorder.
+var Granularities = []Granularity{Daily, Weekly}
+
+// PeriodStart returns the start of the period of this granularity containing t.
+func (g Granularity) PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if g == Weekly {
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// Rollup holds precomputed XP totals for one user and period.
+type Rollup struct {
+	User        string      `json:"user"`
+	Granularity Granularity `json:"granularity"`
+	Start       time.Time   `json:"start"`
+
+	// StartXP is the total XP before the period, EndXP the latest total within it.
+	StartXP godestats.XP `json:"start_xp"`
+	EndXP   godestats.XP `json:"end_xp"`
+
+	// Languages holds the latest total XP per language within the period.
+	Languages map[string]godestats.XP `json:"languages,omitempty"`
+
+	// Snapshots is the number of snapshots that contributed to the rollup.
+	Snapshots int `json:"snapshots"`
+}
+
+// Gained returns the XP gained within the period.
+func (r Rollup) Gained() godestats.XP {
+	return r.EndXP - r.StartXP
+}
+
+// RollupStore is implemented by stores that can persist materialized rollups.
+// The recorder keeps them up to date on every snapshot.
+type RollupStore interface {
+	// PutRollup stores a rollup, replacing an existing one for the same user, granularity and start.
+	PutRollup(ctx context.Context, rollup Rollup) error
+
+	// GetRollup returns the rollup for the given user, granularity and period start.
+	// The boolean result is false if no rollup exists.
+	GetRollup(ctx context.Context, user string, granularity Granularity, start time.Time) (Rollup, bool, error)
+
+	// Rollups returns the user's rollups whose periods start within [from, to), in chronological order.
+	Rollups(ctx context.Context, user string, granularity Granularity, from, to time.Time) ([]Rollup, error)
+}
+
+// UpdateRollups folds a new snapshot into the rollups of every granularity.
+// The previous snapshot, if known, provides the starting total of new periods.
+func UpdateRollups(ctx context.Context, store RollupStore, previous *Snapshot, snapshot Snapshot) error {
+	if snapshot.Profile == nil {
+		return nil
+	}
+
+	for _, granularity := range Granularities {
+		start := granularity.PeriodStart(snapshot.TakenAt)
+
+		rollup, ok, err := store.GetRollup(ctx, snapshot.User, granularity, start)
+		if err != nil {
+			return fmt.Errorf("failed to load %s rollup: %w", granularity, err)
+		}
+
+		if !ok {
+			rollup = Rollup{
+				User:        snapshot.User,
+				Granularity: granularity,
+				Start:       start,
+				StartXP:     snapshot.Profile.TotalXP,
+			}
+			if previous != nil && previous.Profile != nil && previous.TakenAt.Before(start) {
+				rollup.StartXP = previous.Profile.TotalXP
+			}
+		}
+
+		rollup.EndXP = snapshot.Profile.TotalXP
+		rollup.Snapshots++
+		rollup.Languages = make(map[string]godestats.XP, len(snapshot.Profile.Languages))
+		for name, info := range snapshot.Profile.Languages {
+			rollup.Languages[name] = info.XPs
+		}
+
+		if err := store.PutRollup(ctx, rollup); err != nil {
+			return fmt.Errorf("failed to store %s rollup: %w", granularity, err)
+		}
+	}
+
+	return nil
+}
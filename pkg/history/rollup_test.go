@@ -0,0 +1,93 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestGranularity_PeriodStart(t *testing.T) {
+	// Thursday afternoon
+	at := time.Date(2023, 6, 15, 15, 30, 0, 0, time.UTC)
+
+	if start := Daily.PeriodStart(at); !start.Equal(time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected daily start: %v", start)
+	}
+	if start := Weekly.PeriodStart(at); !start.Equal(time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected weekly start: %v", start)
+	}
+
+	// Sunday belongs to the week starting on the previous Monday
+	sunday := time.Date(2023, 6, 18, 23, 0, 0, 0, time.UTC)
+	if start := Weekly.PeriodStart(sunday); !start.Equal(time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected weekly start for Sunday: %v", start)
+	}
+}
+
+func TestRecorder_UpdatesRollups(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	times := []time.Time{
+		time.Date(2023, 6, 14, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 15, 8, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 15, 20, 0, 0, 0, time.UTC),
+	}
+
+	i := 0
+	recorder := NewRecorder(&fakeClient{}, store, "testuser")
+	recorder.now = func() time.Time {
+		defer func() { i++ }()
+		return times[i]
+	}
+
+	for range times {
+		if _, err := recorder.Record(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	daily, err := store.Rollups(ctx, "testuser", Daily, time.Time{}, times[2].Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(daily) != 2 {
+		t.Fatalf("Expected 2 daily rollups, got %d", len(daily))
+	}
+
+	// The second day starts from the last total of the first day
+	if daily[1].StartXP != 100 || daily[1].EndXP != 300 || daily[1].Gained() != 200 || daily[1].Snapshots != 2 {
+		t.Errorf("Unexpected second daily rollup: %+v", daily[1])
+	}
+
+	weekly, _ := store.Rollups(ctx, "testuser", Weekly, time.Time{}, times[2].Add(time.Hour))
+	if len(weekly) != 1 || weekly[0].Gained() != 200 || weekly[0].Snapshots != 3 {
+		t.Errorf("Unexpected weekly rollups: %+v", weekly)
+	}
+}
+
+func TestUpdateRollups_Languages(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	at := time.Date(2023, 6, 15, 8, 0, 0, 0, time.UTC)
+
+	snapshot := Snapshot{User: "u", TakenAt: at, Profile: &godestats.UserProfile{
+		TotalXP:   100,
+		Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 100}},
+	}}
+
+	if err := UpdateRollups(ctx, store, nil, snapshot); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rollup, ok, err := store.GetRollup(ctx, "u", Daily, Daily.PeriodStart(at))
+	if err != nil || !ok {
+		t.Fatalf("Expected rollup to exist (%v)", err)
+	}
+	if rollup.Languages["Go"] != 100 || rollup.Gained() != 0 {
+		t.Errorf("Unexpected rollup: %+v", rollup)
+	}
+}
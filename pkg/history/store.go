@@ -0,0 +1,225 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Snapshot is a user profile as it was observed at a specific point in time.
+type Snapshot struct {
+	User    string                 `json:"user"`
+	TakenAt time.Time              `json:"taken_at"`
+	Profile *godestats.UserProfile `json:"profile"`
+}
+
+// snapshotJSON is the JSON encoding of a Snapshot.
+type snapshotJSON struct {
+	User    string          `json:"user"`
+	TakenAt time.Time       `json:"taken_at"`
+	Profile json.RawMessage `json:"profile"`
+}
+
+// MarshalJSON encodes the snapshot with its profile's unknown fields (see
+// godestats.MarshalProfile), so that they survive stores encoding snapshots as JSON.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	profile, err := godestats.MarshalProfile(s.Profile)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(snapshotJSON{User: s.User, TakenAt: s.TakenAt, Profile: profile})
+}
+
+// UnmarshalJSON decodes a snapshot, keeping the profile's unknown fields in its Raw map.
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	var decoded snapshotJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*s = Snapshot{User: decoded.User, TakenAt: decoded.TakenAt}
+	if len(decoded.Profile) == 0 || string(decoded.Profile) == "null" {
+		return nil
+	}
+	profile, err := godestats.UnmarshalProfile(decoded.Profile, true)
+	if err != nil {
+		return err
+	}
+	s.Profile = profile
+	return nil
+}
+
+// Store defines the interface for persisting profile snapshots.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Put stores a snapshot. Storing a snapshot for a user and time that already
+	// exists replaces the previous one.
+	Put(ctx context.Context, snapshot Snapshot) error
+
+	// Range returns the snapshots of the given user taken within [from, to),
+	// ordered by the time they were taken.
+	Range(ctx context.Context, user string, from, to time.Time) ([]Snapshot, error)
+}
+
+// MemoryStore is an in-memory Store, mainly useful for testing and short-lived processes.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	snapshots map[string][]Snapshot
+	rollups   map[rollupKey]Rollup
+}
+
+// rollupKey identifies a rollup within the memory store.
+type rollupKey struct {
+	user        string
+	granularity Granularity
+	start       int64
+}
+
+// NewMemoryStore creates a new empty in-memory snapshot store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		snapshots: make(map[string][]Snapshot),
+		rollups:   make(map[rollupKey]Rollup),
+	}
+}
+
+// Put stores a copy of the snapshot.
+func (s *MemoryStore) Put(ctx context.Context, snapshot Snapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	snapshot.Profile = snapshot.Profile.Clone()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := s.snapshots[snapshot.User]
+	i := sort.Search(len(snapshots), func(i int) bool {
+		return !snapshots[i].TakenAt.Before(snapshot.TakenAt)
+	})
+
+	if i < len(snapshots) && snapshots[i].TakenAt.Equal(snapshot.TakenAt) {
+		snapshots[i] = snapshot
+		return nil
+	}
+
+	snapshots = append(snapshots, Snapshot{})
+	copy(snapshots[i+1:], snapshots[i:])
+	snapshots[i] = snapshot
+	s.snapshots[snapshot.User] = snapshots
+
+	return nil
+}
+
+// Range returns copies of the user's snapshots taken within [from, to).
+func (s *MemoryStore) Range(ctx context.Context, user string, from, to time.Time) ([]Snapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []Snapshot
+	for _, snapshot := range s.snapshots[user] {
+		if snapshot.TakenAt.Before(from) || !snapshot.TakenAt.Before(to) {
+			continue
+		}
+		snapshot.Profile = snapshot.Profile.Clone()
+		result = append(result, snapshot)
+	}
+
+	return result, nil
+}
+
+// Delete removes the user's snapshots taken within [from, to) and returns how many were removed.
+func (s *MemoryStore) Delete(ctx context.Context, user string, from, to time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := s.snapshots[user]
+	kept := snapshots[:0]
+	for _, snapshot := range snapshots {
+		if !snapshot.TakenAt.Before(from) && snapshot.TakenAt.Before(to) {
+			continue
+		}
+		kept = append(kept, snapshot)
+	}
+	s.snapshots[user] = kept
+
+	return len(snapshots) - len(kept), nil
+}
+
+// PutRollup stores a copy of the rollup.
+func (s *MemoryStore) PutRollup(ctx context.Context, rollup Rollup) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rollups[rollupKey{rollup.User, rollup.Granularity, rollup.Start.UnixNano()}] = copyRollup(rollup)
+	return nil
+}
+
+// GetRollup returns a copy of the rollup for the given period, if it exists.
+func (s *MemoryStore) GetRollup(ctx context.Context, user string, granularity Granularity, start time.Time) (Rollup, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return Rollup{}, false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rollup, ok := s.rollups[rollupKey{user, granularity, start.UnixNano()}]
+	return copyRollup(rollup), ok, nil
+}
+
+// Rollups returns copies of the user's rollups starting within [from, to).
+func (s *MemoryStore) Rollups(ctx context.Context, user string, granularity Granularity, from, to time.Time) ([]Rollup, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []Rollup
+	for key, rollup := range s.rollups {
+		if key.user != user || key.granularity != granularity {
+			continue
+		}
+		if rollup.Start.Before(from) || !rollup.Start.Before(to) {
+			continue
+		}
+		result = append(result, copyRollup(rollup))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+
+	return result, nil
+}
+
+// copyRollup returns a copy of the rollup that shares no maps with the original.
+func copyRollup(rollup Rollup) Rollup {
+	if rollup.Languages != nil {
+		languages := make(map[string]godestats.XP, len(rollup.Languages))
+		for name, xp := range rollup.Languages {
+			languages[name] = xp
+		}
+		rollup.Languages = languages
+	}
+	return rollup
+}
@@ -0,0 +1,137 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// metaBucket holds store metadata such as the schema version.
+var (
+	metaBucket       = []byte("meta")
+	schemaVersionKey = []byte("schema_version")
+)
+
+// migration is a schema change together with the function applying it.
+type migration struct {
+	history.Migration
+	apply func(tx *bbolt.Tx) error
+}
+
+// migrations lists all schema changes in the order they must be applied.
+// Existing entries must never be modified; add new ones at the end.
+var migrations = []migration{
+	{
+		Migration: history.Migration{Version: 1, Description: "create snapshot bucket"},
+		apply:     createBucket(usersBucket),
+	},
+	{
+		Migration: history.Migration{Version: 2, Description: "create rollup bucket"},
+		apply:     createBucket(rollupsBucket),
+	},
+}
+
+// Migrations returns all known schema migrations of the bolt store.
+func Migrations() []history.Migration {
+	result := make([]history.Migration, len(migrations))
+	for i, m := range migrations {
+		result[i] = m.Migration
+	}
+	return result
+}
+
+// Migrate brings the schema of the database at path up to date and returns the
+// migrations that were applied. With dryRun, nothing is changed and the pending
+// migrations are returned. The database must not be opened by another process.
+func Migrate(path string, dryRun bool) ([]history.Migration, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: DefaultTimeout, ReadOnly: dryRun})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt store: %w", err)
+	}
+	defer db.Close()
+
+	s := &Store{db: db}
+	return s.migrate(dryRun)
+}
+
+// SchemaVersion returns the schema version of the store.
+func (s *Store) SchemaVersion() (int, error) {
+	var version int
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		version = schemaVersion(tx)
+		return nil
+	})
+	return version, err
+}
+
+// migrate applies or, with dryRun, only reports the pending migrations.
+// Each migration is applied in its own transaction together with the version update.
+func (s *Store) migrate(dryRun bool) ([]history.Migration, error) {
+	current, err := s.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := history.PendingMigrations(current, Migrations())
+	if err != nil || dryRun {
+		return pending, err
+	}
+
+	var applied []history.Migration
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+
+		err := s.db.Update(func(tx *bbolt.Tx) error {
+			if err := m.apply(tx); err != nil {
+				return err
+			}
+			return setSchemaVersion(tx, m.Version)
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %s failed: %w", m, err)
+		}
+
+		applied = append(applied, m.Migration)
+	}
+
+	return applied, nil
+}
+
+// schemaVersion reads the schema version, treating a missing value as version 0.
+func schemaVersion(tx *bbolt.Tx) int {
+	bucket := tx.Bucket(metaBucket)
+	if bucket == nil {
+		return 0
+	}
+
+	value := bucket.Get(schemaVersionKey)
+	if len(value) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(value))
+}
+
+// setSchemaVersion records the schema version.
+func setSchemaVersion(tx *bbolt.Tx, version int) error {
+	bucket, err := tx.CreateBucketIfNotExists(metaBucket)
+	if err != nil {
+		return err
+	}
+
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(version))
+	return bucket.Put(schemaVersionKey, value)
+}
+
+// createBucket returns a migration step creating a top-level bucket.
+func createBucket(name []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(name)
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+package bolt
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func TestMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	// Create an empty database file without any schema
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	db.Close()
+
+	pending, err := Migrate(path, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pending) != len(migrations) {
+		t.Errorf("Expected %d pending migrations, got %v", len(migrations), pending)
+	}
+
+	if _, err := Open(path, WithManualMigration()); !errors.Is(err, history.ErrMigrationRequired) {
+		t.Errorf("Expected ErrMigrationRequired after dry run, got %v", err)
+	}
+
+	applied, err := Migrate(path, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("Expected all migrations to be applied, got %v", applied)
+	}
+
+	store, err := Open(path, WithManualMigration())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer store.Close()
+
+	version, err := store.SchemaVersion()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if latest := migrations[len(migrations)-1].Version; version != latest {
+		t.Errorf("Expected schema version %d, got %d", latest, version)
+	}
+}
+
+func TestOpen_SchemaTooNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	db.Update(func(tx *bbolt.Tx) error { return setSchemaVersion(tx, 99) })
+	db.Close()
+
+	if _, err := Open(path); !errors.Is(err, history.ErrSchemaTooNew) {
+		t.Errorf("Expected ErrSchemaTooNew, got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+// Package bolt provides a bbolt-backed implementation of the history Store,
+// suitable for single-binary tracker daemons.
+//
+// Snapshots are kept in one bucket per user, keyed by the time they were taken,
+// so iteration always yields snapshots in chronological order. Rollups are kept
+// the same way in one bucket per user and granularity.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	bbolt "go.etcd.io/bbolt"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// DefaultTimeout is the default time to wait for the database file lock.
+const DefaultTimeout = 5 * time.Second
+
+// Top-level buckets: one nested bucket per user for snapshots, and one nested
+// bucket per user and granularity for rollups.
+var (
+	usersBucket   = []byte("users")
+	rollupsBucket = []byte("rollups")
+)
+
+// Store implements the history.Store interface on top of a bbolt database file.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Option configures optional behavior of Open.
+type Option func(*openConfig)
+
+// openConfig holds the settings applied by Options.
+type openConfig struct {
+	manualMigration bool
+}
+
+// WithManualMigration disables automatic schema migration in Open.
+// Opening a store with an outdated schema then fails with history.ErrMigrationRequired,
+// and the schema must be updated explicitly with Migrate.
+func WithManualMigration() Option {
+	return func(c *openConfig) {
+		c.manualMigration = true
+	}
+}
+
+// Open opens or creates the bbolt database at the given path and migrates
+// its schema to the latest version.
+func Open(path string, opts ...Option) (*Store, error) {
+	var config openConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: DefaultTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt store: %w", err)
+	}
+
+	s := &Store{db: db}
+
+	pending, err := s.migrate(config.manualMigration)
+	if err == nil && len(pending) > 0 && config.manualMigration {
+		err = history.ErrMigrationRequired
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate bolt store: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put stores a snapshot in the user's bucket, replacing any snapshot taken at the same time.
+func (s *Store) Put(ctx context.Context, snapshot history.Snapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to serialize snapshot: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(usersBucket).CreateBucketIfNotExists([]byte(snapshot.User))
+		if err != nil {
+			return err
+		}
+		return bucket.Put(encodeTime(snapshot.TakenAt), value)
+	})
+}
+
+// Range returns the user's snapshots taken within [from, to) in chronological order.
+func (s *Store) Range(ctx context.Context, user string, from, to time.Time) ([]history.Snapshot, error) {
+	var snapshots []history.Snapshot
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(usersBucket).Bucket([]byte(user))
+		if bucket == nil {
+			return nil
+		}
+
+		end := encodeTime(to)
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(encodeTime(from)); k != nil && bytes.Compare(k, end) < 0; k, v = cursor.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			var snapshot history.Snapshot
+			if err := json.Unmarshal(v, &snapshot); err != nil {
+				return fmt.Errorf("failed to decode snapshot: %w", err)
+			}
+			if snapshot.Profile != nil && snapshot.Profile.Recent.IsZero() {
+				snapshot.Profile.Recent = godestats.NewRecentPeriod(snapshot.TakenAt)
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// Users returns the names of all users with stored snapshots, in byte order.
+func (s *Store) Users() ([]string, error) {
+	var users []string
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(usersBucket).ForEachBucket(func(name []byte) error {
+			users = append(users, string(name))
+			return nil
+		})
+	})
+
+	return users, err
+}
+
+// Delete removes the user's snapshots taken within [from, to) and returns how many were removed.
+func (s *Store) Delete(ctx context.Context, user string, from, to time.Time) (int, error) {
+	removed := 0
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(usersBucket).Bucket([]byte(user))
+		if bucket == nil {
+			return nil
+		}
+
+		var keys [][]byte
+		end := encodeTime(to)
+		cursor := bucket.Cursor()
+		for k, _ := cursor.Seek(encodeTime(from)); k != nil && bytes.Compare(k, end) < 0; k, _ = cursor.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+
+		for _, key := range keys {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		removed = len(keys)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return removed, nil
+}
+
+// PutRollup stores a rollup, replacing an existing one for the same period.
+func (s *Store) PutRollup(ctx context.Context, rollup history.Rollup) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(rollup)
+	if err != nil {
+		return fmt.Errorf("failed to serialize rollup: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		user, err := tx.Bucket(rollupsBucket).CreateBucketIfNotExists([]byte(rollup.User))
+		if err != nil {
+			return err
+		}
+		bucket, err := user.CreateBucketIfNotExists([]byte(rollup.Granularity))
+		if err != nil {
+			return err
+		}
+		return bucket.Put(encodeTime(rollup.Start), value)
+	})
+}
+
+// GetRollup returns the rollup for the given period, if it exists.
+func (s *Store) GetRollup(ctx context.Context, user string, granularity history.Granularity, start time.Time) (history.Rollup, bool, error) {
+	var (
+		rollup history.Rollup
+		found  bool
+	)
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := rollupBucket(tx, user, granularity)
+		if bucket == nil {
+			return nil
+		}
+
+		value := bucket.Get(encodeTime(start))
+		if value == nil {
+			return nil
+		}
+
+		found = true
+		return json.Unmarshal(value, &rollup)
+	})
+
+	return rollup, found, err
+}
+
+// Rollups returns the user's rollups starting within [from, to) in chronological order.
+func (s *Store) Rollups(ctx context.Context, user string, granularity history.Granularity, from, to time.Time) ([]history.Rollup, error) {
+	var rollups []history.Rollup
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := rollupBucket(tx, user, granularity)
+		if bucket == nil {
+			return nil
+		}
+
+		end := encodeTime(to)
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(encodeTime(from)); k != nil && bytes.Compare(k, end) < 0; k, v = cursor.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			var rollup history.Rollup
+			if err := json.Unmarshal(v, &rollup); err != nil {
+				return fmt.Errorf("failed to decode rollup: %w", err)
+			}
+			rollups = append(rollups, rollup)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rollups, nil
+}
+
+// Compact writes a compacted copy of the database to dstPath, reclaiming the
+// space left behind by deleted snapshots. The copy can replace the original
+// file once the store has been closed.
+func (s *Store) Compact(dstPath string) error {
+	dst, err := bbolt.Open(dstPath, 0o600, &bbolt.Options{Timeout: DefaultTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open compaction target: %w", err)
+	}
+
+	if err := bbolt.Compact(dst, s.db, 0); err != nil {
+		dst.Close()
+		os.Remove(dstPath)
+		return fmt.Errorf("failed to compact bolt store: %w", err)
+	}
+
+	return dst.Close()
+}
+
+// rollupBucket returns the bucket holding a user's rollups of one granularity, or nil.
+func rollupBucket(tx *bbolt.Tx, user string, granularity history.Granularity) *bbolt.Bucket {
+	userBucket := tx.Bucket(rollupsBucket).Bucket([]byte(user))
+	if userBucket == nil {
+		return nil
+	}
+	return userBucket.Bucket([]byte(granularity))
+}
+
+// encodeTime encodes a timestamp as a big-endian key whose byte order matches
+// chronological order, including times before the Unix epoch.
+func encodeTime(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano())^(1<<63))
+	return key
+}
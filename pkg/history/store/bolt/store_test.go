@@ -0,0 +1,196 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// Compile-time checks that Store implements the history store interfaces
+var (
+	_ history.Store       = (*Store)(nil)
+	_ history.Deleter     = (*Store)(nil)
+	_ history.RollupStore = (*Store)(nil)
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func putSnapshots(t *testing.T, store *Store, user string, base time.Time, hours ...int) {
+	t.Helper()
+
+	for _, h := range hours {
+		snapshot := history.Snapshot{
+			User:    user,
+			TakenAt: base.Add(time.Duration(h) * time.Hour),
+			Profile: &godestats.UserProfile{User: user, TotalXP: godestats.XP(h * 100)},
+		}
+		if err := store.Put(context.Background(), snapshot); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func TestStore_PutRange(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	putSnapshots(t, store, "alice", base, 3, 1, 0, 2)
+	putSnapshots(t, store, "bob", base, 1)
+
+	snapshots, err := store.Range(context.Background(), "alice", base.Add(time.Hour), base.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].Profile.TotalXP != 100 || snapshots[1].Profile.TotalXP != 200 {
+		t.Errorf("Expected chronological order, got %d, %d",
+			snapshots[0].Profile.TotalXP, snapshots[1].Profile.TotalXP)
+	}
+	if snapshots[0].Profile.Recent.End() != snapshots[0].TakenAt {
+		t.Errorf("Expected recent period to be restored from snapshot time")
+	}
+
+	users, err := store.Users()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[0] != "alice" || users[1] != "bob" {
+		t.Errorf("Expected [alice bob], got %v", users)
+	}
+
+	missing, err := store.Range(context.Background(), "carol", base, base.Add(time.Hour))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("Expected no snapshots for unknown user, got %v (%v)", missing, err)
+	}
+}
+
+func TestStore_UnknownFields(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	takenAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	profile := &godestats.UserProfile{User: "alice", Raw: map[string]json.RawMessage{"country": json.RawMessage(`"DE"`)}}
+	if err := store.Put(ctx, history.Snapshot{User: "alice", TakenAt: takenAt, Profile: profile}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshots, err := store.Range(ctx, "alice", takenAt, takenAt.Add(time.Hour))
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("Expected 1 snapshot, got %d (%v)", len(snapshots), err)
+	}
+	if country := string(snapshots[0].Profile.Raw["country"]); country != `"DE"` {
+		t.Errorf("Expected unknown fields to be stored, got %q", country)
+	}
+}
+
+func TestStore_PreEpochOrdering(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(1969, 12, 31, 22, 0, 0, 0, time.UTC)
+
+	putSnapshots(t, store, "alice", base, 3, 0)
+
+	snapshots, err := store.Range(context.Background(), "alice", base, base.Add(4*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(snapshots) != 2 || !snapshots[0].TakenAt.Before(snapshots[1].TakenAt) {
+		t.Errorf("Expected chronological order across the epoch, got %+v", snapshots)
+	}
+}
+
+func TestStore_DeleteCompact(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	putSnapshots(t, store, "alice", base, 0, 1, 2, 3)
+
+	removed, err := store.Delete(context.Background(), "alice", base, base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 removed snapshots, got %d", removed)
+	}
+
+	compactPath := filepath.Join(t.TempDir(), "compact.db")
+	if err := store.Compact(compactPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	compacted, err := Open(compactPath)
+	if err != nil {
+		t.Fatalf("Failed to open compacted store: %v", err)
+	}
+	defer compacted.Close()
+
+	snapshots, err := compacted.Range(context.Background(), "alice", base, base.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Profile.TotalXP != 200 {
+		t.Errorf("Expected remaining snapshots in compacted store, got %+v", snapshots)
+	}
+}
+
+func TestStore_Rollups(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	day := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	if _, ok, err := store.GetRollup(ctx, "alice", history.Daily, day); ok || err != nil {
+		t.Fatalf("Expected no rollup yet, got ok=%v err=%v", ok, err)
+	}
+
+	for i := 0; i < 3; i++ {
+		rollup := history.Rollup{
+			User:        "alice",
+			Granularity: history.Daily,
+			Start:       day.AddDate(0, 0, i),
+			StartXP:     godestats.XP(i * 100),
+			EndXP:       godestats.XP(i*100 + 50),
+			Languages:   map[string]godestats.XP{"Go": godestats.XP(i*100 + 50)},
+		}
+		if err := store.PutRollup(ctx, rollup); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Replace the first rollup
+	store.PutRollup(ctx, history.Rollup{User: "alice", Granularity: history.Daily, Start: day, EndXP: 75})
+
+	rollup, ok, err := store.GetRollup(ctx, "alice", history.Daily, day)
+	if err != nil || !ok || rollup.EndXP != 75 {
+		t.Errorf("Expected replaced rollup, got %+v (ok=%v, err=%v)", rollup, ok, err)
+	}
+
+	rollups, err := store.Rollups(ctx, "alice", history.Daily, day.AddDate(0, 0, 1), day.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rollups) != 2 || rollups[0].EndXP != 150 || rollups[1].Languages["Go"] != 250 {
+		t.Errorf("Unexpected rollups: %+v", rollups)
+	}
+
+	weekly, _ := store.Rollups(ctx, "alice", history.Weekly, day, day.AddDate(0, 0, 7))
+	if len(weekly) != 0 {
+		t.Errorf("Expected no weekly rollups, got %+v", weekly)
+	}
+}
@@ -0,0 +1,148 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// migration is a schema change together with the statements applying it.
+type migration struct {
+	history.Migration
+	statements func(s *Store) []string
+}
+
+// migrations lists all schema changes in the order they must be applied.
+// Existing entries must never be modified; add new ones at the end.
+var migrations = []migration{
+	{
+		Migration: history.Migration{Version: 1, Description: "create snapshot table"},
+		statements: func(s *Store) []string {
+			return []string{fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	user_name VARCHAR(255) NOT NULL,
+	taken_at BIGINT NOT NULL,
+	profile %s NOT NULL,
+	PRIMARY KEY (user_name, taken_at)
+)`, s.table, s.dialect.ProfileType)}
+		},
+	},
+	{
+		Migration: history.Migration{Version: 2, Description: "create rollup table"},
+		statements: func(s *Store) []string {
+			return []string{fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	user_name VARCHAR(255) NOT NULL,
+	granularity VARCHAR(16) NOT NULL,
+	period_start BIGINT NOT NULL,
+	data %s NOT NULL,
+	PRIMARY KEY (user_name, granularity, period_start)
+)`, s.rollupTable(), s.dialect.ProfileType)}
+		},
+	},
+}
+
+// Migrations returns all known schema migrations of the SQL store.
+func Migrations() []history.Migration {
+	result := make([]history.Migration, len(migrations))
+	for i, m := range migrations {
+		result[i] = m.Migration
+	}
+	return result
+}
+
+// Migrate brings the store's schema up to date and returns the migrations that were
+// applied. With dryRun, nothing is changed and the pending migrations are returned.
+// Each migration is applied in its own transaction together with the version update.
+func Migrate(ctx context.Context, db *sql.DB, dialect Dialect, dryRun bool, opts ...Option) ([]history.Migration, error) {
+	s := &Store{db: db, dialect: dialect, table: DefaultTable}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s.migrate(ctx, dryRun)
+}
+
+// SchemaVersion returns the schema version of the store.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	return s.schemaVersion(ctx)
+}
+
+// migrate applies or, with dryRun, only reports the pending migrations.
+func (s *Store) migrate(ctx context.Context, dryRun bool) ([]history.Migration, error) {
+	if !dryRun {
+		if _, err := s.db.ExecContext(ctx, fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s (version INTEGER NOT NULL)", s.versionTable())); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := s.schemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := history.PendingMigrations(current, Migrations())
+	if err != nil || dryRun {
+		return pending, err
+	}
+
+	var applied []history.Migration
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := s.apply(ctx, m, current); err != nil {
+			return applied, fmt.Errorf("migration %s failed: %w", m, err)
+		}
+		applied = append(applied, m.Migration)
+		current = m.Version
+	}
+
+	return applied, nil
+}
+
+// apply runs a single migration and records the new schema version in one transaction.
+func (s *Store) apply(ctx context.Context, m migration, from int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range m.statements(s) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	p := s.placeholders(1)
+	if from == 0 {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version) VALUES (%s)", s.versionTable(), p[0]), m.Version)
+	} else {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET version = %s", s.versionTable(), p[0]), m.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// schemaVersion reads the recorded schema version, treating a missing version table as version 0.
+func (s *Store) schemaVersion(ctx context.Context) (int, error) {
+	var version sql.NullInt64
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT MAX(version) FROM %s", s.versionTable())).Scan(&version)
+	if err != nil {
+		// The version table only exists once the store has been migrated
+		if pingErr := s.db.PingContext(ctx); pingErr == nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// versionTable returns the name of the schema version table.
+func (s *Store) versionTable() string {
+	return s.table + "_schema"
+}
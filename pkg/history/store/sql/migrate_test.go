@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	// A single connection keeps the in-memory database alive across statements
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestMigrate_DryRun(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	pending, err := Migrate(ctx, db, SQLite, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pending) != len(migrations) {
+		t.Errorf("Expected %d pending migrations, got %v", len(migrations), pending)
+	}
+
+	// A dry run must not create anything
+	if _, err := db.Exec("SELECT 1 FROM " + DefaultTable); err == nil {
+		t.Error("Expected snapshot table not to exist after dry run")
+	}
+}
+
+func TestMigrate_Apply(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	applied, err := Migrate(ctx, db, SQLite, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("Expected all migrations to be applied, got %v", applied)
+	}
+
+	// Migrating again is a no-op
+	applied, err = Migrate(ctx, db, SQLite, false)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("Expected no migrations on second run, got %v (%v)", applied, err)
+	}
+
+	store, err := Open(ctx, db, SQLite, WithManualMigration())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer store.Close()
+
+	version, err := store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if latest := migrations[len(migrations)-1].Version; version != latest {
+		t.Errorf("Expected schema version %d, got %d", latest, version)
+	}
+}
+
+func TestOpen_ManualMigrationRequired(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := Open(context.Background(), db, SQLite, WithManualMigration())
+	if !errors.Is(err, history.ErrMigrationRequired) {
+		t.Errorf("Expected ErrMigrationRequired, got %v", err)
+	}
+}
+
+func TestOpen_SchemaTooNew(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	if _, err := Migrate(ctx, db, SQLite, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := db.Exec("UPDATE " + DefaultTable + "_schema SET version = 99"); err != nil {
+		t.Fatalf("Failed to bump schema version: %v", err)
+	}
+
+	_, err := Open(ctx, db, SQLite)
+	if !errors.Is(err, history.ErrSchemaTooNew) {
+		t.Errorf("Expected ErrSchemaTooNew, got %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+// Package sql provides a database/sql implementation of the history Store for
+// PostgreSQL, MySQL and SQLite, suitable for shared stats services tracking many users.
+//
+// The caller is responsible for importing the database driver and opening the
+// *sql.DB; the store migrates its schema and reuses prepared statements.
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// Dialect describes the SQL differences between the supported databases.
+type Dialect struct {
+	// Name identifies the dialect in error messages.
+	Name string
+
+	// Placeholder returns the bind parameter for the n-th argument (1-based).
+	Placeholder func(n int) string
+
+	// Upsert returns the clause appended to an insert statement to replace the
+	// column of an existing row with the given primary key columns.
+	Upsert func(keys []string, column string) string
+
+	// ProfileType is the column type used for the serialized profile.
+	ProfileType string
+}
+
+// Supported dialects
+var (
+	Postgres = Dialect{
+		Name:        "postgres",
+		Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		Upsert:      onConflictUpsert,
+		ProfileType: "TEXT",
+	}
+
+	MySQL = Dialect{
+		Name:        "mysql",
+		Placeholder: func(int) string { return "?" },
+		Upsert: func(_ []string, column string) string {
+			return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s = VALUES(%s)", column, column)
+		},
+		ProfileType: "LONGTEXT",
+	}
+
+	SQLite = Dialect{
+		Name:        "sqlite",
+		Placeholder: func(int) string { return "?" },
+		Upsert:      onConflictUpsert,
+		ProfileType: "TEXT",
+	}
+)
+
+// onConflictUpsert builds the upsert clause shared by PostgreSQL and SQLite.
+func onConflictUpsert(keys []string, column string) string {
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s = excluded.%s", strings.Join(keys, ", "), column, column)
+}
+
+// DefaultTable is the default name of the snapshot table.
+// Rollups are stored in a second table with the suffix "_rollups".
+const DefaultTable = "godestats_snapshots"
+
+// Store implements the history.Store interface on top of database/sql.
+type Store struct {
+	db      *sql.DB
+	dialect Dialect
+	table   string
+
+	manualMigration bool
+
+	putStmt    *sql.Stmt
+	rangeStmt  *sql.Stmt
+	deleteStmt *sql.Stmt
+	usersStmt  *sql.Stmt
+
+	putRollupStmt    *sql.Stmt
+	getRollupStmt    *sql.Stmt
+	rangeRollupsStmt *sql.Stmt
+}
+
+// Option configures optional behavior of a Store.
+type Option func(*Store)
+
+// WithTable sets the name of the snapshot table; the rollup table name is derived from it.
+func WithTable(table string) Option {
+	return func(s *Store) {
+		s.table = table
+	}
+}
+
+// WithManualMigration disables automatic schema migration in Open.
+// Opening a store with an outdated schema then fails with history.ErrMigrationRequired,
+// and the schema must be updated explicitly with Migrate.
+func WithManualMigration() Option {
+	return func(s *Store) {
+		s.manualMigration = true
+	}
+}
+
+// Open migrates the schema to the latest version and prepares the statements
+// used by the store. The database handle remains owned by the caller.
+func Open(ctx context.Context, db *sql.DB, dialect Dialect, opts ...Option) (*Store, error) {
+	s := &Store{
+		db:      db,
+		dialect: dialect,
+		table:   DefaultTable,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	pending, err := s.migrate(ctx, s.manualMigration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate %s store: %w", dialect.Name, err)
+	}
+	if len(pending) > 0 && s.manualMigration {
+		return nil, history.ErrMigrationRequired
+	}
+
+	if err := s.prepare(ctx); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to prepare %s store: %w", dialect.Name, err)
+	}
+
+	return s, nil
+}
+
+// Close releases the prepared statements. It does not close the database handle.
+func (s *Store) Close() error {
+	var errs []error
+	for _, stmt := range []*sql.Stmt{
+		s.putStmt, s.rangeStmt, s.deleteStmt, s.usersStmt,
+		s.putRollupStmt, s.getRollupStmt, s.rangeRollupsStmt,
+	} {
+		if stmt != nil {
+			errs = append(errs, stmt.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Put stores a snapshot, replacing any snapshot of the same user taken at the same time.
+func (s *Store) Put(ctx context.Context, snapshot history.Snapshot) error {
+	profile, err := godestats.MarshalProfile(snapshot.Profile)
+	if err != nil {
+		return fmt.Errorf("failed to serialize profile: %w", err)
+	}
+
+	_, err = s.putStmt.ExecContext(ctx, snapshot.User, snapshot.TakenAt.UnixNano(), string(profile))
+	return err
+}
+
+// Range returns the user's snapshots taken within [from, to) in chronological order.
+func (s *Store) Range(ctx context.Context, user string, from, to time.Time) ([]history.Snapshot, error) {
+	rows, err := s.rangeStmt.QueryContext(ctx, user, from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []history.Snapshot
+	for rows.Next() {
+		var (
+			takenAt int64
+			data    string
+		)
+		if err := rows.Scan(&takenAt, &data); err != nil {
+			return nil, err
+		}
+
+		profile, err := godestats.UnmarshalProfile([]byte(data), true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+		}
+
+		snapshot := history.Snapshot{
+			User:    user,
+			TakenAt: time.Unix(0, takenAt).UTC(),
+			Profile: profile,
+		}
+		profile.Recent = godestats.NewRecentPeriod(snapshot.TakenAt)
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// Delete removes the user's snapshots taken within [from, to) and returns how many were removed.
+func (s *Store) Delete(ctx context.Context, user string, from, to time.Time) (int, error) {
+	result, err := s.deleteStmt.ExecContext(ctx, user, from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := result.RowsAffected()
+	return int(removed), err
+}
+
+// Users returns the names of all users with stored snapshots in ascending order.
+func (s *Store) Users(ctx context.Context) ([]string, error) {
+	rows, err := s.usersStmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var user string
+		if err := rows.Scan(&user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// PutRollup stores a rollup, replacing an existing one for the same period.
+func (s *Store) PutRollup(ctx context.Context, rollup history.Rollup) error {
+	data, err := json.Marshal(rollup)
+	if err != nil {
+		return fmt.Errorf("failed to serialize rollup: %w", err)
+	}
+
+	_, err = s.putRollupStmt.ExecContext(ctx, rollup.User, string(rollup.Granularity), rollup.Start.UnixNano(), string(data))
+	return err
+}
+
+// GetRollup returns the rollup for the given period, if it exists.
+func (s *Store) GetRollup(ctx context.Context, user string, granularity history.Granularity, start time.Time) (history.Rollup, bool, error) {
+	var data string
+	err := s.getRollupStmt.QueryRowContext(ctx, user, string(granularity), start.UnixNano()).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return history.Rollup{}, false, nil
+	}
+	if err != nil {
+		return history.Rollup{}, false, err
+	}
+
+	var rollup history.Rollup
+	if err := json.Unmarshal([]byte(data), &rollup); err != nil {
+		return history.Rollup{}, false, fmt.Errorf("failed to decode rollup: %w", err)
+	}
+
+	return rollup, true, nil
+}
+
+// Rollups returns the user's rollups starting within [from, to) in chronological order.
+func (s *Store) Rollups(ctx context.Context, user string, granularity history.Granularity, from, to time.Time) ([]history.Rollup, error) {
+	rows, err := s.rangeRollupsStmt.QueryContext(ctx, user, string(granularity), from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rollups []history.Rollup
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var rollup history.Rollup
+		if err := json.Unmarshal([]byte(data), &rollup); err != nil {
+			return nil, fmt.Errorf("failed to decode rollup: %w", err)
+		}
+		rollups = append(rollups, rollup)
+	}
+
+	return rollups, rows.Err()
+}
+
+// rollupTable returns the name of the rollup table.
+func (s *Store) rollupTable() string {
+	return s.table + "_rollups"
+}
+
+// prepare prepares the statements reused by every store operation.
+func (s *Store) prepare(ctx context.Context) error {
+	p := s.placeholders(3)
+
+	var err error
+	s.putStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (user_name, taken_at, profile) VALUES (%s, %s, %s) %s",
+		s.table, p[0], p[1], p[2], s.dialect.Upsert([]string{"user_name", "taken_at"}, "profile")))
+	if err != nil {
+		return err
+	}
+
+	s.rangeStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"SELECT taken_at, profile FROM %s WHERE user_name = %s AND taken_at >= %s AND taken_at < %s ORDER BY taken_at",
+		s.table, p[0], p[1], p[2]))
+	if err != nil {
+		return err
+	}
+
+	s.deleteStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"DELETE FROM %s WHERE user_name = %s AND taken_at >= %s AND taken_at < %s",
+		s.table, p[0], p[1], p[2]))
+	if err != nil {
+		return err
+	}
+
+	s.usersStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"SELECT DISTINCT user_name FROM %s ORDER BY user_name", s.table))
+	if err != nil {
+		return err
+	}
+
+	return s.prepareRollups(ctx)
+}
+
+// prepareRollups prepares the statements used for rollups.
+func (s *Store) prepareRollups(ctx context.Context) error {
+	p := s.placeholders(4)
+
+	upsert := s.dialect.Upsert([]string{"user_name", "granularity", "period_start"}, "data")
+
+	var err error
+	s.putRollupStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (user_name, granularity, period_start, data) VALUES (%s, %s, %s, %s) %s",
+		s.rollupTable(), p[0], p[1], p[2], p[3], upsert))
+	if err != nil {
+		return err
+	}
+
+	s.getRollupStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"SELECT data FROM %s WHERE user_name = %s AND granularity = %s AND period_start = %s",
+		s.rollupTable(), p[0], p[1], p[2]))
+	if err != nil {
+		return err
+	}
+
+	s.rangeRollupsStmt, err = s.db.PrepareContext(ctx, fmt.Sprintf(
+		"SELECT data FROM %s WHERE user_name = %s AND granularity = %s AND period_start >= %s AND period_start < %s ORDER BY period_start",
+		s.rollupTable(), p[0], p[1], p[2], p[3]))
+	return err
+}
+
+// placeholders returns the dialect's bind parameters for n arguments.
+func (s *Store) placeholders(n int) []string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = s.dialect.Placeholder(i + 1)
+	}
+	return p
+}
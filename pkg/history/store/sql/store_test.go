@@ -0,0 +1,183 @@
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// Compile-time checks that Store implements the history store interfaces
+var (
+	_ history.Store       = (*Store)(nil)
+	_ history.Deleter     = (*Store)(nil)
+	_ history.RollupStore = (*Store)(nil)
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(context.Background(), openTestDB(t), SQLite)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestStore_PutRange(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, h := range []int{2, 0, 1, 3} {
+		snapshot := history.Snapshot{
+			User:    "alice",
+			TakenAt: base.Add(time.Duration(h) * time.Hour),
+			Profile: &godestats.UserProfile{User: "alice", TotalXP: godestats.XP(h * 100)},
+		}
+		if err := store.Put(ctx, snapshot); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Replacing an existing snapshot must not create a duplicate
+	err := store.Put(ctx, history.Snapshot{
+		User:    "alice",
+		TakenAt: base.Add(time.Hour),
+		Profile: &godestats.UserProfile{User: "alice", TotalXP: 150},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshots, err := store.Range(ctx, "alice", base.Add(time.Hour), base.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].Profile.TotalXP != 150 || snapshots[1].Profile.TotalXP != 200 {
+		t.Errorf("Unexpected snapshots: %d, %d", snapshots[0].Profile.TotalXP, snapshots[1].Profile.TotalXP)
+	}
+	if !snapshots[0].TakenAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("Unexpected snapshot time: %v", snapshots[0].TakenAt)
+	}
+}
+
+func TestStore_UnknownFields(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	takenAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	profile := &godestats.UserProfile{User: "alice", Raw: map[string]json.RawMessage{"country": json.RawMessage(`"DE"`)}}
+	if err := store.Put(ctx, history.Snapshot{User: "alice", TakenAt: takenAt, Profile: profile}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshots, err := store.Range(ctx, "alice", takenAt, takenAt.Add(time.Hour))
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("Expected 1 snapshot, got %d (%v)", len(snapshots), err)
+	}
+	if country := string(snapshots[0].Profile.Raw["country"]); country != `"DE"` {
+		t.Errorf("Expected unknown fields to be stored, got %q", country)
+	}
+}
+
+func TestStore_DeleteUsers(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, user := range []string{"bob", "alice"} {
+		for h := 0; h < 3; h++ {
+			store.Put(ctx, history.Snapshot{
+				User:    user,
+				TakenAt: base.Add(time.Duration(h) * time.Hour),
+				Profile: &godestats.UserProfile{User: user},
+			})
+		}
+	}
+
+	removed, err := store.Delete(ctx, "alice", base, base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 removed snapshots, got %d", removed)
+	}
+
+	users, err := store.Users(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[0] != "alice" || users[1] != "bob" {
+		t.Errorf("Expected [alice bob], got %v", users)
+	}
+}
+
+func TestDialectPlaceholders(t *testing.T) {
+	store := &Store{dialect: Postgres}
+	p := store.placeholders(2)
+	if p[0] != "$1" || p[1] != "$2" {
+		t.Errorf("Unexpected Postgres placeholders: %v", p)
+	}
+
+	store.dialect = MySQL
+	if p := store.placeholders(1); p[0] != "?" {
+		t.Errorf("Unexpected MySQL placeholders: %v", p)
+	}
+}
+
+func TestStore_Rollups(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	day := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	if _, ok, err := store.GetRollup(ctx, "alice", history.Daily, day); ok || err != nil {
+		t.Fatalf("Expected no rollup yet, got ok=%v err=%v", ok, err)
+	}
+
+	for i := 0; i < 3; i++ {
+		rollup := history.Rollup{
+			User:        "alice",
+			Granularity: history.Daily,
+			Start:       day.AddDate(0, 0, i),
+			StartXP:     godestats.XP(i * 100),
+			EndXP:       godestats.XP(i*100 + 50),
+			Languages:   map[string]godestats.XP{"Go": godestats.XP(i*100 + 50)},
+		}
+		if err := store.PutRollup(ctx, rollup); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Replace the first rollup
+	store.PutRollup(ctx, history.Rollup{User: "alice", Granularity: history.Daily, Start: day, EndXP: 75})
+
+	rollup, ok, err := store.GetRollup(ctx, "alice", history.Daily, day)
+	if err != nil || !ok || rollup.EndXP != 75 {
+		t.Errorf("Expected replaced rollup, got %+v (ok=%v, err=%v)", rollup, ok, err)
+	}
+
+	rollups, err := store.Rollups(ctx, "alice", history.Daily, day.AddDate(0, 0, 1), day.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rollups) != 2 || rollups[0].EndXP != 150 || rollups[1].Languages["Go"] != 250 {
+		t.Errorf("Unexpected rollups: %+v", rollups)
+	}
+
+	weekly, _ := store.Rollups(ctx, "alice", history.Weekly, day, day.AddDate(0, 0, 7))
+	if len(weekly) != 0 {
+		t.Errorf("Expected no weekly rollups, got %+v", weekly)
+	}
+}
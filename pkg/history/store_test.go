@@ -0,0 +1,73 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestMemoryStore_PutRange(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	base := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, hours := range []int{2, 0, 1, 3} {
+		snapshot := Snapshot{
+			User:    "testuser",
+			TakenAt: base.Add(time.Duration(hours) * time.Hour),
+			Profile: &godestats.UserProfile{TotalXP: godestats.XP(hours * 100)},
+		}
+		if err := store.Put(ctx, snapshot); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	snapshots, err := store.Range(ctx, "testuser", base.Add(time.Hour), base.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].Profile.TotalXP != 100 || snapshots[1].Profile.TotalXP != 200 {
+		t.Errorf("Expected snapshots in time order, got %d, %d",
+			snapshots[0].Profile.TotalXP, snapshots[1].Profile.TotalXP)
+	}
+
+	other, _ := store.Range(ctx, "otheruser", base, base.Add(24*time.Hour))
+	if len(other) != 0 {
+		t.Errorf("Expected no snapshots for other user, got %d", len(other))
+	}
+}
+
+func TestMemoryStore_Replace(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	takenAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	store.Put(ctx, Snapshot{User: "u", TakenAt: takenAt, Profile: &godestats.UserProfile{TotalXP: 1}})
+	store.Put(ctx, Snapshot{User: "u", TakenAt: takenAt, Profile: &godestats.UserProfile{TotalXP: 2}})
+
+	snapshots, _ := store.Range(ctx, "u", takenAt, takenAt.Add(time.Second))
+	if len(snapshots) != 1 || snapshots[0].Profile.TotalXP != 2 {
+		t.Errorf("Expected single replaced snapshot, got %+v", snapshots)
+	}
+}
+
+func TestMemoryStore_CopiesProfiles(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	takenAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	profile := &godestats.UserProfile{TotalXP: 1}
+	store.Put(ctx, Snapshot{User: "u", TakenAt: takenAt, Profile: profile})
+	profile.TotalXP = 99
+
+	snapshots, _ := store.Range(ctx, "u", takenAt, takenAt.Add(time.Second))
+	if snapshots[0].Profile.TotalXP != 1 {
+		t.Error("Expected store to keep its own copy of the profile")
+	}
+}
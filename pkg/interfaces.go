@@ -2,57 +2,118 @@ package godestats
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
 // CodeStatsClient defines the interface for interacting with the Code::Stats API.
 type CodeStatsClient interface {
 	// GetUserProfile retrieves the public profile information for the specified user.
-	// Returns an error if the user does not exist or their profile is private.
-	GetUserProfile(ctx context.Context, username string) (*UserProfile, error)
+	// Returns an error if the user does not exist or their profile is private,
+	// unless the profile belongs to the owner of the configured API token.
+	GetUserProfile(ctx context.Context, username string, opts ...CallOption) (*UserProfile, error)
 
 	// SendPulse submits a pulse (collection of XPs for different languages) to the API.
 	// The pulse must contain a coded_at timestamp and should be no older than a week.
-	SendPulse(ctx context.Context, pulse Pulse) error
+	SendPulse(ctx context.Context, pulse Pulse, opts ...CallOption) error
+}
+
+// ProfileClient retrieves user profiles. It is all that read-only consumers such
+// as watchers and HTTP handlers need, and is implemented by anonymous clients,
+// which can't send pulses.
+type ProfileClient interface {
+	// GetUserProfile retrieves the public profile information for the specified user.
+	GetUserProfile(ctx context.Context, username string, opts ...CallOption) (*UserProfile, error)
+}
+
+// OwnProfileClient is implemented by clients that can fetch the profile of the API
+// token's owner, which includes private data such as the machines' last activity.
+type OwnProfileClient interface {
+	// GetMyProfile retrieves the profile of the owner of the configured API token.
+	GetMyProfile(ctx context.Context, opts ...CallOption) (*UserProfile, error)
+}
+
+// TokenProvider supplies the API token for authenticated requests, e.g. from the
+// environment, a file, the OS keyring or a secret manager such as Vault. Clients
+// ask for the token before every authenticated request, so providers can rotate it.
+type TokenProvider interface {
+	// Token returns the current API token.
+	Token(ctx context.Context) (string, error)
+}
+
+// CapabilitiesClient is implemented by clients that can detect which optional
+// features the Code::Stats instance they talk to supports.
+type CapabilitiesClient interface {
+	// Capabilities returns the features supported by the instance.
+	Capabilities(ctx context.Context) (Capabilities, error)
+}
+
+// Capabilities describes the optional features of a Code::Stats instance. Older
+// self-hosted instances may lack endpoints that codestats.net provides.
+type Capabilities struct {
+	// Version is the version announced by the instance, if any.
+	Version string `json:"version,omitempty"`
+
+	// MyProfile reports whether the authenticated profile endpoint is available.
+	MyProfile bool `json:"my_profile"`
+
+	// GraphQL reports whether the profile GraphQL endpoint is available.
+	GraphQL bool `json:"graphql"`
+
+	// LiveUpdates reports whether the WebSocket endpoint for live updates is available.
+	LiveUpdates bool `json:"live_updates"`
 }
 
 // XpCalculator defines the interface for calculating levels and percentages from XP.
 type XpCalculator interface {
 	// GetLevel calculates the level for the given XP amount.
-	GetLevel(xp int) int
+	GetLevel(xp XP) int
 
 	// GetLevelPercentage calculates the percentage progress within the current level.
 	// Returns a value between 0.0 and 1.0.
-	GetLevelPercentage(xp int) float64
+	GetLevelPercentage(xp XP) float64
 
 	// GetXpForLevel calculates the minimum XP required to reach the specified level.
-	GetXpForLevel(level int) int
+	GetXpForLevel(level int) XP
 
 	// GetXpForNextLevel calculates the minimum XP required to reach the next level
 	// from the current XP amount.
-	GetXpForNextLevel(xp int) int
+	GetXpForNextLevel(xp XP) XP
 }
 
 // UserProfile represents the public profile information of a user.
 type UserProfile struct {
 	User      string                  `json:"user"`
-	TotalXP   int                     `json:"total_xp"`
-	NewXP     int                     `json:"new_xp"`
+	TotalXP   XP                      `json:"total_xp"`
+	NewXP     XP                      `json:"new_xp"`
 	Machines  map[string]MachineInfo  `json:"machines"`
 	Languages map[string]LanguageInfo `json:"languages"`
-	Dates     map[string]int          `json:"dates"`
+	Dates     map[string]XP           `json:"dates"`
+
+	// Recent describes the time window covered by NewXP and the per-language and
+	// per-machine NewXPs values. It is set by the client when the profile is fetched.
+	Recent RecentPeriod `json:"-"`
+
+	// Raw holds JSON fields not known to this library. It is only populated
+	// when decoding with unknown field preservation enabled. It is left out by
+	// json.Marshal; MarshalProfile includes it, and the caching client and the
+	// history stores use that to keep it.
+	Raw map[string]json.RawMessage `json:"-"`
 }
 
 // MachineInfo represents XP information for a specific machine.
+// LastActive and TokenID are only populated by the authenticated profile endpoint.
 type MachineInfo struct {
-	XPs    int `json:"xps"`
-	NewXPs int `json:"new_xps"`
+	XPs        XP        `json:"xps"`
+	NewXPs     XP        `json:"new_xps"`
+	LastActive time.Time `json:"last_active,omitzero"`
+	TokenID    string    `json:"token_id,omitempty"`
 }
 
 // LanguageInfo represents XP information for a specific language.
 type LanguageInfo struct {
-	XPs    int `json:"xps"`
-	NewXPs int `json:"new_xps"`
+	XPs    XP `json:"xps"`
+	NewXPs XP `json:"new_xps"`
 }
 
 // Pulse represents a collection of XPs for different languages at a specific time.
@@ -64,5 +125,5 @@ type Pulse struct {
 // LanguageXP represents the XP gained for a specific language.
 type LanguageXP struct {
 	Language string `json:"language"`
-	XP       int    `json:"xp"`
+	XP       XP     `json:"xp"`
 }
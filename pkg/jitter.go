@@ -0,0 +1,20 @@
+package godestats
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter returns d shifted by a random amount of up to fraction times d in either
+// direction, so that periodic work of many instances started from the same
+// configuration drifts apart instead of hitting the API in lockstep.
+// The fraction is clamped to [0, 1]; a fraction of 0 returns d unchanged.
+func Jitter(d time.Duration, fraction float64) time.Duration {
+	fraction = min(max(fraction, 0), 1)
+	if d <= 0 || fraction == 0 {
+		return d
+	}
+
+	spread := fraction * float64(d)
+	return d + time.Duration(spread*(2*rand.Float64()-1))
+}
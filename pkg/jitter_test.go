@@ -0,0 +1,42 @@
+package godestats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        time.Duration
+		fraction float64
+		min, max time.Duration
+	}{
+		{"no jitter", time.Minute, 0, time.Minute, time.Minute},
+		{"negative fraction", time.Minute, -0.5, time.Minute, time.Minute},
+		{"tenth", time.Minute, 0.1, 54 * time.Second, 66 * time.Second},
+		{"clamped", time.Minute, 3, 0, 2 * time.Minute},
+		{"zero duration", 0, 0.5, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				got := Jitter(tt.d, tt.fraction)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("Expected jitter within [%v, %v], got %v", tt.min, tt.max, got)
+				}
+			}
+		})
+	}
+}
+
+func TestJitter_Varies(t *testing.T) {
+	first := Jitter(time.Hour, 0.5)
+	for range 100 {
+		if Jitter(time.Hour, 0.5) != first {
+			return
+		}
+	}
+	t.Error("Expected jittered durations to vary")
+}
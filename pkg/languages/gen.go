@@ -0,0 +1,108 @@
+//go:build ignore
+
+// gen generates registry.go from languages.csv. Run it with go generate after
+// editing the CSV file.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// categories maps the categories of the CSV file to their constants.
+var categories = map[string]string{
+	"programming": "CategoryProgramming",
+	"markup":      "CategoryMarkup",
+	"style":       "CategoryStyle",
+	"data":        "CategoryData",
+	"prose":       "CategoryProse",
+	"shell":       "CategoryShell",
+	"build":       "CategoryBuild",
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+func main() {
+	f, err := os.Open("languages.csv")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+	records = records[1:]
+	slices.SortFunc(records, func(a, b []string) int {
+		return strings.Compare(strings.ToLower(a[0]), strings.ToLower(b[0]))
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen.go from languages.csv; DO NOT EDIT.\n\npackage languages\n\n")
+	buf.WriteString("// registry holds the known languages, sorted by name.\nvar registry = []Language{\n")
+
+	names, patterns := map[string]bool{}, map[string]string{}
+	for _, r := range records {
+		name, category, color := r[0], categories[r[1]], r[3]
+		if category == "" {
+			log.Fatalf("%s: unknown category %q", name, r[1])
+		}
+		if !colorPattern.MatchString(color) {
+			log.Fatalf("%s: invalid color %q, expected lowercase #rrggbb", name, color)
+		}
+		if names[strings.ToLower(name)] {
+			log.Fatalf("%s: duplicate name", name)
+		}
+		names[strings.ToLower(name)] = true
+
+		var extensions, filenames []string
+		for _, pattern := range strings.Fields(r[2]) {
+			key := pattern
+			if strings.HasPrefix(pattern, ".") {
+				key = strings.ToLower(pattern)
+				extensions = append(extensions, key)
+			} else {
+				filenames = append(filenames, pattern)
+			}
+			if other, ok := patterns[key]; ok {
+				log.Fatalf("%s: %s already belongs to %s", name, pattern, other)
+			}
+			patterns[key] = name
+		}
+
+		fmt.Fprintf(&buf, "\t{Name: %q, Category: %s", name, category)
+		if len(extensions) > 0 {
+			fmt.Fprintf(&buf, ", Extensions: %s", stringSlice(extensions))
+		}
+		if len(filenames) > 0 {
+			fmt.Fprintf(&buf, ", Filenames: %s", stringSlice(filenames))
+		}
+		fmt.Fprintf(&buf, ", Color: %q},\n", color)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("registry.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// stringSlice formats the strings as a Go slice literal.
+func stringSlice(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
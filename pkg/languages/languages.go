@@ -0,0 +1,133 @@
+// Package languages is a registry of Code::Stats language names with metadata:
+// their category, common file extensions and a display color. Detection,
+// grouping, badges and charts share it for consistent naming and colors.
+//
+// The registry is generated from languages.csv; run go generate after editing it.
+package languages
+
+//go:generate go run gen.go
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Category classifies languages by their purpose.
+type Category string
+
+// Language categories
+const (
+	CategoryProgramming Category = "programming"
+	CategoryMarkup      Category = "markup"
+	CategoryStyle       Category = "style"
+	CategoryData        Category = "data"
+	CategoryProse       Category = "prose"
+	CategoryShell       Category = "shell"
+	CategoryBuild       Category = "build"
+)
+
+// Categories lists all categories in display order.
+var Categories = []Category{
+	CategoryProgramming, CategoryMarkup, CategoryStyle, CategoryData,
+	CategoryProse, CategoryShell, CategoryBuild,
+}
+
+// Title returns the category name for display, e.g. "Programming".
+func (c Category) Title() string {
+	if c == "" {
+		return ""
+	}
+	return strings.ToUpper(string(c[:1])) + string(c[1:])
+}
+
+// Language describes a known language. Languages returned by the registry share
+// their slices, which must not be modified.
+type Language struct {
+	// Name is the language name as used by Code::Stats, e.g. "C++".
+	Name     string
+	Category Category
+	// Extensions are the lowercase file extensions of the language, with a leading dot.
+	Extensions []string
+	// Filenames are file names without a distinctive extension, e.g. "Makefile".
+	Filenames []string
+	// Color is the display color as #rrggbb.
+	Color string
+}
+
+// Indexes of the registry, built once from the generated data
+var (
+	byName      = make(map[string]int, len(registry))
+	byExtension = make(map[string]int)
+	byFilename  = make(map[string]int)
+)
+
+func init() {
+	for i, lang := range registry {
+		byName[strings.ToLower(lang.Name)] = i
+		for _, ext := range lang.Extensions {
+			byExtension[ext] = i
+		}
+		for _, name := range lang.Filenames {
+			byFilename[name] = i
+		}
+	}
+}
+
+// All returns all known languages, sorted by name.
+func All() []Language {
+	return slices.Clone(registry)
+}
+
+// Lookup returns the language with the name, compared case-insensitively.
+func Lookup(name string) (Language, bool) {
+	i, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Language{}, false
+	}
+	return registry[i], true
+}
+
+// ByExtension returns the language of a file extension such as ".go" or "go",
+// compared case-insensitively.
+func ByExtension(ext string) (Language, bool) {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	i, ok := byExtension[ext]
+	if !ok {
+		return Language{}, false
+	}
+	return registry[i], true
+}
+
+// Detect returns the language of a file from its name, e.g. "Go" for
+// "cmd/main.go" and "Makefile" for "Makefile".
+func Detect(path string) (Language, bool) {
+	base := filepath.Base(filepath.ToSlash(path))
+	if i, ok := byFilename[base]; ok {
+		return registry[i], true
+	}
+	if ext := filepath.Ext(base); ext != "" {
+		return ByExtension(ext)
+	}
+	return Language{}, false
+}
+
+// Color returns the display color of the language, or "" if it is unknown.
+func Color(name string) string {
+	lang, _ := Lookup(name)
+	return lang.Color
+}
+
+// InCategory returns the names of the known languages in the category, sorted.
+func InCategory(category Category) []string {
+	var names []string
+	for _, lang := range registry {
+		if lang.Category == category {
+			names = append(names, lang.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,99 @@
+package languages
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"Go", "Go", true},
+		{"c++", "C++", true},
+		{" plain TEXT ", "Plain text", true},
+		{"Brainfuck", "", false},
+	}
+
+	for _, tt := range tests {
+		lang, ok := Lookup(tt.name)
+		if lang.Name != tt.expected || ok != tt.ok {
+			t.Errorf("Lookup(%q): expected %q, %v, got %q, %v", tt.name, tt.expected, tt.ok, lang.Name, ok)
+		}
+	}
+
+	if lang, _ := Lookup("Go"); lang.Category != CategoryProgramming || lang.Color != "#00add8" {
+		t.Errorf("Expected Go's metadata, got %+v", lang)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"main.go", "Go"},
+		{"/src/app/Component.TSX", "TSX"},
+		{`C:\project\build.ps1`, "PowerShell"},
+		{"deploy/Dockerfile", "Dockerfile"},
+		{"Makefile", "Makefile"},
+		{"archive.tar.gz", ""},
+		{"README", ""},
+	}
+
+	for _, tt := range tests {
+		lang, ok := Detect(tt.path)
+		if lang.Name != tt.expected || ok != (tt.expected != "") {
+			t.Errorf("Detect(%q): expected %q, got %q (ok=%v)", tt.path, tt.expected, lang.Name, ok)
+		}
+	}
+
+	if lang, ok := ByExtension("rs"); !ok || lang.Name != "Rust" {
+		t.Errorf("Expected extensions without a dot to be found, got %q (ok=%v)", lang.Name, ok)
+	}
+}
+
+func TestColor(t *testing.T) {
+	if c := Color("python"); c != "#3572a5" {
+		t.Errorf("Expected Python's color, got %q", c)
+	}
+	if c := Color("Unknown"); c != "" {
+		t.Errorf("Expected no color for unknown languages, got %q", c)
+	}
+}
+
+func TestInCategory(t *testing.T) {
+	style := InCategory(CategoryStyle)
+	if !slices.Equal(style, []string{"CSS", "Less", "Sass", "SCSS"}) {
+		t.Errorf("Expected the style languages, got %v", style)
+	}
+	if title := CategoryProgramming.Title(); title != "Programming" {
+		t.Errorf("Expected Programming, got %q", title)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	all := All()
+	if len(all) == 0 {
+		t.Fatal("Expected a non-empty registry")
+	}
+
+	color := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	for i, lang := range all {
+		if i > 0 && strings.ToLower(all[i-1].Name) >= strings.ToLower(lang.Name) {
+			t.Errorf("Expected languages sorted by name, got %q before %q", all[i-1].Name, lang.Name)
+		}
+		if !slices.Contains(Categories, lang.Category) || !color.MatchString(lang.Color) {
+			t.Errorf("Expected a known category and color for %q, got %+v", lang.Name, lang)
+		}
+		for _, ext := range lang.Extensions {
+			if got, _ := ByExtension(ext); got.Name != lang.Name {
+				t.Errorf("Expected %s to map to %q, got %q", ext, lang.Name, got.Name)
+			}
+		}
+	}
+}
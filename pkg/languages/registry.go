@@ -0,0 +1,63 @@
+// Code generated by gen.go from languages.csv; DO NOT EDIT.
+
+package languages
+
+// registry holds the known languages, sorted by name.
+var registry = []Language{
+	{Name: "AsciiDoc", Category: CategoryProse, Extensions: []string{".adoc", ".asciidoc"}, Color: "#73a0c5"},
+	{Name: "C", Category: CategoryProgramming, Extensions: []string{".c", ".h"}, Color: "#555555"},
+	{Name: "C#", Category: CategoryProgramming, Extensions: []string{".cs", ".csx"}, Color: "#178600"},
+	{Name: "C++", Category: CategoryProgramming, Extensions: []string{".cpp", ".cc", ".cxx", ".hpp", ".hh", ".hxx"}, Color: "#f34b7d"},
+	{Name: "Clojure", Category: CategoryProgramming, Extensions: []string{".clj", ".cljs", ".cljc", ".edn"}, Color: "#db5855"},
+	{Name: "CMake", Category: CategoryBuild, Extensions: []string{".cmake"}, Filenames: []string{"CMakeLists.txt"}, Color: "#da3434"},
+	{Name: "CSS", Category: CategoryStyle, Extensions: []string{".css"}, Color: "#563d7c"},
+	{Name: "Dart", Category: CategoryProgramming, Extensions: []string{".dart"}, Color: "#00b4ab"},
+	{Name: "Dockerfile", Category: CategoryBuild, Extensions: []string{".dockerfile"}, Filenames: []string{"Dockerfile", "Containerfile"}, Color: "#384d54"},
+	{Name: "Elixir", Category: CategoryProgramming, Extensions: []string{".ex", ".exs"}, Color: "#6e4a7e"},
+	{Name: "Elm", Category: CategoryProgramming, Extensions: []string{".elm"}, Color: "#60b5cc"},
+	{Name: "Erlang", Category: CategoryProgramming, Extensions: []string{".erl", ".hrl"}, Color: "#b83998"},
+	{Name: "F#", Category: CategoryProgramming, Extensions: []string{".fs", ".fsi", ".fsx"}, Color: "#b845fc"},
+	{Name: "Fish", Category: CategoryShell, Extensions: []string{".fish"}, Color: "#4aae47"},
+	{Name: "Go", Category: CategoryProgramming, Extensions: []string{".go"}, Color: "#00add8"},
+	{Name: "GraphQL", Category: CategoryData, Extensions: []string{".graphql", ".gql"}, Color: "#e10098"},
+	{Name: "Haskell", Category: CategoryProgramming, Extensions: []string{".hs", ".lhs"}, Color: "#5e5086"},
+	{Name: "HTML", Category: CategoryMarkup, Extensions: []string{".html", ".htm", ".xhtml"}, Color: "#e34c26"},
+	{Name: "INI", Category: CategoryData, Extensions: []string{".ini", ".cfg", ".conf"}, Color: "#d1dbe0"},
+	{Name: "Java", Category: CategoryProgramming, Extensions: []string{".java"}, Color: "#b07219"},
+	{Name: "JavaScript", Category: CategoryProgramming, Extensions: []string{".js", ".mjs", ".cjs"}, Color: "#f1e05a"},
+	{Name: "JSON", Category: CategoryData, Extensions: []string{".json", ".jsonc"}, Color: "#292929"},
+	{Name: "JSX", Category: CategoryProgramming, Extensions: []string{".jsx"}, Color: "#f1e05a"},
+	{Name: "Julia", Category: CategoryProgramming, Extensions: []string{".jl"}, Color: "#a270ba"},
+	{Name: "Kotlin", Category: CategoryProgramming, Extensions: []string{".kt", ".kts"}, Color: "#a97bff"},
+	{Name: "Less", Category: CategoryStyle, Extensions: []string{".less"}, Color: "#1d365d"},
+	{Name: "Lua", Category: CategoryProgramming, Extensions: []string{".lua"}, Color: "#000080"},
+	{Name: "Makefile", Category: CategoryBuild, Extensions: []string{".mk", ".mak"}, Filenames: []string{"Makefile", "GNUmakefile"}, Color: "#427819"},
+	{Name: "Markdown", Category: CategoryProse, Extensions: []string{".md", ".markdown"}, Color: "#083fa1"},
+	{Name: "Nim", Category: CategoryProgramming, Extensions: []string{".nim"}, Color: "#ffc200"},
+	{Name: "Objective-C", Category: CategoryProgramming, Extensions: []string{".m", ".mm"}, Color: "#438eff"},
+	{Name: "OCaml", Category: CategoryProgramming, Extensions: []string{".ml", ".mli"}, Color: "#ef7a08"},
+	{Name: "Perl", Category: CategoryProgramming, Extensions: []string{".pl", ".pm"}, Color: "#0298c3"},
+	{Name: "PHP", Category: CategoryProgramming, Extensions: []string{".php"}, Color: "#4f5d95"},
+	{Name: "Plain text", Category: CategoryProse, Extensions: []string{".txt"}, Color: "#999999"},
+	{Name: "PowerShell", Category: CategoryShell, Extensions: []string{".ps1", ".psm1", ".psd1"}, Color: "#012456"},
+	{Name: "Python", Category: CategoryProgramming, Extensions: []string{".py", ".pyw", ".pyi"}, Color: "#3572a5"},
+	{Name: "R", Category: CategoryProgramming, Extensions: []string{".r", ".rmd"}, Color: "#198ce7"},
+	{Name: "reStructuredText", Category: CategoryProse, Extensions: []string{".rst"}, Color: "#141414"},
+	{Name: "Ruby", Category: CategoryProgramming, Extensions: []string{".rb", ".rake", ".gemspec"}, Filenames: []string{"Gemfile", "Rakefile"}, Color: "#701516"},
+	{Name: "Rust", Category: CategoryProgramming, Extensions: []string{".rs"}, Color: "#dea584"},
+	{Name: "Sass", Category: CategoryStyle, Extensions: []string{".sass"}, Color: "#a53b70"},
+	{Name: "Scala", Category: CategoryProgramming, Extensions: []string{".scala", ".sc"}, Color: "#c22d40"},
+	{Name: "SCSS", Category: CategoryStyle, Extensions: []string{".scss"}, Color: "#c6538c"},
+	{Name: "Shell", Category: CategoryShell, Extensions: []string{".sh", ".bash"}, Color: "#89e051"},
+	{Name: "SQL", Category: CategoryData, Extensions: []string{".sql"}, Color: "#e38c00"},
+	{Name: "Svelte", Category: CategoryMarkup, Extensions: []string{".svelte"}, Color: "#ff3e00"},
+	{Name: "Swift", Category: CategoryProgramming, Extensions: []string{".swift"}, Color: "#f05138"},
+	{Name: "TOML", Category: CategoryData, Extensions: []string{".toml"}, Color: "#9c4221"},
+	{Name: "TSX", Category: CategoryProgramming, Extensions: []string{".tsx"}, Color: "#3178c6"},
+	{Name: "TypeScript", Category: CategoryProgramming, Extensions: []string{".ts", ".mts", ".cts"}, Color: "#3178c6"},
+	{Name: "Vue", Category: CategoryMarkup, Extensions: []string{".vue"}, Color: "#41b883"},
+	{Name: "XML", Category: CategoryData, Extensions: []string{".xml", ".xsd", ".xsl", ".svg"}, Color: "#0060ac"},
+	{Name: "YAML", Category: CategoryData, Extensions: []string{".yaml", ".yml"}, Color: "#cb171e"},
+	{Name: "Zig", Category: CategoryProgramming, Extensions: []string{".zig"}, Color: "#ec915c"},
+	{Name: "Zsh", Category: CategoryShell, Extensions: []string{".zsh"}, Color: "#89e051"},
+}
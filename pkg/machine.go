@@ -0,0 +1,29 @@
+package godestats
+
+import (
+	"sort"
+	"time"
+)
+
+// IsInactive reports whether the machine has had no activity in the given number
+// of days before now. Machines without a known LastActive timestamp are never
+// reported as inactive, since their activity cannot be determined.
+func (m MachineInfo) IsInactive(days int, now time.Time) bool {
+	if m.LastActive.IsZero() {
+		return false
+	}
+	return m.LastActive.Before(now.AddDate(0, 0, -days))
+}
+
+// InactiveMachines returns the sorted names of all machines that have had
+// no activity in the given number of days before now.
+func (p *UserProfile) InactiveMachines(days int, now time.Time) []string {
+	var names []string
+	for name, machine := range p.Machines {
+		if machine.IsInactive(days, now) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
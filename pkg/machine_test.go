@@ -0,0 +1,73 @@
+package godestats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMachineInfo_IsInactive(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		lastActive time.Time
+		days       int
+		expected   bool
+	}{
+		{"unknown activity", time.Time{}, 7, false},
+		{"active today", now.Add(-time.Hour), 7, false},
+		{"active within window", now.AddDate(0, 0, -6), 7, false},
+		{"inactive beyond window", now.AddDate(0, 0, -8), 7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := MachineInfo{LastActive: tt.lastActive}
+			if result := machine.IsInactive(tt.days, now); result != tt.expected {
+				t.Errorf("Expected IsInactive() = %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUserProfile_InactiveMachines(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	profile := &UserProfile{
+		Machines: map[string]MachineInfo{
+			"laptop":  {LastActive: now.AddDate(0, 0, -1)},
+			"old-pc":  {LastActive: now.AddDate(0, -2, 0)},
+			"server":  {LastActive: now.AddDate(0, 0, -40)},
+			"unknown": {},
+		},
+	}
+
+	inactive := profile.InactiveMachines(30, now)
+	if len(inactive) != 2 || inactive[0] != "old-pc" || inactive[1] != "server" {
+		t.Errorf("Expected [old-pc server], got %v", inactive)
+	}
+}
+
+func TestMachineInfo_JSON(t *testing.T) {
+	var machine MachineInfo
+	data := `{"xps": 100, "new_xps": 5, "last_active": "2023-06-15T12:00:00Z", "token_id": "abc"}`
+	if err := json.Unmarshal([]byte(data), &machine); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if machine.TokenID != "abc" {
+		t.Errorf("Expected token ID 'abc', got '%s'", machine.TokenID)
+	}
+	if !machine.LastActive.Equal(time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected last active time: %v", machine.LastActive)
+	}
+
+	// Metadata must be omitted for plain public profile data
+	out, err := json.Marshal(MachineInfo{XPs: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(out) != `{"xps":1,"new_xps":0}` {
+		t.Errorf("Unexpected JSON: %s", out)
+	}
+}
@@ -0,0 +1,161 @@
+package godestats
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// MessageKey identifies an entry in the user-facing message catalog.
+type MessageKey string
+
+// Message keys for the user-facing message catalog
+const (
+	MsgUserNotFound     MessageKey = "user_not_found"
+	MsgUnauthorized     MessageKey = "unauthorized"
+	MsgPulseTooOld      MessageKey = "pulse_too_old"
+	MsgEmptyUsername    MessageKey = "empty_username"
+	MsgRateLimited      MessageKey = "rate_limited"
+	MsgNetworkError     MessageKey = "network_error"
+	MsgInvalidResponse  MessageKey = "invalid_response"
+	MsgServerError      MessageKey = "server_error"
+	MsgRequestCancelled MessageKey = "request_cancelled"
+	MsgUnknown          MessageKey = "unknown"
+)
+
+// DefaultLanguage is the language used when a requested language is not in the catalog.
+const DefaultLanguage = "en"
+
+// messageCatalog holds the translated user-facing messages, keyed by base language tag.
+var messageCatalog = map[string]map[MessageKey]string{
+	"en": {
+		MsgUserNotFound:     "This Code::Stats profile doesn't exist or is private.",
+		MsgUnauthorized:     "Your API token is missing or invalid. Please check your settings.",
+		MsgPulseTooOld:      "Some coding activity is older than a week and can no longer be recorded.",
+		MsgEmptyUsername:    "Please enter a Code::Stats username.",
+		MsgRateLimited:      "Code::Stats is receiving too many requests. Please try again in a moment.",
+		MsgNetworkError:     "Couldn't reach Code::Stats. Please check your internet connection.",
+		MsgInvalidResponse:  "Code::Stats sent a response that couldn't be read. Please try again later.",
+		MsgServerError:      "Code::Stats is having problems right now. Please try again later.",
+		MsgRequestCancelled: "The request was cancelled or took too long.",
+		MsgUnknown:          "Something went wrong while talking to Code::Stats.",
+	},
+	"de": {
+		MsgUserNotFound:     "Dieses Code::Stats-Profil existiert nicht oder ist privat.",
+		MsgUnauthorized:     "Dein API-Token fehlt oder ist ungültig. Bitte prüfe deine Einstellungen.",
+		MsgPulseTooOld:      "Einige Aktivitäten sind älter als eine Woche und können nicht mehr erfasst werden.",
+		MsgEmptyUsername:    "Bitte gib einen Code::Stats-Benutzernamen ein.",
+		MsgRateLimited:      "Code::Stats erhält zu viele Anfragen. Bitte versuche es gleich noch einmal.",
+		MsgNetworkError:     "Code::Stats ist nicht erreichbar. Bitte prüfe deine Internetverbindung.",
+		MsgInvalidResponse:  "Die Antwort von Code::Stats konnte nicht gelesen werden. Bitte versuche es später erneut.",
+		MsgServerError:      "Bei Code::Stats gibt es gerade Probleme. Bitte versuche es später erneut.",
+		MsgRequestCancelled: "Die Anfrage wurde abgebrochen oder hat zu lange gedauert.",
+		MsgUnknown:          "Bei der Kommunikation mit Code::Stats ist ein Fehler aufgetreten.",
+	},
+	"fr": {
+		MsgUserNotFound:     "Ce profil Code::Stats n'existe pas ou est privé.",
+		MsgUnauthorized:     "Votre jeton d'API est manquant ou invalide. Veuillez vérifier vos paramètres.",
+		MsgPulseTooOld:      "Une partie de l'activité date de plus d'une semaine et ne peut plus être enregistrée.",
+		MsgEmptyUsername:    "Veuillez saisir un nom d'utilisateur Code::Stats.",
+		MsgRateLimited:      "Code::Stats reçoit trop de requêtes. Veuillez réessayer dans un instant.",
+		MsgNetworkError:     "Impossible de joindre Code::Stats. Veuillez vérifier votre connexion internet.",
+		MsgInvalidResponse:  "La réponse de Code::Stats est illisible. Veuillez réessayer plus tard.",
+		MsgServerError:      "Code::Stats rencontre actuellement des problèmes. Veuillez réessayer plus tard.",
+		MsgRequestCancelled: "La requête a été annulée ou a pris trop de temps.",
+		MsgUnknown:          "Une erreur s'est produite lors de la communication avec Code::Stats.",
+	},
+	"es": {
+		MsgUserNotFound:     "Este perfil de Code::Stats no existe o es privado.",
+		MsgUnauthorized:     "Tu token de API falta o no es válido. Revisa tu configuración.",
+		MsgPulseTooOld:      "Parte de la actividad tiene más de una semana y ya no se puede registrar.",
+		MsgEmptyUsername:    "Introduce un nombre de usuario de Code::Stats.",
+		MsgRateLimited:      "Code::Stats está recibiendo demasiadas solicitudes. Inténtalo de nuevo en un momento.",
+		MsgNetworkError:     "No se pudo conectar con Code::Stats. Revisa tu conexión a internet.",
+		MsgInvalidResponse:  "No se pudo leer la respuesta de Code::Stats. Inténtalo de nuevo más tarde.",
+		MsgServerError:      "Code::Stats tiene problemas en este momento. Inténtalo de nuevo más tarde.",
+		MsgRequestCancelled: "La solicitud se canceló o tardó demasiado.",
+		MsgUnknown:          "Se produjo un error al comunicarse con Code::Stats.",
+	},
+}
+
+// UserMessage translates an error returned by this library into a friendly,
+// localized message suitable for showing to end users.
+// The lang parameter accepts BCP 47 style tags such as "de" or "de-AT";
+// unsupported languages fall back to English. Returns an empty string for a nil error.
+func UserMessage(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+	return Message(MessageKeyFor(err), lang)
+}
+
+// MessageKeyFor classifies an error into the message catalog key that best describes it.
+func MessageKeyFor(err error) MessageKey {
+	switch {
+	case err == nil:
+		return MsgUnknown
+	case errors.Is(err, ErrEmptyUsername):
+		return MsgEmptyUsername
+	case errors.Is(err, ErrPulseTimestampTooOld):
+		return MsgPulseTooOld
+	case errors.Is(err, ErrInvalidResponse):
+		return MsgInvalidResponse
+	case IsUserNotFound(err):
+		return MsgUserNotFound
+	case IsUnauthorized(err):
+		return MsgUnauthorized
+	case IsRateLimited(err):
+		return MsgRateLimited
+	case isCancellation(err):
+		return MsgRequestCancelled
+	case IsNetworkError(err):
+		return MsgNetworkError
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= 500 {
+		return MsgServerError
+	}
+
+	return MsgUnknown
+}
+
+// Message returns the catalog entry for the given key in the requested language,
+// falling back to English when the language or key is not available.
+func Message(key MessageKey, lang string) string {
+	if messages, ok := messageCatalog[baseLanguage(lang)]; ok {
+		if msg, ok := messages[key]; ok {
+			return msg
+		}
+	}
+
+	if msg, ok := messageCatalog[DefaultLanguage][key]; ok {
+		return msg
+	}
+	return messageCatalog[DefaultLanguage][MsgUnknown]
+}
+
+// SupportedLanguages returns the sorted base language tags available in the message catalog.
+func SupportedLanguages() []string {
+	langs := make([]string, 0, len(messageCatalog))
+	for lang := range messageCatalog {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// baseLanguage reduces a language tag like "de-AT" or "pt_BR" to its lowercase base language.
+func baseLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// isCancellation checks if an error stems from a cancelled or expired context
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,71 @@
+package godestats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMessageKeyFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected MessageKey
+	}{
+		{"nil error", nil, MsgUnknown},
+		{"ErrUserNotFound", ErrUserNotFound, MsgUserNotFound},
+		{"404 API error", NewAPIError(404, "Not found", ""), MsgUserNotFound},
+		{"ErrUnauthorized", ErrUnauthorized, MsgUnauthorized},
+		{"ErrRateLimited", ErrRateLimited, MsgRateLimited},
+		{"ErrEmptyUsername", ErrEmptyUsername, MsgEmptyUsername},
+		{"ErrPulseTimestampTooOld", ErrPulseTimestampTooOld, MsgPulseTooOld},
+		{"wrapped ErrInvalidResponse", fmt.Errorf("%w: bad json", ErrInvalidResponse), MsgInvalidResponse},
+		{"network error", NewNetworkError("GET", "", errors.New("connection refused")), MsgNetworkError},
+		{"cancelled request", NewNetworkError("GET", "", context.Canceled), MsgRequestCancelled},
+		{"500 API error", NewAPIError(500, "Server error", ""), MsgServerError},
+		{"400 API error", NewAPIError(400, "Bad request", ""), MsgUnknown},
+		{"other error", errors.New("random error"), MsgUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MessageKeyFor(tt.err)
+			if result != tt.expected {
+				t.Errorf("Expected MessageKeyFor() = %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUserMessage(t *testing.T) {
+	if msg := UserMessage(nil, "en"); msg != "" {
+		t.Errorf("Expected empty message for nil error, got '%s'", msg)
+	}
+
+	en := UserMessage(ErrUserNotFound, "en")
+	if en != messageCatalog["en"][MsgUserNotFound] {
+		t.Errorf("Unexpected English message: '%s'", en)
+	}
+
+	de := UserMessage(ErrUserNotFound, "de-AT")
+	if de != messageCatalog["de"][MsgUserNotFound] {
+		t.Errorf("Expected German message for 'de-AT', got '%s'", de)
+	}
+
+	fallback := UserMessage(ErrUserNotFound, "xx")
+	if fallback != en {
+		t.Errorf("Expected English fallback for unsupported language, got '%s'", fallback)
+	}
+}
+
+// TestMessageCatalogComplete ensures every language provides every message key.
+func TestMessageCatalogComplete(t *testing.T) {
+	for _, lang := range SupportedLanguages() {
+		for key := range messageCatalog[DefaultLanguage] {
+			if _, ok := messageCatalog[lang][key]; !ok {
+				t.Errorf("Language '%s' is missing message '%s'", lang, key)
+			}
+		}
+	}
+}
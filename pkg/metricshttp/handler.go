@@ -0,0 +1,161 @@
+// Package metricshttp exposes profile-derived metrics in the OpenMetrics text format.
+package metricshttp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/bulk"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// ContentType is the content type of the OpenMetrics text exposition format.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Option configures the metrics handler.
+type Option func(*handler)
+
+// WithCalculator sets the calculator used for level metrics.
+func WithCalculator(calc godestats.XpCalculator) Option {
+	return func(h *handler) {
+		h.calc = calc
+	}
+}
+
+// handler serves metrics for a fixed set of users.
+type handler struct {
+	client godestats.ProfileClient
+	users  []string
+	calc   godestats.XpCalculator
+	mux    *http.ServeMux
+}
+
+// Handler returns an http.Handler that serves metrics for the given users at /metrics.
+// Profiles are fetched on every scrape; pass a caching client to limit API requests.
+// Users whose profile cannot be fetched are reported with a scrape success of 0.
+func Handler(client godestats.ProfileClient, users []string, opts ...Option) http.Handler {
+	h := &handler{
+		client: client,
+		users:  users,
+		calc:   xp.NewCalculator(),
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("GET /metrics", h.serveMetrics)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// serveMetrics fetches all profiles and writes their metrics.
+func (h *handler) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	profiles := make(map[string]*godestats.UserProfile, len(h.users))
+	for _, result := range bulk.FetchProfiles(r.Context(), h.client, h.users, bulk.Options{}) {
+		profiles[result.Username] = result.Value
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	Write(w, profiles, h.calc)
+}
+
+// metricFamily is a single metric family with its samples.
+type metricFamily struct {
+	name    string
+	kind    string
+	help    string
+	samples []sample
+}
+
+// sample is a single metric sample.
+type sample struct {
+	suffix string
+	labels [][2]string
+	value  int64
+}
+
+// Write writes the metrics for the profiles keyed by user in OpenMetrics text format.
+// A nil profile marks a user whose profile could not be fetched.
+func Write(w io.Writer, profiles map[string]*godestats.UserProfile, calc godestats.XpCalculator) error {
+	users := make([]string, 0, len(profiles))
+	for user := range profiles {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+
+	up := metricFamily{name: "codestats_scrape_success", kind: "gauge", help: "Whether the profile was fetched successfully."}
+	xpTotal := metricFamily{name: "codestats_xp", kind: "counter", help: "Total XP of the user."}
+	level := metricFamily{name: "codestats_level", kind: "gauge", help: "Overall level of the user."}
+	recent := metricFamily{name: "codestats_recent_xp", kind: "gauge", help: "XP gained within the recent period."}
+	languages := metricFamily{name: "codestats_language_xp", kind: "counter", help: "Total XP per language."}
+	machines := metricFamily{name: "codestats_machine_xp", kind: "counter", help: "Total XP per machine."}
+
+	for _, user := range users {
+		profile := profiles[user]
+		userLabel := [][2]string{{"user", user}}
+
+		if profile == nil {
+			up.samples = append(up.samples, sample{labels: userLabel, value: 0})
+			continue
+		}
+
+		up.samples = append(up.samples, sample{labels: userLabel, value: 1})
+		xpTotal.samples = append(xpTotal.samples, sample{suffix: "_total", labels: userLabel, value: profile.TotalXP.Int64()})
+		level.samples = append(level.samples, sample{labels: userLabel, value: int64(calc.GetLevel(profile.TotalXP))})
+		recent.samples = append(recent.samples, sample{labels: userLabel, value: profile.NewXP.Int64()})
+
+		for _, lang := range godestats.LanguagesByXP(profile) {
+			languages.samples = append(languages.samples, sample{
+				suffix: "_total",
+				labels: [][2]string{{"user", user}, {"language", lang.Name}},
+				value:  lang.XPs.Int64(),
+			})
+		}
+		for _, machine := range godestats.MachinesByXP(profile) {
+			machines.samples = append(machines.samples, sample{
+				suffix: "_total",
+				labels: [][2]string{{"user", user}, {"machine", machine.Name}},
+				value:  machine.XPs.Int64(),
+			})
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, family := range []metricFamily{up, xpTotal, level, recent, languages, machines} {
+		fmt.Fprintf(bw, "# TYPE %s %s\n", family.name, family.kind)
+		fmt.Fprintf(bw, "# HELP %s %s\n", family.name, family.help)
+		for _, s := range family.samples {
+			fmt.Fprintf(bw, "%s%s%s %d\n", family.name, s.suffix, formatLabels(s.labels), s.value)
+		}
+	}
+	bw.WriteString("# EOF\n")
+
+	return bw.Flush()
+}
+
+// labelEscaper escapes label values as required by the exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats a label set as {name="value",...}.
+func formatLabels(labels [][2]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = label[0] + `="` + labelEscaper.Replace(label[1]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
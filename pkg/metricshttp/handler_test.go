@@ -0,0 +1,85 @@
+package metricshttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// fakeClient serves fixed profiles.
+type fakeClient struct {
+	profiles map[string]*godestats.UserProfile
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	if profile, ok := f.profiles[username]; ok {
+		return profile, nil
+	}
+	return nil, godestats.ErrUserNotFound
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	client := &fakeClient{profiles: map[string]*godestats.UserProfile{
+		"alice": {
+			User:      "alice",
+			TotalXP:   12345,
+			NewXP:     10,
+			Languages: map[string]godestats.LanguageInfo{`C "quoted"`: {XPs: 12345}},
+			Machines:  map[string]godestats.MachineInfo{"laptop": {XPs: 12345}},
+		},
+	}}
+	handler := Handler(client, []string{"alice", "bob"})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Expected content type '%s', got '%s'", ContentType, ct)
+	}
+
+	body := rec.Body.String()
+	for _, expected := range []string{
+		"# TYPE codestats_xp counter\n",
+		`codestats_scrape_success{user="alice"} 1`,
+		`codestats_scrape_success{user="bob"} 0`,
+		`codestats_xp_total{user="alice"} 12345`,
+		`codestats_level{user="alice"} 2`,
+		`codestats_recent_xp{user="alice"} 10`,
+		`codestats_language_xp_total{user="alice",language="C \"quoted\""} 12345`,
+		`codestats_machine_xp_total{user="alice",machine="laptop"} 12345`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected metrics to contain '%s', got:\n%s", expected, body)
+		}
+	}
+
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Error("Expected metrics to end with '# EOF'")
+	}
+	if strings.Contains(body, `codestats_xp_total{user="bob"}`) {
+		t.Error("Expected no XP metrics for a failed profile")
+	}
+}
+
+func TestWrite_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, nil, xp.NewCalculator()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "# EOF\n") {
+		t.Errorf("Expected '# EOF' terminator, got '%s'", buf.String())
+	}
+}
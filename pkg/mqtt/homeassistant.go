@@ -0,0 +1,133 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultDiscoveryPrefix is the topic prefix Home Assistant listens to for
+// MQTT discovery messages.
+const DefaultDiscoveryPrefix = "homeassistant"
+
+// Device groups the sensors of a user in Home Assistant.
+type Device struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer,omitempty"`
+	Model        string   `json:"model,omitempty"`
+}
+
+// SensorConfig is a Home Assistant MQTT discovery config of a sensor. The
+// sensors read their values from the JSON published to TopicState.
+type SensorConfig struct {
+	Name              string `json:"name"`
+	UniqueID          string `json:"unique_id"`
+	StateTopic        string `json:"state_topic"`
+	ValueTemplate     string `json:"value_template"`
+	UnitOfMeasurement string `json:"unit_of_measurement,omitempty"`
+	StateClass        string `json:"state_class,omitempty"`
+	Icon              string `json:"icon,omitempty"`
+	Device            Device `json:"device"`
+}
+
+// sensor describes one of the published sensors.
+type sensor struct {
+	key        string
+	name       string
+	unit       string
+	stateClass string
+	icon       string
+}
+
+// sensors are the sensors announced to Home Assistant, keyed like the JSON
+// fields of Stats.
+var sensors = []sensor{
+	{TopicLevel, "Level", "", "measurement", "mdi:star-circle"},
+	{TopicTotalXP, "Total XP", "XP", "total_increasing", "mdi:counter"},
+	{TopicXPToday, "XP Today", "XP", "measurement", "mdi:keyboard"},
+	{TopicStreak, "Streak", "days", "measurement", "mdi:fire"},
+}
+
+// WithHomeAssistant announces the stats as Home Assistant sensors through MQTT
+// discovery below the given prefix, usually DefaultDiscoveryPrefix. The configs
+// are published before the first stats.
+func WithHomeAssistant(discoveryPrefix string) Option {
+	return func(p *Publisher) {
+		p.discovery = strings.Trim(discoveryPrefix, "/")
+	}
+}
+
+// DiscoveryConfigs returns the Home Assistant sensor configs of the user keyed
+// by their discovery topic, e.g. homeassistant/sensor/godestats_alice/level/config.
+func (p *Publisher) DiscoveryConfigs(discoveryPrefix string) map[string]SensorConfig {
+	node := objectID("godestats_" + p.username)
+	device := Device{
+		Identifiers:  []string{node},
+		Name:         "Code::Stats " + p.username,
+		Manufacturer: "Code::Stats",
+		Model:        "gode-stats",
+	}
+
+	configs := make(map[string]SensorConfig, len(sensors))
+	for _, s := range sensors {
+		topic := strings.Trim(discoveryPrefix, "/") + "/sensor/" + node + "/" + s.key + "/config"
+		configs[topic] = SensorConfig{
+			Name:              s.name,
+			UniqueID:          node + "_" + s.key,
+			StateTopic:        p.Topic(TopicState),
+			ValueTemplate:     "{{ value_json." + s.key + " }}",
+			UnitOfMeasurement: s.unit,
+			StateClass:        s.stateClass,
+			Icon:              s.icon,
+			Device:            device,
+		}
+	}
+	return configs
+}
+
+// PublishDiscovery publishes the Home Assistant sensor configs as retained
+// messages, so the sensors appear without any configuration in Home Assistant.
+func (p *Publisher) PublishDiscovery(ctx context.Context, discoveryPrefix string) error {
+	var errs []error
+	for topic, config := range p.DiscoveryConfigs(discoveryPrefix) {
+		payload, err := json.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("failed to serialize discovery config: %w", err)
+		}
+		if err := p.broker.Publish(ctx, topic, payload, true); err != nil {
+			errs = append(errs, fmt.Errorf("publishing %s: %w", topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// announce publishes the discovery configs once if enabled.
+func (p *Publisher) announce(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery == "" || p.announced {
+		return nil
+	}
+	if err := p.PublishDiscovery(ctx, p.discovery); err != nil {
+		return err
+	}
+	p.announced = true
+	return nil
+}
+
+// objectID replaces characters Home Assistant doesn't allow in node and object IDs.
+func objectID(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,82 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPublisher_DiscoveryConfigs(t *testing.T) {
+	p := NewPublisher(&fakeBroker{}, &fakeClient{}, "Alice.Dev")
+
+	configs := p.DiscoveryConfigs("homeassistant/")
+	if len(configs) != 4 {
+		t.Fatalf("Expected 4 sensors, got %d", len(configs))
+	}
+
+	config, ok := configs["homeassistant/sensor/godestats_alice_dev/xp_today/config"]
+	if !ok {
+		t.Fatalf("Expected a sanitized discovery topic, got %v", configs)
+	}
+	if config.StateTopic != "godestats/Alice.Dev/state" || config.ValueTemplate != "{{ value_json.xp_today }}" {
+		t.Errorf("Expected the sensor to read the state topic, got %+v", config)
+	}
+	if config.UniqueID != "godestats_alice_dev_xp_today" || config.Device.Identifiers[0] != "godestats_alice_dev" {
+		t.Errorf("Expected unique IDs of the user's device, got %+v", config)
+	}
+	if config.UnitOfMeasurement != "XP" {
+		t.Errorf("Expected XP as unit, got %q", config.UnitOfMeasurement)
+	}
+}
+
+func TestPublisher_WithHomeAssistant(t *testing.T) {
+	broker := &fakeBroker{}
+	p := NewPublisher(broker, &fakeClient{}, "alice", WithHomeAssistant(DefaultDiscoveryPrefix))
+	p.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 2 {
+		if err := p.Publish(ctx, testProfile()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if broker.count != 4+2*5 {
+		t.Errorf("Expected the configs to be published once, got %d messages", broker.count)
+	}
+
+	var config SensorConfig
+	if err := json.Unmarshal([]byte(broker.message("homeassistant/sensor/godestats_alice/streak/config")), &config); err != nil {
+		t.Fatalf("Expected a JSON config, got %v", err)
+	}
+	if config.Name != "Streak" || config.Device.Name != "Code::Stats alice" {
+		t.Errorf("Expected the streak sensor, got %+v", config)
+	}
+
+	var state map[string]any
+	if err := json.Unmarshal([]byte(broker.message(config.StateTopic)), &state); err != nil {
+		t.Fatalf("Expected a JSON state, got %v", err)
+	}
+	if state["streak"] != 3.0 {
+		t.Errorf("Expected the streak in the state, got %v", state)
+	}
+}
+
+func TestPublisher_WithHomeAssistantRetries(t *testing.T) {
+	broker := &fakeBroker{err: errors.New("not connected")}
+	p := NewPublisher(broker, &fakeClient{}, "alice", WithHomeAssistant(DefaultDiscoveryPrefix))
+	ctx := context.Background()
+
+	if err := p.Publish(ctx, testProfile()); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	broker.err = nil
+	if err := p.Publish(ctx, testProfile()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if broker.message("homeassistant/sensor/godestats_alice/level/config") == "" {
+		t.Error("Expected the configs to be published after reconnecting")
+	}
+}
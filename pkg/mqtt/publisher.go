@@ -0,0 +1,239 @@
+// Package mqtt publishes a user's level, daily XP and streak to MQTT topics, so
+// home-automation dashboards can display coding stats. Stats are published on a
+// schedule with Run, or whenever a watcher detects a change with Handler.
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// Defaults of the publisher
+const (
+	// DefaultTopicPrefix is the first level of all published topics.
+	DefaultTopicPrefix = "godestats"
+
+	// DefaultInterval is how often Run publishes the stats.
+	DefaultInterval = 5 * time.Minute
+)
+
+// Topics below <prefix>/<user> that stats are published to
+const (
+	TopicLevel   = "level"
+	TopicTotalXP = "total_xp"
+	TopicXPToday = "xp_today"
+	TopicStreak  = "streak"
+
+	// TopicState holds all stats as a JSON object.
+	TopicState = "state"
+)
+
+// Broker publishes messages to an MQTT broker. PahoBroker implements it.
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte, retain bool) error
+}
+
+// Stats are the values published for a user.
+type Stats struct {
+	User      string       `json:"user"`
+	Level     int          `json:"level"`
+	TotalXP   godestats.XP `json:"total_xp"`
+	XPToday   godestats.XP `json:"xp_today"`
+	Streak    int          `json:"streak"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// StatsFor computes the stats of a profile at the given time. Days are taken in
+// the location of now. A nil calculator uses the standard Code::Stats formula.
+func StatsFor(profile *godestats.UserProfile, calc godestats.XpCalculator, now time.Time) Stats {
+	if calc == nil {
+		calc = xp.NewCalculator()
+	}
+	return Stats{
+		User:      profile.User,
+		Level:     calc.GetLevel(profile.TotalXP),
+		TotalXP:   profile.TotalXP,
+		XPToday:   profile.XPOn(now),
+		Streak:    profile.Streaks(now).Current,
+		UpdatedAt: now,
+	}
+}
+
+// Option configures a Publisher.
+type Option func(*Publisher)
+
+// WithTopicPrefix sets the first levels of the published topics.
+func WithTopicPrefix(prefix string) Option {
+	return func(p *Publisher) {
+		p.prefix = strings.Trim(prefix, "/")
+	}
+}
+
+// WithInterval sets how often Run publishes the stats.
+func WithInterval(interval time.Duration) Option {
+	return func(p *Publisher) {
+		if interval > 0 {
+			p.interval = interval
+		}
+	}
+}
+
+// WithCalculator sets the calculator used for levels.
+func WithCalculator(calc godestats.XpCalculator) Option {
+	return func(p *Publisher) {
+		p.calc = calc
+	}
+}
+
+// WithErrorHandler sets a function that is called for every failed publication
+// while running or handling events. Errors are ignored by default.
+func WithErrorHandler(handler func(error)) Option {
+	return func(p *Publisher) {
+		p.onError = handler
+	}
+}
+
+// Publisher publishes the stats of a user as retained messages, one topic per value
+// below <prefix>/<user>, plus all values as JSON in <prefix>/<user>/state.
+type Publisher struct {
+	broker   Broker
+	client   godestats.ProfileClient
+	username string
+	prefix   string
+	interval time.Duration
+	calc     godestats.XpCalculator
+	onError  func(error)
+	now      func() time.Time
+
+	// discovery is the Home Assistant discovery prefix, empty if disabled
+	discovery string
+
+	mu        sync.Mutex
+	last      *godestats.UserProfile
+	announced bool
+}
+
+// NewPublisher creates a publisher for the user's stats, fetching the profile
+// through the client when publishing on a schedule.
+func NewPublisher(broker Broker, client godestats.ProfileClient, username string, opts ...Option) *Publisher {
+	p := &Publisher{
+		broker:   broker,
+		client:   client,
+		username: username,
+		prefix:   DefaultTopicPrefix,
+		interval: DefaultInterval,
+		calc:     xp.NewCalculator(),
+		onError:  func(error) {},
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Topic returns the full topic of a value, e.g. godestats/alice/level.
+func (p *Publisher) Topic(name string) string {
+	if p.prefix == "" {
+		return p.username + "/" + name
+	}
+	return p.prefix + "/" + p.username + "/" + name
+}
+
+// Publish publishes the stats of the profile. All values are attempted even if
+// some fail; the returned error joins the failures. With WithHomeAssistant, the
+// discovery configs are published first until that succeeds once.
+func (p *Publisher) Publish(ctx context.Context, profile *godestats.UserProfile) error {
+	if err := p.announce(ctx); err != nil {
+		return err
+	}
+
+	stats := StatsFor(profile, p.calc, p.now())
+
+	state, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to serialize stats: %w", err)
+	}
+
+	messages := []struct {
+		topic   string
+		payload string
+	}{
+		{TopicLevel, strconv.Itoa(stats.Level)},
+		{TopicTotalXP, strconv.FormatInt(int64(stats.TotalXP), 10)},
+		{TopicXPToday, strconv.FormatInt(int64(stats.XPToday), 10)},
+		{TopicStreak, strconv.Itoa(stats.Streak)},
+		{TopicState, string(state)},
+	}
+
+	var errs []error
+	for _, m := range messages {
+		if err := p.broker.Publish(ctx, p.Topic(m.topic), []byte(m.payload), true); err != nil {
+			errs = append(errs, fmt.Errorf("publishing %s: %w", p.Topic(m.topic), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishNow fetches the profile and publishes its stats.
+func (p *Publisher) PublishNow(ctx context.Context) error {
+	profile, err := p.client.GetUserProfile(ctx, p.username)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, profile)
+}
+
+// Run publishes the stats immediately and then once per interval until the
+// context is cancelled, returning its error. Failures are passed to the error handler.
+func (p *Publisher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.PublishNow(ctx); err != nil && ctx.Err() == nil {
+			p.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Handler returns a bus handler that publishes the stats of the profile an event
+// was detected in. Events without a profile, of other users or of a profile that
+// was already published, such as further events of the same poll, are skipped.
+func (p *Publisher) Handler(ctx context.Context) watch.Handler {
+	return func(e watch.Event) {
+		if e.After == nil || !strings.EqualFold(e.User, p.username) {
+			return
+		}
+
+		p.mu.Lock()
+		published := p.last == e.After
+		p.last = e.After
+		p.mu.Unlock()
+
+		if published {
+			return
+		}
+		if err := p.Publish(ctx, e.After); err != nil {
+			p.onError(err)
+		}
+	}
+}
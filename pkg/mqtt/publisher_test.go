@@ -0,0 +1,144 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+// fakeBroker records the retained message of every topic.
+type fakeBroker struct {
+	mu       sync.Mutex
+	messages map[string]string
+	count    int
+	err      error
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, topic string, payload []byte, retain bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+	if !retain {
+		return errors.New("expected retained message")
+	}
+	if b.messages == nil {
+		b.messages = make(map[string]string)
+	}
+	b.messages[topic] = string(payload)
+	b.count++
+	return nil
+}
+
+func (b *fakeBroker) message(topic string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.messages[topic]
+}
+
+// fakeClient returns a fixed profile.
+type fakeClient struct {
+	profile *godestats.UserProfile
+}
+
+func (c *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return c.profile, nil
+}
+
+func (c *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+// now is the fixed time of the tests.
+var now = time.Date(2023, 6, 15, 20, 0, 0, 0, time.UTC)
+
+func testProfile() *godestats.UserProfile {
+	return &godestats.UserProfile{
+		User:    "alice",
+		TotalXP: 7000,
+		Dates:   map[string]godestats.XP{"2023-06-13": 100, "2023-06-14": 200, "2023-06-15": 300},
+	}
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	broker := &fakeBroker{}
+	p := NewPublisher(broker, &fakeClient{}, "alice", WithTopicPrefix("home/coding/"))
+	p.now = func() time.Time { return now }
+
+	if err := p.Publish(context.Background(), testProfile()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"home/coding/alice/level":    "2",
+		"home/coding/alice/total_xp": "7000",
+		"home/coding/alice/xp_today": "300",
+		"home/coding/alice/streak":   "3",
+	}
+	for topic, payload := range expected {
+		if got := broker.message(topic); got != payload {
+			t.Errorf("Expected %s on %s, got %q", payload, topic, got)
+		}
+	}
+
+	var state Stats
+	if err := json.Unmarshal([]byte(broker.message("home/coding/alice/state")), &state); err != nil {
+		t.Fatalf("Expected JSON state, got %v", err)
+	}
+	if state.Level != 2 || state.XPToday != 300 || state.Streak != 3 || !state.UpdatedAt.Equal(now) {
+		t.Errorf("Expected all stats in the state, got %+v", state)
+	}
+}
+
+func TestPublisher_PublishErrors(t *testing.T) {
+	broker := &fakeBroker{err: errors.New("not connected")}
+	p := NewPublisher(broker, &fakeClient{}, "alice")
+
+	err := p.Publish(context.Background(), testProfile())
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if !strings.HasPrefix(err.Error(), "publishing godestats/alice/level") || strings.Count(err.Error(), "not connected") != 5 {
+		t.Errorf("Expected the failures of all topics, got %v", err)
+	}
+}
+
+func TestPublisher_Run(t *testing.T) {
+	broker := &fakeBroker{}
+	p := NewPublisher(broker, &fakeClient{profile: testProfile()}, "alice", WithInterval(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	if err := p.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if broker.message("godestats/alice/total_xp") != "7000" || broker.count <= 5 {
+		t.Errorf("Expected repeated publications, got %d messages", broker.count)
+	}
+}
+
+func TestPublisher_Handler(t *testing.T) {
+	broker := &fakeBroker{}
+	p := NewPublisher(broker, &fakeClient{}, "alice")
+	handle := p.Handler(context.Background())
+
+	after := testProfile()
+	handle(watch.Event{Kind: watch.EventXPGained, User: "alice", After: after})
+	handle(watch.Event{Kind: watch.EventLevelUp, User: "alice", After: after})
+	handle(watch.Event{Kind: watch.EventXPGained, User: "bob", After: &godestats.UserProfile{User: "bob"}})
+	handle(watch.Event{Kind: watch.EventXPGained, User: "alice"})
+
+	if broker.count != 5 {
+		t.Errorf("Expected one publication of 5 topics for the poll, got %d messages", broker.count)
+	}
+}
@@ -0,0 +1,104 @@
+package godestats
+
+import (
+	"strings"
+
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+// LanguageAliases maps lowercase alternative language names reported by some
+// editor plugins to the canonical Code::Stats language name.
+var LanguageAliases = map[string]string{
+	"golang":     "Go",
+	"js":         "JavaScript",
+	"javascript": "JavaScript",
+	"ts":         "TypeScript",
+	"typescript": "TypeScript",
+	"py":         "Python",
+	"python3":    "Python",
+	"rb":         "Ruby",
+	"rs":         "Rust",
+	"cpp":        "C++",
+	"c#":         "C#",
+	"csharp":     "C#",
+	"sh":         "Shell",
+	"bash":       "Shell",
+	"shell":      "Shell",
+	"yml":        "YAML",
+	"yaml":       "YAML",
+	"md":         "Markdown",
+	"markdown":   "Markdown",
+}
+
+// CanonicalLanguage returns the canonical name for a language as reported by a plugin.
+// Surrounding whitespace is removed, and known aliases and the names of the language
+// registry (see package languages) are resolved case-insensitively; unknown names
+// are returned trimmed but otherwise unchanged.
+func CanonicalLanguage(name string) string {
+	name = strings.TrimSpace(name)
+	if canonical, ok := LanguageAliases[strings.ToLower(name)]; ok {
+		return canonical
+	}
+	if lang, ok := languages.Lookup(name); ok {
+		return lang.Name
+	}
+	return name
+}
+
+// Normalize merges language entries that differ only in case or whitespace or that
+// are aliases of each other, and recomputes the profile totals from the languages.
+// When variants are merged without a known alias or registry name, the spelling with
+// the most XP wins.
+func Normalize(profile *UserProfile) {
+	if profile == nil || len(profile.Languages) == 0 {
+		return
+	}
+
+	type group struct {
+		name    string
+		nameXPs XP
+		aliased bool
+		info    LanguageInfo
+	}
+
+	groups := make(map[string]*group)
+	for name, info := range profile.Languages {
+		canonical := CanonicalLanguage(name)
+		key := strings.ToLower(canonical)
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+		}
+
+		g.info.XPs += info.XPs
+		g.info.NewXPs += info.NewXPs
+
+		// Prefer the alias or registry spelling, then the variant with the most XP
+		_, aliased := LanguageAliases[strings.ToLower(strings.TrimSpace(name))]
+		if !aliased {
+			_, aliased = languages.Lookup(name)
+		}
+		switch {
+		case aliased:
+			g.name, g.aliased = canonical, true
+		case g.aliased:
+			// Keep the alias spelling
+		case g.name == "" || info.XPs > g.nameXPs || (info.XPs == g.nameXPs && canonical < g.name):
+			g.name, g.nameXPs = canonical, info.XPs
+		}
+	}
+
+	languages := make(map[string]LanguageInfo, len(groups))
+	var totalXP, newXP XP
+	for _, g := range groups {
+		languages[g.name] = g.info
+		totalXP += g.info.XPs
+		newXP += g.info.NewXPs
+	}
+
+	profile.Languages = languages
+	profile.TotalXP = totalXP
+	profile.NewXP = newXP
+}
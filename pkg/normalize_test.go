@@ -0,0 +1,86 @@
+package godestats
+
+import (
+	"testing"
+
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+func TestCanonicalLanguage(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Go", "Go"},
+		{"  golang ", "Go"},
+		{"JS", "JavaScript"},
+		{"Elixir", "Elixir"},
+		{" Elixir\t", "Elixir"},
+		{"c++", "C++"},
+		{"My DSL", "My DSL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := CanonicalLanguage(tt.input); result != tt.expected {
+				t.Errorf("CanonicalLanguage(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanonicalLanguage_Registry(t *testing.T) {
+	// Detected languages are reported under their registry names, so those must
+	// agree with the aliases
+	for _, lang := range languages.All() {
+		if canonical := CanonicalLanguage(lang.Name); canonical != lang.Name {
+			t.Errorf("Expected registry name %q to be canonical, got %q", lang.Name, canonical)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	profile := &UserProfile{
+		TotalXP: 9999,
+		NewXP:   9999,
+		Languages: map[string]LanguageInfo{
+			"Go":      {XPs: 100, NewXPs: 10},
+			"go":      {XPs: 50, NewXPs: 5},
+			"golang ": {XPs: 25, NewXPs: 0},
+			"elixir":  {XPs: 10, NewXPs: 1},
+			"Elixir ": {XPs: 40, NewXPs: 2},
+			"Rust":    {XPs: 75, NewXPs: 0},
+		},
+	}
+
+	Normalize(profile)
+
+	if len(profile.Languages) != 3 {
+		t.Fatalf("Expected 3 languages, got %d: %v", len(profile.Languages), profile.Languages)
+	}
+
+	if info := profile.Languages["Go"]; info.XPs != 175 || info.NewXPs != 15 {
+		t.Errorf("Expected Go to have 175/15 XP, got %+v", info)
+	}
+
+	if info := profile.Languages["Elixir"]; info.XPs != 50 || info.NewXPs != 3 {
+		t.Errorf("Expected Elixir to have 50/3 XP, got %+v", info)
+	}
+
+	if profile.TotalXP != 300 {
+		t.Errorf("Expected total XP 300, got %d", profile.TotalXP)
+	}
+	if profile.NewXP != 18 {
+		t.Errorf("Expected new XP 18, got %d", profile.NewXP)
+	}
+}
+
+func TestNormalize_EmptyProfile(t *testing.T) {
+	Normalize(nil)
+
+	profile := &UserProfile{TotalXP: 100}
+	Normalize(profile)
+	if profile.TotalXP != 100 {
+		t.Errorf("Expected totals to be kept without language data, got %d", profile.TotalXP)
+	}
+}
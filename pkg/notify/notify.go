@@ -0,0 +1,242 @@
+// Package notify sends watcher events as chat messages to Discord and Slack, or as
+// JSON to generic webhook endpoints.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/client"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+// DefaultTimeout is the time a background notification may take, see Handler.
+const DefaultTimeout = 10 * time.Second
+
+// EventTest is the kind of the event sent by Test. Watchers never emit it.
+const EventTest watch.EventKind = "test"
+
+// Errors returned by sinks
+var (
+	// ErrUnknownKind is returned for a sink kind other than Discord, Slack or Webhook.
+	ErrUnknownKind = errors.New("unknown notification kind")
+
+	// ErrInvalidURL is returned when a sink URL is not an absolute HTTP(S) URL.
+	ErrInvalidURL = errors.New("invalid notification URL")
+
+	// ErrNotificationFailed is returned when an endpoint did not accept a notification.
+	ErrNotificationFailed = errors.New("notification failed")
+)
+
+// Kind identifies the format a sink posts events in.
+type Kind string
+
+// Kinds of sinks
+const (
+	// Discord posts a message to a Discord webhook.
+	Discord Kind = "discord"
+
+	// Slack posts a message to a Slack incoming webhook.
+	Slack Kind = "slack"
+
+	// Webhook posts the message together with the event as JSON.
+	Webhook Kind = "webhook"
+)
+
+// Kinds lists all kinds of sinks.
+var Kinds = []Kind{Discord, Slack, Webhook}
+
+// ParseKind parses the name of a sink kind, ignoring case.
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if strings.EqualFold(s, string(kind)) {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownKind, s)
+}
+
+// Sink delivers events to a notification channel.
+type Sink interface {
+	Notify(ctx context.Context, e watch.Event) error
+}
+
+// HTTPSink posts events to an HTTP endpoint in the format of its kind.
+type HTTPSink struct {
+	kind       Kind
+	url        string
+	httpClient *http.Client
+}
+
+// Option configures an HTTPSink.
+type Option func(*HTTPSink)
+
+// WithHTTPClient sets the HTTP client used to post notifications.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(s *HTTPSink) {
+		s.httpClient = httpClient
+	}
+}
+
+// New creates a sink posting events of the given kind to the URL.
+func New(kind Kind, rawURL string, opts ...Option) (*HTTPSink, error) {
+	if _, err := ParseKind(string(kind)); err != nil {
+		return nil, err
+	}
+	if err := ValidateURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	s := &HTTPSink{
+		kind:       kind,
+		url:        rawURL,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// ValidateURL checks that the URL is an absolute HTTP(S) URL.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidURL, rawURL)
+	}
+	return nil
+}
+
+// Kind returns the kind of the sink.
+func (s *HTTPSink) Kind() Kind {
+	return s.kind
+}
+
+// webhookPayload is the body posted by Webhook sinks.
+type webhookPayload struct {
+	Message string      `json:"message"`
+	Event   watch.Event `json:"event"`
+}
+
+// Notify posts the event to the endpoint.
+func (s *HTTPSink) Notify(ctx context.Context, e watch.Event) error {
+	var payload any
+	switch s.kind {
+	case Discord:
+		payload = map[string]string{"content": Message(e)}
+	case Slack:
+		payload = map[string]string{"text": Message(e)}
+	default:
+		payload = webhookPayload{Message: Message(e), Event: e}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return godestats.NewNetworkError("POST request", s.url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s %s: unexpected status %d", ErrNotificationFailed, s.kind, redact(s.url), resp.StatusCode)
+	}
+	return nil
+}
+
+// redact strips the path of a URL for error messages, since webhook URLs of chat
+// services embed their secret in the path.
+func redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid URL>"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// Handler returns a bus handler that sends every event to the sink in the background,
+// so that slow endpoints don't delay the watcher. Failed notifications are passed to
+// onError, which may be nil. Notifications still pending when ctx is canceled are aborted.
+func Handler(ctx context.Context, sink Sink, onError func(error)) watch.Handler {
+	return func(e watch.Event) {
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+			defer cancel()
+
+			if err := sink.Notify(ctx, e); err != nil && onError != nil {
+				onError(err)
+			}
+		}()
+	}
+}
+
+// Test sends a test event for the user to the sink.
+func Test(ctx context.Context, sink Sink, user string) error {
+	return sink.Notify(ctx, watch.Event{Kind: EventTest, User: user, At: time.Now()})
+}
+
+// Message describes the event in a short sentence.
+func Message(e watch.Event) string {
+	switch e.Kind {
+	case EventTest:
+		return fmt.Sprintf("Test notification for %s from godestats", e.User)
+	case watch.EventXPGained:
+		return fmt.Sprintf("%s gained %d XP, now at %s XP", e.User, e.Delta(), e.NewXP.Short())
+	case watch.EventLevelUp:
+		return fmt.Sprintf("%s reached level %d", e.User, e.NewLevel)
+	case watch.EventLanguageLevelUp:
+		return fmt.Sprintf("%s reached level %d in %s", e.User, e.NewLevel, e.Language)
+	case watch.EventNewLanguage:
+		return fmt.Sprintf("%s started coding in %s", e.User, e.Language)
+	case watch.EventNewMachine:
+		return fmt.Sprintf("%s started coding on %s", e.User, e.Machine)
+	case watch.EventStreakExtended:
+		return fmt.Sprintf("%s extended their streak to %s", e.User, days(e.NewStreak))
+	case watch.EventStreakBroken:
+		return fmt.Sprintf("%s's streak of %s ended", e.User, days(e.OldStreak))
+	case watch.EventGoalReached:
+		if e.Goal == nil {
+			return fmt.Sprintf("%s reached a goal", e.User)
+		}
+		target := fmt.Sprintf("%s XP", e.Goal.XP.Short())
+		if e.Goal.Language != "" {
+			target += " in " + e.Goal.Language
+		}
+		return fmt.Sprintf("%s reached the goal %s of %s", e.User, e.Goal.Name, target)
+	case watch.EventTokenInvalid:
+		if e.User == "" {
+			return "The Code::Stats API token was rejected, no XP is recorded until it is replaced"
+		}
+		return fmt.Sprintf("%s's Code::Stats API token was rejected, no XP is recorded until it is replaced", e.User)
+	default:
+		return fmt.Sprintf("%s: %s", e.User, e.Kind)
+	}
+}
+
+// days formats a number of days.
+func days(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+func TestParseKind(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Kind
+		err      error
+	}{
+		{"discord", Discord, nil},
+		{"Slack", Slack, nil},
+		{"WEBHOOK", Webhook, nil},
+		{"teams", "", ErrUnknownKind},
+	}
+
+	for _, tt := range tests {
+		kind, err := ParseKind(tt.input)
+		if kind != tt.expected || !errors.Is(err, tt.err) {
+			t.Errorf("ParseKind(%q): expected %q (err %v), got %q (err %v)", tt.input, tt.expected, tt.err, kind, err)
+		}
+	}
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	for _, rawURL := range []string{"", "discord.com/api/webhooks/1", "ftp://example.com", "https://"} {
+		if _, err := New(Discord, rawURL); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("Expected ErrInvalidURL for %q, got %v", rawURL, err)
+		}
+	}
+
+	if _, err := New("teams", "https://example.com"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("Expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func TestHTTPSink_Notify(t *testing.T) {
+	event := watch.Event{Kind: watch.EventLevelUp, User: "alice", OldLevel: 1, NewLevel: 2, OldXP: 6000, NewXP: 6500}
+
+	tests := []struct {
+		kind     Kind
+		expected string
+	}{
+		{Discord, `{"content":"alice reached level 2"}`},
+		{Slack, `{"text":"alice reached level 2"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("Expected JSON POST, got %s %s", r.Method, r.Header.Get("Content-Type"))
+				}
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			sink, err := New(tt.kind, server.URL)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := sink.Notify(context.Background(), event); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if body != tt.expected {
+				t.Errorf("Expected body %s, got %s", tt.expected, body)
+			}
+		})
+	}
+}
+
+func TestHTTPSink_NotifyWebhook(t *testing.T) {
+	var payload struct {
+		Message string      `json:"message"`
+		Event   watch.Event `json:"event"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	sink, _ := New(Webhook, server.URL)
+	event := watch.Event{Kind: watch.EventNewLanguage, User: "alice", Language: "Rust", NewXP: 50}
+	if err := sink.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if payload.Message != "alice started coding in Rust" {
+		t.Errorf("Expected message, got %q", payload.Message)
+	}
+	if payload.Event.Kind != watch.EventNewLanguage || payload.Event.Language != "Rust" || payload.Event.NewXP != 50 {
+		t.Errorf("Expected the event in the payload, got %+v", payload.Event)
+	}
+}
+
+func TestHTTPSink_NotifyFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sink, _ := New(Discord, server.URL+"/api/webhooks/1/secret")
+	err := Test(context.Background(), sink, "alice")
+	if !errors.Is(err, ErrNotificationFailed) {
+		t.Fatalf("Expected ErrNotificationFailed, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("Expected the webhook secret to be redacted, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		received <- body["text"]
+	}))
+	defer server.Close()
+
+	sink, _ := New(Slack, server.URL)
+	bus := watch.NewBus()
+	bus.Subscribe(watch.AllEvents, Handler(context.Background(), sink, func(err error) {
+		t.Errorf("Unexpected error: %v", err)
+	}))
+	bus.Publish(watch.Event{Kind: watch.EventStreakExtended, User: "alice", OldStreak: 4, NewStreak: 5})
+
+	select {
+	case text := <-received:
+		if text != "alice extended their streak to 5 days" {
+			t.Errorf("Expected streak message, got %q", text)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for notification")
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		event    watch.Event
+		expected string
+	}{
+		{watch.Event{Kind: EventTest, User: "alice"}, "Test notification for alice from godestats"},
+		{watch.Event{Kind: watch.EventXPGained, User: "alice", OldXP: 1400, NewXP: 1650}, "alice gained 250 XP, now at 1.6k XP"},
+		{watch.Event{Kind: watch.EventLanguageLevelUp, User: "alice", Language: "Go", NewLevel: 3}, "alice reached level 3 in Go"},
+		{watch.Event{Kind: watch.EventNewMachine, User: "alice", Machine: "laptop"}, "alice started coding on laptop"},
+		{watch.Event{Kind: watch.EventStreakBroken, User: "alice", OldStreak: 1}, "alice's streak of 1 day ended"},
+		{watch.Event{Kind: watch.EventGoalReached, User: "alice", Goal: &watch.Goal{Name: "rustacean", Language: "Rust", XP: 10000}}, "alice reached the goal rustacean of 10k XP in Rust"},
+		{watch.Event{Kind: watch.EventTokenInvalid, User: "alice"}, "alice's Code::Stats API token was rejected, no XP is recorded until it is replaced"},
+	}
+
+	for _, tt := range tests {
+		if got := Message(tt.event); got != tt.expected {
+			t.Errorf("Message(%s): expected %q, got %q", tt.event.Kind, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Package statsd emits metrics for watcher events and sent pulses to a StatsD or
+// DogStatsD endpoint over UDP, such as XP gained, levels and streaks.
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+// Defaults of the sink
+const (
+	// DefaultAddress is the address StatsD agents listen on by default.
+	DefaultAddress = "127.0.0.1:8125"
+
+	// DefaultPrefix is prepended to all metric names.
+	DefaultPrefix = "godestats."
+)
+
+// Names of the emitted metrics, without the prefix
+const (
+	// MetricXPGained counts the XP gained.
+	MetricXPGained = "xp_gained"
+
+	// MetricTotalXP is a gauge of the total XP.
+	MetricTotalXP = "total_xp"
+
+	// MetricLevel is a gauge of the overall level.
+	MetricLevel = "level"
+
+	// MetricLanguageLevel is a gauge of the level in a language.
+	MetricLanguageLevel = "language_level"
+
+	// MetricStreak is a gauge of the current streak in days.
+	MetricStreak = "streak"
+
+	// MetricEvents counts the watcher events by kind.
+	MetricEvents = "events"
+
+	// MetricPulsesSent counts the pulses accepted by the API.
+	MetricPulsesSent = "pulses_sent"
+
+	// MetricPulsesFailed counts the pulses that could not be sent.
+	MetricPulsesFailed = "pulses_failed"
+
+	// MetricPulseXP counts the XP of the sent pulses.
+	MetricPulseXP = "pulse_xp"
+)
+
+// metric is a single StatsD metric with its DogStatsD tags.
+type metric struct {
+	name  string
+	value int64
+	kind  string // "c" for counters, "g" for gauges
+	tags  []string
+}
+
+// Sink sends metrics to a StatsD endpoint. It implements notify.Sink and is safe
+// for concurrent use.
+type Sink struct {
+	conn      net.Conn
+	prefix    string
+	dogstatsd bool
+	tags      []string
+
+	mu sync.Mutex
+}
+
+// Option configures a Sink.
+type Option func(*Sink)
+
+// WithPrefix sets the prefix of all metric names, DefaultPrefix by default.
+func WithPrefix(prefix string) Option {
+	return func(s *Sink) {
+		s.prefix = prefix
+	}
+}
+
+// WithDogStatsD tags metrics with the user, language and event kind in
+// the DogStatsD format, and adds the given constant tags such as "env:home".
+// Plain StatsD has no tags, so the user is part of the metric names instead,
+// e.g. godestats.alice.xp_gained.
+func WithDogStatsD(tags ...string) Option {
+	return func(s *Sink) {
+		s.dogstatsd = true
+		s.tags = tags
+	}
+}
+
+// New creates a sink sending metrics to the UDP address, e.g. DefaultAddress.
+func New(addr string, opts ...Option) (*Sink, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to StatsD at %s: %w", addr, err)
+	}
+
+	s := &Sink{conn: conn, prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Close closes the connection of the sink.
+func (s *Sink) Close() error {
+	return s.conn.Close()
+}
+
+// Notify sends the metrics of a watcher event: the XP gained and total XP, levels,
+// streaks, and a count of the event kind.
+func (s *Sink) Notify(ctx context.Context, e watch.Event) error {
+	var metrics []metric
+	counter := func(name string, value int64, tags ...string) {
+		metrics = append(metrics, metric{name, value, "c", tags})
+	}
+	gauge := func(name string, value int64, tags ...string) {
+		metrics = append(metrics, metric{name, value, "g", tags})
+	}
+
+	switch e.Kind {
+	case watch.EventXPGained:
+		counter(MetricXPGained, int64(e.Delta()))
+		gauge(MetricTotalXP, int64(e.NewXP))
+	case watch.EventLevelUp:
+		gauge(MetricLevel, int64(e.NewLevel))
+	case watch.EventLanguageLevelUp, watch.EventNewLanguage:
+		gauge(MetricLanguageLevel, int64(e.NewLevel), "language:"+e.Language)
+	case watch.EventStreakExtended, watch.EventStreakBroken:
+		gauge(MetricStreak, int64(e.NewStreak))
+	}
+	counter(MetricEvents, 1, "kind:"+string(e.Kind))
+
+	return s.send(ctx, e.User, metrics)
+}
+
+// PulseSent records the outcome of sending a pulse for the user: the pulse and its
+// XP per language if err is nil, or a failed pulse otherwise.
+func (s *Sink) PulseSent(ctx context.Context, user string, pulse godestats.Pulse, err error) error {
+	if err != nil {
+		return s.send(ctx, user, []metric{{MetricPulsesFailed, 1, "c", nil}})
+	}
+
+	metrics := []metric{{MetricPulsesSent, 1, "c", nil}}
+	for _, x := range pulse.XPs {
+		metrics = append(metrics, metric{MetricPulseXP, int64(x.XP), "c", []string{"language:" + x.Language}})
+	}
+	return s.send(ctx, user, metrics)
+}
+
+// send writes the metrics in a single packet.
+func (s *Sink) send(ctx context.Context, user string, metrics []metric) error {
+	var b strings.Builder
+	for _, m := range metrics {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		s.format(&b, user, m)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	s.conn.SetWriteDeadline(deadline)
+	if _, err := s.conn.Write([]byte(b.String())); err != nil {
+		return fmt.Errorf("failed to send metrics: %w", err)
+	}
+	return nil
+}
+
+// format writes a metric line such as godestats.xp_gained:25|c|#user:alice.
+func (s *Sink) format(b *strings.Builder, user string, m metric) {
+	b.WriteString(s.prefix)
+
+	if !s.dogstatsd {
+		// Without tags, the user and the tag values become part of the name
+		if user != "" {
+			b.WriteString(sanitize(user) + ".")
+		}
+		b.WriteString(m.name)
+		for _, tag := range m.tags {
+			_, value, _ := strings.Cut(tag, ":")
+			b.WriteString("." + sanitize(value))
+		}
+		b.WriteString(":" + strconv.FormatInt(m.value, 10) + "|" + m.kind)
+		return
+	}
+
+	b.WriteString(m.name + ":" + strconv.FormatInt(m.value, 10) + "|" + m.kind)
+
+	tags := append([]string(nil), s.tags...)
+	if user != "" {
+		tags = append(tags, "user:"+user)
+	}
+	tags = append(tags, m.tags...)
+	for i, tag := range tags {
+		if i == 0 {
+			b.WriteString("|#")
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(sanitizeTag(tag))
+	}
+}
+
+// sanitize replaces characters that have a meaning in metric names.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ':', '|', '@', '#', ',', ' ', '\n':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+// sanitizeTag replaces characters that separate tags or metrics.
+func sanitizeTag(s string) string {
+	return strings.NewReplacer("|", "_", ",", "_", "\n", "_", "#", "_").Replace(s)
+}
+
+// Client is a CodeStatsClient decorator that records every sent pulse in a Sink.
+type Client struct {
+	inner godestats.CodeStatsClient
+	sink  *Sink
+	user  string
+}
+
+// NewClient wraps a client so that sent pulses are recorded for the user owning
+// the client's token.
+func NewClient(inner godestats.CodeStatsClient, sink *Sink, user string) *Client {
+	return &Client{inner: inner, sink: sink, user: user}
+}
+
+// GetUserProfile fetches the profile through the wrapped client.
+func (c *Client) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return c.inner.GetUserProfile(ctx, username, opts...)
+}
+
+// SendPulse submits the pulse through the wrapped client and records the outcome.
+// Failures to record metrics are ignored.
+func (c *Client) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	err := c.inner.SendPulse(ctx, pulse, opts...)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	_ = c.sink.PulseSent(ctx, c.user, pulse, err)
+
+	return err
+}
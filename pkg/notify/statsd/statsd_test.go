@@ -0,0 +1,135 @@
+package statsd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/notify"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+var _ notify.Sink = (*Sink)(nil)
+
+// listen starts a UDP listener standing in for a StatsD agent.
+func listen(t *testing.T) *net.UDPConn {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// receive reads the next packet.
+func receive(t *testing.T, conn *net.UDPConn) string {
+	t.Helper()
+
+	buf := make([]byte, 1500)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Expected a packet, got %v", err)
+	}
+	return string(buf[:n])
+}
+
+func TestSink_Notify(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		event    watch.Event
+		expected string
+	}{
+		{
+			"xp gained",
+			nil,
+			watch.Event{Kind: watch.EventXPGained, User: "alice", OldXP: 1000, NewXP: 1250},
+			"godestats.alice.xp_gained:250|c\ngodestats.alice.total_xp:1250|g\ngodestats.alice.events.xp_gained:1|c",
+		},
+		{
+			"language level with tags",
+			[]Option{WithDogStatsD("env:home")},
+			watch.Event{Kind: watch.EventLanguageLevelUp, User: "alice", Language: "Go", NewLevel: 3},
+			"godestats.language_level:3|g|#env:home,user:alice,language:Go\ngodestats.events:1|c|#env:home,user:alice,kind:language_level_up",
+		},
+		{
+			"language level in names",
+			nil,
+			watch.Event{Kind: watch.EventLanguageLevelUp, User: "Alice", Language: "C#", NewLevel: 3},
+			"godestats.alice.language_level.c_:3|g\ngodestats.alice.events.language_level_up:1|c",
+		},
+		{
+			"streak with prefix",
+			[]Option{WithPrefix("dev.")},
+			watch.Event{Kind: watch.EventStreakBroken, User: "alice", OldStreak: 4},
+			"dev.alice.streak:0|g\ndev.alice.events.streak_broken:1|c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := listen(t)
+			sink, err := New(agent.LocalAddr().String(), tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer sink.Close()
+
+			if err := sink.Notify(context.Background(), tt.event); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := receive(t, agent); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// pulseClient fails pulses without XP.
+type pulseClient struct{}
+
+func (pulseClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return nil, godestats.ErrUserNotFound
+}
+
+func (pulseClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	if len(pulse.XPs) == 0 {
+		return errors.New("empty pulse")
+	}
+	return nil
+}
+
+func TestClient_SendPulse(t *testing.T) {
+	agent := listen(t)
+	sink, err := New(agent.LocalAddr().String(), WithDogStatsD())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer sink.Close()
+
+	c := NewClient(pulseClient{}, sink, "alice")
+	ctx := context.Background()
+
+	pulse := godestats.Pulse{CodedAt: time.Now(), XPs: []godestats.LanguageXP{{Language: "Go", XP: 25}, {Language: "Rust", XP: 5}}}
+	if err := c.SendPulse(ctx, pulse); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "godestats.pulses_sent:1|c|#user:alice\ngodestats.pulse_xp:25|c|#user:alice,language:Go\ngodestats.pulse_xp:5|c|#user:alice,language:Rust"
+	if got := receive(t, agent); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if err := c.SendPulse(ctx, godestats.Pulse{CodedAt: time.Now()}); err == nil {
+		t.Fatal("Expected the client's error")
+	}
+	if got := receive(t, agent); !strings.HasPrefix(got, "godestats.pulses_failed:1|c") {
+		t.Errorf("Expected a failed pulse, got %q", got)
+	}
+}
@@ -0,0 +1,225 @@
+// Package overlayhttp serves a live stats overlay for streaming software such as
+// OBS: the current level, the XP gained during the stream and the last language,
+// as JSON and as a transparent HTML page that refreshes itself.
+package overlayhttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// DefaultRefresh is how often the HTML overlay fetches the state.
+const DefaultRefresh = 2 * time.Second
+
+// State is the state shown by the overlay.
+type State struct {
+	User            string       `json:"user"`
+	Level           int          `json:"level"`
+	LevelPercentage float64      `json:"level_percentage"`
+	TotalXP         godestats.XP `json:"total_xp"`
+	StreamXP        godestats.XP `json:"stream_xp"`
+	LastLanguage    string       `json:"last_language,omitempty"`
+	StartedAt       time.Time    `json:"started_at"`
+	UpdatedAt       time.Time    `json:"updated_at,omitzero"`
+}
+
+// Option configures an Overlay.
+type Option func(*Overlay)
+
+// WithCalculator sets the calculator used for levels.
+func WithCalculator(calc godestats.XpCalculator) Option {
+	return func(o *Overlay) {
+		o.calc = calc
+	}
+}
+
+// WithRefresh sets how often the HTML overlay fetches the state.
+func WithRefresh(refresh time.Duration) Option {
+	return func(o *Overlay) {
+		if refresh > 0 {
+			o.refresh = refresh
+		}
+	}
+}
+
+// Overlay keeps the stream state of a user up to date from watcher events and
+// serves it over HTTP:
+//
+//   - GET / serves the HTML overlay, to be added as a browser source.
+//   - GET /overlay.json serves the State.
+type Overlay struct {
+	username string
+	calc     godestats.XpCalculator
+	refresh  time.Duration
+	now      func() time.Time
+	mux      *http.ServeMux
+
+	mu       sync.Mutex
+	state    State
+	baseline godestats.XP
+	started  bool
+}
+
+// New creates an overlay for the user. The stream starts with the first observed
+// profile, or explicitly with Reset.
+func New(username string, opts ...Option) *Overlay {
+	o := &Overlay{
+		username: username,
+		calc:     xp.NewCalculator(),
+		refresh:  DefaultRefresh,
+		now:      time.Now,
+		mux:      http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	o.state = State{User: username, StartedAt: o.now()}
+	o.mux.HandleFunc("GET /{$}", o.serveHTML)
+	o.mux.HandleFunc("GET /overlay.json", o.serveJSON)
+	return o
+}
+
+// Reset starts a new stream at the profile, so that only XP gained from now on
+// counts as stream XP. A nil profile starts it at the last observed total XP.
+func (o *Overlay) Reset(profile *godestats.UserProfile) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.state.StartedAt = o.now()
+	o.state.StreamXP = 0
+	o.state.LastLanguage = ""
+	if profile != nil {
+		o.update(profile)
+	}
+	o.baseline = o.state.TotalXP
+	o.started = true
+}
+
+// Observe updates the state from a watcher event; pass it to Bus.Subscribe with
+// watch.AllEvents. Events of other users and without profiles are ignored.
+func (o *Overlay) Observe(e watch.Event) {
+	if e.After == nil || !strings.EqualFold(e.User, o.username) {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.started {
+		start := e.After
+		if e.Before != nil {
+			start = e.Before
+		}
+		o.baseline = start.TotalXP
+		o.started = true
+	}
+
+	if language := topGain(e.Before, e.After); language != "" {
+		o.state.LastLanguage = language
+	}
+	o.update(e.After)
+}
+
+// update sets the state from the profile. The lock must be held.
+func (o *Overlay) update(profile *godestats.UserProfile) {
+	o.state.Level = o.calc.GetLevel(profile.TotalXP)
+	o.state.LevelPercentage = o.calc.GetLevelPercentage(profile.TotalXP)
+	o.state.TotalXP = profile.TotalXP
+	o.state.StreamXP = max(profile.TotalXP-o.baseline, 0)
+	o.state.UpdatedAt = o.now()
+}
+
+// topGain returns the language that gained the most XP between the profiles.
+func topGain(before, after *godestats.UserProfile) string {
+	if before == nil {
+		return ""
+	}
+
+	var language string
+	var best godestats.XP
+	for name, info := range after.Languages {
+		gain := info.XPs - before.Languages[name].XPs
+		if gain > best || (gain == best && gain > 0 && name < language) {
+			language, best = name, gain
+		}
+	}
+	return language
+}
+
+// State returns the current state.
+func (o *Overlay) State() State {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.state
+}
+
+// ServeHTTP implements http.Handler.
+func (o *Overlay) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mux.ServeHTTP(w, r)
+}
+
+// serveJSON writes the state as JSON.
+func (o *Overlay) serveJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(w).Encode(o.State())
+}
+
+// serveHTML writes the overlay page.
+func (o *Overlay) serveHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page.Execute(w, struct {
+		State     State
+		RefreshMS int64
+	}{o.State(), o.refresh.Milliseconds()})
+}
+
+// page is the overlay page. It is rendered with the current state and then keeps
+// itself up to date by polling overlay.json.
+var page = template.Must(template.New("overlay").Funcs(template.FuncMap{
+	"percent": func(f float64) float64 { return f * 100 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.State.User}} - Code::Stats</title>
+<style>
+  body { margin: 0; background: transparent; font: 600 28px/1.3 system-ui, sans-serif; color: #fff; text-shadow: 0 2px 4px #000; }
+  .overlay { display: inline-block; padding: 12px 20px; }
+  .bar { height: 6px; margin-top: 6px; background: rgba(255, 255, 255, .3); border-radius: 3px; }
+  .bar div { height: 100%; background: #4c9ee9; border-radius: 3px; }
+  .small { font-size: 20px; opacity: .85; }
+</style>
+</head>
+<body>
+<div class="overlay">
+  <div>Level <span id="level">{{.State.Level}}</span></div>
+  <div class="bar"><div id="progress" style="width: {{printf "%.0f" (.State.LevelPercentage | percent)}}%"></div></div>
+  <div class="small">+<span id="stream">{{.State.StreamXP}}</span> XP this stream</div>
+  <div class="small" id="language">{{.State.LastLanguage}}</div>
+</div>
+<script>
+setInterval(async () => {
+  try {
+    const state = await (await fetch("overlay.json", {cache: "no-store"})).json();
+    document.getElementById("level").textContent = state.level;
+    document.getElementById("progress").style.width = Math.round(state.level_percentage * 100) + "%";
+    document.getElementById("stream").textContent = state.stream_xp.toLocaleString();
+    document.getElementById("language").textContent = state.last_language || "";
+  } catch (e) {}
+}, {{.RefreshMS}});
+</script>
+</body>
+</html>
+`))
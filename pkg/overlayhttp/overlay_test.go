@@ -0,0 +1,102 @@
+package overlayhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/watch"
+)
+
+// profile returns a profile of alice with the given XP per language.
+func profile(languages map[string]godestats.XP) *godestats.UserProfile {
+	p := &godestats.UserProfile{User: "alice", Languages: map[string]godestats.LanguageInfo{}}
+	for name, xp := range languages {
+		p.Languages[name] = godestats.LanguageInfo{XPs: xp}
+		p.TotalXP += xp
+	}
+	return p
+}
+
+func TestOverlay_Observe(t *testing.T) {
+	o := New("alice")
+
+	first := profile(map[string]godestats.XP{"Go": 1000, "Rust": 500})
+	second := profile(map[string]godestats.XP{"Go": 1100, "Rust": 500})
+	third := profile(map[string]godestats.XP{"Go": 1100, "Rust": 900, "Zig": 20})
+
+	o.Observe(watch.Event{Kind: watch.EventXPGained, User: "alice", Before: first, After: second})
+	state := o.State()
+	if state.StreamXP != 100 || state.LastLanguage != "Go" || state.TotalXP != 1600 || state.Level != 1 {
+		t.Errorf("Expected 100 stream XP in Go, got %+v", state)
+	}
+
+	o.Observe(watch.Event{Kind: watch.EventXPGained, User: "alice", Before: second, After: third})
+	o.Observe(watch.Event{Kind: watch.EventXPGained, User: "bob", Before: third, After: profile(nil)})
+	state = o.State()
+	if state.StreamXP != 520 || state.LastLanguage != "Rust" || state.TotalXP != 2020 {
+		t.Errorf("Expected 520 stream XP in Rust, got %+v", state)
+	}
+
+	o.Reset(nil)
+	state = o.State()
+	if state.StreamXP != 0 || state.LastLanguage != "" || state.TotalXP != 2020 {
+		t.Errorf("Expected a new stream at the last total XP, got %+v", state)
+	}
+}
+
+func TestOverlay_Reset(t *testing.T) {
+	o := New("alice")
+	o.Reset(profile(map[string]godestats.XP{"Go": 1000}))
+
+	// The baseline is the reset profile, not the Before profile of the event
+	o.Observe(watch.Event{
+		Kind:   watch.EventXPGained,
+		User:   "alice",
+		Before: profile(map[string]godestats.XP{"Go": 1100}),
+		After:  profile(map[string]godestats.XP{"Go": 1200}),
+	})
+	if state := o.State(); state.StreamXP != 200 {
+		t.Errorf("Expected 200 stream XP, got %d", state.StreamXP)
+	}
+}
+
+func TestOverlay_ServeHTTP(t *testing.T) {
+	o := New("alice", WithRefresh(5*time.Second))
+	o.Reset(profile(map[string]godestats.XP{"Go": 4000}))
+
+	req := httptest.NewRequest(http.MethodGet, "/overlay.json", nil)
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, req)
+
+	var state State
+	if err := json.NewDecoder(rec.Body).Decode(&state); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if state.User != "alice" || state.Level != 1 || state.LevelPercentage <= 0 {
+		t.Errorf("Expected alice's state, got %+v", state)
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Expected the state not to be cached, got %q", rec.Header().Get("Cache-Control"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `<span id="level">1</span>`) || !strings.Contains(body, " 5000 ") {
+		t.Errorf("Expected the rendered page with the refresh interval, got %s", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/other", nil)
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", rec.Code)
+	}
+}
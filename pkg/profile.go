@@ -0,0 +1,122 @@
+package godestats
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DateFormat is the layout used by the Code::Stats API for the keys of the Dates map.
+const DateFormat = "2006-01-02"
+
+// DateXP represents the XP gained on a single calendar day.
+type DateXP struct {
+	Date Date `json:"date"`
+	XP   XP   `json:"xp"`
+}
+
+// DateSeries parses the keys of the Dates map and returns them sorted in ascending order.
+// Keys that cannot be parsed are skipped and reported in the returned error,
+// so the valid part of the series is always usable.
+//
+// DateXP.Date used to be the midnight of the day in tz and is now a Date, which
+// has no location. Locations other than UTC are therefore ignored and reported
+// as ErrLocationIgnored next to the parse errors; the series is complete
+// nonetheless. Use Date.Time to get the midnight of a date in a location.
+//
+// Deprecated: Use DailySeries.
+func (p *UserProfile) DateSeries(tz *time.Location) ([]DateXP, error) {
+	series, err := p.DailySeries()
+	if tz != nil && tz != time.UTC {
+		err = errors.Join(err, fmt.Errorf("%w: %s", ErrLocationIgnored, tz))
+	}
+	return series, err
+}
+
+// DailySeries parses the keys of the Dates map and returns them sorted in ascending order.
+// Keys that cannot be parsed are skipped and reported in the returned error,
+// so the valid part of the series is always usable.
+func (p *UserProfile) DailySeries() ([]DateXP, error) {
+	series := make([]DateXP, 0, len(p.Dates))
+	var errs []error
+	for key, xp := range p.Dates {
+		date, err := ParseDate(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid date key %q: %w", key, err))
+			continue
+		}
+		series = append(series, DateXP{Date: date, XP: xp})
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Date.Before(series[j].Date)
+	})
+
+	return series, errors.Join(errs...)
+}
+
+// FillDateGaps returns a copy of a sorted date series in which every missing day
+// between the first and last entry is present with zero XP.
+func FillDateGaps(series []DateXP) []DateXP {
+	if len(series) == 0 {
+		return nil
+	}
+
+	first, last := series[0].Date, series[len(series)-1].Date
+	filled := make([]DateXP, 0, last.DaysSince(first)+1)
+	i := 0
+	for day := range Days(first, last) {
+		entry := DateXP{Date: day}
+		for i < len(series) && !series[i].Date.After(day) {
+			if series[i].Date == day {
+				entry.XP += series[i].XP
+			}
+			i++
+		}
+		filled = append(filled, entry)
+	}
+
+	return filled
+}
+
+// HasLanguage reports whether the profile contains XP for the given language.
+func (p *UserProfile) HasLanguage(name string) bool {
+	_, ok := p.Languages[name]
+	return ok
+}
+
+// LanguageXP returns the total XP for the given language, or 0 if the language is unknown.
+func (p *UserProfile) LanguageXP(name string) XP {
+	return p.Languages[name].XPs
+}
+
+// LanguageShare returns the fraction of the profile's total XP earned in the given language.
+// Returns a value between 0.0 and 1.0, or 0.0 if the profile has no XP.
+func (p *UserProfile) LanguageShare(name string) float64 {
+	if p.TotalXP <= 0 {
+		return 0.0
+	}
+	return float64(p.Languages[name].XPs) / float64(p.TotalXP)
+}
+
+// TotalLanguages returns the number of languages the user has XP in.
+func (p *UserProfile) TotalLanguages() int {
+	return len(p.Languages)
+}
+
+// TotalMachines returns the number of machines the user has reported XP from.
+func (p *UserProfile) TotalMachines() int {
+	return len(p.Machines)
+}
+
+// XPOn returns the XP gained on the calendar day of the given time.
+// The day is taken in the time's own location, matching the keys of the Dates map.
+func (p *UserProfile) XPOn(date time.Time) XP {
+	return p.XPOnDate(DateOf(date))
+}
+
+// XPOnDate returns the XP gained on the given day.
+func (p *UserProfile) XPOnDate(date Date) XP {
+	return p.Dates[date.String()]
+}
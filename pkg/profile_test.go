@@ -0,0 +1,140 @@
+package godestats
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserProfile_DailySeries(t *testing.T) {
+	profile := &UserProfile{
+		Dates: map[string]XP{
+			"2023-01-03": 30,
+			"2023-01-01": 10,
+			"not-a-date": 99,
+			"2023-01-02": 20,
+		},
+	}
+
+	series, err := profile.DailySeries()
+	if err == nil {
+		t.Error("Expected parse error for invalid date key")
+	}
+
+	if len(series) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(series))
+	}
+
+	for i, expected := range []XP{10, 20, 30} {
+		if series[i].XP != expected {
+			t.Errorf("Expected entry %d to have %d XP, got %d", i, expected, series[i].XP)
+		}
+	}
+
+	if expected := NewDate(2023, 1, 1); series[0].Date != expected {
+		t.Errorf("Expected %s, got %s", expected, series[0].Date)
+	}
+}
+
+func TestUserProfile_DateSeries(t *testing.T) {
+	profile := &UserProfile{Dates: map[string]XP{"2023-01-02": 20, "2023-01-01": 10}}
+
+	// The location is ignored, so every location yields the same series
+	for _, tz := range []*time.Location{nil, time.UTC, time.FixedZone("UTC-8", -8*60*60)} {
+		series, err := profile.DateSeries(tz)
+		if len(series) != 2 || series[0].Date != NewDate(2023, 1, 1) || series[1].Date != NewDate(2023, 1, 2) {
+			t.Errorf("Unexpected series in %v: %v", tz, series)
+		}
+
+		// Locations that would have changed the result are reported
+		ignored := tz != nil && tz != time.UTC
+		if errors.Is(err, ErrLocationIgnored) != ignored || (err != nil) != ignored {
+			t.Errorf("Expected ErrLocationIgnored to be %v in %v, got %v", ignored, tz, err)
+		}
+	}
+}
+
+func TestFillDateGaps(t *testing.T) {
+	profile := &UserProfile{
+		Dates: map[string]XP{
+			"2023-02-27": 10,
+			"2023-03-02": 40,
+		},
+	}
+
+	series, err := profile.DailySeries()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	filled := FillDateGaps(series)
+	expected := []XP{10, 0, 0, 40}
+	if len(filled) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(filled))
+	}
+
+	for i, xp := range expected {
+		if filled[i].XP != xp {
+			t.Errorf("Expected entry %d to have %d XP, got %d", i, xp, filled[i].XP)
+		}
+	}
+
+	if FillDateGaps(nil) != nil {
+		t.Error("Expected nil for empty series")
+	}
+}
+
+func TestUserProfile_Accessors(t *testing.T) {
+	profile := &UserProfile{
+		TotalXP: 1000,
+		Machines: map[string]MachineInfo{
+			"laptop":  {XPs: 600},
+			"desktop": {XPs: 400},
+		},
+		Languages: map[string]LanguageInfo{
+			"Go":   {XPs: 750},
+			"Rust": {XPs: 250},
+		},
+		Dates: map[string]XP{"2023-01-01": 50},
+	}
+
+	if !profile.HasLanguage("Go") {
+		t.Error("Expected profile to have Go")
+	}
+	if profile.HasLanguage("Java") {
+		t.Error("Expected profile not to have Java")
+	}
+
+	if xp := profile.LanguageXP("Rust"); xp != 250 {
+		t.Errorf("Expected 250 Rust XP, got %d", xp)
+	}
+
+	if share := profile.LanguageShare("Go"); share != 0.75 {
+		t.Errorf("Expected Go share 0.75, got %f", share)
+	}
+	if share := profile.LanguageShare("Java"); share != 0.0 {
+		t.Errorf("Expected Java share 0.0, got %f", share)
+	}
+
+	if n := profile.TotalLanguages(); n != 2 {
+		t.Errorf("Expected 2 languages, got %d", n)
+	}
+	if n := profile.TotalMachines(); n != 2 {
+		t.Errorf("Expected 2 machines, got %d", n)
+	}
+
+	if xp := profile.XPOn(time.Date(2023, 1, 1, 15, 30, 0, 0, time.UTC)); xp != 50 {
+		t.Errorf("Expected 50 XP on 2023-01-01, got %d", xp)
+	}
+	if xp := profile.XPOnDate(NewDate(2023, 1, 1)); xp != 50 {
+		t.Errorf("Expected 50 XP on 2023-01-01, got %d", xp)
+	}
+	if xp := profile.XPOn(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)); xp != 0 {
+		t.Errorf("Expected 0 XP on 2023-01-02, got %d", xp)
+	}
+
+	empty := &UserProfile{}
+	if share := empty.LanguageShare("Go"); share != 0.0 {
+		t.Errorf("Expected 0.0 share for empty profile, got %f", share)
+	}
+}
@@ -0,0 +1,145 @@
+// Package proxyhttp serves Code::Stats profiles as CORS-enabled JSON, so static
+// web frontends can display profile data without exposing API tokens.
+package proxyhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// DefaultMaxAge is how long browsers and CDNs may cache a served profile.
+const DefaultMaxAge = time.Minute
+
+// Option configures the proxy handler.
+type Option func(*handler)
+
+// WithAllowedOrigins restricts CORS access to the given origins.
+// By default any origin is allowed.
+func WithAllowedOrigins(origins ...string) Option {
+	return func(h *handler) {
+		h.origins = origins
+	}
+}
+
+// WithMaxAge sets the max-age of the Cache-Control header of served profiles.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(h *handler) {
+		h.maxAge = maxAge
+	}
+}
+
+// handler serves profiles fetched through the client.
+type handler struct {
+	client  godestats.ProfileClient
+	origins []string
+	maxAge  time.Duration
+	mux     *http.ServeMux
+}
+
+// Handler returns an http.Handler that serves profiles at /api/users/{user},
+// mirroring the Code::Stats API path, so frontends only need to change their base URL.
+// Pass a caching client (see cache.NewClient) to avoid hitting the API rate limit.
+func Handler(client godestats.ProfileClient, opts ...Option) http.Handler {
+	h := &handler{
+		client: client,
+		maxAge: DefaultMaxAge,
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("GET /api/users/{user}", h.serveProfile)
+	h.mux.HandleFunc("OPTIONS /api/users/{user}", h.servePreflight)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// serveProfile writes the requested profile as JSON.
+func (h *handler) serveProfile(w http.ResponseWriter, r *http.Request) {
+	if !h.setCORSHeaders(w, r) {
+		writeJSON(w, http.StatusForbidden, errorBody("origin not allowed"))
+		return
+	}
+
+	profile, err := h.client.GetUserProfile(r.Context(), r.PathValue("user"))
+	if err != nil {
+		status := statusFor(err)
+		writeJSON(w, status, errorBody(godestats.UserMessage(err, r.Header.Get("Accept-Language"))))
+		return
+	}
+
+	// Profiles the client fetched for the token owner contain private data
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(h.maxAge.Seconds())))
+	writeJSON(w, http.StatusOK, profile.Public())
+}
+
+// servePreflight answers CORS preflight requests.
+func (h *handler) servePreflight(w http.ResponseWriter, r *http.Request) {
+	if !h.setCORSHeaders(w, r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Max-Age", "86400")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setCORSHeaders sets the CORS response headers and reports whether the
+// request's origin is allowed. Requests without an Origin header are always allowed.
+func (h *handler) setCORSHeaders(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+
+	if len(h.origins) == 0 {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return true
+	}
+
+	w.Header().Add("Vary", "Origin")
+	if origin == "" {
+		return true
+	}
+	if !slices.ContainsFunc(h.origins, func(allowed string) bool {
+		return strings.EqualFold(allowed, origin)
+	}) {
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	return true
+}
+
+// errorBody builds a JSON error response in the format of the Code::Stats API.
+func errorBody(message string) map[string]string {
+	return map[string]string{"error": message}
+}
+
+// writeJSON encodes the value as the JSON response body.
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// statusFor maps a client error to the HTTP status of the error response.
+func statusFor(err error) int {
+	switch {
+	case godestats.IsUserNotFound(err):
+		return http.StatusNotFound
+	case godestats.IsRateLimited(err):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusBadGateway
+	}
+}
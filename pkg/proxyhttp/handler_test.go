@@ -0,0 +1,136 @@
+package proxyhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/client"
+)
+
+// fakeClient serves fixed profiles.
+type fakeClient struct {
+	profiles map[string]*godestats.UserProfile
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	if profile, ok := f.profiles[username]; ok {
+		return profile, nil
+	}
+	return nil, godestats.ErrUserNotFound
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{profiles: map[string]*godestats.UserProfile{
+		"alice": {User: "alice", TotalXP: 1234},
+	}}
+}
+
+func TestHandler_ServesProfile(t *testing.T) {
+	handler := Handler(newFakeClient())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/alice", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if origin := rec.Header().Get("Access-Control-Allow-Origin"); origin != "*" {
+		t.Errorf("Expected wildcard origin, got '%s'", origin)
+	}
+
+	var profile godestats.UserProfile
+	if err := json.NewDecoder(rec.Body).Decode(&profile); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if profile.User != "alice" || profile.TotalXP != 1234 {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+}
+
+func TestHandler_TokenOwnerProfile(t *testing.T) {
+	// An instance where alice's profile is private, so the client falls back to the
+	// authenticated endpoint for its token owner
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/my/profile" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"user": "alice", "total_xp": 1234, "new_xp": 0, "languages": {}, "dates": {},
+			"machines": {"laptop": {"xps": 1234, "new_xps": 0, "last_active": "2023-06-15T12:00:00Z", "token_id": "secret-token-id"}}}`))
+	}))
+	defer api.Close()
+
+	handler := Handler(client.NewWithBaseURL("test-token", api.URL))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/alice", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "secret-token-id") || strings.Contains(body, "last_active") {
+		t.Errorf("Expected no private machine data, got %s", body)
+	}
+}
+
+func TestHandler_NotFound(t *testing.T) {
+	handler := Handler(newFakeClient())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/bob", nil)
+	req.Header.Set("Accept-Language", "de")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404, got %d", rec.Code)
+	}
+
+	var body map[string]string
+	json.NewDecoder(rec.Body).Decode(&body)
+	if expected := godestats.Message(godestats.MsgUserNotFound, "de"); body["error"] != expected {
+		t.Errorf("Expected error '%s', got '%s'", expected, body["error"])
+	}
+}
+
+func TestHandler_AllowedOrigins(t *testing.T) {
+	handler := Handler(newFakeClient(), WithAllowedOrigins("https://example.com"))
+
+	tests := []struct {
+		name   string
+		method string
+		origin string
+		status int
+		allow  string
+	}{
+		{"allowed origin", http.MethodGet, "https://example.com", http.StatusOK, "https://example.com"},
+		{"disallowed origin", http.MethodGet, "https://evil.example", http.StatusForbidden, ""},
+		{"no origin", http.MethodGet, "", http.StatusOK, ""},
+		{"preflight", http.MethodOptions, "https://example.com", http.StatusNoContent, "https://example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/users/alice", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if allow := rec.Header().Get("Access-Control-Allow-Origin"); allow != tt.allow {
+				t.Errorf("Expected allowed origin '%s', got '%s'", tt.allow, allow)
+			}
+		})
+	}
+}
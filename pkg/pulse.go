@@ -0,0 +1,52 @@
+package godestats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PulseResult is the response of the API to an accepted pulse, so callers can
+// confirm what the server recorded.
+type PulseResult struct {
+	// Message is the confirmation of the server, e.g. "Great success!".
+	Message string `json:"ok"`
+
+	// XPs is the XP the server recorded per language, if the response includes
+	// it. It can differ from the pulse, e.g. if the server caps XP.
+	XPs []LanguageXP `json:"xps,omitempty"`
+
+	// Raw holds all fields of the response, including those not known to this
+	// library, such as level rollover details of newer instances.
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// PulseResultClient is implemented by clients that return the response to a pulse.
+type PulseResultClient interface {
+	// SendPulseResult submits a pulse like SendPulse and returns the server's response.
+	SendPulseResult(ctx context.Context, pulse Pulse, opts ...CallOption) (*PulseResult, error)
+}
+
+// ParsePulseResult decodes the response body of an accepted pulse.
+func ParsePulseResult(data []byte) (*PulseResult, error) {
+	var result PulseResult
+	if err := json.Unmarshal(data, &result.Raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return &result, nil
+}
+
+// SendPulseResult submits a pulse and returns the server's response if the client
+// supports it, and an empty result after a successful SendPulse otherwise.
+func SendPulseResult(ctx context.Context, client CodeStatsClient, pulse Pulse, opts ...CallOption) (*PulseResult, error) {
+	if c, ok := client.(PulseResultClient); ok {
+		return c.SendPulseResult(ctx, pulse, opts...)
+	}
+	if err := client.SendPulse(ctx, pulse, opts...); err != nil {
+		return nil, err
+	}
+	return &PulseResult{}, nil
+}
@@ -0,0 +1,50 @@
+package godestats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type pulseOnlyClient struct {
+	err error
+}
+
+func (c *pulseOnlyClient) GetUserProfile(ctx context.Context, username string, opts ...CallOption) (*UserProfile, error) {
+	return nil, ErrUserNotFound
+}
+
+func (c *pulseOnlyClient) SendPulse(ctx context.Context, pulse Pulse, opts ...CallOption) error {
+	return c.err
+}
+
+func TestParsePulseResult(t *testing.T) {
+	result, err := ParsePulseResult([]byte(`{"ok": "Great success!", "xps": [{"language": "Go", "xp": 10}], "rollover": {"level": 5}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Message != "Great success!" || len(result.XPs) != 1 || result.XPs[0].XP != 10 {
+		t.Errorf("Expected the message and recorded XP, got %+v", result)
+	}
+	if string(result.Raw["rollover"]) != `{"level": 5}` {
+		t.Errorf("Expected unknown fields in Raw, got %s", result.Raw["rollover"])
+	}
+
+	if _, err := ParsePulseResult([]byte(`Created`)); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("Expected ErrInvalidResponse, got %v", err)
+	}
+}
+
+func TestSendPulseResult_Fallback(t *testing.T) {
+	pulse := Pulse{CodedAt: time.Now()}
+
+	result, err := SendPulseResult(context.Background(), &pulseOnlyClient{}, pulse)
+	if err != nil || result == nil || result.Message != "" {
+		t.Errorf("Expected an empty result, got %+v (err=%v)", result, err)
+	}
+
+	if _, err := SendPulseResult(context.Background(), &pulseOnlyClient{err: ErrUnauthorized}, pulse); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+}
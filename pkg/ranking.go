@@ -0,0 +1,73 @@
+package godestats
+
+import (
+	"cmp"
+	"slices"
+)
+
+// RankedLanguage is a language entry of a profile together with its rank by XP.
+type RankedLanguage struct {
+	Rank int    `json:"rank"`
+	Name string `json:"name"`
+	LanguageInfo
+}
+
+// RankedMachine is a machine entry of a profile together with its rank by XP.
+type RankedMachine struct {
+	Rank int    `json:"rank"`
+	Name string `json:"name"`
+	MachineInfo
+}
+
+// LanguagesByXP returns the profile's languages sorted by total XP in descending order.
+// Entries with equal XP are ordered by name and share the same rank (1-based).
+func LanguagesByXP(profile *UserProfile) []RankedLanguage {
+	if profile == nil {
+		return nil
+	}
+
+	ranked := make([]RankedLanguage, 0, len(profile.Languages))
+	for name, info := range profile.Languages {
+		ranked = append(ranked, RankedLanguage{Name: name, LanguageInfo: info})
+	}
+
+	for i, rank := range rankByXP(ranked, func(l RankedLanguage) XP { return l.XPs }, func(l RankedLanguage) string { return l.Name }) {
+		ranked[i].Rank = rank
+	}
+	return ranked
+}
+
+// MachinesByXP returns the profile's machines sorted by total XP in descending order.
+// Entries with equal XP are ordered by name and share the same rank (1-based).
+func MachinesByXP(profile *UserProfile) []RankedMachine {
+	if profile == nil {
+		return nil
+	}
+
+	ranked := make([]RankedMachine, 0, len(profile.Machines))
+	for name, info := range profile.Machines {
+		ranked = append(ranked, RankedMachine{Name: name, MachineInfo: info})
+	}
+
+	for i, rank := range rankByXP(ranked, func(m RankedMachine) XP { return m.XPs }, func(m RankedMachine) string { return m.Name }) {
+		ranked[i].Rank = rank
+	}
+	return ranked
+}
+
+// rankByXP sorts the items by XP in descending order, then by name, and returns
+// their 1-based ranks. Items with equal XP share a rank.
+func rankByXP[T any](items []T, xp func(T) XP, name func(T) string) []int {
+	slices.SortFunc(items, func(a, b T) int {
+		return cmp.Or(cmp.Compare(xp(b), xp(a)), cmp.Compare(name(a), name(b)))
+	})
+
+	ranks := make([]int, len(items))
+	for i := range items {
+		ranks[i] = i + 1
+		if i > 0 && xp(items[i]) == xp(items[i-1]) {
+			ranks[i] = ranks[i-1]
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,57 @@
+package godestats
+
+import (
+	"testing"
+)
+
+func TestLanguagesByXP(t *testing.T) {
+	profile := &UserProfile{
+		Languages: map[string]LanguageInfo{
+			"Rust":   {XPs: 300},
+			"Go":     {XPs: 500},
+			"Elixir": {XPs: 300},
+			"Python": {XPs: 100},
+		},
+	}
+
+	ranked := LanguagesByXP(profile)
+
+	expected := []struct {
+		name string
+		rank int
+	}{
+		{"Go", 1},
+		{"Elixir", 2},
+		{"Rust", 2},
+		{"Python", 4},
+	}
+
+	if len(ranked) != len(expected) {
+		t.Fatalf("Expected %d languages, got %d", len(expected), len(ranked))
+	}
+
+	for i, e := range expected {
+		if ranked[i].Name != e.name || ranked[i].Rank != e.rank {
+			t.Errorf("Expected #%d to be %s (rank %d), got %s (rank %d)",
+				i, e.name, e.rank, ranked[i].Name, ranked[i].Rank)
+		}
+	}
+}
+
+func TestMachinesByXP(t *testing.T) {
+	profile := &UserProfile{
+		Machines: map[string]MachineInfo{
+			"laptop":  {XPs: 100},
+			"desktop": {XPs: 200},
+		},
+	}
+
+	ranked := MachinesByXP(profile)
+	if len(ranked) != 2 || ranked[0].Name != "desktop" || ranked[1].Rank != 2 {
+		t.Errorf("Unexpected ranking: %+v", ranked)
+	}
+
+	if MachinesByXP(nil) != nil {
+		t.Error("Expected nil for nil profile")
+	}
+}
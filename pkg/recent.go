@@ -0,0 +1,82 @@
+package godestats
+
+import (
+	"errors"
+	"time"
+)
+
+// RecentWindow is the length of the window the Code::Stats API uses for "new" XP.
+const RecentWindow = 12 * time.Hour
+
+// ErrUnknownRecentPeriod is returned when a profile's recent period is needed but was never set.
+var ErrUnknownRecentPeriod = errors.New("profile has no recent period")
+
+// RecentPeriod describes the time window that "new" XP values refer to.
+type RecentPeriod struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// NewRecentPeriod returns the API's recent period for a profile fetched at the given time.
+func NewRecentPeriod(fetchedAt time.Time) RecentPeriod {
+	return RecentPeriod{
+		Start:    fetchedAt.Add(-RecentWindow),
+		Duration: RecentWindow,
+	}
+}
+
+// End returns the end of the period, which is the time the data was fetched.
+func (r RecentPeriod) End() time.Time {
+	return r.Start.Add(r.Duration)
+}
+
+// IsZero reports whether the period is unset.
+func (r RecentPeriod) IsZero() bool {
+	return r.Start.IsZero() && r.Duration == 0
+}
+
+// Contains reports whether t lies within the period.
+func (r RecentPeriod) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End())
+}
+
+// RecomputeRecent returns a copy of the newer profile in which all "new" XP values
+// are redefined as the XP gained since the older snapshot, and whose recent period
+// spans from the older snapshot to the newer one. Values are never negative.
+func RecomputeRecent(older, newer *UserProfile) (*UserProfile, error) {
+	if older == nil || newer == nil {
+		return nil, errors.New("both snapshots are required")
+	}
+	if older.Recent.IsZero() || newer.Recent.IsZero() {
+		return nil, ErrUnknownRecentPeriod
+	}
+
+	from, to := older.Recent.End(), newer.Recent.End()
+	if to.Before(from) {
+		return nil, errors.New("newer snapshot was fetched before the older one")
+	}
+
+	combined := newer.Clone()
+	combined.Recent = RecentPeriod{Start: from, Duration: to.Sub(from)}
+	combined.NewXP = gain(older.TotalXP, newer.TotalXP)
+
+	for name, info := range combined.Languages {
+		info.NewXPs = gain(older.Languages[name].XPs, info.XPs)
+		combined.Languages[name] = info
+	}
+
+	for name, info := range combined.Machines {
+		info.NewXPs = gain(older.Machines[name].XPs, info.XPs)
+		combined.Machines[name] = info
+	}
+
+	return combined, nil
+}
+
+// gain returns the non-negative XP difference between two totals.
+func gain(before, after XP) XP {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
@@ -0,0 +1,84 @@
+package godestats
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecentPeriod(t *testing.T) {
+	fetchedAt := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	period := NewRecentPeriod(fetchedAt)
+
+	if !period.End().Equal(fetchedAt) {
+		t.Errorf("Expected end %v, got %v", fetchedAt, period.End())
+	}
+	if !period.Contains(fetchedAt.Add(-time.Hour)) {
+		t.Error("Expected period to contain one hour before fetch")
+	}
+	if period.Contains(fetchedAt.Add(-13 * time.Hour)) {
+		t.Error("Expected period not to contain 13 hours before fetch")
+	}
+	if period.IsZero() || !(RecentPeriod{}).IsZero() {
+		t.Error("Unexpected IsZero result")
+	}
+}
+
+func TestRecomputeRecent(t *testing.T) {
+	start := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	older := &UserProfile{
+		TotalXP:   1000,
+		Languages: map[string]LanguageInfo{"Go": {XPs: 1000, NewXPs: 500}},
+		Machines:  map[string]MachineInfo{"laptop": {XPs: 1000}},
+		Recent:    NewRecentPeriod(start),
+	}
+	newer := &UserProfile{
+		TotalXP: 1300,
+		NewXP:   900,
+		Languages: map[string]LanguageInfo{
+			"Go":   {XPs: 1200, NewXPs: 700},
+			"Rust": {XPs: 100, NewXPs: 100},
+		},
+		Machines: map[string]MachineInfo{"laptop": {XPs: 1300, NewXPs: 900}},
+		Recent:   NewRecentPeriod(start.Add(24 * time.Hour)),
+	}
+
+	combined, err := RecomputeRecent(older, newer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if combined.NewXP != 300 {
+		t.Errorf("Expected new XP 300, got %d", combined.NewXP)
+	}
+	if combined.Languages["Go"].NewXPs != 200 || combined.Languages["Rust"].NewXPs != 100 {
+		t.Errorf("Unexpected language new XP: %+v", combined.Languages)
+	}
+	if combined.Machines["laptop"].NewXPs != 300 {
+		t.Errorf("Unexpected machine new XP: %+v", combined.Machines)
+	}
+	if combined.Recent.Duration != 24*time.Hour || !combined.Recent.Start.Equal(start) {
+		t.Errorf("Unexpected recent period: %+v", combined.Recent)
+	}
+	if newer.NewXP != 900 {
+		t.Error("Expected newer snapshot to be left unchanged")
+	}
+}
+
+func TestRecomputeRecent_Errors(t *testing.T) {
+	now := time.Now()
+	withPeriod := &UserProfile{Recent: NewRecentPeriod(now)}
+
+	if _, err := RecomputeRecent(&UserProfile{}, withPeriod); !errors.Is(err, ErrUnknownRecentPeriod) {
+		t.Errorf("Expected ErrUnknownRecentPeriod, got %v", err)
+	}
+
+	earlier := &UserProfile{Recent: NewRecentPeriod(now.Add(-time.Hour))}
+	if _, err := RecomputeRecent(withPeriod, earlier); err == nil {
+		t.Error("Expected error for out-of-order snapshots")
+	}
+
+	if _, err := RecomputeRecent(nil, withPeriod); err == nil {
+		t.Error("Expected error for nil snapshot")
+	}
+}
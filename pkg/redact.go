@@ -0,0 +1,107 @@
+package godestats
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces secrets in redacted text.
+const Redacted = "REDACTED"
+
+// sensitiveParams are query parameters whose values are credentials.
+var sensitiveParams = []string{
+	"token", "api_token", "apitoken", "api_key", "apikey", "key",
+	"access_token", "auth", "secret", "password",
+}
+
+// urlPattern matches URLs embedded in text, such as in wrapped error messages.
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"']+`)
+
+// RedactURL returns the URL with the password of its user info and the values of
+// credential query parameters such as token or api_key replaced by Redacted.
+// Text that is not a URL is returned unchanged.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Opaque != "" {
+		return rawURL
+	}
+
+	changed := false
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), Redacted)
+		changed = true
+	}
+
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			name, _, found := strings.Cut(param, "=")
+			if unescaped, err := url.QueryUnescape(name); err == nil {
+				name = unescaped
+			}
+			if found && slices.Contains(sensitiveParams, strings.ToLower(name)) {
+				params[i] = name + "=" + Redacted
+				changed = true
+			}
+		}
+		u.RawQuery = strings.Join(params, "&")
+	}
+
+	if !changed {
+		return rawURL
+	}
+	return u.String()
+}
+
+// RedactSecret replaces all occurrences of the secrets in s, plain or URL-encoded,
+// by Redacted, as well as credentials in URLs contained in s. Empty secrets are ignored.
+func RedactSecret(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, secret, Redacted)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			s = strings.ReplaceAll(s, escaped, Redacted)
+		}
+	}
+	return urlPattern.ReplaceAllStringFunc(s, func(match string) string {
+		// Punctuation following a URL in a message is not part of it
+		trimmed := strings.TrimRight(match, ".,:;)")
+		return RedactURL(trimmed) + match[len(trimmed):]
+	})
+}
+
+// redactedError is an error whose message had secrets removed.
+type redactedError struct {
+	message string
+	err     error
+}
+
+// Error returns the redacted message.
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original error, so that errors.Is and errors.As keep working.
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// RedactError returns an error whose message has the secrets and credentials in URLs
+// replaced by Redacted, see RedactSecret. The original error stays available to
+// errors.Is and errors.As; errors without secrets are returned unchanged.
+func RedactError(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	redacted := RedactSecret(message, secrets...)
+	if redacted == message {
+		return err
+	}
+	return &redactedError{message: redacted, err: err}
+}
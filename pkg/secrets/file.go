@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// File returns a provider reading the token from the file at path. The file is
+// checked for changes on every call, so rotated tokens of mounted secrets
+// (Kubernetes, Vault agent) are picked up without restarting. While the file is
+// missing or empty, e.g. during an update, the last token read is returned.
+func File(path string) godestats.TokenProvider {
+	return &file{path: path}
+}
+
+// file reads a token from a file, reloading it when the file changed.
+type file struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// Token returns the token in the file, reading it again if the file's
+// modification time or size changed since it was last read.
+func (f *file) Token(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return f.fallback(fmt.Errorf("%w: failed to read token file: %w", ErrNoToken, err))
+	}
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.fallback(fmt.Errorf("%w: failed to read token file: %w", ErrNoToken, err))
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return f.fallback(fmt.Errorf("%w: token file %s is empty", ErrNoToken, f.path))
+	}
+
+	f.token, f.modTime, f.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
+
+// fallback returns the last token read, or err if there is none.
+// The lock must be held.
+func (f *file) fallback(err error) (string, error) {
+	if f.token != "" {
+		return f.token, nil
+	}
+	return "", err
+}
@@ -0,0 +1,44 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	provider := File(path)
+	ctx := context.Background()
+
+	if _, err := provider.Token(ctx); !errors.Is(err, ErrNoToken) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected ErrNoToken and a missing file error, got %v", err)
+	}
+
+	steps := []struct {
+		content  string
+		expected string
+	}{
+		{"token-1\n", "token-1"},
+		{"token-2\n", "token-2"},
+		// The last token is kept while the file is empty
+		{"", "token-2"},
+	}
+	modTime := time.Now().Add(-time.Hour)
+	for i, step := range steps {
+		if err := os.WriteFile(path, []byte(step.content), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		modTime = modTime.Add(time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if token, err := provider.Token(ctx); err != nil || token != step.expected {
+			t.Errorf("Step %d: expected %s, got %q (%v)", i+1, step.expected, token, err)
+		}
+	}
+}
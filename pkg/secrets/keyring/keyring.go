@@ -0,0 +1,88 @@
+// Package keyring stores API tokens in the OS credential store: the Keychain on
+// macOS, the Credential Manager on Windows and the Secret Service (e.g. GNOME
+// Keyring or KWallet) on Linux, so they don't have to be kept in plaintext files.
+package keyring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/secrets"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// Service is the service name all tokens are stored under.
+const Service = "godestats"
+
+// DefaultName is the name of the token used by the godestats CLI. An empty name
+// selects it.
+const DefaultName = "api-token"
+
+// Errors returned when accessing tokens
+var (
+	// ErrNotFound is returned when no token is stored under the name.
+	ErrNotFound = errors.New("token not found in keyring")
+
+	// ErrUnavailable is returned when the OS credential store cannot be used, e.g.
+	// on headless Linux machines without a Secret Service.
+	ErrUnavailable = errors.New("OS keyring unavailable")
+
+	// ErrTooLarge is returned when the credential store rejects a token as too large.
+	ErrTooLarge = errors.New("token too large for keyring")
+)
+
+// StoreToken stores the token under the name, replacing a token stored before.
+func StoreToken(name, token string) error {
+	return wrap(gokeyring.Set(Service, entry(name), token))
+}
+
+// LoadToken returns the token stored under the name.
+func LoadToken(name string) (string, error) {
+	token, err := gokeyring.Get(Service, entry(name))
+	if err != nil {
+		return "", wrap(err)
+	}
+	return token, nil
+}
+
+// DeleteToken removes the token stored under the name.
+func DeleteToken(name string) error {
+	return wrap(gokeyring.Delete(Service, entry(name)))
+}
+
+// Provider returns a provider loading the token stored under the name on every
+// call. Missing tokens and an unavailable credential store are reported as
+// secrets.ErrNoToken, so that secrets.Chain falls back to other sources.
+func Provider(name string) godestats.TokenProvider {
+	return secrets.Func(func(context.Context) (string, error) {
+		token, err := LoadToken(name)
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnavailable) {
+			return "", fmt.Errorf("%w: %w", secrets.ErrNoToken, err)
+		}
+		return token, err
+	})
+}
+
+// entry returns the keyring entry of a token name.
+func entry(name string) string {
+	if name == "" {
+		return DefaultName
+	}
+	return name
+}
+
+// wrap maps errors of the credential store to the errors of this package.
+func wrap(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gokeyring.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, gokeyring.ErrSetDataTooBig):
+		return ErrTooLarge
+	default:
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+}
@@ -0,0 +1,79 @@
+package keyring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Yeti47/gode-stats/pkg/secrets"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+func TestStoreToken(t *testing.T) {
+	gokeyring.MockInit()
+
+	if _, err := LoadToken(""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err := StoreToken("", "token-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := StoreToken("laptop", "token-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"", "token-1"},
+		{DefaultName, "token-1"},
+		{"laptop", "token-2"},
+	}
+	for _, tt := range tests {
+		token, err := LoadToken(tt.name)
+		if err != nil || token != tt.expected {
+			t.Errorf("LoadToken(%q): expected %s, got %q (%v)", tt.name, tt.expected, token, err)
+		}
+	}
+
+	if err := DeleteToken("laptop"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := LoadToken("laptop"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after deleting, got %v", err)
+	}
+	if err := DeleteToken("laptop"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound when deleting twice, got %v", err)
+	}
+}
+
+func TestStoreToken_Unavailable(t *testing.T) {
+	gokeyring.MockInitWithError(errors.New("dbus: no session bus"))
+	defer gokeyring.MockInit()
+
+	err := StoreToken("", "token")
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable, got %v", err)
+	}
+	if _, err := LoadToken(""); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestProvider(t *testing.T) {
+	gokeyring.MockInit()
+	provider := Provider("laptop")
+
+	if _, err := provider.Token(context.Background()); !errors.Is(err, secrets.ErrNoToken) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNoToken and ErrNotFound, got %v", err)
+	}
+
+	if err := StoreToken("laptop", "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token, err := provider.Token(context.Background()); err != nil || token != "token" {
+		t.Errorf("Expected token, got %q (%v)", token, err)
+	}
+}
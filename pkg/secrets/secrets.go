@@ -0,0 +1,71 @@
+// Package secrets provides sources of API tokens implementing
+// godestats.TokenProvider: static values, environment variables, files and
+// chains of them. The keyring subpackage reads tokens from the OS credential
+// store; other secret managers such as Vault can be plugged in with Func.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// ErrNoToken is returned by providers whose source holds no token, e.g. an unset
+// environment variable. Chain skips providers failing with it.
+var ErrNoToken = errors.New("no token available")
+
+// Func adapts a function to a godestats.TokenProvider, e.g. to read tokens from
+// a secret manager.
+type Func func(ctx context.Context) (string, error)
+
+// Token calls f.
+func (f Func) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// Static returns a provider always returning the token.
+func Static(token string) godestats.TokenProvider {
+	return Func(func(context.Context) (string, error) {
+		if token == "" {
+			return "", ErrNoToken
+		}
+		return token, nil
+	})
+}
+
+// Env returns a provider reading the token from the environment variable on
+// every call.
+func Env(name string) godestats.TokenProvider {
+	return Func(func(context.Context) (string, error) {
+		if token := os.Getenv(name); token != "" {
+			return token, nil
+		}
+		return "", fmt.Errorf("%w: %s is not set", ErrNoToken, name)
+	})
+}
+
+// Chain returns a provider returning the token of the first provider that has
+// one. Providers failing with ErrNoToken are skipped, other errors are returned.
+// If no provider has a token, the error of the last provider is returned.
+func Chain(providers ...godestats.TokenProvider) godestats.TokenProvider {
+	return Func(func(ctx context.Context) (string, error) {
+		missing := ErrNoToken
+		for _, p := range providers {
+			token, err := p.Token(ctx)
+			switch {
+			case err == nil && token != "":
+				return token, nil
+			case err == nil:
+				missing = ErrNoToken
+			case errors.Is(err, ErrNoToken):
+				missing = err
+			default:
+				return "", err
+			}
+		}
+		return "", missing
+	})
+}
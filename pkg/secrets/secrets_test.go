@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestEnv(t *testing.T) {
+	t.Setenv("GODESTATS_TEST_TOKEN", "")
+	provider := Env("GODESTATS_TEST_TOKEN")
+
+	if _, err := provider.Token(context.Background()); !errors.Is(err, ErrNoToken) {
+		t.Errorf("Expected ErrNoToken, got %v", err)
+	}
+
+	// The variable is read on every call
+	t.Setenv("GODESTATS_TEST_TOKEN", "token")
+	if token, err := provider.Token(context.Background()); err != nil || token != "token" {
+		t.Errorf("Expected token, got %q (%v)", token, err)
+	}
+}
+
+func TestChain(t *testing.T) {
+	failure := errors.New("vault unreachable")
+	failing := Func(func(context.Context) (string, error) { return "", failure })
+
+	tests := []struct {
+		name      string
+		providers []godestats.TokenProvider
+		expected  string
+		err       error
+	}{
+		{"first token wins", []godestats.TokenProvider{Static("a"), Static("b")}, "a", nil},
+		{"skips missing tokens", []godestats.TokenProvider{Static(""), Env("GODESTATS_TEST_UNSET"), Static("b")}, "b", nil},
+		{"stops at failures", []godestats.TokenProvider{Static(""), failing, Static("b")}, "", failure},
+		{"no token", []godestats.TokenProvider{Static("")}, "", ErrNoToken},
+		{"empty", nil, "", ErrNoToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := Chain(tt.providers...).Token(context.Background())
+			if token != tt.expected || !errors.Is(err, tt.err) {
+				t.Errorf("Expected %q (%v), got %q (%v)", tt.expected, tt.err, token, err)
+			}
+		})
+	}
+}
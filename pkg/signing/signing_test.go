@@ -0,0 +1,94 @@
+package signing
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyRequest(t *testing.T) {
+	signedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+
+	newRequest := func(body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/api/my/pulses?x=1", strings.NewReader(body))
+		now = func() time.Time { return signedAt }
+		if err := SignRequest(r, "secret", ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(r *http.Request)
+		secret   string
+		verifyAt time.Time
+		expected error
+	}{
+		{"valid", func(r *http.Request) {}, "secret", signedAt.Add(time.Minute), nil},
+		{"wrong secret", func(r *http.Request) {}, "other", signedAt, ErrInvalidSignature},
+		{"modified body", func(r *http.Request) { r.Body = io.NopCloser(strings.NewReader(`{"xp":9000}`)) }, "secret", signedAt, ErrInvalidSignature},
+		{"modified path", func(r *http.Request) { r.URL.Path = "/api/users/alice" }, "secret", signedAt, ErrInvalidSignature},
+		{"missing", func(r *http.Request) { r.Header.Del(DefaultHeader) }, "secret", signedAt, ErrMissingSignature},
+		{"malformed", func(r *http.Request) { r.Header.Set(DefaultHeader, "sha256=abc") }, "secret", signedAt, ErrInvalidSignature},
+		{"replayed", func(r *http.Request) {}, "secret", signedAt.Add(DefaultMaxAge + time.Second), ErrExpiredSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRequest(`{"xp":1}`)
+			tt.modify(r)
+			now = func() time.Time { return tt.verifyAt }
+
+			err := VerifyRequest(r, tt.secret, "", 0)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// The body stays readable after signing and verifying
+	r := newRequest(`{"xp":1}`)
+	VerifyRequest(r, "secret", "", 0)
+	if body, _ := io.ReadAll(r.Body); string(body) != `{"xp":1}` {
+		t.Errorf("Expected the body to be kept, got %q", body)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(Middleware("secret", "X-Relay-Signature", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	})))
+	defer server.Close()
+
+	tests := []struct {
+		secret string
+		status int
+	}{
+		{"secret", http.StatusOK},
+		{"guessed", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		c := &http.Client{Transport: &Transport{Secret: tt.secret, Header: "X-Relay-Signature"}}
+		resp, err := c.Post(server.URL+"/api/my/pulses", "application/json", strings.NewReader(`{"xp":1}`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("Secret %s: expected status %d, got %d", tt.secret, tt.status, resp.StatusCode)
+		}
+		if tt.status == http.StatusOK && string(body) != `{"xp":1}` {
+			t.Errorf("Expected the body to reach the handler, got %q", body)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+// Package sitegen renders a static website from Code::Stats profiles,
+// suitable for publishing to static hosts such as GitHub Pages.
+package sitegen
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/charts"
+	"github.com/Yeti47/gode-stats/pkg/widget"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// DefaultDays is the number of days shown in the daily XP charts.
+const DefaultDays = 90
+
+// Options configures the generated site. Zero values select the defaults.
+type Options struct {
+	// Title is the site title. Defaults to "Code::Stats".
+	Title string
+	// Days is the number of days shown in daily XP charts.
+	Days int
+	// Calculator is used for levels. Defaults to the Code::Stats formula.
+	Calculator godestats.XpCalculator
+	// Now is the last day shown in charts. Defaults to the current time.
+	Now time.Time
+	// Location is the time zone of chart days. Defaults to UTC.
+	Location *time.Location
+}
+
+// withDefaults returns a copy of the options with zero values replaced by defaults.
+func (o Options) withDefaults() Options {
+	if o.Title == "" {
+		o.Title = "Code::Stats"
+	}
+	if o.Days <= 0 {
+		o.Days = DefaultDays
+	}
+	if o.Calculator == nil {
+		o.Calculator = xp.NewCalculator()
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	return o
+}
+
+// Generate renders the site for the profiles into dir, creating it if necessary.
+// It writes index.html, users/<user>.html, languages/<language>.html and
+// charts under charts/. Existing files are overwritten.
+func Generate(dir string, profiles []*godestats.UserProfile, opts Options) error {
+	opts = opts.withDefaults()
+	g := &generator{dir: dir, opts: opts, userSlugs: newSlugger(), langSlugs: newSlugger()}
+
+	for _, sub := range []string{"users", "languages", "charts"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	sorted := append([]*godestats.UserProfile(nil), profiles...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].TotalXP != sorted[j].TotalXP {
+			return sorted[i].TotalXP > sorted[j].TotalXP
+		}
+		return sorted[i].User < sorted[j].User
+	})
+
+	languages := g.collectLanguages(sorted)
+
+	users := make([]userLink, 0, len(sorted))
+	for _, profile := range sorted {
+		page, err := g.userPage(profile)
+		if err != nil {
+			return err
+		}
+		users = append(users, page.userLink)
+	}
+
+	for _, lang := range languages {
+		if err := g.write(filepath.Join("languages", lang.Slug+".html"), "language", lang); err != nil {
+			return err
+		}
+	}
+
+	return g.write("index.html", "index", indexPage{
+		page:      page{Title: opts.Title, Root: ""},
+		Users:     users,
+		Languages: languages,
+	})
+}
+
+// generator holds the state of a single site generation.
+type generator struct {
+	dir       string
+	opts      Options
+	userSlugs *slugger
+	langSlugs *slugger
+}
+
+// page holds the fields common to all pages.
+type page struct {
+	Title string
+	Root  string
+}
+
+// userLink references a user page.
+type userLink struct {
+	Name    string
+	Slug    string
+	Level   int
+	TotalXP string
+}
+
+// languageRow is a language entry on a user page.
+type languageRow struct {
+	Name  string
+	Slug  string
+	Level int
+	XP    string
+}
+
+// userPage is the data of a user page.
+type userPage struct {
+	page
+	userLink
+	Widget    template.HTML
+	Chart     string
+	XPChart   string
+	Languages []languageRow
+}
+
+// languageUser is a user entry on a language page.
+type languageUser struct {
+	Rank  int
+	Name  string
+	Slug  string
+	Level int
+	XP    godestats.XP
+}
+
+// languagePage is the data of a language page.
+type languagePage struct {
+	page
+	Name    string
+	Slug    string
+	TotalXP godestats.XP
+	Users   []languageUser
+}
+
+// indexPage is the data of the index page.
+type indexPage struct {
+	page
+	Users     []userLink
+	Languages []*languagePage
+}
+
+// collectLanguages builds the language pages across all profiles, ordered by name.
+func (g *generator) collectLanguages(profiles []*godestats.UserProfile) []*languagePage {
+	byName := make(map[string]*languagePage)
+	for _, profile := range profiles {
+		for name, info := range profile.Languages {
+			lang, ok := byName[name]
+			if !ok {
+				lang = &languagePage{page: page{Title: name, Root: "../"}, Name: name, Slug: g.langSlugs.slug(name)}
+				byName[name] = lang
+			}
+			lang.TotalXP += info.XPs
+			lang.Users = append(lang.Users, languageUser{
+				Name:  profile.User,
+				Slug:  g.userSlugs.slug(profile.User),
+				Level: g.opts.Calculator.GetLevel(info.XPs),
+				XP:    info.XPs,
+			})
+		}
+	}
+
+	languages := make([]*languagePage, 0, len(byName))
+	for _, lang := range byName {
+		// Users were appended in overall order and are re-ranked by language XP
+		sort.SliceStable(lang.Users, func(i, j int) bool {
+			return lang.Users[i].XP > lang.Users[j].XP
+		})
+		for i := range lang.Users {
+			lang.Users[i].Rank = i + 1
+			if i > 0 && lang.Users[i].XP == lang.Users[i-1].XP {
+				lang.Users[i].Rank = lang.Users[i-1].Rank
+			}
+		}
+		languages = append(languages, lang)
+	}
+
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i].Name < languages[j].Name
+	})
+	return languages
+}
+
+// userPage renders the page and chart of a single user.
+func (g *generator) userPage(profile *godestats.UserProfile) (*userPage, error) {
+	calc := g.opts.Calculator
+	slug := g.userSlugs.slug(profile.User)
+
+	html, err := widget.RenderString(profile, widget.Options{
+		Calculator: calc,
+		Now:        g.opts.Now,
+		Location:   g.opts.Location,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := filepath.ToSlash(filepath.Join("charts", slug+"-daily.svg"))
+	if err := os.WriteFile(filepath.Join(g.dir, chart), dailyChart(profile, g.opts), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write chart: %w", err)
+	}
+
+	xpChart, err := g.xpChart(profile, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &userPage{
+		page: page{Title: profile.User, Root: "../"},
+		userLink: userLink{
+			Name:    profile.User,
+			Slug:    slug,
+			Level:   calc.GetLevel(profile.TotalXP),
+			TotalXP: profile.TotalXP.String(),
+		},
+		Widget:  template.HTML(html),
+		Chart:   chart,
+		XPChart: xpChart,
+	}
+
+	for _, lang := range godestats.LanguagesByXP(profile) {
+		p.Languages = append(p.Languages, languageRow{
+			Name:  lang.Name,
+			Slug:  g.langSlugs.slug(lang.Name),
+			Level: calc.GetLevel(lang.XPs),
+			XP:    lang.XPs.String(),
+		})
+	}
+
+	if err := g.write(filepath.Join("users", slug+".html"), "user", p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// write renders a named template into a file relative to the site directory.
+func (g *generator) write(name, tmpl string, data any) error {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(g.dir, name), buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// xpChart renders the total XP over the chart range as a PNG image and returns
+// its path relative to the site directory, or an empty path if there is no data.
+func (g *generator) xpChart(profile *godestats.UserProfile, slug string) (string, error) {
+	daily, _ := profile.DailySeries()
+	if len(daily) == 0 {
+		return "", nil
+	}
+
+	series := godestats.FillDateGaps(daily)
+	series = charts.Cumulative(series, profile.TotalXP)
+	start := godestats.DateOf(g.opts.Now.In(g.opts.Location)).AddDays(-g.opts.Days)
+	for len(series) > 1 && series[0].Date.Before(start) {
+		series = series[1:]
+	}
+
+	name := filepath.ToSlash(filepath.Join("charts", slug+"-xp.png"))
+	f, err := os.Create(filepath.Join(g.dir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to write chart: %w", err)
+	}
+	defer f.Close()
+
+	if err := charts.XPOverTimePNG(f, series, charts.Options{Title: "Total XP"}); err != nil {
+		return "", err
+	}
+	return name, f.Close()
+}
+
+// dailyChart renders the XP gained per day as an SVG bar chart.
+func dailyChart(profile *godestats.UserProfile, opts Options) []byte {
+	const (
+		barWidth = 6
+		height   = 120
+		top      = 20
+	)
+
+	today := godestats.DateOf(opts.Now.In(opts.Location))
+	start := today.AddDays(1 - opts.Days)
+
+	values := make([]godestats.XP, opts.Days)
+	var peak godestats.XP
+	for i := range values {
+		values[i] = profile.XPOnDate(start.AddDays(i))
+		peak = max(peak, values[i])
+	}
+
+	width := opts.Days * barWidth
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="Daily XP">`, width, height+top, width, height+top)
+	fmt.Fprintf(&buf, `<text x="0" y="14" font-family="sans-serif" font-size="12" fill="#57606a">Daily XP, peak %s</text>`, peak)
+	for i, value := range values {
+		if value <= 0 {
+			continue
+		}
+		h := int(float64(value) / float64(peak) * height)
+		h = max(h, 1)
+		day := start.AddDays(i)
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="#4c9ee9"><title>%s: %s XP</title></rect>`,
+			i*barWidth, top+height-h, barWidth-1, h, day, value)
+	}
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes()
+}
+
+// slugger assigns unique, file-system safe slugs to names.
+type slugger struct {
+	byName map[string]string
+	used   map[string]bool
+}
+
+// newSlugger creates an empty slugger.
+func newSlugger() *slugger {
+	return &slugger{byName: make(map[string]string), used: make(map[string]bool)}
+}
+
+// slug returns the slug for name, deriving a new unique one on first use.
+func (s *slugger) slug(name string) string {
+	if slug, ok := s.byName[name]; ok {
+		return slug
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == '+':
+			b.WriteString("plus")
+		case r == '#':
+			b.WriteString("sharp")
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	base := b.String()
+	if base == "" {
+		base = "item"
+	}
+
+	slug := base
+	for i := 2; s.used[slug]; i++ {
+		slug = base + "-" + strconv.Itoa(i)
+	}
+
+	s.byName[name] = slug
+	s.used[slug] = true
+	return slug
+}
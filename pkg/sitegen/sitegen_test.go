@@ -0,0 +1,91 @@
+package sitegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	profiles := []*godestats.UserProfile{
+		{
+			User:      "alice",
+			TotalXP:   5000,
+			Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 3000}, "C++": {XPs: 2000}},
+			Dates:     map[string]godestats.XP{"2023-06-15": 250},
+		},
+		{
+			User:      "bob",
+			TotalXP:   8000,
+			Languages: map[string]godestats.LanguageInfo{"Go": {XPs: 8000}},
+		},
+	}
+
+	err := Generate(dir, profiles, Options{
+		Title: "Team <Stats>",
+		Days:  7,
+		Now:   time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	files := map[string][]string{
+		"index.html":               {"Team &lt;Stats&gt;", `href="users/alice.html"`, `href="languages/cplusplus.html"`},
+		"users/alice.html":         {"gode-stats-widget", `src="../charts/alice-daily.svg"`, `src="../charts/alice-xp.png"`, `href="../languages/go.html"`},
+		"languages/go.html":        {"11,000 XP in total", "<td>1</td><td><a href=\"../users/bob.html\">bob</a>"},
+		"languages/cplusplus.html": {"alice"},
+		"charts/alice-daily.svg":   {"2023-06-15: 250 XP", "<svg"},
+	}
+
+	for name, expected := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("Expected file %s: %v", name, err)
+			continue
+		}
+		for _, s := range expected {
+			if !strings.Contains(string(data), s) {
+				t.Errorf("Expected %s to contain '%s'", name, s)
+			}
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "charts", "alice-xp.png")); err != nil {
+		t.Errorf("Expected XP chart: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "charts", "bob-xp.png")); !os.IsNotExist(err) {
+		t.Error("Expected no XP chart without dates")
+	}
+
+	index, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if strings.Index(string(index), "bob") > strings.Index(string(index), "alice") {
+		t.Error("Expected users to be ordered by XP")
+	}
+}
+
+func TestSlugger(t *testing.T) {
+	s := newSlugger()
+
+	tests := []struct {
+		name, expected string
+	}{
+		{"C#", "csharp"},
+		{"C++", "cplusplus"},
+		{"Objective C", "objective-c"},
+		{"Objective-C", "objective-c-2"},
+		{"C#", "csharp"},
+		{"", "item"},
+	}
+
+	for _, tt := range tests {
+		if result := s.slug(tt.name); result != tt.expected {
+			t.Errorf("Expected slug(%q) = %q, got %q", tt.name, tt.expected, result)
+		}
+	}
+}
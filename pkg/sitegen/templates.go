@@ -0,0 +1,63 @@
+package sitegen
+
+import "html/template"
+
+// templates holds the page templates of the generated site.
+var templates = template.Must(template.New("site").Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;color:#24292f;max-width:960px;margin:0 auto;padding:24px}
+a{color:#0969da;text-decoration:none}
+table{border-collapse:collapse;margin:16px 0}
+th,td{text-align:left;padding:4px 12px;border-bottom:1px solid #d0d7de}
+.users{display:flex;flex-wrap:wrap;gap:16px}
+</style>
+</head>
+<body>
+<nav><a href="{{.Root}}index.html">Home</a></nav>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+
+{{define "index"}}{{template "header" .}}
+<h1>{{.Title}}</h1>
+<table>
+<tr><th>User</th><th>Level</th><th>XP</th></tr>
+{{range .Users}}<tr><td><a href="users/{{.Slug}}.html">{{.Name}}</a></td><td>{{.Level}}</td><td>{{.TotalXP}}</td></tr>
+{{end}}</table>
+<h2>Languages</h2>
+<ul>
+{{range .Languages}}<li><a href="languages/{{.Slug}}.html">{{.Name}}</a> ({{.TotalXP}} XP)</li>
+{{end}}</ul>
+{{template "footer" .}}{{end}}
+
+{{define "user"}}{{template "header" .}}
+<h1>{{.Name}}</h1>
+{{.Widget}}
+<h2>Daily XP</h2>
+<img src="../{{.Chart}}" alt="Daily XP of {{.Name}}">
+{{if .XPChart}}<h2>Total XP</h2>
+<img src="../{{.XPChart}}" alt="Total XP of {{.Name}}">
+{{end}}<h2>Languages</h2>
+<table>
+<tr><th>Language</th><th>Level</th><th>XP</th></tr>
+{{range .Languages}}<tr><td><a href="../languages/{{.Slug}}.html">{{.Name}}</a></td><td>{{.Level}}</td><td>{{.XP}}</td></tr>
+{{end}}</table>
+{{template "footer" .}}{{end}}
+
+{{define "language"}}{{template "header" .}}
+<h1>{{.Name}}</h1>
+<p>{{.TotalXP}} XP in total</p>
+<table>
+<tr><th>#</th><th>User</th><th>Level</th><th>XP</th></tr>
+{{range .Users}}<tr><td>{{.Rank}}</td><td><a href="../users/{{.Slug}}.html">{{.Name}}</a></td><td>{{.Level}}</td><td>{{.XP}}</td></tr>
+{{end}}</table>
+{{template "footer" .}}{{end}}
+`))
@@ -0,0 +1,50 @@
+package godestats
+
+import "time"
+
+// Streak describes runs of consecutive days with XP.
+type Streak struct {
+	// Current is the number of consecutive days with XP ending today, or ending
+	// yesterday if no XP has been gained today yet.
+	Current int `json:"current"`
+	// Longest is the longest run of consecutive days with XP.
+	Longest int `json:"longest"`
+	// LongestEnd is the last day of the longest run. It is zero if there is no run.
+	LongestEnd time.Time `json:"longest_end,omitzero"`
+}
+
+// Streaks computes the user's coding streaks from the Dates map.
+// Days are taken in the location of now.
+func (p *UserProfile) Streaks(now time.Time) Streak {
+	var streak Streak
+
+	series, _ := p.DailySeries()
+	run := 0
+	var prev Date
+	for _, day := range series {
+		if day.XP <= 0 {
+			run = 0
+			continue
+		}
+		if run > 0 && day.Date == prev.AddDays(1) {
+			run++
+		} else {
+			run = 1
+		}
+		prev = day.Date
+		if run > streak.Longest {
+			streak.Longest, streak.LongestEnd = run, day.Date.Time(now.Location())
+		}
+	}
+
+	day := DateOf(now)
+	if p.XPOnDate(day) <= 0 {
+		day = day.AddDays(-1)
+	}
+	for p.XPOnDate(day) > 0 {
+		streak.Current++
+		day = day.AddDays(-1)
+	}
+
+	return streak
+}
@@ -0,0 +1,61 @@
+package godestats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserProfile_Streaks(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		dates      map[string]XP
+		current    int
+		longest    int
+		longestEnd string
+	}{
+		{"no activity", nil, 0, 0, ""},
+		{
+			"active today",
+			map[string]XP{"2023-06-13": 10, "2023-06-14": 10, "2023-06-15": 10},
+			3, 3, "2023-06-15",
+		},
+		{
+			"not yet active today",
+			map[string]XP{"2023-06-13": 10, "2023-06-14": 10},
+			2, 2, "2023-06-14",
+		},
+		{
+			"broken streak",
+			map[string]XP{"2023-06-01": 5, "2023-06-02": 5, "2023-06-03": 5, "2023-06-13": 10},
+			0, 3, "2023-06-03",
+		},
+		{
+			"zero XP day breaks a run",
+			map[string]XP{"2023-06-10": 5, "2023-06-11": 0, "2023-06-12": 5, "2023-06-13": 5, "2023-06-14": 5},
+			3, 3, "2023-06-14",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := (&UserProfile{Dates: tt.dates}).Streaks(now)
+
+			if streak.Current != tt.current {
+				t.Errorf("Expected current streak %d, got %d", tt.current, streak.Current)
+			}
+			if streak.Longest != tt.longest {
+				t.Errorf("Expected longest streak %d, got %d", tt.longest, streak.Longest)
+			}
+
+			end := ""
+			if !streak.LongestEnd.IsZero() {
+				end = streak.LongestEnd.Format(DateFormat)
+			}
+			if end != tt.longestEnd {
+				t.Errorf("Expected longest streak to end on '%s', got '%s'", tt.longestEnd, end)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Package termchart renders compact charts for terminals using Unicode block and
+// braille characters, optionally colored with ANSI escape sequences.
+package termchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// blocks are the eighth-height block characters used by Sparkline.
+var blocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// partialBlocks are the eighth-width block characters used by bar charts.
+var partialBlocks = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// heatmapShades are the uncolored heatmap cells from no activity to the highest activity.
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapColors are the ANSI 256-color codes of the heatmap levels.
+var heatmapColors = []int{237, 24, 31, 38, 45}
+
+// ANSI escape sequences
+const (
+	ansiReset = "\x1b[0m"
+	ansiBar   = "\x1b[38;5;39m"
+)
+
+// Sparkline renders the values as a single line of block characters scaled to the maximum.
+func Sparkline(values []godestats.XP) string {
+	peak := maxXP(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		if peak <= 0 || v <= 0 {
+			b.WriteRune(blocks[0])
+			continue
+		}
+		i := int(float64(v) / float64(peak) * float64(len(blocks)-1))
+		b.WriteRune(blocks[i])
+	}
+	return b.String()
+}
+
+// Braille renders the values as a filled area chart of the given number of text rows.
+// Every character holds two values side by side with four vertical dots each,
+// giving twice the horizontal and four times the vertical resolution of block characters.
+func Braille(values []godestats.XP, rows int) []string {
+	if rows <= 0 {
+		rows = 1
+	}
+
+	peak := maxXP(values)
+	dots := rows * 4
+	heights := make([]int, len(values))
+	for i, v := range values {
+		if peak > 0 && v > 0 {
+			heights[i] = max(1, int(float64(v)/float64(peak)*float64(dots)+0.5))
+		}
+	}
+
+	// Dot bit offsets within a braille cell, from the bottom row up, for the left and right column
+	leftBits := [4]rune{0x40, 0x04, 0x02, 0x01}
+	rightBits := [4]rune{0x80, 0x20, 0x10, 0x08}
+
+	lines := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		base := (rows - 1 - row) * 4 // dot height at the bottom of this row
+		var b strings.Builder
+		for i := 0; i < len(heights); i += 2 {
+			cell := rune(0x2800)
+			for dot := 0; dot < 4; dot++ {
+				if heights[i] > base+dot {
+					cell |= leftBits[dot]
+				}
+				if i+1 < len(heights) && heights[i+1] > base+dot {
+					cell |= rightBits[dot]
+				}
+			}
+			b.WriteRune(cell)
+		}
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// Bar is a labeled value of a bar chart.
+type Bar struct {
+	Label string
+	Value godestats.XP
+}
+
+// Bars renders a horizontal bar chart with one line per bar. Bars are scaled so that
+// the largest value spans width characters; labels are padded to a common width.
+func Bars(bars []Bar, width int, color bool) []string {
+	peak := godestats.XP(0)
+	labelWidth := 0
+	for _, bar := range bars {
+		peak = max(peak, bar.Value)
+		labelWidth = max(labelWidth, utf8.RuneCountInString(bar.Label))
+	}
+
+	lines := make([]string, len(bars))
+	for i, bar := range bars {
+		eighths := 0
+		if peak > 0 && bar.Value > 0 {
+			eighths = int(float64(bar.Value) / float64(peak) * float64(width*8))
+		}
+
+		label := bar.Label + strings.Repeat(" ", labelWidth-utf8.RuneCountInString(bar.Label))
+		lines[i] = fmt.Sprintf("%s %s %s", label, fill(eighths, width, color), bar.Value.Short())
+	}
+	return lines
+}
+
+// Gauge renders a progress bar of the given width filled to the fraction, which is
+// clamped to [0, 1].
+func Gauge(fraction float64, width int, color bool) string {
+	fraction = min(max(fraction, 0), 1)
+	return fill(int(fraction*float64(width*8)), width, color)
+}
+
+// fill renders a bar of the given number of eighth blocks, padded to width characters.
+func fill(eighths, width int, color bool) string {
+	full := strings.Repeat("█", eighths/8)
+	if eighths%8 > 0 {
+		full += string(partialBlocks[eighths%8])
+	}
+	padding := strings.Repeat(" ", width-utf8.RuneCountInString(full))
+	if color {
+		full = ansiBar + full + ansiReset
+	}
+	return full + padding
+}
+
+// Heatmap renders a GitHub-style calendar of daily XP with one row per weekday,
+// starting on Monday, and one column per week ending with the week containing now.
+// Without color, activity levels are drawn with shading characters.
+func Heatmap(profile *godestats.UserProfile, weeks int, now time.Time, color bool) []string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekday := (int(today.Weekday()) + 6) % 7 // Monday = 0
+	start := today.AddDate(0, 0, -weekday-7*(weeks-1))
+
+	var peak godestats.XP
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		peak = max(peak, profile.XPOn(day))
+	}
+
+	labels := []string{"Mon", "   ", "Wed", "   ", "Fri", "   ", "Sun"}
+	lines := make([]string, 7)
+	for row := range lines {
+		var b strings.Builder
+		b.WriteString(labels[row])
+		b.WriteByte(' ')
+		for week := 0; week < weeks; week++ {
+			day := start.AddDate(0, 0, week*7+row)
+			if day.After(today) {
+				break
+			}
+			level := level(profile.XPOn(day), peak, len(heatmapShades)-1)
+			if color {
+				fmt.Fprintf(&b, "\x1b[38;5;%dm■%s", heatmapColors[level], ansiReset)
+			} else {
+				b.WriteString(heatmapShades[level])
+			}
+		}
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// HeatmapMonths renders the month labels above a Heatmap of the given number of
+// weeks. Each label starts at the first week column of its month, padded to line up
+// with the weekday labels; labels that would overlap the previous one are skipped.
+func HeatmapMonths(weeks int, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekday := (int(today.Weekday()) + 6) % 7 // Monday = 0
+	start := today.AddDate(0, 0, -weekday-7*(weeks-1))
+
+	line := []byte(strings.Repeat(" ", 4+weeks))
+	next := 0 // first column a label may start at
+	for week := 0; week < weeks; week++ {
+		// A week belongs to the month of its last day, so labels start at the first full week
+		day := start.AddDate(0, 0, week*7+6)
+		if week > 0 && day.AddDate(0, 0, -7).Month() == day.Month() {
+			continue
+		}
+
+		col := 4 + week
+		label := day.Format("Jan")
+		if col < next || col+len(label) > len(line) {
+			continue
+		}
+		copy(line[col:], label)
+		next = col + len(label) + 1
+	}
+	return strings.TrimRight(string(line), " ")
+}
+
+// HeatmapLegend renders the activity levels of a Heatmap from least to most activity.
+func HeatmapLegend(color bool) string {
+	var b strings.Builder
+	b.WriteString("Less ")
+	for level, shade := range heatmapShades {
+		if color {
+			fmt.Fprintf(&b, "\x1b[38;5;%dm■%s", heatmapColors[level], ansiReset)
+		} else {
+			b.WriteString(shade)
+		}
+	}
+	b.WriteString(" More")
+	return b.String()
+}
+
+// Calendar renders a small month-style calendar with a header of weekdays, starting
+// on Monday, and one row per week ending with the week containing now. Days with XP
+// show their day of the month, days without XP a dot; days after now are left empty.
+// With color, active days are highlighted by their activity level.
+func Calendar(profile *godestats.UserProfile, weeks int, now time.Time, color bool) []string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekday := (int(today.Weekday()) + 6) % 7 // Monday = 0
+	start := today.AddDate(0, 0, -weekday-7*(weeks-1))
+
+	var peak godestats.XP
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		peak = max(peak, profile.XPOn(day))
+	}
+
+	lines := []string{"Mo Tu We Th Fr Sa Su"}
+	for week := 0; week < weeks; week++ {
+		cells := make([]string, 0, 7)
+		for col := 0; col < 7; col++ {
+			day := start.AddDate(0, 0, week*7+col)
+			if day.After(today) {
+				break
+			}
+
+			xp := profile.XPOn(day)
+			switch {
+			case xp <= 0:
+				cells = append(cells, " ·")
+			case color:
+				cells = append(cells, fmt.Sprintf("\x1b[38;5;%dm%2d%s", heatmapColors[level(xp, peak, len(heatmapColors)-1)], day.Day(), ansiReset))
+			default:
+				cells = append(cells, fmt.Sprintf("%2d", day.Day()))
+			}
+		}
+		lines = append(lines, strings.Join(cells, " "))
+	}
+	return lines
+}
+
+// level maps a value to an activity level between 0 and levels relative to the peak.
+func level(value, peak godestats.XP, levels int) int {
+	if value <= 0 || peak <= 0 {
+		return 0
+	}
+	return min(int(math.Ceil(float64(value)/float64(peak)*float64(levels))), levels)
+}
+
+// maxXP returns the largest value, or 0 for an empty slice.
+func maxXP(values []godestats.XP) godestats.XP {
+	var peak godestats.XP
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	return peak
+}
@@ -0,0 +1,168 @@
+package termchart
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []godestats.XP
+		expected string
+	}{
+		{"empty", nil, ""},
+		{"all zero", []godestats.XP{0, 0}, "▁▁"},
+		{"scaled", []godestats.XP{0, 50, 100}, "▁▄█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Sparkline(tt.values); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBraille(t *testing.T) {
+	lines := Braille([]godestats.XP{0, 100, 50}, 1)
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line, got %d", len(lines))
+	}
+
+	// First cell: left empty, right full column; second cell: left half column
+	expected := string([]rune{0x2800 | 0x80 | 0x20 | 0x10 | 0x08, 0x2800 | 0x40 | 0x04})
+	if lines[0] != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, lines[0])
+	}
+
+	two := Braille([]godestats.XP{100, 100}, 2)
+	if two[0] != "⣿" || two[1] != "⣿" {
+		t.Errorf("Expected full cells in both rows, got %q", two)
+	}
+}
+
+func TestBars(t *testing.T) {
+	lines := Bars([]Bar{{"Go", 1000}, {"Rust", 500}, {"C", 0}}, 10, false)
+
+	expected := []string{
+		"Go   ██████████ 1k",
+		"Rust █████      500",
+		"C               0",
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Expected line %d to be '%s', got '%s'", i, expected[i], lines[i])
+		}
+	}
+
+	colored := Bars([]Bar{{"Go", 1}}, 4, true)
+	if !strings.Contains(colored[0], ansiBar) || !strings.Contains(colored[0], ansiReset) {
+		t.Errorf("Expected ANSI colors, got %q", colored[0])
+	}
+}
+
+func TestGauge(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		expected string
+	}{
+		{"empty", 0, "          "},
+		{"half", 0.5, "█████     "},
+		{"partial", 0.05, "▌         "},
+		{"overfull", 1.5, "██████████"},
+		{"negative", -1, "          "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Gauge(tt.fraction, 10, false); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	now := time.Date(2023, 6, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+	profile := &godestats.UserProfile{Dates: map[string]godestats.XP{
+		"2023-06-12": 100, // Monday
+		"2023-06-14": 25,  // Wednesday
+	}}
+
+	lines := Heatmap(profile, 2, now, false)
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines, got %d", len(lines))
+	}
+	if lines[0] != "Mon ·█" {
+		t.Errorf("Expected Monday row 'Mon ·█', got '%s'", lines[0])
+	}
+	if lines[2] != "Wed ·░" {
+		t.Errorf("Expected Wednesday row 'Wed ·░', got '%s'", lines[2])
+	}
+	if lines[3] != "    ·" {
+		t.Errorf("Expected Thursday row to stop at today, got '%s'", lines[3])
+	}
+
+	colored := Heatmap(profile, 1, now, true)
+	if !strings.Contains(colored[0], "\x1b[38;5;45m") {
+		t.Errorf("Expected highest activity color, got %q", colored[0])
+	}
+}
+
+func TestHeatmapMonths(t *testing.T) {
+	now := time.Date(2023, 6, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	// Weeks ending Sundays from Apr 23 to Jun 18, with May overlapping April's label
+	if got, expected := HeatmapMonths(9, now), "    Apr   Jun"; got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+
+	// Weeks ending Jun 4, Jun 11 and Jun 18 all belong to June
+	if got, expected := HeatmapMonths(3, now), "    Jun"; got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestHeatmapLegend(t *testing.T) {
+	if got, expected := HeatmapLegend(false), "Less ·░▒▓█ More"; got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+	if !strings.Contains(HeatmapLegend(true), "\x1b[38;5;45m■") {
+		t.Error("Expected colored legend")
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	now := time.Date(2023, 6, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+	profile := &godestats.UserProfile{Dates: map[string]godestats.XP{
+		"2023-06-05": 10,  // Monday of the previous week
+		"2023-06-12": 100, // Monday
+		"2023-06-14": 25,  // Wednesday
+	}}
+
+	lines := Calendar(profile, 2, now, false)
+	expected := []string{
+		"Mo Tu We Th Fr Sa Su",
+		" 5  ·  ·  ·  ·  ·  ·",
+		"12  · 14",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %q", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Expected line %d '%s', got '%s'", i, expected[i], lines[i])
+		}
+	}
+
+	colored := Calendar(profile, 1, now, true)
+	if !strings.Contains(colored[1], "\x1b[38;5;45m12") {
+		t.Errorf("Expected highest activity color on the 12th, got %q", colored[1])
+	}
+}
@@ -0,0 +1,112 @@
+// Package tracker converts editor events into Code::Stats XP, so that third-party
+// editor integrations award XP comparable to the official plugins.
+package tracker
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// EventKind is the kind of an editor event.
+type EventKind string
+
+// Kinds of editor events
+const (
+	// Typed is text typed or deleted with the keyboard, one character per keystroke.
+	Typed EventKind = "typed"
+	// Changed is an edit not made by typing, such as a paste, an autocompletion,
+	// an undo or a refactoring.
+	Changed EventKind = "changed"
+	// Saved is a file being saved.
+	Saved EventKind = "saved"
+)
+
+// Event is something that happened in an editor.
+type Event struct {
+	Kind EventKind `json:"kind"`
+	// Language is the language of the document, as reported by the editor.
+	Language string `json:"language"`
+	// Characters is the number of characters typed, inserted or deleted.
+	Characters int `json:"characters,omitempty"`
+	// Lines is the number of lines a change touched.
+	Lines int `json:"lines,omitempty"`
+}
+
+// XPModel describes how much XP editor events are worth.
+type XPModel struct {
+	// PerCharacter is the XP per character typed or deleted with the keyboard.
+	PerCharacter godestats.XP `json:"per_character"`
+	// PerChange is the XP per change not made by typing, regardless of its size.
+	PerChange godestats.XP `json:"per_change"`
+	// PerLine is the XP per line touched by a change, in addition to PerChange.
+	PerLine godestats.XP `json:"per_line"`
+	// PerSave is the XP per file save.
+	PerSave godestats.XP `json:"per_save"`
+	// MaxPerEvent caps the XP of a single event. Zero means no cap.
+	MaxPerEvent godestats.XP `json:"max_per_event"`
+}
+
+// DefaultXPModel matches the official plugins, which award one XP per change to a
+// document: every typed or deleted character counts, a paste counts once, and saving
+// is worth nothing.
+var DefaultXPModel = XPModel{
+	PerCharacter: 1,
+	PerChange:    1,
+}
+
+// XP returns the XP the event is worth. Events that didn't change anything, such
+// as a change without characters or lines, and events of unknown kinds are worth
+// nothing; negative counts are treated as zero.
+func (m XPModel) XP(e Event) godestats.XP {
+	characters := godestats.XP(max(e.Characters, 0))
+	lines := godestats.XP(max(e.Lines, 0))
+
+	var xp godestats.XP
+	switch e.Kind {
+	case Typed:
+		xp = m.PerCharacter * characters
+	case Changed:
+		if characters > 0 || lines > 0 {
+			xp = m.PerChange + m.PerLine*lines
+		}
+	case Saved:
+		xp = m.PerSave
+	}
+
+	if m.MaxPerEvent > 0 {
+		xp = min(xp, m.MaxPerEvent)
+	}
+	return max(xp, 0)
+}
+
+// Tally returns the XP of the events per language, named as on Code::Stats.
+// Events without a language and languages without XP are left out.
+func (m XPModel) Tally(events ...Event) map[string]godestats.XP {
+	tally := make(map[string]godestats.XP)
+	for _, e := range events {
+		language := godestats.CanonicalLanguage(e.Language)
+		if language == "" {
+			continue
+		}
+		if xp := m.XP(e); xp > 0 {
+			tally[language] = tally[language].AddSaturating(xp)
+		}
+	}
+	return tally
+}
+
+// Pulse returns a pulse crediting the XP of the events, ordered by language.
+// The pulse has no XPs if the events are worth nothing.
+func (m XPModel) Pulse(codedAt time.Time, events ...Event) godestats.Pulse {
+	pulse := godestats.Pulse{CodedAt: codedAt}
+	for language, xp := range m.Tally(events...) {
+		pulse.XPs = append(pulse.XPs, godestats.LanguageXP{Language: language, XP: xp})
+	}
+	slices.SortFunc(pulse.XPs, func(a, b godestats.LanguageXP) int {
+		return strings.Compare(a.Language, b.Language)
+	})
+	return pulse
+}
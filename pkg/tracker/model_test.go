@@ -0,0 +1,81 @@
+package tracker
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestXPModel_XP(t *testing.T) {
+	custom := XPModel{PerCharacter: 2, PerChange: 5, PerLine: 1, PerSave: 3, MaxPerEvent: 20}
+
+	tests := []struct {
+		name     string
+		model    XPModel
+		event    Event
+		expected godestats.XP
+	}{
+		{"typed", DefaultXPModel, Event{Kind: Typed, Characters: 12}, 12},
+		{"paste", DefaultXPModel, Event{Kind: Changed, Characters: 400, Lines: 20}, 1},
+		{"deleted line", DefaultXPModel, Event{Kind: Changed, Lines: 1}, 1},
+		{"empty change", DefaultXPModel, Event{Kind: Changed}, 0},
+		{"save", DefaultXPModel, Event{Kind: Saved}, 0},
+		{"unknown kind", DefaultXPModel, Event{Kind: "scrolled", Characters: 5}, 0},
+		{"negative counts", DefaultXPModel, Event{Kind: Typed, Characters: -3}, 0},
+		{"custom typed", custom, Event{Kind: Typed, Characters: 4}, 8},
+		{"custom change", custom, Event{Kind: Changed, Characters: 30, Lines: 3}, 8},
+		{"custom save", custom, Event{Kind: Saved}, 3},
+		{"capped", custom, Event{Kind: Typed, Characters: 100}, 20},
+	}
+
+	for _, tt := range tests {
+		if xp := tt.model.XP(tt.event); xp != tt.expected {
+			t.Errorf("%s: expected %d XP, got %d", tt.name, tt.expected, xp)
+		}
+	}
+}
+
+func TestXPModel_Pulse(t *testing.T) {
+	codedAt := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Kind: Typed, Language: "golang", Characters: 10},
+		{Kind: Typed, Language: "Go", Characters: 5},
+		{Kind: Changed, Language: "rust", Characters: 80, Lines: 4},
+		{Kind: Saved, Language: "Go"},
+		{Kind: Typed, Characters: 7},
+		{Kind: Changed, Language: "Markdown"},
+	}
+
+	pulse := DefaultXPModel.Pulse(codedAt, events...)
+	if !pulse.CodedAt.Equal(codedAt) {
+		t.Errorf("Expected coded at %v, got %v", codedAt, pulse.CodedAt)
+	}
+
+	expected := []godestats.LanguageXP{{Language: "Go", XP: 15}, {Language: "Rust", XP: 1}}
+	if len(pulse.XPs) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, pulse.XPs)
+	}
+	for i := range expected {
+		if pulse.XPs[i] != expected[i] {
+			t.Errorf("Expected %v at %d, got %v", expected[i], i, pulse.XPs[i])
+		}
+	}
+
+	if empty := DefaultXPModel.Pulse(codedAt, Event{Kind: Saved, Language: "Go"}); len(empty.XPs) != 0 {
+		t.Errorf("Expected no XPs, got %v", empty.XPs)
+	}
+}
+
+func TestXPModel_JSON(t *testing.T) {
+	var model XPModel
+	if err := json.Unmarshal([]byte(`{"per_character": 1, "per_change": 2, "per_save": 1, "max_per_event": 50}`), &model); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := XPModel{PerCharacter: 1, PerChange: 2, PerSave: 1, MaxPerEvent: 50}
+	if model != expected {
+		t.Errorf("Expected %+v, got %+v", expected, model)
+	}
+}
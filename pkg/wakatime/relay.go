@@ -0,0 +1,344 @@
+// Package wakatime relays heartbeats of WakaTime editor plugins to Code::Stats, so
+// that any editor with a WakaTime plugin can feed Code::Stats through a small local
+// server. Point the plugin's api_url at the relay, e.g. http://localhost:8080/api/v1.
+package wakatime
+
+import (
+	"cmp"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/languages"
+)
+
+// Defaults of the relay
+const (
+	// DefaultXPPerMinute is the XP credited per minute of activity. Heartbeats don't
+	// carry keystrokes, so XP is estimated from the time spent coding.
+	DefaultXPPerMinute = 30
+
+	// DefaultTimeout is the longest gap between two heartbeats that still counts as
+	// activity, matching WakaTime's own keystroke timeout.
+	DefaultTimeout = 2 * time.Minute
+
+	// DefaultFlushInterval is how often Run sends the accumulated XP as a pulse.
+	DefaultFlushInterval = time.Minute
+)
+
+// Request body limits. Larger bodies are rejected with 413 Request Entity Too Large.
+const (
+	// MaxHeartbeatSize is the largest body of a single heartbeat.
+	MaxHeartbeatSize = 64 << 10
+
+	// MaxBulkSize is the largest body of a bulk request. Plugins send at most a few
+	// dozen heartbeats at once.
+	MaxBulkSize = 1 << 20
+)
+
+// Heartbeat is a WakaTime heartbeat as posted by editor plugins. Fields the relay
+// doesn't use are ignored.
+type Heartbeat struct {
+	Entity   string  `json:"entity"`
+	Type     string  `json:"type"`
+	Category string  `json:"category,omitempty"`
+	Time     float64 `json:"time"`
+	Project  string  `json:"project,omitempty"`
+	Language string  `json:"language,omitempty"`
+	IsWrite  bool    `json:"is_write,omitempty"`
+}
+
+// At returns the time of the heartbeat.
+func (h Heartbeat) At() time.Time {
+	sec, frac := math.Modf(h.Time)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// language returns the Code::Stats name of the heartbeat's language, detected
+// from the file name if the plugin didn't report one.
+func (h Heartbeat) language() string {
+	if h.Language != "" {
+		return godestats.CanonicalLanguage(h.Language)
+	}
+	if h.Type == "file" {
+		if lang, ok := languages.Detect(h.Entity); ok {
+			return godestats.CanonicalLanguage(lang.Name)
+		}
+	}
+	return ""
+}
+
+// Option configures a Relay.
+type Option func(*Relay)
+
+// WithXPPerMinute sets the XP credited per minute of activity.
+func WithXPPerMinute(xp float64) Option {
+	return func(r *Relay) {
+		if xp > 0 {
+			r.rate = xp
+		}
+	}
+}
+
+// WithTimeout sets the longest gap between two heartbeats that counts as activity.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Relay) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
+
+// WithFlushInterval sets how often Run sends the accumulated XP.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(r *Relay) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithAPIKey makes the relay reject heartbeats that are not authenticated with the
+// given key, which is the api_key configured in the WakaTime plugin.
+// By default, heartbeats are accepted without authentication.
+func WithAPIKey(key string) Option {
+	return func(r *Relay) {
+		r.apiKey = key
+	}
+}
+
+// WithErrorHandler sets a function that is called for every failed flush while
+// the relay is running. Errors are ignored by default.
+func WithErrorHandler(handler func(error)) Option {
+	return func(r *Relay) {
+		r.onError = handler
+	}
+}
+
+// Relay accumulates the XP of WakaTime heartbeats per language and sends it to
+// Code::Stats as pulses. It serves the heartbeat endpoints of the WakaTime API as
+// an http.Handler and is safe for concurrent use.
+type Relay struct {
+	client   godestats.CodeStatsClient
+	rate     float64
+	timeout  time.Duration
+	interval time.Duration
+	apiKey   string
+	onError  func(error)
+	mux      *http.ServeMux
+
+	mu      sync.Mutex
+	last    *Heartbeat
+	pending map[string]float64
+	codedAt time.Time
+}
+
+// NewRelay creates a relay sending pulses through the client, which needs an API token.
+func NewRelay(client godestats.CodeStatsClient, opts ...Option) *Relay {
+	r := &Relay{
+		client:   client,
+		rate:     DefaultXPPerMinute,
+		timeout:  DefaultTimeout,
+		interval: DefaultFlushInterval,
+		onError:  func(error) {},
+		mux:      http.NewServeMux(),
+		pending:  make(map[string]float64),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.mux.HandleFunc("POST /api/v1/users/{user}/heartbeats", r.serveHeartbeat)
+	r.mux.HandleFunc("POST /api/v1/users/{user}/heartbeats.bulk", r.serveBulk)
+	return r
+}
+
+// Add credits the time since the previous heartbeat to the previous heartbeat's
+// language, unless the gap exceeds the timeout. Heartbeats older than the latest
+// one already added are ignored. Languages are named as on Code::Stats, and
+// detected from the file name of heartbeats without one.
+func (r *Relay) Add(heartbeats ...Heartbeat) {
+	heartbeats = slices.Clone(heartbeats)
+	slices.SortStableFunc(heartbeats, func(a, b Heartbeat) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, hb := range heartbeats {
+		if r.last != nil {
+			gap := hb.At().Sub(r.last.At())
+			if gap < 0 {
+				continue
+			}
+			if gap <= r.timeout && r.last.Language != "" {
+				r.pending[r.last.Language] += gap.Minutes() * r.rate
+				r.codedAt = hb.At()
+			}
+		}
+		hb.Language = hb.language()
+		r.last = &hb
+	}
+}
+
+// Pending returns the whole XP per language not yet sent.
+func (r *Relay) Pending() map[string]godestats.XP {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending := make(map[string]godestats.XP)
+	for language, xp := range r.pending {
+		if whole := godestats.XP(xp); whole > 0 {
+			pending[language] = whole
+		}
+	}
+	return pending
+}
+
+// Flush sends the whole XP accumulated so far as a single pulse; fractions are kept
+// for the next flush. If sending fails, the XP is kept and sent with the next flush,
+// unless the API rejected the pulse for being too old.
+func (r *Relay) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pulse := godestats.Pulse{CodedAt: r.codedAt}
+	for language, xp := range r.pending {
+		if whole := godestats.XP(xp); whole > 0 {
+			pulse.XPs = append(pulse.XPs, godestats.LanguageXP{Language: language, XP: whole})
+			r.pending[language] -= float64(whole)
+		}
+	}
+	r.mu.Unlock()
+
+	if len(pulse.XPs) == 0 {
+		return nil
+	}
+	slices.SortFunc(pulse.XPs, func(a, b godestats.LanguageXP) int {
+		return strings.Compare(a.Language, b.Language)
+	})
+
+	err := r.client.SendPulse(ctx, pulse)
+	if err != nil && !errors.Is(err, godestats.ErrPulseTimestampTooOld) {
+		r.mu.Lock()
+		for _, lxp := range pulse.XPs {
+			r.pending[lxp.Language] += float64(lxp.XP)
+		}
+		r.mu.Unlock()
+	}
+	return err
+}
+
+// Run flushes the accumulated XP once per flush interval until the context is
+// canceled, then flushes a last time and returns the context's error.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			if err := r.Flush(flushCtx); err != nil {
+				r.onError(err)
+			}
+			cancel()
+			return ctx.Err()
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.onError(err)
+			}
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Relay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !r.authorized(req) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid api key"})
+		return
+	}
+	r.mux.ServeHTTP(w, req)
+}
+
+// serveHeartbeat accepts a single heartbeat.
+func (r *Relay) serveHeartbeat(w http.ResponseWriter, req *http.Request) {
+	var hb Heartbeat
+	if !decodeBody(w, req, MaxHeartbeatSize, &hb, "heartbeat") {
+		return
+	}
+
+	r.Add(hb)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": hb})
+}
+
+// serveBulk accepts a list of heartbeats, answering with a response per heartbeat
+// as the WakaTime API does.
+func (r *Relay) serveBulk(w http.ResponseWriter, req *http.Request) {
+	var heartbeats []Heartbeat
+	if !decodeBody(w, req, MaxBulkSize, &heartbeats, "heartbeats") {
+		return
+	}
+
+	r.Add(heartbeats...)
+
+	responses := make([][]any, len(heartbeats))
+	for i, hb := range heartbeats {
+		responses[i] = []any{map[string]any{"data": hb}, http.StatusCreated}
+	}
+	writeJSON(w, http.StatusCreated, map[string]any{"responses": responses})
+}
+
+// decodeBody decodes the JSON body of the request into v, reading at most limit
+// bytes. Invalid or oversized bodies are answered with an error naming what, and
+// decodeBody reports whether the request can proceed.
+func decodeBody(w http.ResponseWriter, req *http.Request, limit int64, v any, what string) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, req.Body, limit)).Decode(v)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &tooLarge):
+		writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"error": what + " too large"})
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid " + what})
+	}
+	return false
+}
+
+// authorized reports whether the request carries the API key, which plugins send
+// base64-encoded as HTTP basic credentials or bearer token.
+func (r *Relay) authorized(req *http.Request) bool {
+	if r.apiKey == "" {
+		return true
+	}
+
+	scheme, credentials, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+	key := credentials
+	if strings.EqualFold(scheme, "Basic") {
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return false
+		}
+		key, _, _ = strings.Cut(string(decoded), ":")
+	} else if !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(key), []byte(r.apiKey)) == 1
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
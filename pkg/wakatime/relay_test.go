@@ -0,0 +1,211 @@
+package wakatime
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// pulseClient records the pulses sent through it.
+type pulseClient struct {
+	mu     sync.Mutex
+	pulses []godestats.Pulse
+	err    error
+}
+
+func (c *pulseClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	return nil, godestats.ErrUserNotFound
+}
+
+func (c *pulseClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+	c.pulses = append(c.pulses, pulse)
+	return nil
+}
+
+// start is the time of the first heartbeat in the tests.
+var start = float64(time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC).Unix())
+
+func TestRelay_Add(t *testing.T) {
+	r := NewRelay(&pulseClient{}, WithXPPerMinute(60))
+
+	// Out of order, with a gap beyond the timeout and an unknown language
+	r.Add(
+		Heartbeat{Time: start + 60, Language: "Go"},
+		Heartbeat{Time: start, Language: "Go"},
+		Heartbeat{Time: start + 90, Language: "Rust"},
+		Heartbeat{Time: start + 120, Language: ""},
+		Heartbeat{Time: start + 150, Language: "Rust"},
+		Heartbeat{Time: start + 600, Language: "Rust"},
+		Heartbeat{Time: start + 630, Language: "Rust"},
+	)
+	// Older than the latest heartbeat
+	r.Add(Heartbeat{Time: start + 10, Language: "Go"})
+
+	pending := r.Pending()
+	if len(pending) != 2 || pending["Go"] != 90 || pending["Rust"] != 60 {
+		t.Errorf("Expected 90 XP for Go and 60 XP for Rust, got %v", pending)
+	}
+}
+
+func TestRelay_AddDetectsLanguages(t *testing.T) {
+	r := NewRelay(&pulseClient{}, WithXPPerMinute(60))
+
+	// Plugins without language detection, and with non-canonical names
+	r.Add(
+		Heartbeat{Time: start, Type: "file", Entity: "/src/main.go"},
+		Heartbeat{Time: start + 30, Type: "file", Entity: "/src/lib.rs", Language: "rust"},
+		Heartbeat{Time: start + 60, Type: "domain", Entity: "example.com"},
+		Heartbeat{Time: start + 90},
+	)
+
+	pending := r.Pending()
+	if len(pending) != 2 || pending["Go"] != 30 || pending["Rust"] != 30 {
+		t.Errorf("Expected 30 XP for Go and Rust each, got %v", pending)
+	}
+
+	if lang := (Heartbeat{Type: "file", Entity: "/scripts/deploy.bash"}).language(); lang != "Shell" {
+		t.Errorf("Expected Shell, got %q", lang)
+	}
+}
+
+func TestRelay_Flush(t *testing.T) {
+	client := &pulseClient{}
+	r := NewRelay(client)
+	ctx := context.Background()
+
+	r.Add(Heartbeat{Time: start, Language: "Go"}, Heartbeat{Time: start + 61, Language: "Go"})
+
+	client.err = godestats.ErrRateLimited
+	if err := r.Flush(ctx); !errors.Is(err, godestats.ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if r.Pending()["Go"] != 30 {
+		t.Errorf("Expected the XP to be kept after a failed flush, got %v", r.Pending())
+	}
+
+	client.err = nil
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.pulses) != 1 {
+		t.Fatalf("Expected one pulse, got %+v", client.pulses)
+	}
+	pulse := client.pulses[0]
+	if len(pulse.XPs) != 1 || pulse.XPs[0] != (godestats.LanguageXP{Language: "Go", XP: 30}) {
+		t.Errorf("Expected 30 XP for Go, got %+v", pulse.XPs)
+	}
+	if !pulse.CodedAt.Equal(time.Unix(int64(start)+61, 0)) {
+		t.Errorf("Expected the pulse at the last heartbeat, got %v", pulse.CodedAt)
+	}
+
+	// The remaining half XP is kept, so nothing is sent
+	if err := r.Flush(ctx); err != nil || len(client.pulses) != 1 {
+		t.Errorf("Expected no pulse for fractional XP, got %+v (err=%v)", client.pulses, err)
+	}
+}
+
+func TestRelay_FlushTooOld(t *testing.T) {
+	client := &pulseClient{err: godestats.ErrPulseTimestampTooOld}
+	r := NewRelay(client)
+
+	r.Add(Heartbeat{Time: start, Language: "Go"}, Heartbeat{Time: start + 60, Language: "Go"})
+	if err := r.Flush(context.Background()); !errors.Is(err, godestats.ErrPulseTimestampTooOld) {
+		t.Fatalf("Expected ErrPulseTimestampTooOld, got %v", err)
+	}
+	if len(r.Pending()) != 0 {
+		t.Errorf("Expected XP of a rejected pulse to be dropped, got %v", r.Pending())
+	}
+}
+
+func TestRelay_ServeHTTP(t *testing.T) {
+	relay := NewRelay(&pulseClient{}, WithAPIKey("waka-key"))
+	server := httptest.NewServer(relay)
+	defer server.Close()
+
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("waka-key"))
+	post := func(path, body, authorization string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(body))
+		req.Header.Set("Authorization", authorization)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	resp := post("/api/v1/users/current/heartbeats", `{"entity": "main.go", "time": 1686830400, "language": "Go"}`, auth)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", resp.StatusCode)
+	}
+
+	resp = post("/api/v1/users/current/heartbeats.bulk",
+		`[{"entity": "main.go", "time": 1686830460, "language": "Go"}, {"entity": "lib.rs", "time": 1686830520, "language": "Rust"}]`, auth)
+	var bulk struct {
+		Responses [][]json.RawMessage `json:"responses"`
+	}
+	json.NewDecoder(resp.Body).Decode(&bulk)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || len(bulk.Responses) != 2 || string(bulk.Responses[1][1]) != "201" {
+		t.Errorf("Expected a response per heartbeat, got %d %+v", resp.StatusCode, bulk.Responses)
+	}
+
+	resp = post("/api/v1/users/current/heartbeats", `{"time": 1686830580}`, "Basic "+base64.StdEncoding.EncodeToString([]byte("wrong")))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for a wrong key, got %d", resp.StatusCode)
+	}
+
+	resp = post("/api/v1/users/current/heartbeats", `not json`, "Bearer waka-key")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid heartbeat, got %d", resp.StatusCode)
+	}
+
+	resp = post("/api/v1/users/current/heartbeats", `{"entity": "`+strings.Repeat("a", MaxHeartbeatSize)+`"}`, auth)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for an oversized heartbeat, got %d", resp.StatusCode)
+	}
+
+	resp = post("/api/v1/users/current/heartbeats.bulk", `[{"entity": "`+strings.Repeat("a", MaxBulkSize)+`"}]`, auth)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for an oversized bulk request, got %d", resp.StatusCode)
+	}
+
+	if pending := relay.Pending(); len(pending) != 1 || pending["Go"] != 60 {
+		t.Errorf("Expected 60 XP for Go from the accepted heartbeats, got %v", pending)
+	}
+}
+
+func TestRelay_Run(t *testing.T) {
+	client := &pulseClient{}
+	r := NewRelay(client, WithFlushInterval(time.Hour))
+	r.Add(Heartbeat{Time: start, Language: "Go"}, Heartbeat{Time: start + 60, Language: "Go"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := r.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(client.pulses) != 1 {
+		t.Errorf("Expected a final flush when stopped, got %+v", client.pulses)
+	}
+}
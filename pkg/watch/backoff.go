@@ -0,0 +1,33 @@
+package watch
+
+import (
+	"time"
+)
+
+// DefaultMaxBackoff is the default limit of the polling interval relative to the
+// configured interval while the API is rate limiting the watcher.
+const DefaultMaxBackoff = 32
+
+// backoff adapts the polling interval to rate limiting. The interval is doubled
+// on every rate-limited poll, but never below the delay requested by the API,
+// and shrinks by a quarter on every successful poll until it is back at base.
+type backoff struct {
+	base    time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+// newBackoff creates a backoff starting at base.
+func newBackoff(base time.Duration) backoff {
+	return backoff{base: base, max: base * DefaultMaxBackoff, current: base}
+}
+
+// throttled lengthens the interval after a rate-limited poll.
+func (b *backoff) throttled(retryAfter time.Duration) {
+	b.current = max(min(b.current*2, b.max), retryAfter)
+}
+
+// succeeded shortens the interval after a successful poll.
+func (b *backoff) succeeded() {
+	b.current = max(b.current-b.current/4, b.base)
+}
@@ -0,0 +1,71 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func TestBackoff(t *testing.T) {
+	b := newBackoff(time.Minute)
+
+	steps := []struct {
+		name       string
+		throttled  bool
+		retryAfter time.Duration
+		expected   time.Duration
+	}{
+		{"first 429", true, 0, 2 * time.Minute},
+		{"second 429", true, 0, 4 * time.Minute},
+		{"retry after exceeds doubling", true, 20 * time.Minute, 20 * time.Minute},
+		{"capped", true, 0, 32 * time.Minute},
+		{"retry after exceeds cap", true, time.Hour, time.Hour},
+		{"success", false, 0, 45 * time.Minute},
+		{"success again", false, 0, 45 * time.Minute * 3 / 4},
+	}
+
+	for _, step := range steps {
+		if step.throttled {
+			b.throttled(step.retryAfter)
+		} else {
+			b.succeeded()
+		}
+		if b.current != step.expected {
+			t.Errorf("%s: expected interval %v, got %v", step.name, step.expected, b.current)
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		b.succeeded()
+	}
+	if b.current != time.Minute {
+		t.Errorf("Expected interval to return to 1m, got %v", b.current)
+	}
+}
+
+func TestProfileWatcher_BacksOffWhenRateLimited(t *testing.T) {
+	client := &fakeClient{profiles: []*godestats.UserProfile{profileWith(100, nil)}}
+	w := NewProfileWatcher(client, "alice", time.Minute, WithMaxInterval(10*time.Minute))
+	ctx := context.Background()
+
+	client.err = &godestats.RateLimitError{RetryAfter: 3 * time.Minute}
+	w.Poll(ctx)
+	if w.Interval() != 3*time.Minute {
+		t.Errorf("Expected interval of 3m, got %v", w.Interval())
+	}
+
+	client.err = godestats.ErrRateLimited
+	w.Poll(ctx)
+	w.Poll(ctx)
+	if w.Interval() != 10*time.Minute {
+		t.Errorf("Expected interval capped at 10m, got %v", w.Interval())
+	}
+
+	client.err = nil
+	w.Poll(ctx)
+	if w.Interval() >= 10*time.Minute || w.Interval() <= time.Minute {
+		t.Errorf("Expected interval to shrink gradually, got %v", w.Interval())
+	}
+}
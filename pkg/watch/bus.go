@@ -0,0 +1,97 @@
+package watch
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// AllEvents subscribes a handler to events of every kind.
+const AllEvents EventKind = ""
+
+// Handler handles a published event.
+type Handler func(Event)
+
+// subscription is a handler registered with a bus.
+type subscription struct {
+	id      int
+	kind    EventKind
+	handler Handler
+}
+
+// Bus fans events out to the handlers subscribed to their kind, so that one process
+// can feed watchers and recorders into several notifiers without custom glue.
+// It is safe for concurrent use.
+type Bus struct {
+	mu            sync.RWMutex
+	nextID        int
+	subscriptions []subscription
+}
+
+// NewBus creates an event bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for events of the given kind, or of all kinds with
+// AllEvents, and returns a function that removes the subscription again.
+// If filters are given, the handler is only called for events accepted by all of them.
+func (b *Bus) Subscribe(kind EventKind, handler Handler, filters ...Filter) (unsubscribe func()) {
+	handler = Filtered(handler, filters...)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, subscription{id: id, kind: kind, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for i, s := range b.subscriptions {
+			if s.id == id {
+				b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish calls the handlers subscribed to the event's kind synchronously, in the
+// order of subscription. Slow handlers should hand the event off to a goroutine.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	subscriptions := append([]subscription(nil), b.subscriptions...)
+	b.mu.RUnlock()
+
+	for _, s := range subscriptions {
+		if s.kind == AllEvents || s.kind == e.Kind {
+			s.handler(e)
+		}
+	}
+}
+
+// PublishChange publishes a change detected by a history.Recorder. It can be
+// subscribed directly with recorder.Subscribe(bus.PublishChange).
+func (b *Bus) PublishChange(change history.ChangeEvent) {
+	b.Publish(FromChange(change))
+}
+
+// Forward publishes the events received from a watcher's channel until the channel
+// is closed or the context is cancelled.
+func (b *Bus) Forward(ctx context.Context, events <-chan Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			b.Publish(e)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func TestBus_Subscribe(t *testing.T) {
+	bus := NewBus()
+
+	var levelUps, all []EventKind
+	bus.Subscribe(EventLevelUp, func(e Event) { levelUps = append(levelUps, e.Kind) })
+	unsubscribe := bus.Subscribe(AllEvents, func(e Event) { all = append(all, e.Kind) })
+
+	bus.Publish(Event{Kind: EventXPGained})
+	bus.Publish(Event{Kind: EventLevelUp})
+	unsubscribe()
+	bus.Publish(Event{Kind: EventLevelUp})
+
+	if len(levelUps) != 2 {
+		t.Errorf("Expected 2 level-ups, got %v", levelUps)
+	}
+	if len(all) != 2 || all[0] != EventXPGained || all[1] != EventLevelUp {
+		t.Errorf("Expected both events before unsubscribing, got %v", all)
+	}
+}
+
+func TestBus_PublishChange(t *testing.T) {
+	bus := NewBus()
+
+	var got Event
+	bus.Subscribe(EventLanguageLevelUp, func(e Event) { got = e })
+
+	at := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	bus.PublishChange(history.ChangeEvent{
+		Kind:     history.ChangeLanguageLevelUp,
+		User:     "alice",
+		At:       at,
+		Language: "Go",
+		OldXP:    1500,
+		NewXP:    1700,
+		OldLevel: 0,
+		NewLevel: 1,
+	})
+
+	if got.User != "alice" || got.Language != "Go" || got.Delta() != 200 || got.NewLevel != 1 || !got.At.Equal(at) {
+		t.Errorf("Unexpected event: %+v", got)
+	}
+}
+
+func TestBus_Forward(t *testing.T) {
+	bus := NewBus()
+
+	var count int
+	bus.Subscribe(AllEvents, func(e Event) { count++ })
+
+	events := make(chan Event, 2)
+	events <- Event{Kind: EventXPGained}
+	events <- Event{Kind: EventNewLanguage}
+	close(events)
+
+	bus.Forward(context.Background(), events)
+	if count != 2 {
+		t.Errorf("Expected 2 forwarded events, got %d", count)
+	}
+}
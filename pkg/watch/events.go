@@ -0,0 +1,171 @@
+package watch
+
+import (
+	"sort"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// EventKind identifies the type of a watcher event.
+type EventKind string
+
+// Kinds of events emitted by watchers
+const (
+	// EventXPGained is emitted when the total XP changed.
+	EventXPGained EventKind = "xp_gained"
+
+	// EventLevelUp is emitted when the overall level increased.
+	EventLevelUp EventKind = "level_up"
+
+	// EventLanguageLevelUp is emitted when the level of a single language increased.
+	EventLanguageLevelUp EventKind = "language_level_up"
+
+	// EventNewLanguage is emitted when a language appears for the first time.
+	EventNewLanguage EventKind = "new_language"
+
+	// EventNewMachine is emitted when a machine appears for the first time.
+	EventNewMachine EventKind = "new_machine"
+
+	// EventStreakExtended is emitted when the current streak grew by at least a day.
+	EventStreakExtended EventKind = "streak_extended"
+
+	// EventStreakBroken is emitted when a streak ended because a day passed without XP.
+	EventStreakBroken EventKind = "streak_broken"
+
+	// EventGoalReached is emitted when the XP of a goal's target reached the goal.
+	EventGoalReached EventKind = "goal_reached"
+
+	// EventTokenInvalid is emitted by a TokenChecker when the API rejects the token.
+	EventTokenInvalid EventKind = "token_invalid"
+)
+
+// EventKinds lists all kinds of events emitted by watchers.
+var EventKinds = []EventKind{
+	EventXPGained, EventLevelUp, EventLanguageLevelUp, EventNewLanguage,
+	EventNewMachine, EventStreakExtended, EventStreakBroken, EventGoalReached,
+	EventTokenInvalid,
+}
+
+// Goal is an XP target for the whole profile or a single language.
+type Goal struct {
+	Name string `json:"name"`
+	// Language is the language whose XP counts towards the goal; empty for the total XP.
+	Language string       `json:"language,omitempty"`
+	XP       godestats.XP `json:"xp"`
+}
+
+// Event describes a change detected between two consecutive polls of a profile.
+// Which fields are set depends on the kind:
+//
+//   - XP and level events set OldXP, NewXP, OldLevel and NewLevel, and Language
+//     for language-specific events. For new languages, the old values are zero.
+//   - EventNewMachine sets Machine together with its XP values.
+//   - Streak events set OldStreak and NewStreak.
+//   - EventGoalReached sets Goal, Language if the goal has one, and the XP values.
+//   - EventTokenInvalid sets only User, the token's owner if it was known, and At.
+type Event struct {
+	Kind      EventKind    `json:"kind"`
+	User      string       `json:"user"`
+	At        time.Time    `json:"at"`
+	Language  string       `json:"language,omitempty"`
+	Machine   string       `json:"machine,omitempty"`
+	OldXP     godestats.XP `json:"old_xp"`
+	NewXP     godestats.XP `json:"new_xp"`
+	OldLevel  int          `json:"old_level"`
+	NewLevel  int          `json:"new_level"`
+	OldStreak int          `json:"old_streak,omitempty"`
+	NewStreak int          `json:"new_streak,omitempty"`
+	Goal      *Goal        `json:"goal,omitempty"`
+
+	// Before and After are the profiles of the two polls that were compared.
+	// They are shared by all events of a poll and must not be modified.
+	Before *godestats.UserProfile `json:"-"`
+	After  *godestats.UserProfile `json:"-"`
+}
+
+// Delta returns the XP difference described by the event.
+func (e Event) Delta() godestats.XP {
+	return e.NewXP - e.OldXP
+}
+
+// FromChange converts a change detected by a history.Recorder into an event.
+// The profiles of the event are not set.
+func FromChange(change history.ChangeEvent) Event {
+	return Event{
+		Kind:     EventKind(change.Kind),
+		User:     change.User,
+		At:       change.At,
+		Language: change.Language,
+		OldXP:    change.OldXP,
+		NewXP:    change.NewXP,
+		OldLevel: change.OldLevel,
+		NewLevel: change.NewLevel,
+	}
+}
+
+// diff returns the events between two consecutive snapshots of a user, ordered as
+// XP and language events (see history.Diff), new machines by name, streak events,
+// and reached goals in the given order.
+func diff(prev, next history.Snapshot, calc godestats.XpCalculator, goals []Goal) []Event {
+	if prev.Profile == nil || next.Profile == nil {
+		return nil
+	}
+
+	base := Event{User: next.User, At: next.TakenAt, Before: prev.Profile, After: next.Profile}
+
+	var events []Event
+	for _, change := range history.Diff(prev, next, calc) {
+		e := FromChange(change)
+		e.Before, e.After = prev.Profile, next.Profile
+		events = append(events, e)
+	}
+
+	var machines []string
+	for name := range next.Profile.Machines {
+		if _, existed := prev.Profile.Machines[name]; !existed {
+			machines = append(machines, name)
+		}
+	}
+	sort.Strings(machines)
+	for _, name := range machines {
+		e := base
+		e.Kind = EventNewMachine
+		e.Machine = name
+		e.NewXP = next.Profile.Machines[name].XPs
+		e.NewLevel = calc.GetLevel(e.NewXP)
+		events = append(events, e)
+	}
+
+	oldStreak := prev.Profile.Streaks(prev.TakenAt).Current
+	newStreak := next.Profile.Streaks(next.TakenAt).Current
+	if newStreak != oldStreak && (newStreak > oldStreak || newStreak == 0) {
+		e := base
+		e.Kind = EventStreakExtended
+		if newStreak == 0 {
+			e.Kind = EventStreakBroken
+		}
+		e.OldStreak, e.NewStreak = oldStreak, newStreak
+		events = append(events, e)
+	}
+
+	for i := range goals {
+		goal := goals[i]
+		oldXP, newXP := prev.Profile.TotalXP, next.Profile.TotalXP
+		if goal.Language != "" {
+			oldXP, newXP = prev.Profile.LanguageXP(goal.Language), next.Profile.LanguageXP(goal.Language)
+		}
+		if oldXP < goal.XP && newXP >= goal.XP {
+			e := base
+			e.Kind = EventGoalReached
+			e.Goal = &goal
+			e.Language = goal.Language
+			e.OldXP, e.NewXP = oldXP, newXP
+			e.OldLevel, e.NewLevel = calc.GetLevel(oldXP), calc.GetLevel(newXP)
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
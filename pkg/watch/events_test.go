@@ -0,0 +1,98 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+func TestDiff_Machines(t *testing.T) {
+	at := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	prev := history.Snapshot{User: "alice", TakenAt: at, Profile: &godestats.UserProfile{
+		Machines: map[string]godestats.MachineInfo{"laptop": {XPs: 100}},
+	}}
+	next := history.Snapshot{User: "alice", TakenAt: at.Add(time.Minute), Profile: &godestats.UserProfile{
+		Machines: map[string]godestats.MachineInfo{"laptop": {XPs: 100}, "desktop": {XPs: 2000}},
+	}}
+
+	events := diff(prev, next, xp.NewCalculator(), nil)
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %+v", events)
+	}
+	if e := events[0]; e.Kind != EventNewMachine || e.Machine != "desktop" || e.NewXP != 2000 || e.NewLevel != 1 {
+		t.Errorf("Unexpected event: %+v", e)
+	}
+}
+
+func TestDiff_Streaks(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 6, d, 20, 0, 0, 0, time.UTC) }
+	dates := map[string]godestats.XP{"2023-06-13": 10, "2023-06-14": 10}
+
+	tests := []struct {
+		name      string
+		prevAt    time.Time
+		nextAt    time.Time
+		nextDates map[string]godestats.XP
+		kind      EventKind
+		oldStreak int
+		newStreak int
+	}{
+		{"extended", day(15), day(15), map[string]godestats.XP{"2023-06-13": 10, "2023-06-14": 10, "2023-06-15": 5}, EventStreakExtended, 2, 3},
+		{"broken", day(15), day(16), dates, EventStreakBroken, 2, 0},
+		{"unchanged", day(15), day(15), dates, "", 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := history.Snapshot{User: "alice", TakenAt: tt.prevAt, Profile: &godestats.UserProfile{Dates: dates}}
+			next := history.Snapshot{User: "alice", TakenAt: tt.nextAt, Profile: &godestats.UserProfile{Dates: tt.nextDates}}
+
+			var streak *Event
+			for _, e := range diff(prev, next, xp.NewCalculator(), nil) {
+				if e.Kind == EventStreakExtended || e.Kind == EventStreakBroken {
+					streak = &e
+				}
+			}
+
+			if tt.kind == "" {
+				if streak != nil {
+					t.Errorf("Expected no streak event, got %+v", streak)
+				}
+				return
+			}
+			if streak == nil || streak.Kind != tt.kind || streak.OldStreak != tt.oldStreak || streak.NewStreak != tt.newStreak {
+				t.Errorf("Expected %s from %d to %d, got %+v", tt.kind, tt.oldStreak, tt.newStreak, streak)
+			}
+		})
+	}
+}
+
+func TestDiff_Goals(t *testing.T) {
+	at := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	prev := history.Snapshot{User: "alice", TakenAt: at, Profile: profileWith(9900, map[string]godestats.XP{"Go": 4900})}
+	next := history.Snapshot{User: "alice", TakenAt: at, Profile: profileWith(10100, map[string]godestats.XP{"Go": 5100})}
+
+	goals := []Goal{
+		{Name: "10k", XP: 10000},
+		{Name: "Go 5k", Language: "Go", XP: 5000},
+		{Name: "Go 10k", Language: "Go", XP: 10000},
+		{Name: "already reached", XP: 5000},
+	}
+
+	var reached []*Goal
+	for _, e := range diff(prev, next, xp.NewCalculator(), goals) {
+		if e.Kind == EventGoalReached {
+			reached = append(reached, e.Goal)
+			if e.NewXP < e.Goal.XP || e.OldXP >= e.Goal.XP || e.Language != e.Goal.Language {
+				t.Errorf("Unexpected goal event: %+v", e)
+			}
+		}
+	}
+
+	if len(reached) != 2 || reached[0].Name != "10k" || reached[1].Name != "Go 5k" {
+		t.Errorf("Expected goals 10k and Go 5k to be reached, got %+v", reached)
+	}
+}
@@ -0,0 +1,142 @@
+package watch
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// Filter decides whether an event is passed on to a handler.
+type Filter func(Event) bool
+
+// Filtered wraps a handler so that it is only called for events accepted by all
+// filters. Filters are evaluated in order and stop at the first rejection, so
+// stateful filters such as Throttle should come last.
+func Filtered(handler Handler, filters ...Filter) Handler {
+	if len(filters) == 0 {
+		return handler
+	}
+	accept := All(filters...)
+	return func(e Event) {
+		if accept(e) {
+			handler(e)
+		}
+	}
+}
+
+// All accepts events accepted by every filter.
+func All(filters ...Filter) Filter {
+	return func(e Event) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any accepts events accepted by at least one filter.
+func Any(filters ...Filter) Filter {
+	return func(e Event) bool {
+		for _, f := range filters {
+			if f(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not accepts events rejected by the filter.
+func Not(filter Filter) Filter {
+	return func(e Event) bool {
+		return !filter(e)
+	}
+}
+
+// OnlyKinds accepts events of the given kinds.
+func OnlyKinds(kinds ...EventKind) Filter {
+	return func(e Event) bool {
+		for _, kind := range kinds {
+			if e.Kind == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OnlyUsers accepts events of the given users, ignoring case.
+func OnlyUsers(users ...string) Filter {
+	return func(e Event) bool {
+		return containsFold(users, e.User)
+	}
+}
+
+// OnlyLanguages accepts language-specific events for the given languages, ignoring case.
+// Events without a language are rejected.
+func OnlyLanguages(languages ...string) Filter {
+	return func(e Event) bool {
+		return e.Language != "" && containsFold(languages, e.Language)
+	}
+}
+
+// MinLevelGain accepts events whose level increased by at least the given number
+// of levels. Events without levels, such as streak events, are rejected.
+func MinLevelGain(levels int) Filter {
+	return func(e Event) bool {
+		return e.NewLevel-e.OldLevel >= levels
+	}
+}
+
+// MinXPGain accepts events whose XP increased by at least the given amount.
+func MinXPGain(xp godestats.XP) Filter {
+	return func(e Event) bool {
+		return e.Delta() >= xp
+	}
+}
+
+// Throttle accepts at most one event of each kind per user within the given period;
+// further events are rejected until the period has passed. Periods are measured
+// by the events' times, or by the current time for events without one.
+func Throttle(period time.Duration) Filter {
+	type key struct {
+		kind EventKind
+		user string
+	}
+
+	var (
+		mu   sync.Mutex
+		last = make(map[key]time.Time)
+	)
+
+	return func(e Event) bool {
+		at := e.At
+		if at.IsZero() {
+			at = time.Now()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		k := key{kind: e.Kind, user: e.User}
+		if previous, ok := last[k]; ok && at.Sub(previous) < period {
+			return false
+		}
+		last[k] = at
+		return true
+	}
+}
+
+// containsFold reports whether the values contain s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
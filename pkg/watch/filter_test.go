@@ -0,0 +1,77 @@
+package watch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilters(t *testing.T) {
+	goLevelUp := Event{Kind: EventLanguageLevelUp, User: "alice", Language: "Go", OldXP: 1500, NewXP: 1700, OldLevel: 0, NewLevel: 1}
+	bigLevelUp := Event{Kind: EventLevelUp, User: "bob", OldXP: 1000, NewXP: 15000, OldLevel: 0, NewLevel: 3}
+	streak := Event{Kind: EventStreakExtended, User: "alice", OldStreak: 2, NewStreak: 3}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		event    Event
+		expected bool
+	}{
+		{"min level gain met", MinLevelGain(1), goLevelUp, true},
+		{"min level gain not met", MinLevelGain(2), goLevelUp, false},
+		{"min level gain without levels", MinLevelGain(1), streak, false},
+		{"only languages", OnlyLanguages("go", "Rust"), goLevelUp, true},
+		{"only languages other language", OnlyLanguages("Rust"), goLevelUp, false},
+		{"only languages without language", OnlyLanguages("Go"), bigLevelUp, false},
+		{"only kinds", OnlyKinds(EventLevelUp, EventStreakExtended), streak, true},
+		{"only users", OnlyUsers("Alice"), bigLevelUp, false},
+		{"min XP gain", MinXPGain(10000), bigLevelUp, true},
+		{"all", All(MinLevelGain(2), OnlyUsers("bob")), bigLevelUp, true},
+		{"any", Any(OnlyLanguages("Go"), MinLevelGain(3)), bigLevelUp, true},
+		{"not", Not(OnlyUsers("alice")), streak, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter(tt.event); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	throttle := Throttle(time.Hour)
+	at := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		event    Event
+		expected bool
+	}{
+		{Event{Kind: EventXPGained, User: "alice", At: at}, true},
+		{Event{Kind: EventXPGained, User: "alice", At: at.Add(30 * time.Minute)}, false},
+		{Event{Kind: EventXPGained, User: "bob", At: at.Add(30 * time.Minute)}, true},
+		{Event{Kind: EventLevelUp, User: "alice", At: at.Add(30 * time.Minute)}, true},
+		{Event{Kind: EventXPGained, User: "alice", At: at.Add(time.Hour)}, true},
+	}
+
+	for i, step := range steps {
+		if got := throttle(step.event); got != step.expected {
+			t.Errorf("Step %d: expected %v, got %v", i, step.expected, got)
+		}
+	}
+}
+
+func TestBus_SubscribeWithFilters(t *testing.T) {
+	bus := NewBus()
+
+	var got []string
+	bus.Subscribe(EventLanguageLevelUp, func(e Event) { got = append(got, e.Language) }, OnlyLanguages("Go", "Rust"))
+
+	for _, language := range []string{"Go", "Python", "Rust"} {
+		bus.Publish(Event{Kind: EventLanguageLevelUp, Language: language})
+	}
+
+	if len(got) != 2 || got[0] != "Go" || got[1] != "Rust" {
+		t.Errorf("Expected Go and Rust, got %v", got)
+	}
+}
@@ -0,0 +1,156 @@
+package watch
+
+import (
+	"context"
+	"sync"
+)
+
+// lifecycle tracks whether a watcher is running and lets Stop end it.
+type lifecycle struct {
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// begin marks the watcher as started and returns the context its loop runs in.
+func (l *lifecycle) begin(ctx context.Context) (context.Context, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.started {
+		return nil, ErrAlreadyStarted
+	}
+	l.started = true
+	l.done = make(chan struct{})
+
+	ctx, l.cancel = context.WithCancel(ctx)
+	return ctx, nil
+}
+
+// end marks the loop as finished.
+func (l *lifecycle) end() {
+	l.cancel()
+	close(l.done)
+}
+
+// stop cancels the loop and waits for it to finish. It does nothing if the
+// watcher was never started and may be called several times.
+func (l *lifecycle) stop() {
+	l.mu.Lock()
+	if !l.started {
+		l.mu.Unlock()
+		return
+	}
+	l.stopped = true
+	cancel, done := l.cancel, l.done
+	l.mu.Unlock()
+
+	cancel()
+	<-done
+}
+
+// result returns the error a Run method returns once its loop has finished:
+// nil after Stop, or the error of the cancelled context otherwise.
+func (l *lifecycle) result(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stopped {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// poller is a watcher that can be driven by run.
+type poller interface {
+	Poll(ctx context.Context) ([]Event, error)
+
+	// wait blocks until the next poll is due and reports false if the context was cancelled.
+	wait(ctx context.Context) bool
+}
+
+// run polls until the context is cancelled, passing every event to emit and
+// waiting for the poller between polls. Events of a poll that completed are
+// always emitted, even if the context was cancelled in the meantime.
+func run(ctx context.Context, p poller, emit func(Event), onError func(error)) {
+	for {
+		changes, err := p.Poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		for _, event := range changes {
+			emit(event)
+		}
+
+		if !p.wait(ctx) {
+			return
+		}
+	}
+}
+
+// eventQueue buffers events between a watcher's polling loop and its channel, so
+// that polling never blocks on slow receivers and no events are lost on shutdown.
+type eventQueue struct {
+	mu     sync.Mutex
+	events []Event
+	closed bool
+	ready  chan struct{}
+}
+
+// newEventQueue creates an empty queue.
+func newEventQueue() *eventQueue {
+	return &eventQueue{ready: make(chan struct{}, 1)}
+}
+
+// push appends an event to the queue.
+func (q *eventQueue) push(e Event) {
+	q.mu.Lock()
+	q.events = append(q.events, e)
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+// close marks the end of the events. Queued events are still delivered.
+func (q *eventQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+// signal wakes up deliver without blocking.
+func (q *eventQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the queued events to the channel in order and closes the channel
+// after the queue has been closed and all events were sent.
+func (q *eventQueue) deliver(out chan<- Event) {
+	defer close(out)
+
+	for {
+		q.mu.Lock()
+		events, closed := q.events, q.closed
+		q.events = nil
+		q.mu.Unlock()
+
+		for _, e := range events {
+			out <- e
+		}
+
+		if len(events) == 0 {
+			if closed {
+				return
+			}
+			<-q.ready
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProfileWatcher_StopFlushesEvents(t *testing.T) {
+	client := &multiClient{}
+	w := NewProfileWatcher(client, "alice", time.Millisecond)
+
+	events, err := w.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Poll more often than the channel can buffer without receiving anything
+	deadline := time.Now().Add(time.Second)
+	for {
+		client.mu.Lock()
+		polls := len(client.requests)
+		client.mu.Unlock()
+		if polls > 2*eventBuffer {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for polls")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	w.Stop()
+	w.Stop()
+
+	var received int
+	for e := range events {
+		if e.Kind == EventXPGained {
+			received++
+		}
+	}
+
+	client.mu.Lock()
+	polls := len(client.requests)
+	client.mu.Unlock()
+
+	// Every poll after the first yields one XP event
+	if received != polls-1 {
+		t.Errorf("Expected %d XP events, got %d", polls-1, received)
+	}
+}
+
+func TestProfileWatcher_StopBeforeStart(t *testing.T) {
+	w := NewProfileWatcher(&multiClient{}, "alice", time.Millisecond)
+	w.Stop()
+
+	events, err := w.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	w.Stop()
+
+	for range events {
+		// Drain until the watcher closes the channel
+	}
+}
+
+func TestProfileWatcher_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		stop     func(w *ProfileWatcher, cancel context.CancelFunc)
+		expected error
+	}{
+		{
+			name:     "stopped",
+			stop:     func(w *ProfileWatcher, cancel context.CancelFunc) { w.Stop() },
+			expected: nil,
+		},
+		{
+			name:     "cancelled",
+			stop:     func(w *ProfileWatcher, cancel context.CancelFunc) { cancel() },
+			expected: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewBus()
+			gained := make(chan Event, 1)
+			bus.Subscribe(EventXPGained, func(e Event) {
+				select {
+				case gained <- e:
+				default:
+				}
+			})
+
+			w := NewProfileWatcher(&multiClient{}, "alice", time.Millisecond, WithBus(bus))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			result := make(chan error, 1)
+			go func() {
+				result <- w.Run(ctx)
+			}()
+
+			select {
+			case <-gained:
+			case <-time.After(time.Second):
+				t.Fatal("Timed out waiting for a published event")
+			}
+
+			tt.stop(w, cancel)
+
+			select {
+			case err := <-result:
+				if !errors.Is(err, tt.expected) {
+					t.Errorf("Expected %v, got %v", tt.expected, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Timed out waiting for Run to return")
+			}
+
+			if err := w.Run(ctx); !errors.Is(err, ErrAlreadyStarted) {
+				t.Errorf("Expected ErrAlreadyStarted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMultiWatcher_Stop(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		users = map[string]bool{}
+	)
+	bus := NewBus()
+	bus.Subscribe(AllEvents, func(e Event) {
+		mu.Lock()
+		users[e.User] = true
+		mu.Unlock()
+	})
+
+	m := NewMultiWatcher(&multiClient{}, []string{"alice", "bob"}, 2*time.Millisecond, WithBus(bus))
+	events, err := m.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var received int
+	for range events {
+		received++
+		if received == 4 {
+			m.Stop()
+		}
+	}
+
+	if received < 4 {
+		t.Errorf("Expected at least 4 events, got %d", received)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !users["alice"] || !users["bob"] {
+		t.Errorf("Expected events of both users on the bus, got %v", users)
+	}
+}
@@ -0,0 +1,193 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+const (
+	// DefaultLiveURL is the Code::Stats WebSocket endpoint for live profile updates.
+	DefaultLiveURL = "wss://codestats.net/live_update_socket/websocket?vsn=1.0.0"
+
+	// LiveSafetyInterval is how often a watcher receiving live updates still polls,
+	// in case an update was missed.
+	LiveSafetyInterval = 15 * time.Minute
+
+	// liveHeartbeat is how often a heartbeat is sent to keep the connection open.
+	liveHeartbeat = 30 * time.Second
+
+	// liveRetryMin and liveRetryMax bound the delay between reconnection attempts.
+	liveRetryMin = time.Second
+	liveRetryMax = 5 * time.Minute
+)
+
+// phoenixMessage is a message of the Phoenix channels protocol used by the live endpoint.
+type phoenixMessage struct {
+	Topic   string         `json:"topic"`
+	Event   string         `json:"event"`
+	Payload map[string]any `json:"payload"`
+	Ref     string         `json:"ref,omitempty"`
+}
+
+// liveUpdates maintains a connection to the live endpoint and signals new pulses of a user.
+type liveUpdates struct {
+	url   string
+	topic string
+
+	// pulses receives a signal when a pulse arrives or the connection is lost.
+	pulses chan struct{}
+
+	mu        sync.Mutex
+	connected bool
+}
+
+// newLiveUpdates creates live updates for the user's channel at the given URL.
+func newLiveUpdates(url, username string) *liveUpdates {
+	return &liveUpdates{
+		url:    url,
+		topic:  "users:" + username,
+		pulses: make(chan struct{}, 1),
+	}
+}
+
+// isConnected reports whether the channel is currently joined.
+func (l *liveUpdates) isConnected() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.connected
+}
+
+// setConnected records the connection state. Losing the connection also signals the
+// watcher, so that it resumes polling immediately rather than at the safety interval.
+func (l *liveUpdates) setConnected(connected bool) {
+	l.mu.Lock()
+	l.connected = connected
+	l.mu.Unlock()
+
+	if !connected {
+		l.signal()
+	}
+}
+
+// signal notifies the watcher without blocking; pending signals are coalesced.
+func (l *liveUpdates) signal() {
+	select {
+	case l.pulses <- struct{}{}:
+	default:
+	}
+}
+
+// run keeps the connection open until the context is cancelled, reconnecting with
+// an increasing delay after failures, which are reported to onError.
+func (l *liveUpdates) run(ctx context.Context, onError func(error)) {
+	delay := liveRetryMin
+	for {
+		connectedAt := time.Now()
+		err := l.subscribe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		onError(fmt.Errorf("live updates: %w", err))
+
+		if time.Since(connectedAt) > liveRetryMax {
+			delay = liveRetryMin
+		}
+		if !sleep(ctx, delay) {
+			return
+		}
+		delay = min(delay*2, liveRetryMax)
+	}
+}
+
+// subscribe connects, joins the user's channel and signals pulses until the
+// connection fails or the context is cancelled.
+func (l *liveUpdates) subscribe(ctx context.Context) error {
+	conn, _, err := websocket.Dial(ctx, l.url, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.CloseNow()
+	defer l.setConnected(false)
+
+	ref := 0
+	send := func(topic, event string) error {
+		ref++
+		return wsjson.Write(ctx, conn, phoenixMessage{Topic: topic, Event: event, Payload: map[string]any{}, Ref: strconv.Itoa(ref)})
+	}
+
+	if err := send(l.topic, "phx_join"); err != nil {
+		return err
+	}
+	joinRef := strconv.Itoa(ref)
+
+	heartbeat := time.NewTicker(liveHeartbeat)
+	defer heartbeat.Stop()
+
+	messages := make(chan phoenixMessage)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			var msg phoenixMessage
+			if err := wsjson.Read(ctx, conn, &msg); err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close(websocket.StatusNormalClosure, "")
+			return ctx.Err()
+		case err := <-readErr:
+			return err
+		case <-heartbeat.C:
+			if err := send("phoenix", "heartbeat"); err != nil {
+				return err
+			}
+		case msg := <-messages:
+			switch {
+			case msg.Event == "phx_reply" && msg.Ref == joinRef:
+				if status, _ := msg.Payload["status"].(string); status != "ok" {
+					return fmt.Errorf("joining %s failed with status %q", l.topic, status)
+				}
+				l.setConnected(true)
+			case msg.Event == "phx_error" || (msg.Event == "phx_close" && msg.Topic == l.topic):
+				return errors.New("channel closed by server")
+			case msg.Event == "new_pulse" && msg.Topic == l.topic:
+				l.signal()
+			}
+		}
+	}
+}
+
+// sleep waits for the given duration and reports false if the context was cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,173 @@
+package watch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// newLiveServer serves the live endpoint, accepting channel joins and sending a
+// pulse to the joined channel for every value received from pulses.
+func newLiveServer(t *testing.T, pulses <-chan struct{}) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+		ctx := r.Context()
+
+		var join phoenixMessage
+		if err := wsjson.Read(ctx, conn, &join); err != nil || join.Event != "phx_join" {
+			return
+		}
+		wsjson.Write(ctx, conn, phoenixMessage{
+			Topic:   join.Topic,
+			Event:   "phx_reply",
+			Payload: map[string]any{"status": "ok", "response": map[string]any{}},
+			Ref:     join.Ref,
+		})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-pulses:
+				wsjson.Write(ctx, conn, phoenixMessage{Topic: join.Topic, Event: "new_pulse", Payload: map[string]any{}})
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestProfileWatcher_LiveUpdates(t *testing.T) {
+	pulses := make(chan struct{})
+	url := newLiveServer(t, pulses)
+
+	w := NewProfileWatcher(&multiClient{}, "alice", 20*time.Millisecond, WithLiveUpdates(url))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := w.Start(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !w.live.isConnected() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for live connection")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// While connected, the watcher stops polling at the regular interval
+	for quiet := false; !quiet; {
+		select {
+		case <-events:
+		case <-time.After(200 * time.Millisecond):
+			quiet = true
+		}
+	}
+
+	pulses <- struct{}{}
+	select {
+	case e := <-events:
+		if e.Kind != EventXPGained {
+			t.Errorf("Expected XP event, got %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a poll after a live pulse")
+	}
+}
+
+func TestProfileWatcher_LiveUpdatesFallback(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		errors []error
+	)
+	onError := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errors = append(errors, err)
+	}
+
+	w := NewProfileWatcher(&multiClient{}, "alice", time.Millisecond,
+		WithLiveUpdates("ws://127.0.0.1:1/websocket"), WithErrorHandler(onError))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := w.Start(ctx)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-events:
+		case <-time.After(time.Second):
+			t.Fatal("Expected polling to continue without a live connection")
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		reported := len(errors) > 0
+		mu.Unlock()
+		if reported || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errors) == 0 || !strings.HasPrefix(errors[0].Error(), "live updates:") {
+		t.Errorf("Expected a live update error, got %v", errors)
+	}
+}
+
+// capsClient is a multiClient reporting the capabilities of an instance.
+type capsClient struct {
+	multiClient
+	caps godestats.Capabilities
+}
+
+func (c *capsClient) Capabilities(ctx context.Context) (godestats.Capabilities, error) {
+	return c.caps, nil
+}
+
+func TestProfileWatcher_LiveUpdatesUnsupported(t *testing.T) {
+	var errs []error
+	w := NewProfileWatcher(&capsClient{}, "alice", time.Millisecond,
+		WithLiveUpdates("ws://127.0.0.1:1/websocket"), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := w.Start(ctx)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-events:
+		case <-time.After(time.Second):
+			t.Fatal("Expected polling without live updates")
+		}
+	}
+
+	w.Stop()
+	if len(errs) != 0 {
+		t.Errorf("Expected no connection attempts to an instance without live updates, got %v", errs)
+	}
+}
@@ -0,0 +1,162 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// MultiWatcher watches several users over one shared schedule, polling one user
+// at a time in turn so that requests are spread evenly over the interval.
+// Events carry the name of the user they belong to.
+type MultiWatcher struct {
+	watchers []*ProfileWatcher
+	onError  func(error)
+	bus      *Bus
+	jitter   float64
+	life     lifecycle
+
+	mu      sync.Mutex
+	next    int
+	backoff backoff
+}
+
+// NewMultiWatcher creates a watcher that polls each of the users once per interval.
+// An interval of zero or less uses DefaultInterval. Duplicate usernames are ignored.
+//
+// The options apply to every user. Since requests are shared, rate limiting for any
+// user lengthens the time between all requests; WithMaxInterval limits the time
+// between two consecutive requests rather than between two polls of the same user.
+func NewMultiWatcher(client godestats.ProfileClient, usernames []string, interval time.Duration, opts ...Option) *MultiWatcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	var unique []string
+	seen := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		if !seen[username] {
+			seen[username] = true
+			unique = append(unique, username)
+		}
+	}
+
+	step := interval
+	if len(unique) > 0 {
+		step = interval / time.Duration(len(unique))
+	}
+
+	m := &MultiWatcher{onError: func(error) {}}
+	for _, username := range unique {
+		m.watchers = append(m.watchers, NewProfileWatcher(client, username, step, opts...))
+	}
+
+	m.backoff = newBackoff(step)
+	if len(m.watchers) > 0 {
+		m.backoff.max = m.watchers[0].backoff.max
+		m.onError = m.watchers[0].onError
+		m.bus = m.watchers[0].bus
+		m.jitter = m.watchers[0].jitter
+	}
+
+	return m
+}
+
+// Interval returns the current time between two requests, which is the configured
+// interval divided by the number of users unless the watcher is backing off from
+// rate limiting.
+func (m *MultiWatcher) Interval() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.backoff.current
+}
+
+// Poll fetches the profile of the next user in turn and returns the changes since
+// that user's previous poll. Errors are wrapped with the username.
+func (m *MultiWatcher) Poll(ctx context.Context) ([]Event, error) {
+	if len(m.watchers) == 0 {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	w := m.watchers[m.next]
+	m.next = (m.next + 1) % len(m.watchers)
+	m.mu.Unlock()
+
+	events, err := w.Poll(ctx)
+
+	m.mu.Lock()
+	switch {
+	case godestats.IsRateLimited(err):
+		retryAfter, _ := godestats.RetryAfter(err)
+		m.backoff.throttled(retryAfter)
+	case err == nil:
+		m.backoff.succeeded()
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		return nil, fmt.Errorf("watching %s: %w", w.username, err)
+	}
+	return events, nil
+}
+
+// wait blocks until the next request is due and reports false if the context was cancelled.
+func (m *MultiWatcher) wait(ctx context.Context) bool {
+	return sleep(ctx, godestats.Jitter(m.Interval(), m.jitter))
+}
+
+// Start polls the users in turn in the background, sending their events on the
+// returned channel until Stop is called or the context is cancelled. It behaves
+// like ProfileWatcher.Start otherwise.
+func (m *MultiWatcher) Start(ctx context.Context) (<-chan Event, error) {
+	ctx, err := m.life.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := newEventQueue()
+	events := make(chan Event, eventBuffer)
+	go queue.deliver(events)
+
+	go func() {
+		defer m.life.end()
+		defer queue.close()
+
+		run(ctx, m, func(e Event) {
+			m.publish(e)
+			queue.push(e)
+		}, m.onError)
+	}()
+
+	return events, nil
+}
+
+// Run polls the users in turn until Stop is called or the context is cancelled,
+// publishing events to the bus set with WithBus. It behaves like ProfileWatcher.Run.
+func (m *MultiWatcher) Run(ctx context.Context) error {
+	ctx, err := m.life.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer m.life.end()
+
+	run(ctx, m, m.publish, m.onError)
+	return m.life.result(ctx)
+}
+
+// Stop stops polling and waits until the watcher has stopped, like ProfileWatcher.Stop.
+func (m *MultiWatcher) Stop() {
+	m.life.stop()
+}
+
+// publish sends the event to the bus, if any.
+func (m *MultiWatcher) publish(e Event) {
+	if m.bus != nil {
+		m.bus.Publish(e)
+	}
+}
@@ -0,0 +1,142 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// multiClient returns a profile whose XP grows by 100 on every request for the same user.
+type multiClient struct {
+	mu       sync.Mutex
+	requests []string
+	err      error
+}
+
+func (c *multiClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.requests = append(c.requests, username)
+	var count int
+	for _, r := range c.requests {
+		if r == username {
+			count++
+		}
+	}
+	return &godestats.UserProfile{User: username, TotalXP: godestats.XP(count * 100)}, nil
+}
+
+func (c *multiClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func TestMultiWatcher_Poll(t *testing.T) {
+	client := &multiClient{}
+	m := NewMultiWatcher(client, []string{"alice", "bob", "alice", "carol"}, 3*time.Minute)
+	ctx := context.Background()
+
+	if m.Interval() != time.Minute {
+		t.Errorf("Expected requests spread one minute apart, got %v", m.Interval())
+	}
+
+	var events []Event
+	for i := 0; i < 6; i++ {
+		polled, err := m.Poll(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		events = append(events, polled...)
+	}
+
+	expected := []string{"alice", "bob", "carol", "alice", "bob", "carol"}
+	for i, user := range expected {
+		if client.requests[i] != user {
+			t.Errorf("Expected request %d for %s, got %s", i, user, client.requests[i])
+		}
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("Expected one event per user, got %+v", events)
+	}
+	for i, user := range []string{"alice", "bob", "carol"} {
+		if events[i].User != user || events[i].NewXP != 200 {
+			t.Errorf("Expected XP event for %s, got %+v", user, events[i])
+		}
+	}
+}
+
+func TestMultiWatcher_SharedBackoff(t *testing.T) {
+	client := &multiClient{err: godestats.ErrRateLimited}
+	m := NewMultiWatcher(client, []string{"alice", "bob"}, 2*time.Minute)
+
+	_, err := m.Poll(context.Background())
+	if !errors.Is(err, godestats.ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if err == nil || err.Error() != "watching alice: API rate limit exceeded" {
+		t.Errorf("Expected error to name the user, got %v", err)
+	}
+
+	if m.Interval() != 2*time.Minute {
+		t.Errorf("Expected doubled interval of 2m, got %v", m.Interval())
+	}
+}
+
+func TestMultiWatcher_Start(t *testing.T) {
+	m := NewMultiWatcher(&multiClient{}, []string{"alice", "bob"}, 2*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := m.Start(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	users := map[string]bool{}
+	for len(users) < 2 {
+		select {
+		case e := <-events:
+			users[e.User] = true
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for events")
+		}
+	}
+
+	cancel()
+	for range events {
+		// Drain until the watcher closes the channel
+	}
+}
+
+func TestMultiWatcher_Jitter(t *testing.T) {
+	m := NewMultiWatcher(&multiClient{}, []string{"alice", "bob"}, 2*time.Millisecond, WithJitter(0.5))
+	if m.jitter != 0.5 {
+		t.Errorf("Expected jitter 0.5 from the options, got %v", m.jitter)
+	}
+
+	events, err := m.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event")
+	}
+
+	m.Stop()
+	for range events {
+		// Drain until the watcher closes the channel
+	}
+}
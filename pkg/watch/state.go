@@ -0,0 +1,119 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// StateStore persists the last snapshot seen by a watcher, so that a restarted
+// watcher continues from where it stopped instead of establishing a new baseline.
+// Implementations must be safe for concurrent use.
+type StateStore interface {
+	// Load returns the last saved snapshot of the user, or nil if there is none.
+	Load(ctx context.Context, username string) (*history.Snapshot, error)
+
+	// Save stores the snapshot as the last one seen for its user.
+	Save(ctx context.Context, snapshot history.Snapshot) error
+}
+
+// FileState is a StateStore keeping one JSON file per user in a directory.
+type FileState struct {
+	dir string
+}
+
+// NewFileState creates a state store writing to the given directory, which is
+// created on the first save if it does not exist.
+func NewFileState(dir string) *FileState {
+	return &FileState{dir: dir}
+}
+
+// Load reads the user's state file.
+func (f *FileState) Load(ctx context.Context, username string) (*history.Snapshot, error) {
+	data, err := os.ReadFile(f.path(username))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watcher state: %w", err)
+	}
+
+	var snapshot history.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode watcher state: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Save replaces the user's state file atomically.
+func (f *FileState) Save(ctx context.Context, snapshot history.Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode watcher state: %w", err)
+	}
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(f.dir, ".state-*")
+	if err != nil {
+		return fmt.Errorf("failed to write watcher state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write watcher state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write watcher state: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path(snapshot.User)); err != nil {
+		return fmt.Errorf("failed to write watcher state: %w", err)
+	}
+	return nil
+}
+
+// path returns the state file of a user.
+func (f *FileState) path(username string) string {
+	return filepath.Join(f.dir, url.PathEscape(username)+".json")
+}
+
+// historyStateLookback is how far back a HistoryState searches for the last snapshot.
+const historyStateLookback = 30 * 24 * time.Hour
+
+// HistoryState is a StateStore backed by a history store, so that a watcher
+// also records every profile it sees as a snapshot.
+type HistoryState struct {
+	store history.Store
+}
+
+// NewHistoryState creates a state store that saves snapshots into the history store.
+func NewHistoryState(store history.Store) *HistoryState {
+	return &HistoryState{store: store}
+}
+
+// Load returns the user's most recent snapshot from the last 30 days.
+func (h *HistoryState) Load(ctx context.Context, username string) (*history.Snapshot, error) {
+	now := time.Now()
+	snapshots, err := h.store.Range(ctx, username, now.Add(-historyStateLookback), now.Add(time.Second))
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return &snapshots[len(snapshots)-1], nil
+}
+
+// Save stores the snapshot in the history store.
+func (h *HistoryState) Save(ctx context.Context, snapshot history.Snapshot) error {
+	return h.store.Put(ctx, snapshot)
+}
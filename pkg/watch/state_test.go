@@ -0,0 +1,74 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+func TestFileState(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+	state := NewFileState(dir)
+	ctx := context.Background()
+
+	snapshot, err := state.Load(ctx, "alice/bob")
+	if err != nil || snapshot != nil {
+		t.Fatalf("Expected no state before saving, got %+v (err=%v)", snapshot, err)
+	}
+
+	takenAt := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	saved := history.Snapshot{User: "alice/bob", TakenAt: takenAt, Profile: profileWith(500, map[string]godestats.XP{"Go": 500})}
+	if err := state.Save(ctx, saved); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded, err := state.Load(ctx, "alice/bob")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !loaded.TakenAt.Equal(takenAt) || !loaded.Profile.Equal(saved.Profile) {
+		t.Errorf("Expected saved snapshot, got %+v", loaded)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "alice%2Fbob.json" {
+		t.Errorf("Expected a single escaped state file, got %v", entries)
+	}
+}
+
+func TestProfileWatcher_ResumesFromState(t *testing.T) {
+	state := NewHistoryState(history.NewMemoryStore())
+	ctx := context.Background()
+
+	first := NewProfileWatcher(&fakeClient{profiles: []*godestats.UserProfile{
+		profileWith(1000, nil),
+		profileWith(1200, nil),
+	}}, "alice", time.Minute, WithState(state))
+	first.Poll(ctx)
+	if events, _ := first.Poll(ctx); len(events) != 1 {
+		t.Fatalf("Expected one event, got %+v", events)
+	}
+
+	// A restarted watcher reports what changed while it was down, but nothing twice
+	restarted := NewProfileWatcher(&fakeClient{profiles: []*godestats.UserProfile{
+		profileWith(1500, nil),
+		profileWith(1500, nil),
+	}}, "alice", time.Minute, WithState(state))
+
+	events, err := restarted.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].OldXP != 1200 || events[0].NewXP != 1500 {
+		t.Errorf("Expected XP change from 1200 to 1500, got %+v", events)
+	}
+
+	if events, _ := restarted.Poll(ctx); len(events) != 0 {
+		t.Errorf("Expected no further events, got %+v", events)
+	}
+}
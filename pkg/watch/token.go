@@ -0,0 +1,154 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// DefaultTokenCheckInterval is the default time between two token checks.
+const DefaultTokenCheckInterval = 15 * time.Minute
+
+// TokenChecker periodically validates the API token by fetching the profile of its
+// owner, and emits an EventTokenInvalid as soon as the API rejects it, rather than
+// users discovering days later that no XP was recorded. The event is emitted once
+// until the token is accepted again.
+type TokenChecker struct {
+	client   godestats.OwnProfileClient
+	interval time.Duration
+	jitter   float64
+	onError  func(error)
+	now      func() time.Time
+	bus      *Bus
+	life     lifecycle
+
+	mu          sync.Mutex
+	user        string
+	invalid     bool
+	unsupported bool
+}
+
+// NewTokenChecker creates a checker validating the client's token once per
+// interval. An interval of zero or less uses DefaultTokenCheckInterval. Of the
+// options, only WithBus, WithErrorHandler and WithJitter apply.
+func NewTokenChecker(client godestats.OwnProfileClient, interval time.Duration, opts ...Option) *TokenChecker {
+	if interval <= 0 {
+		interval = DefaultTokenCheckInterval
+	}
+
+	w := NewProfileWatcher(nil, "", interval, opts...)
+	return &TokenChecker{
+		client:   client,
+		interval: interval,
+		jitter:   w.jitter,
+		onError:  w.onError,
+		now:      w.now,
+		bus:      w.bus,
+	}
+}
+
+// Valid reports whether the token was accepted by the last check. Tokens are
+// considered valid until a check fails.
+func (c *TokenChecker) Valid() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return !c.invalid
+}
+
+// Poll checks the token once and returns an EventTokenInvalid if the API rejected
+// it for the first time since it was last accepted. Other failures, such as
+// network errors, are returned and leave the state unchanged. Instances without
+// the authenticated profile endpoint can't be checked; this is reported once.
+func (c *TokenChecker) Poll(ctx context.Context) ([]Event, error) {
+	c.mu.Lock()
+	unsupported := c.unsupported
+	c.mu.Unlock()
+	if unsupported {
+		return nil, nil
+	}
+
+	profile, err := c.client.GetMyProfile(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case err == nil:
+		c.user = profile.User
+		c.invalid = false
+		return nil, nil
+	case errors.Is(err, godestats.ErrUnauthorized):
+		if c.invalid {
+			return nil, nil
+		}
+		c.invalid = true
+		return []Event{{Kind: EventTokenInvalid, User: c.user, At: c.now()}}, nil
+	case errors.Is(err, godestats.ErrUnsupported):
+		c.unsupported = true
+		return nil, err
+	default:
+		return nil, err
+	}
+}
+
+// Start checks the token in the background until the context is cancelled or
+// Stop is called. The returned channel receives the events and is closed once
+// the checker has stopped.
+func (c *TokenChecker) Start(ctx context.Context) (<-chan Event, error) {
+	ctx, err := c.life.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := newEventQueue()
+	events := make(chan Event, eventBuffer)
+	go queue.deliver(events)
+
+	go func() {
+		defer c.life.end()
+		defer queue.close()
+
+		run(ctx, c, func(e Event) {
+			c.publish(e)
+			queue.push(e)
+		}, c.onError)
+	}()
+
+	return events, nil
+}
+
+// Run checks like Start but blocks until Stop is called, returning nil, or until
+// the context is cancelled, returning its error. Events are only published to
+// the bus set with WithBus.
+func (c *TokenChecker) Run(ctx context.Context) error {
+	ctx, err := c.life.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.life.end()
+
+	run(ctx, c, c.publish, c.onError)
+	return c.life.result(ctx)
+}
+
+// Stop stops checking and waits until the checker has stopped. It may be called
+// several times and does nothing if the checker was never started.
+func (c *TokenChecker) Stop() {
+	c.life.stop()
+}
+
+// publish sends the event to the bus, if any.
+func (c *TokenChecker) publish(e Event) {
+	if c.bus != nil {
+		c.bus.Publish(e)
+	}
+}
+
+// wait blocks until the next check is due and reports false if the context was cancelled.
+func (c *TokenChecker) wait(ctx context.Context) bool {
+	return sleep(ctx, godestats.Jitter(c.interval, c.jitter))
+}
@@ -0,0 +1,96 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// fakeOwnClient answers authenticated profile requests with the queued errors.
+type fakeOwnClient struct {
+	errs []error
+}
+
+func (f *fakeOwnClient) GetMyProfile(ctx context.Context, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	if err != nil {
+		return nil, err
+	}
+	return &godestats.UserProfile{User: "alice"}, nil
+}
+
+func TestTokenChecker_Poll(t *testing.T) {
+	network := godestats.NewNetworkError("GET request", "", errors.New("timeout"))
+	client := &fakeOwnClient{errs: []error{
+		nil,
+		godestats.ErrUnauthorized,
+		godestats.ErrUnauthorized,
+		network,
+		nil,
+		godestats.ErrUnauthorized,
+	}}
+	checker := NewTokenChecker(client, time.Minute)
+
+	expected := []struct {
+		events int
+		err    error
+		valid  bool
+	}{
+		{0, nil, true},
+		{1, nil, false},
+		// Rejections are reported once
+		{0, nil, false},
+		{0, network, false},
+		{0, nil, true},
+		// and again after the token was accepted in between
+		{1, nil, false},
+	}
+
+	for i, step := range expected {
+		events, err := checker.Poll(context.Background())
+		if len(events) != step.events || !errors.Is(err, step.err) || checker.Valid() != step.valid {
+			t.Errorf("Check %d: expected %d events, %v and valid %v, got %d, %v and %v", i+1, step.events, step.err, step.valid, len(events), err, checker.Valid())
+		}
+		if len(events) == 1 && (events[0].Kind != EventTokenInvalid || events[0].User != "alice") {
+			t.Errorf("Check %d: expected a token_invalid event for alice, got %+v", i+1, events[0])
+		}
+	}
+}
+
+func TestTokenChecker_Unsupported(t *testing.T) {
+	client := &fakeOwnClient{errs: []error{godestats.ErrUnsupported}}
+	checker := NewTokenChecker(client, time.Minute)
+
+	if _, err := checker.Poll(context.Background()); !errors.Is(err, godestats.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+	// Further checks are skipped rather than failing every time
+	if _, err := checker.Poll(context.Background()); err != nil {
+		t.Errorf("Expected no further checks, got %v", err)
+	}
+}
+
+func TestTokenChecker_Run(t *testing.T) {
+	bus := NewBus()
+	received := make(chan Event, 1)
+	bus.Subscribe(EventTokenInvalid, func(e Event) { received <- e })
+
+	checker := NewTokenChecker(&fakeOwnClient{errs: []error{godestats.ErrUnauthorized}}, time.Hour, WithBus(bus))
+	done := make(chan error)
+	go func() { done <- checker.Run(context.Background()) }()
+
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a token_invalid event on the bus")
+	}
+
+	checker.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("Expected nil after Stop, got %v", err)
+	}
+}
@@ -0,0 +1,322 @@
+// Package watch polls Code::Stats profiles and emits events describing what
+// changed between polls, such as XP gains and level-ups. It is the basis for
+// notifiers, exporters and the command line watch command.
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/history"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// DefaultInterval is the default time between two polls.
+const DefaultInterval = time.Minute
+
+// eventBuffer is the capacity of the event channel returned by Start.
+const eventBuffer = 16
+
+// ErrAlreadyStarted is returned when a watcher is started more than once.
+var ErrAlreadyStarted = errors.New("watcher already started")
+
+// ProfileWatcher periodically fetches a user's profile and emits an event for every
+// change since the previous poll. The first poll only establishes the baseline.
+type ProfileWatcher struct {
+	client   godestats.ProfileClient
+	username string
+	calc     godestats.XpCalculator
+	goals    []Goal
+	jitter   float64
+	onError  func(error)
+	now      func() time.Time
+
+	live  *liveUpdates
+	state StateStore
+	bus   *Bus
+	life  lifecycle
+
+	mu       sync.Mutex
+	loaded   bool
+	last     *history.Snapshot
+	polledAt time.Time
+	backoff  backoff
+}
+
+// Option configures optional behavior of a ProfileWatcher.
+type Option func(*ProfileWatcher)
+
+// WithCalculator sets the calculator used to detect level-ups.
+func WithCalculator(calc godestats.XpCalculator) Option {
+	return func(w *ProfileWatcher) {
+		w.calc = calc
+	}
+}
+
+// WithGoals sets XP goals for which an EventGoalReached is emitted once the
+// profile or language reaches them.
+func WithGoals(goals ...Goal) Option {
+	return func(w *ProfileWatcher) {
+		w.goals = append(w.goals, goals...)
+	}
+}
+
+// WithMaxInterval sets the longest interval the watcher backs off to while the
+// API is rate limiting it, unless the API asks to wait even longer.
+// Defaults to DefaultMaxBackoff times the polling interval.
+func WithMaxInterval(interval time.Duration) Option {
+	return func(w *ProfileWatcher) {
+		if interval > 0 {
+			w.backoff.max = interval
+		}
+	}
+}
+
+// WithJitter randomizes the time between two polls by up to the given fraction of
+// the interval in either direction (see godestats.Jitter), so that watchers deployed
+// from the same configuration don't poll the API at the same moment.
+func WithJitter(fraction float64) Option {
+	return func(w *ProfileWatcher) {
+		w.jitter = fraction
+	}
+}
+
+// WithLiveUpdates makes the watcher subscribe to the user's live updates over
+// WebSocket at the given URL (DefaultLiveURL if empty) once started. Every pulse
+// the user sends triggers a poll, though never more often than the polling interval,
+// and while connected the watcher otherwise only polls every LiveSafetyInterval.
+// If the connection fails, the watcher falls back to regular polling and reconnects
+// in the background. Live updates are not used by MultiWatcher.
+func WithLiveUpdates(url string) Option {
+	return func(w *ProfileWatcher) {
+		if url == "" {
+			url = DefaultLiveURL
+		}
+		w.live = newLiveUpdates(url, w.username)
+	}
+}
+
+// WithState persists the last seen snapshot in the given store. On the first poll,
+// the watcher continues from the saved snapshot, so that changes made while the
+// process was down are reported and no changes are reported twice.
+func WithState(state StateStore) Option {
+	return func(w *ProfileWatcher) {
+		w.state = state
+	}
+}
+
+// WithBus publishes every event to the bus, in addition to the channel returned by
+// Start. Events of a watcher driven by Run are only published to the bus.
+func WithBus(bus *Bus) Option {
+	return func(w *ProfileWatcher) {
+		w.bus = bus
+	}
+}
+
+// WithErrorHandler sets a function that is called for every failed poll while
+// the watcher is running. Errors are ignored by default.
+func WithErrorHandler(handler func(error)) Option {
+	return func(w *ProfileWatcher) {
+		w.onError = handler
+	}
+}
+
+// NewProfileWatcher creates a watcher that polls the user's profile once per interval.
+// An interval of zero or less uses DefaultInterval. When the API responds with rate
+// limiting, the interval is lengthened automatically and shortened back gradually
+// once polls succeed again.
+func NewProfileWatcher(client godestats.ProfileClient, username string, interval time.Duration, opts ...Option) *ProfileWatcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	w := &ProfileWatcher{
+		client:   client,
+		username: username,
+		calc:     xp.NewCalculator(),
+		onError:  func(error) {},
+		now:      time.Now,
+		backoff:  newBackoff(interval),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Interval returns the current time between two polls, which is longer than the
+// configured interval while the watcher is backing off from rate limiting.
+func (w *ProfileWatcher) Interval() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.backoff.current
+}
+
+// Poll fetches the profile once and returns the changes since the previous poll.
+// Failed polls keep the previous profile as the baseline. If saving the state
+// fails, the changes are returned together with the error.
+func (w *ProfileWatcher) Poll(ctx context.Context) ([]Event, error) {
+	if err := w.loadState(ctx); err != nil {
+		return nil, err
+	}
+
+	profile, err := w.client.GetUserProfile(ctx, w.username)
+	if err != nil {
+		if godestats.IsRateLimited(err) {
+			retryAfter, _ := godestats.RetryAfter(err)
+			w.mu.Lock()
+			w.backoff.throttled(retryAfter)
+			w.mu.Unlock()
+		}
+		return nil, err
+	}
+
+	snapshot := history.Snapshot{User: w.username, TakenAt: w.now(), Profile: profile}
+
+	w.mu.Lock()
+	previous := w.last
+	w.last = &snapshot
+	w.polledAt = time.Now()
+	w.backoff.succeeded()
+	w.mu.Unlock()
+
+	if w.state != nil {
+		err = w.state.Save(ctx, snapshot)
+	}
+
+	if previous == nil {
+		return nil, err
+	}
+	return diff(*previous, snapshot, w.calc, w.goals), err
+}
+
+// loadState restores the baseline from the state store before the first poll.
+func (w *ProfileWatcher) loadState(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.state == nil || w.loaded {
+		return nil
+	}
+
+	snapshot, err := w.state.Load(ctx, w.username)
+	if err != nil {
+		return err
+	}
+	if w.last == nil && snapshot != nil && snapshot.Profile != nil {
+		w.last = snapshot
+	}
+	w.loaded = true
+
+	return nil
+}
+
+// Start polls the profile immediately and then once per current interval in the
+// background, until Stop is called or the context is cancelled. Events are sent on
+// the returned channel, which is closed once the watcher has stopped and all events
+// detected until then were received; receivers should drain it until it is closed.
+// Failed polls are reported to the error handler and do not stop the watcher.
+// A watcher can only be started once, by either Start or Run.
+func (w *ProfileWatcher) Start(ctx context.Context) (<-chan Event, error) {
+	ctx, err := w.life.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := newEventQueue()
+	events := make(chan Event, eventBuffer)
+	go queue.deliver(events)
+
+	go func() {
+		defer w.life.end()
+		defer queue.close()
+
+		w.run(ctx, func(e Event) {
+			w.publish(e)
+			queue.push(e)
+		})
+	}()
+
+	return events, nil
+}
+
+// Run polls like Start but blocks until Stop is called, returning nil, or until the
+// context is cancelled, returning its error. Events are only published to the bus
+// set with WithBus. This form suits supervisors such as errgroup.
+func (w *ProfileWatcher) Run(ctx context.Context) error {
+	ctx, err := w.life.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer w.life.end()
+
+	w.run(ctx, w.publish)
+	return w.life.result(ctx)
+}
+
+// Stop stops polling, cancelling a poll in progress, and waits until the watcher
+// has stopped. Events detected before are still delivered. Stop may be called
+// several times and does nothing if the watcher was never started.
+func (w *ProfileWatcher) Stop() {
+	w.life.stop()
+}
+
+// run polls until the context is cancelled, keeping live updates connected meanwhile.
+// Live updates are skipped if the client reports that the instance lacks them.
+func (w *ProfileWatcher) run(ctx context.Context, emit func(Event)) {
+	if w.live != nil && w.liveSupported(ctx) {
+		go w.live.run(ctx, w.onError)
+	}
+	run(ctx, w, emit, w.onError)
+}
+
+// liveSupported reports whether the instance offers live updates. Clients that
+// can't detect capabilities, or fail to, are assumed to support them.
+func (w *ProfileWatcher) liveSupported(ctx context.Context) bool {
+	detector, ok := w.client.(godestats.CapabilitiesClient)
+	if !ok {
+		return true
+	}
+	caps, err := detector.Capabilities(ctx)
+	return err != nil || caps.LiveUpdates
+}
+
+// publish sends the event to the bus, if any.
+func (w *ProfileWatcher) publish(e Event) {
+	if w.bus != nil {
+		w.bus.Publish(e)
+	}
+}
+
+// wait blocks until the next poll is due and reports false if the context was
+// cancelled. With live updates, polls are due on pulses or at the safety interval.
+func (w *ProfileWatcher) wait(ctx context.Context) bool {
+	interval := w.Interval()
+	if w.live == nil || !w.live.isConnected() {
+		return sleep(ctx, godestats.Jitter(interval, w.jitter))
+	}
+
+	timer := time.NewTimer(godestats.Jitter(max(LiveSafetyInterval, interval), w.jitter))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	case <-w.live.pulses:
+	}
+
+	// Respect the polling interval even when pulses arrive in quick succession
+	w.mu.Lock()
+	due := w.polledAt.Add(interval)
+	w.mu.Unlock()
+
+	return sleep(ctx, time.Until(due))
+}
@@ -0,0 +1,146 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+// fakeClient returns the queued profiles one by one, repeating the last one.
+type fakeClient struct {
+	mu       sync.Mutex
+	profiles []*godestats.UserProfile
+	err      error
+	calls    int
+}
+
+func (f *fakeClient) GetUserProfile(ctx context.Context, username string, opts ...godestats.CallOption) (*godestats.UserProfile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	profile := f.profiles[0]
+	if len(f.profiles) > 1 {
+		f.profiles = f.profiles[1:]
+	}
+	return profile.Clone(), nil
+}
+
+func (f *fakeClient) SendPulse(ctx context.Context, pulse godestats.Pulse, opts ...godestats.CallOption) error {
+	return nil
+}
+
+func profileWith(total godestats.XP, languages map[string]godestats.XP) *godestats.UserProfile {
+	profile := &godestats.UserProfile{User: "alice", TotalXP: total, Languages: map[string]godestats.LanguageInfo{}}
+	for name, xp := range languages {
+		profile.Languages[name] = godestats.LanguageInfo{XPs: xp}
+	}
+	return profile
+}
+
+func TestProfileWatcher_Poll(t *testing.T) {
+	client := &fakeClient{profiles: []*godestats.UserProfile{
+		profileWith(1500, map[string]godestats.XP{"Go": 1500}),
+		profileWith(1700, map[string]godestats.XP{"Go": 1650, "Rust": 50}),
+	}}
+	w := NewProfileWatcher(client, "alice", time.Minute)
+	ctx := context.Background()
+
+	events, err := w.Poll(ctx)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("Expected no events for the baseline, got %v (err=%v)", events, err)
+	}
+
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		kind     EventKind
+		language string
+	}{
+		{EventXPGained, ""},
+		{EventLevelUp, ""},
+		{EventLanguageLevelUp, "Go"},
+		{EventNewLanguage, "Rust"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), events)
+	}
+	for i, e := range expected {
+		if events[i].Kind != e.kind || events[i].Language != e.language {
+			t.Errorf("Expected event %d to be %s %s, got %s %s", i, e.kind, e.language, events[i].Kind, events[i].Language)
+		}
+		if events[i].Before.TotalXP != 1500 || events[i].After.TotalXP != 1700 {
+			t.Errorf("Expected event %d to carry both profiles", i)
+		}
+	}
+	if events[0].Delta() != 200 {
+		t.Errorf("Expected delta 200, got %d", events[0].Delta())
+	}
+}
+
+func TestProfileWatcher_PollErrorKeepsBaseline(t *testing.T) {
+	client := &fakeClient{profiles: []*godestats.UserProfile{profileWith(100, nil)}}
+	w := NewProfileWatcher(client, "alice", time.Minute)
+	ctx := context.Background()
+
+	w.Poll(ctx)
+
+	client.err = godestats.ErrRateLimited
+	if _, err := w.Poll(ctx); !errors.Is(err, godestats.ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+
+	client.err = nil
+	client.profiles = []*godestats.UserProfile{profileWith(150, nil)}
+	events, _ := w.Poll(ctx)
+	if len(events) != 1 || events[0].OldXP != 100 || events[0].NewXP != 150 {
+		t.Errorf("Expected a single XP event from 100 to 150, got %+v", events)
+	}
+}
+
+func TestProfileWatcher_Start(t *testing.T) {
+	client := &fakeClient{profiles: []*godestats.UserProfile{
+		profileWith(100, nil),
+		profileWith(200, nil),
+		profileWith(300, nil),
+	}}
+	w := NewProfileWatcher(client, "alice", time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := w.Start(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := w.Start(ctx); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("Expected ErrAlreadyStarted, got %v", err)
+	}
+
+	for _, want := range []godestats.XP{200, 300} {
+		select {
+		case e := <-events:
+			if e.Kind != EventXPGained || e.NewXP != want {
+				t.Errorf("Expected XP gain to %d, got %+v", want, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for event")
+		}
+	}
+
+	cancel()
+	for range events {
+		// Drain until the watcher closes the channel
+	}
+}
@@ -0,0 +1,296 @@
+// Package webhooks delivers history change events as signed JSON payloads to HTTP endpoints.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/client"
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+// Headers set on every delivery
+const (
+	// SignatureHeader carries the HMAC-SHA256 signature of the body, formatted as "sha256=<hex>".
+	SignatureHeader = "X-Gode-Stats-Signature"
+	// EventHeader carries the event kind.
+	EventHeader = "X-Gode-Stats-Event"
+	// DeliveryHeader carries the unique delivery ID, which stays the same across retries.
+	DeliveryHeader = "X-Gode-Stats-Delivery"
+
+	// VersionHeader carries the schema version of the payload.
+	VersionHeader = "X-Gode-Stats-Schema-Version"
+)
+
+// SchemaVersion is the version of the Payload schema. Fields may be added within a
+// version; removing, renaming or retyping a field increments it.
+const SchemaVersion = 1
+
+// schemaV1 is the JSON Schema of version 1 of the payload.
+//
+//go:embed schema/v1.json
+var schemaV1 []byte
+
+// Schema returns the JSON Schema describing the payload of the current SchemaVersion,
+// so receivers such as automation platforms can validate deliveries.
+func Schema() []byte {
+	return slices.Clone(schemaV1)
+}
+
+// Defaults for delivery retries
+const (
+	DefaultMaxAttempts = 3
+	DefaultBackoff     = time.Second
+)
+
+// ErrDeliveryFailed is returned when an endpoint did not accept a payload after all attempts.
+var ErrDeliveryFailed = errors.New("webhook delivery failed")
+
+// Endpoint is a webhook receiver. If Kinds is empty, all events are delivered.
+type Endpoint struct {
+	URL    string
+	Secret string
+	Kinds  []history.ChangeKind
+}
+
+// accepts reports whether the endpoint subscribed to the given event kind.
+func (e Endpoint) accepts(kind history.ChangeKind) bool {
+	return len(e.Kinds) == 0 || slices.Contains(e.Kinds, kind)
+}
+
+// Payload is the JSON body posted to endpoints. Its format is versioned, see
+// SchemaVersion and Schema.
+type Payload struct {
+	SchemaVersion int                `json:"schema_version"`
+	ID            string             `json:"id"`
+	Event         history.ChangeKind `json:"event"`
+	User          string             `json:"user"`
+	At            time.Time          `json:"at"`
+	Language      string             `json:"language,omitempty"`
+	OldLevel      int                `json:"old_level"`
+	NewLevel      int                `json:"new_level"`
+	OldXP         godestats.XP       `json:"old_xp"`
+	NewXP         godestats.XP       `json:"new_xp"`
+}
+
+// Delivery records a single delivery attempt.
+type Delivery struct {
+	ID         string
+	URL        string
+	Event      history.ChangeKind
+	Attempt    int
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithHTTPClient sets the HTTP client used for deliveries.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.httpClient = httpClient
+	}
+}
+
+// WithRetries sets the maximum number of attempts per delivery and the initial
+// backoff between them, which doubles after every failed attempt.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = max(maxAttempts, 1)
+		d.backoff = backoff
+	}
+}
+
+// WithDeliveryLog sets a function that is called after every delivery attempt.
+func WithDeliveryLog(log func(Delivery)) Option {
+	return func(d *Dispatcher) {
+		d.log = log
+	}
+}
+
+// Dispatcher posts change events to webhook endpoints.
+type Dispatcher struct {
+	endpoints   []Endpoint
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	log         func(Delivery)
+	wg          sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher delivering to the given endpoints.
+func NewDispatcher(endpoints []Endpoint, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		endpoints:   endpoints,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		log:         func(Delivery) {},
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Handle dispatches the event in the background, so it can be passed to
+// Recorder.Subscribe without delaying recording. Use Wait to drain pending deliveries.
+func (d *Dispatcher) Handle(event history.ChangeEvent) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.Dispatch(context.Background(), event)
+	}()
+}
+
+// Wait blocks until all deliveries started by Handle have finished.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Dispatch delivers the event to all subscribed endpoints, retrying failed deliveries.
+// The returned error joins the failures of all endpoints.
+func (d *Dispatcher) Dispatch(ctx context.Context, event history.ChangeEvent) error {
+	id, err := newDeliveryID()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(Payload{
+		SchemaVersion: SchemaVersion,
+		ID:            id,
+		Event:         event.Kind,
+		User:          event.User,
+		At:            event.At,
+		Language:      event.Language,
+		OldLevel:      event.OldLevel,
+		NewLevel:      event.NewLevel,
+		OldXP:         event.OldXP,
+		NewXP:         event.NewXP,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to serialize payload: %w", err)
+	}
+
+	var errs []error
+	for _, endpoint := range d.endpoints {
+		if !endpoint.accepts(event.Kind) {
+			continue
+		}
+		if err := d.deliver(ctx, endpoint, id, event.Kind, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver posts the body to a single endpoint until it is accepted or all attempts are used.
+func (d *Dispatcher) deliver(ctx context.Context, endpoint Endpoint, id string, kind history.ChangeKind, body []byte) error {
+	backoff := d.backoff
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		status, err := d.post(ctx, endpoint, id, kind, body)
+		d.log(Delivery{
+			ID:         id,
+			URL:        endpoint.URL,
+			Event:      kind,
+			Attempt:    attempt,
+			StatusCode: status,
+			Duration:   time.Since(start),
+			Err:        err,
+		})
+
+		if err == nil {
+			return nil
+		}
+		if attempt >= d.maxAttempts || !retryable(status) {
+			return fmt.Errorf("%w: %s: %v", ErrDeliveryFailed, endpoint.URL, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s: %v", ErrDeliveryFailed, endpoint.URL, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post performs a single delivery and returns the response status code.
+func (d *Dispatcher) post(ctx context.Context, endpoint Endpoint, id string, kind history.ChangeKind, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(kind))
+	req.Header.Set(DeliveryHeader, id)
+	req.Header.Set(VersionHeader, strconv.Itoa(SchemaVersion))
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, godestats.NewNetworkError("POST request", endpoint.URL, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryable reports whether a delivery that ended with the given status should be retried.
+// A status of 0 means the request did not complete.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+// Sign computes the signature header value for a payload body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature matches the payload body.
+// Receivers should use it to authenticate deliveries.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// newDeliveryID generates a random delivery ID.
+func newDeliveryID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate delivery id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yeti47/gode-stats/pkg/history"
+)
+
+var levelUp = history.ChangeEvent{
+	Kind:     history.ChangeLevelUp,
+	User:     "alice",
+	At:       time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC),
+	OldXP:    9900,
+	NewXP:    10100,
+	OldLevel: 3,
+	NewLevel: 4,
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []Payload
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !Verify("secret", body, r.Header.Get(SignatureHeader)) {
+			t.Errorf("Invalid signature '%s'", r.Header.Get(SignatureHeader))
+		}
+		if r.Header.Get(EventHeader) != string(history.ChangeLevelUp) {
+			t.Errorf("Expected event header '%s', got '%s'", history.ChangeLevelUp, r.Header.Get(EventHeader))
+		}
+
+		var p Payload
+		json.Unmarshal(body, &p)
+		mu.Lock()
+		payloads = append(payloads, p)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher([]Endpoint{
+		{URL: server.URL, Secret: "secret"},
+		{URL: server.URL, Secret: "secret", Kinds: []history.ChangeKind{history.ChangeNewLanguage}},
+	})
+
+	if err := d.Dispatch(context.Background(), levelUp); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(payloads) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(payloads))
+	}
+	p := payloads[0]
+	if p.User != "alice" || p.OldLevel != 3 || p.NewLevel != 4 || p.NewXP != 10100 || p.ID == "" {
+		t.Errorf("Unexpected payload: %+v", p)
+	}
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var deliveries []Delivery
+	d := NewDispatcher([]Endpoint{{URL: server.URL}},
+		WithRetries(3, time.Millisecond),
+		WithDeliveryLog(func(del Delivery) { deliveries = append(deliveries, del) }))
+
+	if err := d.Dispatch(context.Background(), levelUp); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(deliveries) != 3 {
+		t.Fatalf("Expected 3 logged attempts, got %d", len(deliveries))
+	}
+	if deliveries[0].StatusCode != http.StatusServiceUnavailable || deliveries[0].Err == nil {
+		t.Errorf("Expected first attempt to fail with 503, got %+v", deliveries[0])
+	}
+	if deliveries[2].Attempt != 3 || deliveries[2].Err != nil {
+		t.Errorf("Expected third attempt to succeed, got %+v", deliveries[2])
+	}
+	if deliveries[0].ID != deliveries[2].ID {
+		t.Error("Expected delivery ID to stay the same across retries")
+	}
+}
+
+func TestDispatcher_NoRetryOnClientError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher([]Endpoint{{URL: server.URL}}, WithRetries(3, time.Millisecond))
+
+	err := d.Dispatch(context.Background(), levelUp)
+	if !errors.Is(err, ErrDeliveryFailed) {
+		t.Errorf("Expected ErrDeliveryFailed, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestDispatcher_Handle(t *testing.T) {
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	d := NewDispatcher([]Endpoint{{URL: server.URL}})
+	d.Handle(levelUp)
+	d.Wait()
+
+	select {
+	case <-received:
+	default:
+		t.Error("Expected the event to be delivered")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"level_up"}`)
+	signature := Sign("secret", body)
+
+	if !Verify("secret", body, signature) {
+		t.Error("Expected signature to verify")
+	}
+	if Verify("other", body, signature) {
+		t.Error("Expected signature with wrong secret to fail")
+	}
+	if Verify("secret", []byte(`{}`), signature) {
+		t.Error("Expected signature of modified body to fail")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(VersionHeader) != "1" {
+			t.Errorf("Expected schema version header 1, got '%s'", r.Header.Get(VersionHeader))
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher([]Endpoint{{URL: server.URL}})
+	newLanguage := history.ChangeEvent{Kind: history.ChangeNewLanguage, User: "alice", At: levelUp.At, Language: "Go", NewXP: 50}
+	for _, event := range []history.ChangeEvent{levelUp, newLanguage} {
+		if err := d.Dispatch(context.Background(), event); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	var schema struct {
+		Required   []string                  `json:"required"`
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("Expected a valid JSON schema, got %v", err)
+	}
+	if schema.Properties["schema_version"]["const"] != float64(SchemaVersion) {
+		t.Errorf("Expected the schema to describe version %d, got %v", SchemaVersion, schema.Properties["schema_version"])
+	}
+
+	// Every payload must have the required fields and only fields described by the schema
+	for _, body := range bodies {
+		var payload map[string]any
+		json.Unmarshal(body, &payload)
+
+		for _, field := range schema.Required {
+			if _, ok := payload[field]; !ok {
+				t.Errorf("Expected required field %s in %s", field, body)
+			}
+		}
+		for field := range payload {
+			if _, ok := schema.Properties[field]; !ok {
+				t.Errorf("Field %s is missing from the schema", field)
+			}
+		}
+	}
+}
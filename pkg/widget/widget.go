@@ -0,0 +1,211 @@
+// Package widget renders self-contained HTML/SVG snippets of Code::Stats profiles
+// that can be inlined into websites without JavaScript.
+package widget
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+	"github.com/Yeti47/gode-stats/pkg/xp"
+)
+
+// Default widget settings
+const (
+	DefaultTopLanguages = 5
+	DefaultHeatmapWeeks = 26
+)
+
+// heatmapColors are the cell colors from no activity to the highest activity.
+var heatmapColors = []string{"#ebedf0", "#c6e0f9", "#8cc1f2", "#4c9ee9", "#1a6fc0"}
+
+// Options configures the rendered widget. Zero values select the defaults.
+type Options struct {
+	// Calculator is used for levels. Defaults to the Code::Stats formula.
+	Calculator godestats.XpCalculator
+	// TopLanguages is the number of languages listed.
+	TopLanguages int
+	// HeatmapWeeks is the number of weeks shown in the heatmap. A negative value hides it.
+	HeatmapWeeks int
+	// Now is the last day shown in the heatmap. Defaults to the current time.
+	Now time.Time
+	// Location is the time zone of the heatmap days. Defaults to UTC.
+	Location *time.Location
+}
+
+// withDefaults returns a copy of the options with zero values replaced by defaults.
+func (o Options) withDefaults() Options {
+	if o.Calculator == nil {
+		o.Calculator = xp.NewCalculator()
+	}
+	if o.TopLanguages <= 0 {
+		o.TopLanguages = DefaultTopLanguages
+	}
+	if o.HeatmapWeeks == 0 {
+		o.HeatmapWeeks = DefaultHeatmapWeeks
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
+
+// ringRadius is the radius of the level ring; ringCircumference its stroke length.
+const (
+	ringRadius        = 40.0
+	ringCircumference = 2 * math.Pi * ringRadius
+	cellSize          = 11
+	cellStep          = 13
+)
+
+// languageBar is a single entry of the top language list.
+type languageBar struct {
+	Name    string
+	XP      string
+	Level   int
+	Percent float64
+}
+
+// heatmapCell is a single day of the heatmap.
+type heatmapCell struct {
+	X, Y  int
+	Color string
+	Title string
+}
+
+// widgetData is the data passed to the widget template.
+type widgetData struct {
+	User          string
+	Level         int
+	TotalXP       string
+	NewXP         godestats.XP
+	RingLength    float64
+	RingRemaining float64
+	Languages     []languageBar
+	Cells         []heatmapCell
+	HeatmapWidth  int
+	HeatmapHeight int
+}
+
+var widgetTemplate = template.Must(template.New("widget").Parse(`<div class="gode-stats-widget">
+<style>
+.gode-stats-widget{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;font-size:14px;color:#24292f;max-width:420px;border:1px solid #d0d7de;border-radius:6px;padding:16px}
+.gode-stats-widget h3{margin:0 0 12px;font-size:16px}
+.gode-stats-widget .gs-header{display:flex;align-items:center;gap:16px}
+.gode-stats-widget ul{list-style:none;margin:12px 0;padding:0}
+.gode-stats-widget li{margin:6px 0}
+.gode-stats-widget .gs-bar{height:6px;background:#ebedf0;border-radius:3px}
+.gode-stats-widget .gs-fill{height:6px;background:#4c9ee9;border-radius:3px}
+</style>
+<h3>{{.User}} on Code::Stats</h3>
+<div class="gs-header">
+<svg width="100" height="100" viewBox="0 0 100 100" role="img" aria-label="Level {{.Level}}">
+<circle cx="50" cy="50" r="40" fill="none" stroke="#ebedf0" stroke-width="8"/>
+<circle cx="50" cy="50" r="40" fill="none" stroke="#4c9ee9" stroke-width="8" stroke-linecap="round" stroke-dasharray="{{printf "%.2f" .RingLength}} {{printf "%.2f" .RingRemaining}}" transform="rotate(-90 50 50)"/>
+<text x="50" y="48" text-anchor="middle" font-size="22" font-weight="bold" fill="#24292f">{{.Level}}</text>
+<text x="50" y="66" text-anchor="middle" font-size="10" fill="#57606a">LEVEL</text>
+</svg>
+<div><strong>{{.TotalXP}}</strong> XP{{if .NewXP}}<br><span>+{{.NewXP}} recently</span>{{end}}</div>
+</div>
+{{if .Languages}}<ul>
+{{range .Languages}}<li><span>{{.Name}}</span> <small>level {{.Level}} · {{.XP}} XP</small>
+<div class="gs-bar"><div class="gs-fill" style="width:{{printf "%.1f" .Percent}}%"></div></div></li>
+{{end}}</ul>{{end}}
+{{if .Cells}}<svg width="{{.HeatmapWidth}}" height="{{.HeatmapHeight}}" role="img" aria-label="Daily activity">
+{{range .Cells}}<rect x="{{.X}}" y="{{.Y}}" width="11" height="11" rx="2" fill="{{.Color}}"><title>{{.Title}}</title></rect>
+{{end}}</svg>{{end}}
+</div>
+`))
+
+// Render writes the widget for the profile to w.
+func Render(w io.Writer, profile *godestats.UserProfile, opts Options) error {
+	opts = opts.withDefaults()
+	calc := opts.Calculator
+
+	progress := calc.GetLevelPercentage(profile.TotalXP)
+	data := widgetData{
+		User:          profile.User,
+		Level:         calc.GetLevel(profile.TotalXP),
+		TotalXP:       profile.TotalXP.String(),
+		NewXP:         profile.NewXP,
+		RingLength:    progress * ringCircumference,
+		RingRemaining: (1 - progress) * ringCircumference,
+	}
+
+	ranked := godestats.LanguagesByXP(profile)
+	if len(ranked) > opts.TopLanguages {
+		ranked = ranked[:opts.TopLanguages]
+	}
+	for _, lang := range ranked {
+		data.Languages = append(data.Languages, languageBar{
+			Name:    lang.Name,
+			XP:      lang.XPs.String(),
+			Level:   calc.GetLevel(lang.XPs),
+			Percent: lang.XPs.PercentOf(ranked[0].XPs),
+		})
+	}
+
+	if opts.HeatmapWeeks > 0 {
+		data.Cells = heatmap(profile, opts)
+		data.HeatmapWidth = opts.HeatmapWeeks * cellStep
+		data.HeatmapHeight = 7 * cellStep
+	}
+
+	if err := widgetTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render widget: %w", err)
+	}
+	return nil
+}
+
+// RenderString renders the widget for the profile into a string.
+func RenderString(profile *godestats.UserProfile, opts Options) (string, error) {
+	var buf bytes.Buffer
+	if err := Render(&buf, profile, opts); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// heatmap lays out one cell per day in columns of weeks starting on Monday,
+// ending with the week containing opts.Now.
+func heatmap(profile *godestats.UserProfile, opts Options) []heatmapCell {
+	today := godestats.DateOf(opts.Now.In(opts.Location))
+	weekday := (int(today.Weekday()) + 6) % 7 // Monday = 0
+	start := today.AddDays(-weekday - 7*(opts.HeatmapWeeks-1))
+
+	var peak godestats.XP
+	for day := range godestats.Days(start, today) {
+		peak = max(peak, profile.XPOnDate(day))
+	}
+
+	var cells []heatmapCell
+	i := 0
+	for day := range godestats.Days(start, today) {
+		dayXP := profile.XPOnDate(day)
+		cells = append(cells, heatmapCell{
+			X:     (i / 7) * cellStep,
+			Y:     (i % 7) * cellStep,
+			Color: heatmapColors[intensity(dayXP, peak)],
+			Title: fmt.Sprintf("%s: %s XP", day, dayXP),
+		})
+		i++
+	}
+
+	return cells
+}
+
+// intensity maps an XP amount to a heatmap color index relative to the peak.
+func intensity(dayXP, peak godestats.XP) int {
+	if dayXP <= 0 || peak <= 0 {
+		return 0
+	}
+	levels := len(heatmapColors) - 1
+	return min(int(math.Ceil(float64(dayXP)/float64(peak)*float64(levels))), levels)
+}
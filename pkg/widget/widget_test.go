@@ -0,0 +1,90 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
+)
+
+func testProfile() *godestats.UserProfile {
+	return &godestats.UserProfile{
+		User:    "<alice>",
+		TotalXP: 12000,
+		NewXP:   300,
+		Languages: map[string]godestats.LanguageInfo{
+			"Go":     {XPs: 8000},
+			"Rust":   {XPs: 3000},
+			"Python": {XPs: 1000},
+		},
+		Dates: map[string]godestats.XP{
+			"2023-06-14": 100,
+			"2023-06-15": 400,
+		},
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	now := time.Date(2023, 6, 15, 18, 0, 0, 0, time.UTC) // Thursday
+	html, err := RenderString(testProfile(), Options{Now: now, HeatmapWeeks: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"&lt;alice&gt;",
+		"12,000",
+		"+300 recently",
+		"Go",
+		"width:100.0%",
+		"2023-06-15: 400 XP",
+		heatmapColors[len(heatmapColors)-1],
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected widget to contain '%s'", expected)
+		}
+	}
+
+	if strings.Contains(html, "<script") {
+		t.Error("Expected widget without JavaScript")
+	}
+
+	// Two weeks: Monday of last week through Thursday of this week
+	if cells := strings.Count(html, "<rect"); cells != 11 {
+		t.Errorf("Expected 11 heatmap cells, got %d", cells)
+	}
+}
+
+func TestRenderString_Options(t *testing.T) {
+	html, err := RenderString(testProfile(), Options{TopLanguages: 1, HeatmapWeeks: -1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Contains(html, "Rust") {
+		t.Error("Expected only the top language to be listed")
+	}
+	if strings.Contains(html, "<rect") {
+		t.Error("Expected heatmap to be hidden")
+	}
+}
+
+func TestIntensity(t *testing.T) {
+	tests := []struct {
+		xp, peak godestats.XP
+		expected int
+	}{
+		{0, 100, 0},
+		{1, 100, 1},
+		{50, 100, 2},
+		{100, 100, 4},
+		{10, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if result := intensity(tt.xp, tt.peak); result != tt.expected {
+			t.Errorf("Expected intensity(%d, %d) = %d, got %d", tt.xp, tt.peak, tt.expected, result)
+		}
+	}
+}
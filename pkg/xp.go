@@ -0,0 +1,207 @@
+package godestats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ErrXPOverflow is returned when the result of an XP calculation does not fit into an XP.
+var ErrXPOverflow = errors.New("XP overflow")
+
+// XP represents an amount of experience points.
+// Using a distinct type prevents XP from being accidentally mixed with other integers.
+type XP int64
+
+// xpType is used to report JSON decoding errors for XP values.
+var xpType = reflect.TypeOf(XP(0))
+
+// Add returns the sum of two XP amounts.
+func (x XP) Add(other XP) XP {
+	return x + other
+}
+
+// AddChecked returns the sum of two XP amounts, or an error wrapping ErrXPOverflow
+// if the sum does not fit into an XP.
+func (x XP) AddChecked(other XP) (XP, error) {
+	sum := x + other
+	if (other > 0 && sum < x) || (other < 0 && sum > x) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrXPOverflow, x, other)
+	}
+	return sum, nil
+}
+
+// AddSaturating returns the sum of two XP amounts, clamped to the range of XP
+// instead of wrapping around on overflow.
+func (x XP) AddSaturating(other XP) XP {
+	sum, err := x.AddChecked(other)
+	if err == nil {
+		return sum
+	}
+	if other > 0 {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// Sub returns the difference of two XP amounts.
+func (x XP) Sub(other XP) XP {
+	return x - other
+}
+
+// Scale multiplies the XP amount by the given factor, rounding to the nearest integer.
+func (x XP) Scale(factor float64) XP {
+	return XP(math.Round(float64(x) * factor))
+}
+
+// PercentOf returns the XP amount as a percentage of total, e.g. 25.0 for a quarter,
+// or 0.0 if total is not positive.
+func (x XP) PercentOf(total XP) float64 {
+	if total <= 0 {
+		return 0.0
+	}
+	return float64(x) / float64(total) * 100
+}
+
+// Distribute splits the XP amount across keys, such as language names, in proportion
+// to their weights, e.g. the XP each language already has. Keys with a weight that is
+// not positive get nothing. The parts always add up to the amount exactly: it is first
+// split rounding towards zero, and the rest goes one XP at a time to the keys with the
+// largest remainders, ties broken by key. Returns nil if no weight is positive.
+func (x XP) Distribute(weights map[string]XP) map[string]XP {
+	type share struct {
+		key       string
+		remainder *big.Int
+	}
+
+	total := new(big.Int)
+	shares := make([]share, 0, len(weights))
+	for key, weight := range weights {
+		if weight > 0 {
+			total.Add(total, big.NewInt(int64(weight)))
+			shares = append(shares, share{key: key})
+		}
+	}
+	if len(shares) == 0 {
+		return nil
+	}
+
+	parts := make(map[string]XP, len(shares))
+	rest := x
+	amount := big.NewInt(int64(x))
+	for i := range shares {
+		// Multiplying in big.Int avoids overflow; the quotient fits because weight <= total
+		product := new(big.Int).Mul(amount, big.NewInt(int64(weights[shares[i].key])))
+		quotient, remainder := new(big.Int).QuoRem(product, total, new(big.Int))
+		parts[shares[i].key] = XP(quotient.Int64())
+		shares[i].remainder = remainder.Abs(remainder)
+		rest -= XP(quotient.Int64())
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if c := shares[i].remainder.Cmp(shares[j].remainder); c != 0 {
+			return c > 0
+		}
+		return shares[i].key < shares[j].key
+	})
+
+	// The rest is smaller than the number of shares and has the sign of the amount
+	step := XP(1)
+	if rest < 0 {
+		step, rest = -1, -rest
+	}
+	for i := XP(0); i < rest; i++ {
+		parts[shares[i].key] += step
+	}
+
+	return parts
+}
+
+// Int64 returns the XP amount as a plain int64.
+func (x XP) Int64() int64 {
+	return int64(x)
+}
+
+// String formats the XP amount with thousands separators, e.g. "1,234,567".
+func (x XP) String() string {
+	digits := strconv.FormatInt(int64(x), 10)
+
+	sign := ""
+	if x < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var buf bytes.Buffer
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteRune(d)
+	}
+
+	return sign + buf.String()
+}
+
+// Short formats the XP amount in a compact form with a magnitude suffix,
+// e.g. "950", "12.3k" or "1.5M".
+func (x XP) Short() string {
+	value := float64(x)
+	abs := math.Abs(value)
+
+	switch {
+	case abs >= 1e9:
+		return trimFloat(value/1e9) + "B"
+	case abs >= 1e6:
+		return trimFloat(value/1e6) + "M"
+	case abs >= 1e3:
+		return trimFloat(value/1e3) + "k"
+	default:
+		return strconv.FormatInt(int64(x), 10)
+	}
+}
+
+// MarshalJSON encodes the XP amount as a plain JSON number.
+func (x XP) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(x), 10), nil
+}
+
+// UnmarshalJSON decodes an XP amount from a JSON number or numeric string.
+// Numbers with an integral value in floating-point notation (e.g. 12.0) are accepted.
+func (x *XP) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	if value, err := strconv.ParseInt(text, 10, 64); err == nil {
+		*x = XP(value)
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value != math.Trunc(value) || math.Abs(value) > math.MaxInt64 {
+		return &json.UnmarshalTypeError{Value: fmt.Sprintf("value %s", data), Type: xpType}
+	}
+
+	*x = XP(value)
+	return nil
+}
+
+// trimFloat formats a value with one decimal place, dropping a trailing ".0".
+func trimFloat(value float64) string {
+	formatted := strconv.FormatFloat(math.Trunc(value*10)/10, 'f', 1, 64)
+	if len(formatted) > 2 && formatted[len(formatted)-2:] == ".0" {
+		return formatted[:len(formatted)-2]
+	}
+	return formatted
+}
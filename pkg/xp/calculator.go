@@ -21,7 +21,7 @@ func NewCalculator() godestats.XpCalculator {
 
 // GetLevel calculates the level for the given XP amount.
 // Formula: floor(LEVEL_FACTOR * sqrt(xp))
-func (c *Calculator) GetLevel(xp int) int {
+func (c *Calculator) GetLevel(xp godestats.XP) int {
 	if xp < 0 {
 		return 0
 	}
@@ -30,7 +30,7 @@ func (c *Calculator) GetLevel(xp int) int {
 
 // GetLevelPercentage calculates the percentage progress within the current level.
 // Returns a value between 0.0 and 1.0 representing the progress to the next level.
-func (c *Calculator) GetLevelPercentage(xp int) float64 {
+func (c *Calculator) GetLevelPercentage(xp godestats.XP) float64 {
 	if xp < 0 {
 		return 0.0
 	}
@@ -68,7 +68,7 @@ func (c *Calculator) GetLevelPercentage(xp int) float64 {
 // GetXpForLevel calculates the minimum XP required to reach the specified level.
 // This is the inverse of the GetLevel function.
 // Formula: (level / LEVEL_FACTOR)^2
-func (c *Calculator) GetXpForLevel(level int) int {
+func (c *Calculator) GetXpForLevel(level int) godestats.XP {
 	if level <= 0 {
 		return 0
 	}
@@ -77,12 +77,12 @@ func (c *Calculator) GetXpForLevel(level int) int {
 	levelFloat := float64(level)
 	xpFloat := math.Pow(levelFloat/LevelFactor, 2)
 
-	return int(math.Ceil(xpFloat))
+	return godestats.XP(math.Ceil(xpFloat))
 }
 
 // GetXpForNextLevel calculates the minimum XP required to reach the next level
 // from the current XP amount.
-func (c *Calculator) GetXpForNextLevel(xp int) int {
+func (c *Calculator) GetXpForNextLevel(xp godestats.XP) godestats.XP {
 	currentLevel := c.GetLevel(xp)
 	return c.GetXpForLevel(currentLevel + 1)
 }
@@ -3,6 +3,8 @@ package xp
 import (
 	"math"
 	"testing"
+
+	godestats "github.com/Yeti47/gode-stats/pkg"
 )
 
 func TestCalculator_GetLevel(t *testing.T) {
@@ -10,7 +12,7 @@ func TestCalculator_GetLevel(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		xp       int
+		xp       godestats.XP
 		expected int
 	}{
 		{"Zero XP", 0, 0},
@@ -39,7 +41,7 @@ func TestCalculator_GetXpForLevel(t *testing.T) {
 	tests := []struct {
 		name     string
 		level    int
-		expected int
+		expected godestats.XP
 	}{
 		{"Level 0", 0, 0},
 		{"Level 1", 1, 1600},
@@ -64,7 +66,7 @@ func TestCalculator_GetLevelPercentage(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		xp       int
+		xp       godestats.XP
 		expected float64
 		delta    float64
 	}{
@@ -92,8 +94,8 @@ func TestCalculator_GetXpForNextLevel(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		xp       int
-		expected int
+		xp       godestats.XP
+		expected godestats.XP
 	}{
 		{"Zero XP", 0, 1600},
 		{"XP at level 1", 1600, 6400},
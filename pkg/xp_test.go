@@ -0,0 +1,192 @@
+package godestats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestXP_Arithmetic(t *testing.T) {
+	x := XP(1000)
+
+	if result := x.Add(250); result != 1250 {
+		t.Errorf("Expected 1250, got %d", result)
+	}
+	if result := x.Sub(250); result != 750 {
+		t.Errorf("Expected 750, got %d", result)
+	}
+	if result := x.Scale(0.333); result != 333 {
+		t.Errorf("Expected 333, got %d", result)
+	}
+	if result := x.Int64(); result != int64(1000) {
+		t.Errorf("Expected 1000, got %d", result)
+	}
+}
+
+func TestXP_AddChecked(t *testing.T) {
+	tests := []struct {
+		x, other  XP
+		expected  XP
+		overflow  bool
+		saturated XP
+	}{
+		{1000, 250, 1250, false, 1250},
+		{-1000, 250, -750, false, -750},
+		{math.MaxInt64, 0, math.MaxInt64, false, math.MaxInt64},
+		{math.MaxInt64, 1, 0, true, math.MaxInt64},
+		{math.MaxInt64 - 5, 10, 0, true, math.MaxInt64},
+		{math.MinInt64, -1, 0, true, math.MinInt64},
+		{math.MinInt64, math.MaxInt64, -1, false, -1},
+	}
+
+	for _, tt := range tests {
+		sum, err := tt.x.AddChecked(tt.other)
+		if tt.overflow != errors.Is(err, ErrXPOverflow) || sum != tt.expected {
+			t.Errorf("%d + %d: expected %d (overflow %v), got %d (%v)", tt.x, tt.other, tt.expected, tt.overflow, sum, err)
+		}
+		if saturated := tt.x.AddSaturating(tt.other); saturated != tt.saturated {
+			t.Errorf("%d + %d: expected saturated sum %d, got %d", tt.x, tt.other, tt.saturated, saturated)
+		}
+	}
+}
+
+func TestXP_PercentOf(t *testing.T) {
+	tests := []struct {
+		x, total XP
+		expected float64
+	}{
+		{250, 1000, 25},
+		{1000, 1000, 100},
+		{1500, 1000, 150},
+		{0, 1000, 0},
+		{100, 0, 0},
+		{100, -5, 0},
+	}
+
+	for _, tt := range tests {
+		if percent := tt.x.PercentOf(tt.total); percent != tt.expected {
+			t.Errorf("%d of %d: expected %v%%, got %v%%", tt.x, tt.total, tt.expected, percent)
+		}
+	}
+}
+
+func TestXP_Distribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		x        XP
+		weights  map[string]XP
+		expected map[string]XP
+	}{
+		{"exact", 100, map[string]XP{"Go": 3, "Rust": 1}, map[string]XP{"Go": 75, "Rust": 25}},
+		{"remainders", 10, map[string]XP{"Go": 1, "Rust": 1, "C": 1}, map[string]XP{"C": 4, "Go": 3, "Rust": 3}},
+		{"largest remainder", 10, map[string]XP{"Go": 5, "Rust": 3, "C": 2}, map[string]XP{"Go": 5, "Rust": 3, "C": 2}},
+		{"rounding", 7, map[string]XP{"Go": 2, "Rust": 1}, map[string]XP{"Go": 5, "Rust": 2}},
+		{"negative", -10, map[string]XP{"Go": 1, "Rust": 1, "C": 1}, map[string]XP{"C": -4, "Go": -3, "Rust": -3}},
+		{"skips non-positive weights", 10, map[string]XP{"Go": 1, "Rust": 0, "C": -5}, map[string]XP{"Go": 10}},
+		{"large", math.MaxInt64, map[string]XP{"Go": math.MaxInt64, "Rust": math.MaxInt64}, map[string]XP{"Go": math.MaxInt64/2 + 1, "Rust": math.MaxInt64 / 2}},
+		{"no weights", 10, map[string]XP{"Go": 0}, nil},
+	}
+
+	for _, tt := range tests {
+		parts := tt.x.Distribute(tt.weights)
+		if len(parts) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, parts)
+			continue
+		}
+
+		var sum XP
+		for key, xp := range tt.expected {
+			if parts[key] != xp {
+				t.Errorf("%s: expected %s to get %d, got %d", tt.name, key, xp, parts[key])
+			}
+			sum += parts[key]
+		}
+		if tt.expected != nil && sum != tt.x {
+			t.Errorf("%s: expected the parts to add up to %d, got %d", tt.name, tt.x, sum)
+		}
+	}
+}
+
+func TestXP_String(t *testing.T) {
+	tests := []struct {
+		xp       XP
+		expected string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1,000"},
+		{1234567, "1,234,567"},
+		{-12345, "-12,345"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := tt.xp.String(); result != tt.expected {
+				t.Errorf("String() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+
+	// %d must still print the plain number
+	if result := fmt.Sprintf("%d", XP(1234)); result != "1234" {
+		t.Errorf("Expected plain number for %%d, got %s", result)
+	}
+}
+
+func TestXP_Short(t *testing.T) {
+	tests := []struct {
+		xp       XP
+		expected string
+	}{
+		{950, "950"},
+		{1000, "1k"},
+		{12345, "12.3k"},
+		{1500000, "1.5M"},
+		{2000000000, "2B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := tt.xp.Short(); result != tt.expected {
+				t.Errorf("Short() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestXP_JSON(t *testing.T) {
+	data, err := json.Marshal(XP(1234))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "1234" {
+		t.Errorf("Expected plain JSON number, got %s", data)
+	}
+
+	tests := []struct {
+		input    string
+		expected XP
+		wantErr  bool
+	}{
+		{`42`, 42, false},
+		{`"42"`, 42, false},
+		{`42.0`, 42, false},
+		{`42.5`, 0, true},
+		{`"abc"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var x XP
+			err := json.Unmarshal([]byte(tt.input), &x)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unexpected error state: %v", err)
+			}
+			if x != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, x)
+			}
+		})
+	}
+}